@@ -22,7 +22,10 @@ import (
 	flowcontrolrequest "k8s.io/apiserver/pkg/util/flowcontrol/request"
 	"k8s.io/apiserver/pkg/util/webhook"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/component-base/tracing"
 	utilversion "k8s.io/component-base/version"
 	"k8s.io/klog/v2"
@@ -48,11 +51,15 @@ import (
 	serviceaccountkeysbackend "go.miloapis.com/milo/internal/apiserver/identity/serviceaccountkeys"
 	sessionsbackend "go.miloapis.com/milo/internal/apiserver/identity/sessions"
 	useridentitiesbackend "go.miloapis.com/milo/internal/apiserver/identity/useridentities"
+	usagesummariesbackend "go.miloapis.com/milo/internal/apiserver/quota/usagesummaries"
 	identitystorage "go.miloapis.com/milo/internal/apiserver/storage/identity"
+	quotausagestorage "go.miloapis.com/milo/internal/apiserver/storage/quotausage"
 	admissionquota "go.miloapis.com/milo/internal/quota/admission"
 	identityapi "go.miloapis.com/milo/pkg/apis/identity"
 	identityopenapi "go.miloapis.com/milo/pkg/apis/identity/v1alpha1"
 	quotaapi "go.miloapis.com/milo/pkg/apis/quota"
+	quotausageapi "go.miloapis.com/milo/pkg/apis/quotausage"
+	quotausageopenapi "go.miloapis.com/milo/pkg/apis/quotausage/v1alpha1"
 	"go.miloapis.com/milo/pkg/features"
 	discoveryctx "go.miloapis.com/milo/pkg/server/discovery"
 	datumfilters "go.miloapis.com/milo/pkg/server/filters"
@@ -176,6 +183,14 @@ func (c *CompletedConfig) GenericStorageProviders(discovery discovery.DiscoveryI
 		providers = append(providers, newEventsV1StorageProvider(eventsBackend))
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(features.QuotaUsageSummaries) {
+		provider, err := c.newQuotaUsageStorageProvider()
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+
 	return providers, nil
 }
 
@@ -242,6 +257,16 @@ func newIdentityStorageProvider(c *CompletedConfig) controlplaneapiserver.RESTSt
 	return provider
 }
 
+// newQuotaUsageStorageProvider serves QuotaUsageSummaries and AllowanceBucketBreakdowns
+// computed from the quota objects read through the loopback client.
+func (c *CompletedConfig) newQuotaUsageStorageProvider() (controlplaneapiserver.RESTStorageProvider, error) {
+	backend, err := usagesummariesbackend.NewDynamicProvider(rest.CopyConfig(c.ControlPlane.Generic.LoopbackClientConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize quota usage summaries: %w", err)
+	}
+	return quotausagestorage.StorageProvider{UsageSummaries: backend, BucketBreakdowns: backend}, nil
+}
+
 // initEventsBackend creates a shared DynamicProvider for both core/v1 and events.k8s.io/v1 APIs
 func (c *CompletedConfig) initEventsBackend() (*eventsbackend.DynamicProvider, error) {
 	allow := make(map[string]struct{}, len(c.ExtraConfig.EventsProvider.ForwardExtras))
@@ -324,6 +349,8 @@ func NewConfig(opts options.CompletedOptions) (*Config, error) {
 	identityapi.Install(legacyscheme.Scheme)
 	quotaapi.Install(miloScheme)
 	quotaapi.Install(legacyscheme.Scheme)
+	quotausageapi.Install(miloScheme)
+	quotausageapi.Install(legacyscheme.Scheme)
 
 	apiResourceConfigSource := controlplane.DefaultAPIResourceConfigSource()
 	apiResourceConfigSource.DisableResources(corev1.SchemeGroupVersion.WithResource("serviceaccounts"))
@@ -335,6 +362,10 @@ func NewConfig(opts options.CompletedOptions) (*Config, error) {
 
 	apiResourceConfigSource.EnableVersions(identityopenapi.SchemeGroupVersion)
 
+	if utilfeature.DefaultFeatureGate.Enabled(features.QuotaUsageSummaries) {
+		apiResourceConfigSource.EnableVersions(quotausageopenapi.SchemeGroupVersion)
+	}
+
 	genericConfig, versionedInformers, storageFactory, err := controlplaneapiserver.BuildGenericConfig(
 		opts,
 		[]*runtime.Scheme{legacyscheme.Scheme, apiextensionsapiserver.Scheme, aggregatorscheme.Scheme, miloScheme},
@@ -345,6 +376,9 @@ func NewConfig(opts options.CompletedOptions) (*Config, error) {
 			for k, v := range id {
 				base[k] = v
 			}
+			for k, v := range quotausageopenapi.GetOpenAPIDefinitions(ref) {
+				base[k] = v
+			}
 			return base
 		},
 	)
@@ -370,7 +404,23 @@ func NewConfig(opts options.CompletedOptions) (*Config, error) {
 		Loopback: rest.CopyConfig(genericConfig.LoopbackClientConfig),
 	}
 
-	kubeAPIs, upstreamInits, err := controlplaneapiserver.CreateConfig(opts, genericConfig, versionedInformers, storageFactory, serviceResolver, []admission.PluginInitializer{loopbackInit})
+	admissionRecorder, err := newAdmissionEventRecorder(rest.CopyConfig(genericConfig.LoopbackClientConfig))
+	if err != nil {
+		return nil, err
+	}
+	eventRecorderInit := initializer.EventRecorderInitializer{
+		Recorder: admissionRecorder,
+	}
+
+	decisionSink, err := admissionquota.NewDecisionSink(quotaDecisionSink)
+	if err != nil {
+		return nil, err
+	}
+	decisionSinkInit := admissionquota.DecisionSinkInitializer{
+		Sink: decisionSink,
+	}
+
+	kubeAPIs, upstreamInits, err := controlplaneapiserver.CreateConfig(opts, genericConfig, versionedInformers, storageFactory, serviceResolver, []admission.PluginInitializer{loopbackInit, eventRecorderInit, decisionSinkInit})
 	if err != nil {
 		return nil, err
 	}
@@ -430,6 +480,18 @@ func NewConfig(opts options.CompletedOptions) (*Config, error) {
 	return c, nil
 }
 
+// newAdmissionEventRecorder returns a recorder that admission plugins use to report
+// decisions as Events through the loopback client.
+func newAdmissionEventRecorder(loopback *rest.Config) (record.EventRecorder, error) {
+	client, err := kubernetes.NewForConfig(loopback)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create admission event client: %w", err)
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	return broadcaster.NewRecorder(legacyscheme.Scheme, corev1.EventSource{Component: "milo-apiserver"}), nil
+}
+
 // DefaultBuildHandlerChain builds the standard Kubernetes filter chain with Milo-specific filters
 func DefaultBuildHandlerChain(apiHandler http.Handler, c *server.Config, loopbackConfig *rest.Config) http.Handler {
 	handler := apiHandler
@@ -79,6 +79,9 @@ var (
 	eventsProviderTimeoutSeconds         int
 	eventsProviderRetries                int
 	eventsForwardExtras                  []string
+	quotaDecisionSink                    string
+	quotaCELProgramCacheSize             int
+	quotaClaimTimeoutBehavior            string
 )
 
 // NewCommand creates a *cobra.Command object with default parameters
@@ -199,6 +202,9 @@ func NewCommand() *cobra.Command {
 	fs.IntVar(&eventsProviderTimeoutSeconds, "events-provider-timeout", 30, "Activity provider request timeout in seconds")
 	fs.IntVar(&eventsProviderRetries, "events-provider-retries", 3, "Activity provider request retries")
 	fs.StringSliceVar(&eventsForwardExtras, "events-forward-extras", []string{"iam.miloapis.com/parent-api-group", "iam.miloapis.com/parent-type", "iam.miloapis.com/parent-name"}, "User extras keys to forward to Activity for events")
+	fs.StringVar(&quotaDecisionSink, "quota-admission-decision-sink", "", "File path or http(s) URL that receives quota admission decisions as JSON records; empty disables the sink")
+	fs.StringVar(&quotaClaimTimeoutBehavior, "quota-admission-claim-timeout-behavior", string(admissionquota.ClaimTimeoutRetry), "How the quota admission plugin rejects a request whose ResourceClaim is not resolved in time: Retry (retryable 503) or Deny (403 quota denial)")
+	fs.IntVar(&quotaCELProgramCacheSize, "quota-admission-cel-program-cache-size", admissionquota.DefaultAdmissionPluginConfig().CELProgramCacheSize, "Number of compiled CEL programs the quota admission plugin keeps for policy evaluation")
 
 	cols, _, _ := term.TerminalSize(cmd.OutOrStdout())
 	cliflag.SetUsageAndHelpFunc(cmd, namedFlagSets, cols)
@@ -213,7 +219,10 @@ func NewOptions() *options.Options {
 		s.Admission.GenericAdmission.Plugins = admission.NewPlugins()
 	}
 
-	admissionquota.Register(s.Admission.GenericAdmission.Plugins)
+	admissionquota.Register(s.Admission.GenericAdmission.Plugins, func(config *admissionquota.AdmissionPluginConfig) {
+		config.CELProgramCacheSize = quotaCELProgramCacheSize
+		config.ClaimTimeoutBehavior = admissionquota.ClaimTimeoutBehavior(quotaClaimTimeoutBehavior)
+	})
 
 	s.Admission.GenericAdmission.RecommendedPluginOrder = GetMiloOrderedPlugins()
 
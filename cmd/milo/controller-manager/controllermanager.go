@@ -84,6 +84,7 @@ import (
 	resourcemanagercontroller "go.miloapis.com/milo/internal/controllers/resourcemanager"
 	infracluster "go.miloapis.com/milo/internal/infra-cluster"
 	quotacontroller "go.miloapis.com/milo/internal/quota/controllers"
+	quotacore "go.miloapis.com/milo/internal/quota/controllers/core"
 	iamv1alpha1webhook "go.miloapis.com/milo/internal/webhooks/iam/v1alpha1"
 	identityv1alpha1webhook "go.miloapis.com/milo/internal/webhooks/identity/v1alpha1"
 	notesv1alpha1webhook "go.miloapis.com/milo/internal/webhooks/notes/v1alpha1"
@@ -117,6 +118,12 @@ var (
 	// This allows service providers to configure where owner roles are stored.
 	OrganizationOwnerRoleNamespace string
 
+	// OrganizationMinOwners is the number of owners an organization must keep.
+	OrganizationMinOwners int
+
+	// DisallowedRoleLaunchStages are the role launch stages that organization memberships may not bind.
+	DisallowedRoleLaunchStages []string
+
 	// ProjectOwnerRoleName is the name of the role that will be used to grant project owner permissions.
 	ProjectOwnerRoleName string
 
@@ -132,6 +139,14 @@ var (
 
 	// UserInvitationEmailTemplate is the template for the user invitation email.
 	UserInvitationEmailTemplate string
+	// UserInvitationEmailTemplatesByLocale maps invitee locales to localized user invitation email templates.
+	UserInvitationEmailTemplatesByLocale map[string]string
+	// UserInvitationBaseURL is the base URL of the portal where user invitations are accepted.
+	UserInvitationBaseURL string
+
+	// UserInvitationTrustedInviters are the usernames of controllers that create user invitations on
+	// behalf of the user set in the invitation's InvitedBy field.
+	UserInvitationTrustedInviters []string
 
 	// UserWaitlistPendingEmailTemplate is the template for the waitlist pending email.
 	UserWaitlistPendingEmailTemplate string
@@ -164,6 +179,32 @@ var (
 	// UserContactNamespace is the namespace where user contacts will be created/managed.
 	// When a User is created, the UserContactController will create or update Contacts in this namespace.
 	UserContactNamespace string
+
+	// GCPartitionDeletionQPS is the maximum number of garbage collector deletion attempts per second
+	// for a single project partition. Zero disables per-partition rate limiting.
+	GCPartitionDeletionQPS float64
+
+	// GCPartitionDeletionBurst is the burst size for the per-partition garbage collector deletion rate limit.
+	GCPartitionDeletionBurst int
+
+	// GCRequireInitialSync fails registration of a project partition whose garbage collector
+	// monitors do not sync in time, so that registration is retried instead of running cold.
+	GCRequireInitialSync bool
+
+	// QuotaUsageWebhook configures notifications sent when a consumer's quota usage crosses a threshold.
+	QuotaUsageWebhook = quotacore.DefaultUsageWebhookConfig()
+
+	// QuotaBucketResyncPeriod is how often each AllowanceBucket is recomputed without a triggering event.
+	QuotaBucketResyncPeriod time.Duration
+
+	// QuotaPauseGranting holds pending ResourceClaims without granting or denying them during maintenance.
+	QuotaPauseGranting bool
+
+	// QuotaCascadeConsumerDeletion deletes ResourceClaims whose consumer has been deleted.
+	QuotaCascadeConsumerDeletion bool
+
+	// QuotaGrantSelection is the order in which a consumer's grants are consumed.
+	QuotaGrantSelection string
 )
 
 func init() {
@@ -287,11 +328,16 @@ func NewCommand() *cobra.Command {
 	fs.StringVar(&SystemNamespace, "system-namespace", "milo-system", "The namespace to use for system components and resources that are automatically created to run the system.")
 	fs.StringVar(&OrganizationOwnerRoleName, "organization-owner-role-name", "resourcemanager.miloapis.com-organizationowner", "The name of the role that will be used to grant organization owner permissions.")
 	fs.StringVar(&OrganizationOwnerRoleNamespace, "organization-owner-role-namespace", "", "The namespace where the organization owner role is located. Defaults to system-namespace if not specified.")
+	fs.IntVar(&OrganizationMinOwners, "organization-min-owners", 1, "The number of owners an organization must keep. Removing an owner membership or its owner role is rejected when fewer owners would remain.")
+	fs.StringSliceVar(&DisallowedRoleLaunchStages, "disallowed-role-launch-stages", resourcemanagerv1alpha1webhook.DefaultDisallowedRoleLaunchStages, "The role launch stages that organization memberships may not bind.")
 	fs.StringVar(&ProjectOwnerRoleName, "project-owner-role-name", "resourcemanager.miloapis.com-projectowner", "The name of the role that will be used to grant project owner permissions.")
 	fs.StringVar(&ProjectOwnerRoleNamespace, "project-owner-role-namespace", "", "The namespace where the project owner role is located. Defaults to system-namespace if not specified.")
 	fs.StringVar(&GetInvitationRoleName, "get-invitation-role-name", "iam.miloapis.com-getinvitation", "The name of the role that will be used to grant get invitation permissions.")
 	fs.StringVar(&AcceptInvitationRoleName, "accept-invitation-role-name", "iam.miloapis.com-acceptinvitation", "The name of the role that will be used to grant accept invitation permissions.")
 	fs.StringVar(&UserInvitationEmailTemplate, "user-invitation-email-template", "emailtemplates.notification.miloapis.com-userinvitationemailtemplate", "The name of the template that will be used to send the user invitation email.")
+	fs.StringToStringVar(&UserInvitationEmailTemplatesByLocale, "user-invitation-email-templates-by-locale", nil, "Localized user invitation email templates, as locale=template pairs (e.g. de=userinvitationemailtemplate-de). Invitations select a locale through the iam.miloapis.com/locale annotation or the invitee User's spec.locale; other invitations use the user-invitation-email-template. Every template must exist when the controller starts.")
+	fs.StringSliceVar(&UserInvitationTrustedInviters, "user-invitation-trusted-inviters", []string{"system:serviceaccount:milo-system:milo-controller-manager"}, "Usernames of controllers, such as the one that fans out bulk user invitations, that may create invitations on behalf of another user. Invitations created by anyone else are attributed to the requesting user.")
+	fs.StringVar(&UserInvitationBaseURL, "user-invitation-base-url", "https://cloud.datum.net", "The base URL of the portal where user invitations are accepted. Invitation emails link to <base-url>/invitation/<name>/accept.")
 	fs.StringVar(&UserWaitlistPendingEmailTemplate, "user-waitlist-pending-email-template", "emailtemplates.notification.miloapis.com-userwaitlistemailtemplate", "The name of the template that will be used to send the waitlist pending email.")
 	fs.StringVar(&UserWaitlistApprovedEmailTemplate, "user-waitlist-approved-email-template", "emailtemplates.notification.miloapis.com-userwelcomeemailtemplate", "The name of the template that will be used to send the waitlist approved email.")
 	fs.StringVar(&UserWaitlistRejectedEmailTemplate, "user-waitlist-rejected-email-template", "emailtemplates.notification.miloapis.com-userrejectedemailtemplate", "The name of the template that will be used to send the waitlist rejected email.")
@@ -302,6 +348,19 @@ func NewCommand() *cobra.Command {
 	fs.StringVar(&OrganizationMembershipSelfDeleteRoleNamespace, "organization-membership-self-delete-role-namespace", "milo-system", "The namespace where the organization membership self delete role is located. Defaults to system-namespace if not specified.")
 	fs.StringVar(&NoteCreatorEditorRoleName, "note-creator-editor-role-name", "notes-creator-editor", "The name of the role that will be used to grant note creator edit permissions.")
 
+	fs.Float64Var(&GCPartitionDeletionQPS, "gc-partition-deletion-qps", 0, "The maximum number of garbage collector deletion attempts per second for a single project partition. Zero disables per-partition rate limiting.")
+	fs.IntVar(&GCPartitionDeletionBurst, "gc-partition-deletion-burst", 10, "The burst size for the per-partition garbage collector deletion rate limit.")
+	fs.BoolVar(&GCRequireInitialSync, "gc-require-initial-sync", false, "Retry registering a project with the garbage collector until its monitors sync, instead of collecting garbage in that project with a cold cache.")
+
+	fs.StringVar(&QuotaUsageWebhook.URL, "quota-usage-webhook-url", "", "URL that receives a JSON POST when a consumer's quota utilization crosses a threshold. Empty disables usage notifications.")
+	fs.IntSliceVar(&QuotaUsageWebhook.Thresholds, "quota-usage-webhook-thresholds", QuotaUsageWebhook.Thresholds, "Quota utilization percentages that trigger a usage webhook notification when crossed.")
+	fs.DurationVar(&QuotaUsageWebhook.Timeout, "quota-usage-webhook-timeout", QuotaUsageWebhook.Timeout, "Timeout for each quota usage webhook delivery attempt.")
+	fs.IntVar(&QuotaUsageWebhook.MaxRetries, "quota-usage-webhook-max-retries", QuotaUsageWebhook.MaxRetries, "Number of retries after a failed quota usage webhook delivery.")
+	fs.DurationVar(&QuotaBucketResyncPeriod, "quota-bucket-resync-period", 10*time.Minute, "How often each AllowanceBucket is recomputed from its grants and claims without a triggering event, correcting drift from missed events or manual edits.")
+	fs.BoolVar(&QuotaPauseGranting, "quota-pause-granting", false, "Pause quota granting for maintenance. ResourceClaims are still created and accounted for, but stay pending until granting resumes, and admission asks clients to retry.")
+	fs.BoolVar(&QuotaCascadeConsumerDeletion, "quota-cascade-consumer-deletion", false, "Delete ResourceClaims whose consumer (for example an Organization or Project) has been deleted, in addition to claims whose triggering resource was deleted.")
+	fs.StringVar(&QuotaGrantSelection, "quota-grant-selection", "OldestFirst", "Order in which a consumer's ResourceGrants are consumed when several could satisfy a claim: OldestFirst, SoonestExpiry or HighestPriority. Each granted request records the grant it consumed.")
+
 	fs.IntVar(&s.ControllerRuntimeWebhookPort, "controller-runtime-webhook-port", 9443, "The port to use for the controller-runtime webhook server.")
 
 	fs.StringVar(&AssignableRolesNamespace, "assignable-roles-namespace", "datum-cloud", "An extra namespace that the system allows to be used for assignable roles.")
@@ -502,7 +561,7 @@ func Run(ctx context.Context, c *config.CompletedConfig, opts *Options) error {
 				logger.Error(err, "Error setting up organization webhook")
 				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 			}
-			if err := resourcemanagerv1alpha1webhook.SetupOrganizationMembershipWebhooksWithManager(ctrl, OrganizationOwnerRoleName, OrganizationOwnerRoleNamespace); err != nil {
+			if err := resourcemanagerv1alpha1webhook.SetupOrganizationMembershipWebhooksWithManager(ctrl, OrganizationOwnerRoleName, OrganizationOwnerRoleNamespace, OrganizationMinOwners, DisallowedRoleLaunchStages); err != nil {
 				logger.Error(err, "Error setting up organizationmembership webhook")
 				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 			}
@@ -526,10 +585,14 @@ func Run(ctx context.Context, c *config.CompletedConfig, opts *Options) error {
 				logger.Error(err, "unable to setup email webhook", "error", err)
 				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 			}
-			if err := iamv1alpha1webhook.SetupUserInvitationWebhooksWithManager(ctrl, SystemNamespace, AssignableRolesNamespace); err != nil {
+			if err := iamv1alpha1webhook.SetupUserInvitationWebhooksWithManager(ctrl, SystemNamespace, AssignableRolesNamespace, UserInvitationTrustedInviters); err != nil {
 				logger.Error(err, "Error setting up user invitation webhook")
 				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 			}
+			if err := iamv1alpha1webhook.SetupBulkUserInvitationWebhooksWithManager(ctrl); err != nil {
+				logger.Error(err, "Error setting up bulk user invitation webhook")
+				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+			}
 			if err := notificationv1alpha1webhook.SetupContactWebhooksWithManager(ctrl); err != nil {
 				logger.Error(err, "Error setting up contact webhook")
 				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
@@ -650,7 +713,13 @@ func Run(ctx context.Context, c *config.CompletedConfig, opts *Options) error {
 				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 			}
 
-			if err := quotacontroller.SetupQuotaControllers(mcMgr, dynamicClient, logger.WithName("quota")); err != nil {
+			if err := quotacontroller.SetupQuotaControllers(mcMgr, dynamicClient, logger.WithName("quota"), quotacontroller.Options{
+				UsageWebhook:            QuotaUsageWebhook,
+				BucketResyncPeriod:      QuotaBucketResyncPeriod,
+				PauseGranting:           QuotaPauseGranting,
+				CascadeConsumerDeletion: QuotaCascadeConsumerDeletion,
+				GrantSelection:          QuotaGrantSelection,
+			}); err != nil {
 				logger.Error(err, "Error setting up quota controllers")
 				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 			}
@@ -743,17 +812,27 @@ func Run(ctx context.Context, c *config.CompletedConfig, opts *Options) error {
 			}
 
 			userInvitationCtrl := iamcontroller.UserInvitationController{
-				Client:                          ctrl.GetClient(),
-				SystemNamespace:                 SystemNamespace,
-				GetInvitationRoleName:           GetInvitationRoleName,
-				AcceptInvitationRoleName:        AcceptInvitationRoleName,
-				UserInvitationEmailTemplateName: UserInvitationEmailTemplate,
+				Client:                               ctrl.GetClient(),
+				SystemNamespace:                      SystemNamespace,
+				GetInvitationRoleName:                GetInvitationRoleName,
+				AcceptInvitationRoleName:             AcceptInvitationRoleName,
+				UserInvitationEmailTemplateName:      UserInvitationEmailTemplate,
+				UserInvitationEmailTemplatesByLocale: UserInvitationEmailTemplatesByLocale,
+				InvitationBaseURL:                    UserInvitationBaseURL,
 			}
 			if err := userInvitationCtrl.SetupWithManager(ctrl); err != nil {
 				logger.Error(err, "Error setting up user invitation controller")
 				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 			}
 
+			bulkUserInvitationCtrl := iamcontroller.BulkUserInvitationController{
+				Client: ctrl.GetClient(),
+			}
+			if err := bulkUserInvitationCtrl.SetupWithManager(ctrl); err != nil {
+				logger.Error(err, "Error setting up bulk user invitation controller")
+				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+			}
+
 			noteCtrl := notescontroller.NoteController{
 				Client:                     ctrl.GetClient(),
 				CreatorEditorRoleName:      NoteCreatorEditorRoleName,
@@ -118,6 +118,9 @@ func startGarbageCollectorController(ctx context.Context, controllerContext Cont
 		return nil, true, fmt.Errorf("failed to start the generic garbage collector: %w", err)
 	}
 
+	// Pace deletions per project so garbage in one partition cannot overwhelm its API server
+	gc.SetPartitionDeletionRateLimit(GCPartitionDeletionQPS, GCPartitionDeletionBurst)
+
 	// Start GC workers
 	workers := int(controllerContext.ComponentConfig.GarbageCollectorController.ConcurrentGCSyncs)
 	const syncPeriod = 30 * time.Second
@@ -128,11 +131,12 @@ func startGarbageCollectorController(ctx context.Context, controllerContext Cont
 
 	// Hook dynamic projects into GC via a sink
 	gcSink := &gccontroller.GCSink{
-		GC:                gc,
-		RootRESTMapper:    controllerContext.RESTMapper, // same API surface across projects
-		Ignored:           ignored,
-		InformersStarted:  controllerContext.InformersStarted,
-		InitialSyncPeriod: 30 * time.Second,
+		GC:                 gc,
+		RootRESTMapper:     controllerContext.RESTMapper, // same API surface across projects
+		Ignored:            ignored,
+		InformersStarted:   controllerContext.InformersStarted,
+		InitialSyncPeriod:  30 * time.Second,
+		RequireInitialSync: GCRequireInitialSync,
 	}
 	prov, err := projectprovider.New(cfg, gcSink)
 	if err != nil {
@@ -0,0 +1,22 @@
+// milo/pkg/apiserver/admission/initializer/events.go
+package initializer
+
+import (
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/client-go/tools/record"
+)
+
+// Local duck-typed interface: any plugin with this method will match.
+type wantsEventRecorder interface {
+	SetEventRecorder(record.EventRecorder)
+}
+
+type EventRecorderInitializer struct {
+	Recorder record.EventRecorder
+}
+
+func (i EventRecorderInitializer) Initialize(p admission.Interface) {
+	if w, ok := p.(wantsEventRecorder); ok && i.Recorder != nil {
+		w.SetEventRecorder(i.Recorder)
+	}
+}
@@ -0,0 +1,116 @@
+package usagesummaries
+
+import (
+	"sort"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	quotausagev1alpha1 "go.miloapis.com/milo/pkg/apis/quotausage/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// buildBreakdown joins a bucket's contributing grant references and its consumer's
+// granted claims to the live grants and claims.
+//
+// Grants are matched to the bucket's references by namespace and name among the
+// grants of the bucket's consumer, as the bucket controller selects them. Claims contribute when
+// they belong to the bucket's consumer and have a granted allocation for its resource
+// type, which is how the controller computes the bucket's allocation.
+func buildBreakdown(bucket *quotav1alpha1.AllowanceBucket, grants []quotav1alpha1.ResourceGrant, claims []quotav1alpha1.ResourceClaim) *quotausagev1alpha1.AllowanceBucketBreakdown {
+	consumer := bucket.Spec.ConsumerRef
+	breakdown := &quotausagev1alpha1.AllowanceBucketBreakdown{
+		ObjectMeta: metav1.ObjectMeta{Name: bucket.Name, Namespace: bucket.Namespace},
+		Status: quotausagev1alpha1.AllowanceBucketBreakdownStatus{
+			ConsumerRef: quotausagev1alpha1.ConsumerRef{
+				APIGroup:  consumer.APIGroup,
+				Kind:      consumer.Kind,
+				Name:      consumer.Name,
+				Namespace: consumer.Namespace,
+			},
+			ResourceType: bucket.Spec.ResourceType,
+			Limit:        bucket.Status.Limit,
+			Allocated:    bucket.Status.Allocated,
+			Grants:       []quotausagev1alpha1.GrantContribution{},
+			Claims:       []quotausagev1alpha1.ClaimContribution{},
+		},
+	}
+
+	grantsByKey := make(map[types.NamespacedName]*quotav1alpha1.ResourceGrant, len(grants))
+	for i := range grants {
+		grant := &grants[i]
+		if grant.Spec.ConsumerRef.Kind != consumer.Kind || grant.Spec.ConsumerRef.Name != consumer.Name {
+			continue
+		}
+		grantsByKey[types.NamespacedName{Namespace: grant.Namespace, Name: grant.Name}] = grant
+	}
+	for _, ref := range bucket.Status.ContributingGrantRefs {
+		contribution := quotausagev1alpha1.GrantContribution{Name: ref.Name, Namespace: ref.Namespace, Amount: ref.Amount, Stale: true}
+		if grant, ok := grantsByKey[types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}]; ok {
+			contribution.Namespace = grant.Namespace
+			contribution.Stale = grant.Generation != ref.LastObservedGeneration
+		}
+		breakdown.Status.Grants = append(breakdown.Status.Grants, contribution)
+	}
+
+	for i := range claims {
+		claim := &claims[i]
+		if claim.Spec.ConsumerRef != consumer {
+			continue
+		}
+		amount, granted := grantedAmount(claim, bucket.Spec.ResourceType)
+		if !granted {
+			continue
+		}
+		ref := claim.Spec.ResourceRef
+		breakdown.Status.Claims = append(breakdown.Status.Claims, quotausagev1alpha1.ClaimContribution{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			Amount:    amount,
+			ResourceRef: quotausagev1alpha1.ObjectRef{
+				APIGroup:  ref.APIGroup,
+				Kind:      ref.Kind,
+				Name:      ref.Name,
+				Namespace: ref.Namespace,
+			},
+		})
+	}
+	sort.SliceStable(breakdown.Status.Claims, func(i, j int) bool {
+		a, b := breakdown.Status.Claims[i], breakdown.Status.Claims[j]
+		if a.Amount != b.Amount {
+			return a.Amount > b.Amount
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return breakdown
+}
+
+// grantedAmount returns the amount granted to the claim for resourceType, and whether
+// the claim requests the resource type and has a granted allocation for it.
+func grantedAmount(claim *quotav1alpha1.ResourceClaim, resourceType string) (int64, bool) {
+	requested := false
+	for _, request := range claim.Spec.Requests {
+		if request.ResourceType == resourceType {
+			requested = true
+			break
+		}
+	}
+	if !requested {
+		return 0, false
+	}
+
+	var amount int64
+	granted := false
+	for _, allocation := range claim.Status.Allocations {
+		if allocation.ResourceType != resourceType || allocation.Status != quotav1alpha1.ResourceClaimAllocationStatusGranted {
+			continue
+		}
+		amount += allocation.AllocatedAmount
+		granted = true
+	}
+	return amount, granted
+}
@@ -0,0 +1,100 @@
+package usagesummaries
+
+import (
+	"context"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	quotausagev1alpha1 "go.miloapis.com/milo/pkg/apis/quotausage/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+// BreakdownBackend reads the AllowanceBucket a breakdown describes and the grants and
+// claims of its consumer that it is joined to.
+type BreakdownBackend interface {
+	GetAllowanceBucket(ctx context.Context, namespace, name string) (*quotav1alpha1.AllowanceBucket, error)
+	ListResourceGrants(ctx context.Context, consumer quotav1alpha1.ConsumerRef) ([]quotav1alpha1.ResourceGrant, error)
+	ListResourceClaims(ctx context.Context, consumer quotav1alpha1.ConsumerRef) ([]quotav1alpha1.ResourceClaim, error)
+}
+
+// BreakdownREST serves AllowanceBucketBreakdowns by joining a bucket to its grants and
+// claims on every call. Breakdowns are only served by name, as listing them would
+// join every bucket in the namespace. Nothing is stored.
+type BreakdownREST struct {
+	backend BreakdownBackend
+}
+
+var _ rest.Scoper = &BreakdownREST{}
+var _ rest.Getter = &BreakdownREST{}
+var _ rest.Storage = &BreakdownREST{}
+var _ rest.SingularNameProvider = &BreakdownREST{}
+
+func NewBreakdownREST(b BreakdownBackend) *BreakdownREST { return &BreakdownREST{backend: b} }
+
+func (r *BreakdownREST) GetSingularName() string { return "allowancebucketbreakdown" }
+func (r *BreakdownREST) NamespaceScoped() bool   { return true }
+func (r *BreakdownREST) New() runtime.Object     { return &quotausagev1alpha1.AllowanceBucketBreakdown{} }
+
+func (r *BreakdownREST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	logger := klog.FromContext(ctx)
+	namespace := apirequest.NamespaceValue(ctx)
+	logger.V(4).Info("Getting allowance bucket breakdown", "namespace", namespace, "name", name)
+
+	bucket, err := r.backend.GetAllowanceBucket(ctx, namespace, name)
+	if apierrors.IsNotFound(err) {
+		return nil, apierrors.NewNotFound(quotausagev1alpha1.Resource("allowancebucketbreakdowns"), name)
+	}
+	if err != nil {
+		logger.Error(err, "Get allowance bucket breakdown failed", "namespace", namespace, "name", name)
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	grants, err := r.backend.ListResourceGrants(ctx, bucket.Spec.ConsumerRef)
+	if err != nil {
+		logger.Error(err, "Get allowance bucket breakdown failed", "namespace", namespace, "name", name)
+		return nil, apierrors.NewInternalError(err)
+	}
+	claims, err := r.backend.ListResourceClaims(ctx, bucket.Spec.ConsumerRef)
+	if err != nil {
+		logger.Error(err, "Get allowance bucket breakdown failed", "namespace", namespace, "name", name)
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	breakdown := buildBreakdown(bucket, grants, claims)
+	logger.V(4).Info("Got allowance bucket breakdown", "namespace", namespace, "name", name,
+		"grants", len(breakdown.Status.Grants), "claims", len(breakdown.Status.Claims))
+	return breakdown, nil
+}
+
+func (r *BreakdownREST) Destroy() {}
+
+// ConvertToTable shows each breakdown's totals alongside how many grants and claims
+// make them up.
+func (r *BreakdownREST) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	breakdown, ok := object.(*quotausagev1alpha1.AllowanceBucketBreakdown)
+	if !ok {
+		// Fallback to default printer
+		return nil, nil
+	}
+
+	return &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Resource Type", Type: "string"},
+			{Name: "Limit", Type: "integer"},
+			{Name: "Allocated", Type: "integer"},
+			{Name: "Grants", Type: "integer"},
+			{Name: "Claims", Type: "integer"},
+		},
+		Rows: []metav1.TableRow{{
+			Cells: []interface{}{breakdown.Name, breakdown.Status.ResourceType, breakdown.Status.Limit,
+				breakdown.Status.Allocated, len(breakdown.Status.Grants), len(breakdown.Status.Claims)},
+			Object: runtime.RawExtension{Object: breakdown},
+		}},
+	}, nil
+}
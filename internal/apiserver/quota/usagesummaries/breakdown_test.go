@@ -0,0 +1,210 @@
+package usagesummaries
+
+import (
+	"context"
+	"testing"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	quotausagev1alpha1 "go.miloapis.com/milo/pkg/apis/quotausage/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+type fakeBreakdownBackend struct {
+	buckets []quotav1alpha1.AllowanceBucket
+	grants  []quotav1alpha1.ResourceGrant
+	claims  []quotav1alpha1.ResourceClaim
+}
+
+func (f fakeBreakdownBackend) GetAllowanceBucket(_ context.Context, namespace, name string) (*quotav1alpha1.AllowanceBucket, error) {
+	for i := range f.buckets {
+		if f.buckets[i].Namespace == namespace && f.buckets[i].Name == name {
+			return &f.buckets[i], nil
+		}
+	}
+	return nil, apierrors.NewNotFound(quotav1alpha1.GroupVersion.WithResource("allowancebuckets").GroupResource(), name)
+}
+
+// The fake returns every grant and claim, so the join must still filter by consumer.
+func (f fakeBreakdownBackend) ListResourceGrants(context.Context, quotav1alpha1.ConsumerRef) ([]quotav1alpha1.ResourceGrant, error) {
+	return f.grants, nil
+}
+
+func (f fakeBreakdownBackend) ListResourceClaims(context.Context, quotav1alpha1.ConsumerRef) ([]quotav1alpha1.ResourceClaim, error) {
+	return f.claims, nil
+}
+
+var webProject = quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Project", Name: "web"}
+
+func newGrant(namespace, name string, generation int64, consumer quotav1alpha1.ConsumerRef) quotav1alpha1.ResourceGrant {
+	return quotav1alpha1.ResourceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: generation},
+		Spec:       quotav1alpha1.ResourceGrantSpec{ConsumerRef: consumer},
+	}
+}
+
+func newClaim(namespace, name, resourceType string, consumer quotav1alpha1.ConsumerRef, status string, amount int64) quotav1alpha1.ResourceClaim {
+	return quotav1alpha1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: quotav1alpha1.ResourceClaimSpec{
+			ConsumerRef: consumer,
+			Requests:    []quotav1alpha1.ResourceRequest{{ResourceType: resourceType, Amount: amount}},
+			ResourceRef: quotav1alpha1.UnversionedObjectReference{APIGroup: "apps", Kind: "Deployment", Name: name, Namespace: namespace},
+		},
+		Status: quotav1alpha1.ResourceClaimStatus{
+			Allocations: []quotav1alpha1.ResourceClaimAllocationStatus{{
+				ResourceType:    resourceType,
+				Status:          status,
+				AllocatedAmount: amount,
+			}},
+		},
+	}
+}
+
+func TestBreakdownREST_Get(t *testing.T) {
+	allowanceBucket := quotav1alpha1.AllowanceBucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "project-web-deployments", Namespace: "milo-system"},
+		Spec:       quotav1alpha1.AllowanceBucketSpec{ConsumerRef: webProject, ResourceType: "apps/deployments"},
+		Status: quotav1alpha1.AllowanceBucketStatus{
+			Limit:     15,
+			Allocated: 7,
+			ContributingGrantRefs: []quotav1alpha1.ContributingGrantRef{
+				{Name: "default", Namespace: "milo-system", LastObservedGeneration: 1, Amount: 10},
+				{Name: "boost", Namespace: "organization-acme", LastObservedGeneration: 1, Amount: 5},
+				{Name: "revoked", Namespace: "milo-system", LastObservedGeneration: 3, Amount: 2},
+			},
+		},
+	}
+	otherProject := quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Project", Name: "api"}
+
+	r := NewBreakdownREST(fakeBreakdownBackend{
+		buckets: []quotav1alpha1.AllowanceBucket{allowanceBucket},
+		grants: []quotav1alpha1.ResourceGrant{
+			newGrant("milo-system", "default", 1, webProject),
+			newGrant("organization-acme", "boost", 2, webProject),
+			newGrant("milo-system", "revoked", 3, otherProject),
+		},
+		claims: []quotav1alpha1.ResourceClaim{
+			newClaim("default", "frontend", "apps/deployments", webProject, quotav1alpha1.ResourceClaimAllocationStatusGranted, 2),
+			newClaim("default", "backend", "apps/deployments", webProject, quotav1alpha1.ResourceClaimAllocationStatusGranted, 5),
+			newClaim("default", "queued", "apps/deployments", webProject, quotav1alpha1.ResourceClaimAllocationStatusPending, 4),
+			newClaim("default", "other-type", "compute.miloapis.com/instances", webProject, quotav1alpha1.ResourceClaimAllocationStatusGranted, 1),
+			newClaim("default", "other-consumer", "apps/deployments", otherProject, quotav1alpha1.ResourceClaimAllocationStatusGranted, 1),
+		},
+	})
+	ctx := apirequest.WithNamespace(context.Background(), "milo-system")
+
+	obj, err := r.Get(ctx, "project-web-deployments", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	breakdown := obj.(*quotausagev1alpha1.AllowanceBucketBreakdown)
+
+	if breakdown.Namespace != "milo-system" || breakdown.Name != "project-web-deployments" {
+		t.Errorf("breakdown = %s/%s, want the bucket's namespace and name", breakdown.Namespace, breakdown.Name)
+	}
+	if breakdown.Status.Limit != 15 || breakdown.Status.Allocated != 7 {
+		t.Errorf("Limit, Allocated = %d, %d, want 15, 7", breakdown.Status.Limit, breakdown.Status.Allocated)
+	}
+
+	wantGrants := []quotausagev1alpha1.GrantContribution{
+		{Name: "default", Namespace: "milo-system", Amount: 10},
+		{Name: "boost", Namespace: "organization-acme", Amount: 5, Stale: true},
+		{Name: "revoked", Namespace: "milo-system", Amount: 2, Stale: true},
+	}
+	if len(breakdown.Status.Grants) != len(wantGrants) {
+		t.Fatalf("Grants = %+v, want %+v", breakdown.Status.Grants, wantGrants)
+	}
+	for i := range wantGrants {
+		if breakdown.Status.Grants[i] != wantGrants[i] {
+			t.Errorf("Grants[%d] = %+v, want %+v", i, breakdown.Status.Grants[i], wantGrants[i])
+		}
+	}
+
+	wantClaims := []quotausagev1alpha1.ClaimContribution{
+		{Name: "backend", Namespace: "default", Amount: 5,
+			ResourceRef: quotausagev1alpha1.ObjectRef{APIGroup: "apps", Kind: "Deployment", Name: "backend", Namespace: "default"}},
+		{Name: "frontend", Namespace: "default", Amount: 2,
+			ResourceRef: quotausagev1alpha1.ObjectRef{APIGroup: "apps", Kind: "Deployment", Name: "frontend", Namespace: "default"}},
+	}
+	if len(breakdown.Status.Claims) != len(wantClaims) {
+		t.Fatalf("Claims = %+v, want %+v", breakdown.Status.Claims, wantClaims)
+	}
+	for i := range wantClaims {
+		if breakdown.Status.Claims[i] != wantClaims[i] {
+			t.Errorf("Claims[%d] = %+v, want %+v", i, breakdown.Status.Claims[i], wantClaims[i])
+		}
+	}
+
+	if _, err := r.Get(ctx, "project-web-missing", &metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Get() for unknown bucket error = %v, want NotFound", err)
+	}
+}
+
+func TestBuildBreakdownGrantsWithTheSameName(t *testing.T) {
+	bucket := &quotav1alpha1.AllowanceBucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "project-web-deployments", Namespace: "milo-system"},
+		Spec:       quotav1alpha1.AllowanceBucketSpec{ConsumerRef: webProject, ResourceType: "apps/deployments"},
+		Status: quotav1alpha1.AllowanceBucketStatus{
+			ContributingGrantRefs: []quotav1alpha1.ContributingGrantRef{
+				{Name: "default", Namespace: "milo-system", LastObservedGeneration: 1, Amount: 10},
+				{Name: "default", Namespace: "organization-acme", LastObservedGeneration: 4, Amount: 5},
+			},
+		},
+	}
+	grants := []quotav1alpha1.ResourceGrant{
+		newGrant("milo-system", "default", 1, webProject),
+		newGrant("organization-acme", "default", 3, webProject),
+	}
+
+	breakdown := buildBreakdown(bucket, grants, nil)
+
+	// Each reference is joined to the grant in its own namespace
+	want := []quotausagev1alpha1.GrantContribution{
+		{Name: "default", Namespace: "milo-system", Amount: 10},
+		{Name: "default", Namespace: "organization-acme", Amount: 5, Stale: true},
+	}
+	if len(breakdown.Status.Grants) != len(want) {
+		t.Fatalf("Grants = %+v, want %+v", breakdown.Status.Grants, want)
+	}
+	for i := range want {
+		if breakdown.Status.Grants[i] != want[i] {
+			t.Errorf("Grants[%d] = %+v, want %+v", i, breakdown.Status.Grants[i], want[i])
+		}
+	}
+}
+
+func TestListConsumerObjectsSelectsTheConsumer(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		resourceClaimsGVR: "ResourceClaimList",
+	})
+
+	if err := listConsumerObjects(context.Background(), client.Resource(resourceClaimsGVR), webProject, func(*unstructured.Unstructured) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("listConsumerObjects() error = %v", err)
+	}
+
+	actions := client.Actions()
+	if len(actions) != 1 {
+		t.Fatalf("actions = %v, want a single list", actions)
+	}
+	list, ok := actions[0].(clienttesting.ListAction)
+	if !ok {
+		t.Fatalf("action = %v, want a list", actions[0])
+	}
+	restrictions := list.GetListRestrictions()
+	if got, want := restrictions.Fields.String(), "spec.consumerRef.kind=Project,spec.consumerRef.name=web"; got != want {
+		t.Errorf("field selector = %q, want %q", got, want)
+	}
+	if list.GetNamespace() != "" {
+		t.Errorf("namespace = %q, want every namespace", list.GetNamespace())
+	}
+}
@@ -0,0 +1,169 @@
+package usagesummaries
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	milorequest "go.miloapis.com/milo/pkg/request"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/pager"
+)
+
+var (
+	allowanceBucketsGVR = quotav1alpha1.GroupVersion.WithResource("allowancebuckets")
+	resourceGrantsGVR   = quotav1alpha1.GroupVersion.WithResource("resourcegrants")
+	resourceClaimsGVR   = quotav1alpha1.GroupVersion.WithResource("resourceclaims")
+)
+
+// DynamicProvider reads AllowanceBuckets, and the ResourceGrants and ResourceClaims
+// behind them, through the API server's loopback client.
+//
+// The loopback identity is privileged, so callers must already have been authorized
+// to read QuotaUsageSummaries or AllowanceBucketBreakdowns in the namespace; the API
+// server does this before the request reaches REST storage. Requests made in a project's control plane read the
+// buckets of that control plane.
+type DynamicProvider struct {
+	base           *rest.Config
+	client         dynamic.Interface
+	projectClients sync.Map
+}
+
+func NewDynamicProvider(base *rest.Config) (*DynamicProvider, error) {
+	if base == nil {
+		return nil, fmt.Errorf("loopback client config is required")
+	}
+	client, err := dynamic.NewForConfig(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return &DynamicProvider{base: base, client: client}, nil
+}
+
+func (p *DynamicProvider) ListAllowanceBuckets(ctx context.Context, namespace string) ([]quotav1alpha1.AllowanceBucket, error) {
+	client, err := p.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.Resource(allowanceBucketsGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list allowance buckets in namespace %q: %w", namespace, err)
+	}
+
+	buckets := make([]quotav1alpha1.AllowanceBucket, 0, len(list.Items))
+	for i := range list.Items {
+		var bucket quotav1alpha1.AllowanceBucket
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &bucket); err != nil {
+			return nil, fmt.Errorf("failed to decode allowance bucket %s: %w", list.Items[i].GetName(), err)
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
+
+func (p *DynamicProvider) GetAllowanceBucket(ctx context.Context, namespace, name string) (*quotav1alpha1.AllowanceBucket, error) {
+	client, err := p.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.Resource(allowanceBucketsGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowance bucket %s/%s: %w", namespace, name, err)
+	}
+
+	var bucket quotav1alpha1.AllowanceBucket
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &bucket); err != nil {
+		return nil, fmt.Errorf("failed to decode allowance bucket %s: %w", name, err)
+	}
+	return &bucket, nil
+}
+
+// ListResourceGrants lists the consumer's ResourceGrants in every namespace, as buckets
+// count grants wherever they are created.
+func (p *DynamicProvider) ListResourceGrants(ctx context.Context, consumer quotav1alpha1.ConsumerRef) ([]quotav1alpha1.ResourceGrant, error) {
+	client, err := p.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []quotav1alpha1.ResourceGrant
+	err = listConsumerObjects(ctx, client.Resource(resourceGrantsGVR), consumer, func(obj *unstructured.Unstructured) error {
+		var grant quotav1alpha1.ResourceGrant
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &grant); err != nil {
+			return fmt.Errorf("failed to decode resource grant %s: %w", obj.GetName(), err)
+		}
+		grants = append(grants, grant)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource grants of %s %s: %w", consumer.Kind, consumer.Name, err)
+	}
+	return grants, nil
+}
+
+// ListResourceClaims lists the consumer's ResourceClaims in every namespace, as buckets
+// count claims wherever they are created.
+func (p *DynamicProvider) ListResourceClaims(ctx context.Context, consumer quotav1alpha1.ConsumerRef) ([]quotav1alpha1.ResourceClaim, error) {
+	client, err := p.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims []quotav1alpha1.ResourceClaim
+	err = listConsumerObjects(ctx, client.Resource(resourceClaimsGVR), consumer, func(obj *unstructured.Unstructured) error {
+		var claim quotav1alpha1.ResourceClaim
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &claim); err != nil {
+			return fmt.Errorf("failed to decode resource claim %s: %w", obj.GetName(), err)
+		}
+		claims = append(claims, claim)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource claims of %s %s: %w", consumer.Kind, consumer.Name, err)
+	}
+	return claims, nil
+}
+
+// listConsumerObjects pages through the objects of resource whose spec.consumerRef
+// names consumer, filtered by the API server through the resource's selectable fields.
+func listConsumerObjects(ctx context.Context, resource dynamic.NamespaceableResourceInterface, consumer quotav1alpha1.ConsumerRef, fn func(*unstructured.Unstructured) error) error {
+	selector := fields.SelectorFromSet(fields.Set{
+		"spec.consumerRef.kind": consumer.Kind,
+		"spec.consumerRef.name": consumer.Name,
+	})
+	listPager := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		return resource.List(ctx, opts)
+	}))
+	return listPager.EachListItem(ctx, metav1.ListOptions{FieldSelector: selector.String()}, func(obj runtime.Object) error {
+		return fn(obj.(*unstructured.Unstructured))
+	})
+}
+
+// clientFor returns the client for the control plane the request was made in.
+func (p *DynamicProvider) clientFor(ctx context.Context) (dynamic.Interface, error) {
+	projectID, ok := milorequest.ProjectID(ctx)
+	if !ok || projectID == "" {
+		return p.client, nil
+	}
+	if cached, ok := p.projectClients.Load(projectID); ok {
+		return cached.(dynamic.Interface), nil
+	}
+
+	cfg := rest.CopyConfig(p.base)
+	cfg.Host += fmt.Sprintf("/apis/resourcemanager.miloapis.com/v1alpha1/projects/%s/control-plane", projectID)
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client for project %s: %w", projectID, err)
+	}
+	actual, _ := p.projectClients.LoadOrStore(projectID, client)
+	return actual.(dynamic.Interface), nil
+}
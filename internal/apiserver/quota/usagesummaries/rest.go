@@ -0,0 +1,116 @@
+package usagesummaries
+
+import (
+	"context"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	quotausagev1alpha1 "go.miloapis.com/milo/pkg/apis/quotausage/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+// Backend lists the AllowanceBuckets that summaries are computed from.
+type Backend interface {
+	ListAllowanceBuckets(ctx context.Context, namespace string) ([]quotav1alpha1.AllowanceBucket, error)
+}
+
+// REST serves QuotaUsageSummaries by reading the AllowanceBuckets of the requested
+// namespace on every call. Nothing is stored.
+type REST struct {
+	backend Backend
+}
+
+var _ rest.Scoper = &REST{}
+var _ rest.Lister = &REST{}
+var _ rest.Getter = &REST{}
+var _ rest.Storage = &REST{}
+var _ rest.SingularNameProvider = &REST{}
+
+func NewREST(b Backend) *REST { return &REST{backend: b} }
+
+func (r *REST) GetSingularName() string { return "quotausagesummary" }
+func (r *REST) NamespaceScoped() bool   { return true }
+func (r *REST) New() runtime.Object     { return &quotausagev1alpha1.QuotaUsageSummary{} }
+func (r *REST) NewList() runtime.Object { return &quotausagev1alpha1.QuotaUsageSummaryList{} }
+
+func (r *REST) List(ctx context.Context, _ *metainternalversion.ListOptions) (runtime.Object, error) {
+	logger := klog.FromContext(ctx)
+	namespace := apirequest.NamespaceValue(ctx)
+	logger.V(4).Info("Listing quota usage summaries", "namespace", namespace)
+
+	// ignore selectors; summaries are computed from every bucket in the namespace
+	summaries, err := r.summaries(ctx, namespace)
+	if err != nil {
+		logger.Error(err, "List quota usage summaries failed", "namespace", namespace)
+		return nil, err
+	}
+	logger.V(4).Info("Listed quota usage summaries", "namespace", namespace, "count", len(summaries))
+	return &quotausagev1alpha1.QuotaUsageSummaryList{Items: summaries}, nil
+}
+
+func (r *REST) Get(ctx context.Context, name string, _ *metav1.GetOptions) (runtime.Object, error) {
+	logger := klog.FromContext(ctx)
+	namespace := apirequest.NamespaceValue(ctx)
+	logger.V(4).Info("Getting quota usage summary", "namespace", namespace, "name", name)
+
+	summaries, err := r.summaries(ctx, namespace)
+	if err != nil {
+		logger.Error(err, "Get quota usage summary failed", "namespace", namespace, "name", name)
+		return nil, err
+	}
+	for i := range summaries {
+		if summaries[i].Name == name {
+			return &summaries[i], nil
+		}
+	}
+	return nil, apierrors.NewNotFound(quotausagev1alpha1.Resource("quotausagesummaries"), name)
+}
+
+func (r *REST) summaries(ctx context.Context, namespace string) ([]quotausagev1alpha1.QuotaUsageSummary, error) {
+	buckets, err := r.backend.ListAllowanceBuckets(ctx, namespace)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	return buildSummaries(namespace, buckets), nil
+}
+
+func (r *REST) Destroy() {}
+
+// ConvertToTable lists each summary with the number of resource types it reports on.
+func (r *REST) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Consumer Kind", Type: "string"},
+			{Name: "Consumer", Type: "string"},
+			{Name: "Resource Types", Type: "integer"},
+		},
+	}
+
+	appendRow := func(s *quotausagev1alpha1.QuotaUsageSummary) {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells:  []interface{}{s.Name, s.Status.ConsumerRef.Kind, s.Status.ConsumerRef.Name, len(s.Status.Resources)},
+			Object: runtime.RawExtension{Object: s},
+		})
+	}
+
+	switch obj := object.(type) {
+	case *quotausagev1alpha1.QuotaUsageSummaryList:
+		for i := range obj.Items {
+			appendRow(&obj.Items[i])
+		}
+	case *quotausagev1alpha1.QuotaUsageSummary:
+		appendRow(obj)
+	default:
+		// Fallback to default printer
+		return nil, nil
+	}
+
+	return table, nil
+}
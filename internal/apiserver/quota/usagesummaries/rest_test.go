@@ -0,0 +1,101 @@
+package usagesummaries
+
+import (
+	"context"
+	"testing"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	quotausagev1alpha1 "go.miloapis.com/milo/pkg/apis/quotausage/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+type fakeBackend map[string][]quotav1alpha1.AllowanceBucket
+
+func (f fakeBackend) ListAllowanceBuckets(_ context.Context, namespace string) ([]quotav1alpha1.AllowanceBucket, error) {
+	return f[namespace], nil
+}
+
+func bucket(kind, name, resourceType string, limit, allocated int64) quotav1alpha1.AllowanceBucket {
+	return quotav1alpha1.AllowanceBucket{
+		Spec: quotav1alpha1.AllowanceBucketSpec{
+			ConsumerRef:  quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: kind, Name: name},
+			ResourceType: resourceType,
+		},
+		Status: quotav1alpha1.AllowanceBucketStatus{
+			Limit:      limit,
+			Allocated:  allocated,
+			Available:  limit - allocated,
+			ClaimCount: int32(allocated),
+			GrantCount: 1,
+		},
+	}
+}
+
+func TestREST_Get(t *testing.T) {
+	r := NewREST(fakeBackend{
+		"milo-system": {
+			bucket("Project", "web", "compute.miloapis.com/instances", 10, 4),
+			bucket("Project", "web", "apps/deployments", 5, 1),
+			bucket("Project", "api", "apps/deployments", 3, 3),
+		},
+	})
+	ctx := apirequest.WithNamespace(context.Background(), "milo-system")
+
+	obj, err := r.Get(ctx, "project-web", &metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	summary := obj.(*quotausagev1alpha1.QuotaUsageSummary)
+
+	if summary.Namespace != "milo-system" {
+		t.Errorf("Namespace = %q, want milo-system", summary.Namespace)
+	}
+	if summary.Status.ConsumerRef.Kind != "Project" || summary.Status.ConsumerRef.Name != "web" {
+		t.Errorf("ConsumerRef = %+v, want Project web", summary.Status.ConsumerRef)
+	}
+	want := []quotausagev1alpha1.ResourceUsage{
+		{ResourceType: "apps/deployments", Limit: 5, Allocated: 1, Available: 4, ClaimCount: 1, GrantCount: 1},
+		{ResourceType: "compute.miloapis.com/instances", Limit: 10, Allocated: 4, Available: 6, ClaimCount: 4, GrantCount: 1},
+	}
+	if len(summary.Status.Resources) != len(want) {
+		t.Fatalf("Resources = %+v, want %+v", summary.Status.Resources, want)
+	}
+	for i := range want {
+		if summary.Status.Resources[i] != want[i] {
+			t.Errorf("Resources[%d] = %+v, want %+v", i, summary.Status.Resources[i], want[i])
+		}
+	}
+
+	if _, err := r.Get(ctx, "project-missing", &metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Get() for unknown consumer error = %v, want NotFound", err)
+	}
+}
+
+func TestREST_List(t *testing.T) {
+	r := NewREST(fakeBackend{
+		"milo-system": {
+			bucket("Project", "web", "apps/deployments", 5, 1),
+			bucket("Project", "api", "apps/deployments", 3, 3),
+		},
+		"organization-acme": {
+			bucket("Organization", "acme", "resourcemanager.miloapis.com/projects", 10, 2),
+		},
+	})
+
+	obj, err := r.List(apirequest.WithNamespace(context.Background(), "milo-system"), nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	list := obj.(*quotausagev1alpha1.QuotaUsageSummaryList)
+
+	var names []string
+	for _, item := range list.Items {
+		names = append(names, item.Name)
+	}
+	if len(names) != 2 || names[0] != "project-api" || names[1] != "project-web" {
+		t.Errorf("List() names = %v, want [project-api project-web]", names)
+	}
+}
@@ -0,0 +1,63 @@
+package usagesummaries
+
+import (
+	"sort"
+	"strings"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	quotausagev1alpha1 "go.miloapis.com/milo/pkg/apis/quotausage/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SummaryName returns the name of the QuotaUsageSummary reporting on a consumer,
+// formed from the consumer's lowercased kind and its name.
+func SummaryName(consumerRef quotav1alpha1.ConsumerRef) string {
+	return strings.ToLower(consumerRef.Kind) + "-" + consumerRef.Name
+}
+
+// buildSummaries rolls the buckets of a namespace up into one summary per consumer,
+// ordered by name, with each summary's resources ordered by resource type.
+func buildSummaries(namespace string, buckets []quotav1alpha1.AllowanceBucket) []quotausagev1alpha1.QuotaUsageSummary {
+	byName := map[string]*quotausagev1alpha1.QuotaUsageSummary{}
+	for i := range buckets {
+		bucket := &buckets[i]
+		name := SummaryName(bucket.Spec.ConsumerRef)
+
+		summary, ok := byName[name]
+		if !ok {
+			summary = &quotausagev1alpha1.QuotaUsageSummary{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Status: quotausagev1alpha1.QuotaUsageSummaryStatus{
+					ConsumerRef: quotausagev1alpha1.ConsumerRef{
+						APIGroup:  bucket.Spec.ConsumerRef.APIGroup,
+						Kind:      bucket.Spec.ConsumerRef.Kind,
+						Name:      bucket.Spec.ConsumerRef.Name,
+						Namespace: bucket.Spec.ConsumerRef.Namespace,
+					},
+					Resources: []quotausagev1alpha1.ResourceUsage{},
+				},
+			}
+			byName[name] = summary
+		}
+
+		summary.Status.Resources = append(summary.Status.Resources, quotausagev1alpha1.ResourceUsage{
+			ResourceType: bucket.Spec.ResourceType,
+			Limit:        bucket.Status.Limit,
+			Allocated:    bucket.Status.Allocated,
+			Available:    bucket.Status.Available,
+			ClaimCount:   bucket.Status.ClaimCount,
+			GrantCount:   bucket.Status.GrantCount,
+		})
+	}
+
+	summaries := make([]quotausagev1alpha1.QuotaUsageSummary, 0, len(byName))
+	for _, summary := range byName {
+		sort.Slice(summary.Status.Resources, func(i, j int) bool {
+			return summary.Status.Resources[i].ResourceType < summary.Status.Resources[j].ResourceType
+		})
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	return summaries
+}
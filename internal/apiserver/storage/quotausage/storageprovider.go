@@ -0,0 +1,46 @@
+package quotausage
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	generic "k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/registry/rest"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	serverstorage "k8s.io/apiserver/pkg/server/storage"
+	"k8s.io/kubernetes/pkg/api/legacyscheme"
+	controlplaneapiserver "k8s.io/kubernetes/pkg/controlplane/apiserver"
+
+	usagesummariesregistry "go.miloapis.com/milo/internal/apiserver/quota/usagesummaries"
+	quotausagev1alpha1 "go.miloapis.com/milo/pkg/apis/quotausage/v1alpha1"
+)
+
+type StorageProvider struct {
+	UsageSummaries   usagesummariesregistry.Backend
+	BucketBreakdowns usagesummariesregistry.BreakdownBackend
+}
+
+func (p StorageProvider) GroupName() string { return quotausagev1alpha1.SchemeGroupVersion.Group }
+
+func (p StorageProvider) NewRESTStorage(
+	_ serverstorage.APIResourceConfigSource,
+	_ generic.RESTOptionsGetter,
+) (genericapiserver.APIGroupInfo, error) {
+	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(
+		quotausagev1alpha1.SchemeGroupVersion.Group,
+		legacyscheme.Scheme,
+		metav1.ParameterCodec,
+		legacyscheme.Codecs,
+	)
+
+	storage := map[string]rest.Storage{
+		"quotausagesummaries":       usagesummariesregistry.NewREST(p.UsageSummaries),
+		"allowancebucketbreakdowns": usagesummariesregistry.NewBreakdownREST(p.BucketBreakdowns),
+	}
+
+	apiGroupInfo.VersionedResourcesStorageMap = map[string]map[string]rest.Storage{
+		quotausagev1alpha1.SchemeGroupVersion.Version: storage,
+	}
+
+	return apiGroupInfo, nil
+}
+
+var _ controlplaneapiserver.RESTStorageProvider = StorageProvider{}
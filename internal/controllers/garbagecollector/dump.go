@@ -18,6 +18,7 @@ package garbagecollector
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -331,7 +332,42 @@ func (h *debugHTTPHandler) pickBuilders(project string) []*GraphBuilder {
 	return nil
 }
 
+// partitionStatus is the per-partition entry served by the /partitions debug endpoint.
+type partitionStatus struct {
+	Project   string `json:"project"`
+	Synced    bool   `json:"synced"`
+	Resources int    `json:"resources"`
+}
+
+// servePartitions writes the sync state and monitored resource count of every
+// partition as JSON, sorted by project.
+func (h *debugHTTPHandler) servePartitions(w http.ResponseWriter) {
+	synced := h.controller.PartitionStatus()
+	counts := h.controller.PartitionMonitorCounts()
+
+	partitions := make([]partitionStatus, 0, len(synced))
+	for project, ok := range synced {
+		partitions = append(partitions, partitionStatus{Project: project, Synced: ok, Resources: counts[project]})
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i].Project < partitions[j].Project })
+
+	body, err := json.Marshal(partitions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
 func (h *debugHTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/partitions" {
+		h.servePartitions(w)
+		return
+	}
 	if req.URL.Path != "/graph" {
 		http.Error(w, "", http.StatusNotFound)
 		return
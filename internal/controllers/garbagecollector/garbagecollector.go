@@ -77,11 +77,24 @@ type GarbageCollector struct {
 
 	dependencyGraphBuilders []*GraphBuilder
 	cancels                 map[string]context.CancelFunc
+	// removedProjects records when partitions were removed by RemoveProject, so their
+	// items still sitting in the shared queues are forgotten instead of requeued
+	// forever. A project is cleared from it when it is added again, or once
+	// removedProjectRetention has passed and its items are gone from the queues.
+	removedProjects map[string]time.Time
+
+	// deletionLimiter paces attemptToDelete work per partition. Nil means unlimited.
+	deletionLimiter *partitionDeletionLimiter
 }
 
 var _ controller.Interface = (*GarbageCollector)(nil)
 var _ controller.Debuggable = (*GarbageCollector)(nil)
 
+// AddProject starts a partition of the garbage collector for project and waits
+// up to initialSyncTimeout for its monitors to sync. When requireInitialSync is
+// true and the monitors do not sync in time, the partition is removed again and
+// an error is returned so registration can be retried; otherwise the partition
+// keeps running and syncs in the background.
 func (gc *GarbageCollector) AddProject(
 	parent context.Context,
 	project string,
@@ -92,12 +105,15 @@ func (gc *GarbageCollector) AddProject(
 	informersStarted <-chan struct{},
 	discover discovery.ServerResourcesInterface,
 	initialSyncTimeout time.Duration,
+	requireInitialSync bool,
 ) error {
 	// ensure maps
 	gc.mu.Lock()
 	if gc.cancels == nil {
 		gc.cancels = make(map[string]context.CancelFunc)
 	}
+	delete(gc.removedProjects, project)
+	gc.pruneRemovedProjects(time.Now())
 	gc.mu.Unlock()
 
 	// Reuse shared queues/cache from GC (created from the root GB).
@@ -143,7 +159,12 @@ func (gc *GarbageCollector) AddProject(
 		func() bool { return gb.IsSynced(logger) },
 	)
 	if !ok {
-		logger.Info("GC: partition monitors not fully synced; continuing", "project", project)
+		if requireInitialSync {
+			gc.RemoveProject(project)
+			return fmt.Errorf("gc(%s): partition monitors did not sync within %s", project, initialSyncTimeout)
+		}
+		logger.Info("GC: partition monitors not fully synced within initial sync timeout; continuing while they sync in the background",
+			"project", project, "timeout", initialSyncTimeout)
 	}
 
 	return nil
@@ -163,7 +184,44 @@ func (gc *GarbageCollector) RemoveProject(project string) {
 		}
 	}
 	gc.dependencyGraphBuilders = dst
+	if gc.removedProjects == nil {
+		gc.removedProjects = make(map[string]time.Time)
+	}
+	now := time.Now()
+	gc.pruneRemovedProjects(now)
+	gc.removedProjects[project] = now
 	gc.mu.Unlock()
+
+	gc.deletionLimiter.forget(project)
+	forgetPartitionMetrics(project)
+}
+
+// removedProjectRetention is how long a removed partition is remembered. It exceeds
+// the shared queues' maximum backoff of 1000s, so every item the partition left in
+// them has been processed and forgotten by then.
+const removedProjectRetention = 30 * time.Minute
+
+// pruneRemovedProjects forgets partitions removed more than removedProjectRetention
+// before now. gc.mu must be held.
+func (gc *GarbageCollector) pruneRemovedProjects(now time.Time) {
+	for project, removedAt := range gc.removedProjects {
+		if now.Sub(removedAt) > removedProjectRetention {
+			delete(gc.removedProjects, project)
+		}
+	}
+}
+
+// SetPartitionDeletionRateLimit limits attemptToDelete processing to qps items
+// per second (with the given burst) for each partition. Items that exceed the
+// limit are requeued after the limiter's delay instead of blocking a worker,
+// so other partitions are unaffected. A non-positive qps disables the limit.
+// It must be called before Run.
+func (gc *GarbageCollector) SetPartitionDeletionRateLimit(qps float64, burst int) {
+	if qps <= 0 {
+		gc.deletionLimiter = nil
+		return
+	}
+	gc.deletionLimiter = newPartitionDeletionLimiter(qps, burst)
 }
 
 // NewGarbageCollector creates a new GarbageCollector.
@@ -425,6 +483,33 @@ func (gc *GarbageCollector) IsSynced(logger klog.Logger) bool {
 	return true
 }
 
+// PartitionStatus returns whether the monitors of each partition have synced,
+// keyed by project.
+func (gc *GarbageCollector) PartitionStatus() map[string]bool {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+
+	logger := klog.Background()
+	status := make(map[string]bool, len(gc.dependencyGraphBuilders))
+	for _, gb := range gc.dependencyGraphBuilders {
+		status[gb.project] = gb.IsSynced(logger)
+	}
+	return status
+}
+
+// PartitionMonitorCounts returns the number of resources monitored by each
+// partition, keyed by project.
+func (gc *GarbageCollector) PartitionMonitorCounts() map[string]int {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+
+	counts := make(map[string]int, len(gc.dependencyGraphBuilders))
+	for _, gb := range gc.dependencyGraphBuilders {
+		counts[gb.project] = gb.monitorCount()
+	}
+	return counts
+}
+
 func (gc *GarbageCollector) runAttemptToDeleteWorker(ctx context.Context) {
 	for gc.processAttemptToDeleteWorker(ctx) {
 	}
@@ -457,6 +542,9 @@ type workQueueItemAction int
 const (
 	requeueItem = iota
 	forgetItem
+	// delayedItem indicates the worker already re-added the item with a delay,
+	// so the queue's rate limiter state must be left untouched.
+	delayedItem
 )
 
 // helper: find builder by project id
@@ -469,6 +557,18 @@ func (gc *GarbageCollector) builderForProject(project string) *GraphBuilder {
 	return nil
 }
 
+// isRemovedProject reports whether the partition for project was removed and
+// not added again.
+func (gc *GarbageCollector) isRemovedProject(project string) bool {
+	if project == "" {
+		return false
+	}
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	_, removed := gc.removedProjects[project]
+	return removed
+}
+
 // helper: fallback — search any builder by UID (only used if project is empty)
 func (gc *GarbageCollector) findNodeInAnyBuilder(uid types.UID) (*node, bool) {
 	for _, gb := range gc.dependencyGraphBuilders {
@@ -479,7 +579,7 @@ func (gc *GarbageCollector) findNodeInAnyBuilder(uid types.UID) (*node, bool) {
 	return nil, false
 }
 
-func (gc *GarbageCollector) attemptToDeleteWorker(ctx context.Context, item interface{}) workQueueItemAction {
+func (gc *GarbageCollector) attemptToDeleteWorker(ctx context.Context, item interface{}) (action workQueueItemAction) {
 	n, ok := item.(*node)
 	if !ok {
 		utilruntime.HandleError(fmt.Errorf("expect *node, got %#v", item))
@@ -487,6 +587,14 @@ func (gc *GarbageCollector) attemptToDeleteWorker(ctx context.Context, item inte
 	}
 	logger := klog.FromContext(ctx)
 
+	// Items are labeled with their partition once its graph is found
+	partition := unknownPartitionLabel
+	defer func() {
+		if action == requeueItem {
+			partitionRequeues.WithLabelValues(partition, attemptToDeleteQueueLabel).Inc()
+		}
+	}()
+
 	// choose the right graph for this node’s partition
 	var gb *GraphBuilder
 	if n.identity.Project != "" {
@@ -499,12 +607,19 @@ func (gc *GarbageCollector) attemptToDeleteWorker(ctx context.Context, item inte
 		}
 	}
 	if gb == nil {
-		// No graph for that project (partition removed or not registered yet).
+		if gc.isRemovedProject(n.identity.Project) {
+			logger.V(2).Info("node's project was removed; forgetting item",
+				"project", n.identity.Project, "item", n.identity)
+			return forgetItem
+		}
+		// No graph for that project (not registered yet).
 		// Requeue to give registration a chance to happen.
 		logger.V(2).Info("no graphbuilder for node's project; requeuing",
 			"project", n.identity.Project, "item", n.identity)
 		return requeueItem
 	}
+	partition = gb.project
+	partitionDeleteAttempts.WithLabelValues(partition).Inc()
 
 	if !n.isObserved() {
 		nodeFromGraph, existsInGraph := gb.uidToNode.Read(n.identity.UID)
@@ -520,6 +635,13 @@ func (gc *GarbageCollector) attemptToDeleteWorker(ctx context.Context, item inte
 		}
 	}
 
+	if d := gc.deletionLimiter.delay(n.identity.Project, time.Now()); d > 0 {
+		logger.V(5).Info("partition deletion rate limit reached; delaying item",
+			"project", n.identity.Project, "item", n.identity, "delay", d)
+		gc.attemptToDelete.AddAfter(n, d)
+		return delayedItem
+	}
+
 	err := gc.attemptToDeleteItem(ctx, n)
 	switch {
 	case err == nil:
@@ -855,12 +977,26 @@ func (gc *GarbageCollector) processAttemptToOrphanWorker(logger klog.Logger) boo
 	return true
 }
 
-func (gc *GarbageCollector) attemptToOrphanWorker(logger klog.Logger, item interface{}) workQueueItemAction {
+func (gc *GarbageCollector) attemptToOrphanWorker(logger klog.Logger, item interface{}) (action workQueueItemAction) {
 	owner, ok := item.(*node)
 	if !ok {
 		utilruntime.HandleError(fmt.Errorf("expect *node, got %#v", item))
 		return forgetItem
 	}
+
+	if gc.isRemovedProject(owner.identity.Project) {
+		logger.V(2).Info("owner's project was removed; forgetting item",
+			"project", owner.identity.Project, "item", owner.identity)
+		return forgetItem
+	}
+
+	partition := gc.partitionMetricLabel(owner.identity.Project)
+	partitionOrphanOperations.WithLabelValues(partition).Inc()
+	defer func() {
+		if action == requeueItem {
+			partitionRequeues.WithLabelValues(partition, attemptToOrphanQueueLabel).Inc()
+		}
+	}()
 	// we don't need to lock each element, because they never get updated
 	owner.dependentsLock.RLock()
 	dependents := make([]*node, 0, len(owner.dependents))
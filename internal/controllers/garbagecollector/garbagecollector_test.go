@@ -0,0 +1,162 @@
+package garbagecollector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// fakeMonitorController reports a fixed sync state for a monitor.
+type fakeMonitorController struct {
+	cache.Controller
+	synced bool
+}
+
+func (c *fakeMonitorController) HasSynced() bool { return c.synced }
+
+func newTestGraphBuilder(project string, synced bool, resources ...schema.GroupVersionResource) *GraphBuilder {
+	gb := &GraphBuilder{project: project, monitors: monitors{}}
+	for _, resource := range resources {
+		gb.monitors[resource] = &monitor{controller: &fakeMonitorController{synced: synced}}
+	}
+	return gb
+}
+
+func TestPartitionStatus(t *testing.T) {
+	configMaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	secrets := schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+
+	gc := &GarbageCollector{
+		dependencyGraphBuilders: []*GraphBuilder{
+			newTestGraphBuilder("synced", true, configMaps, secrets),
+			newTestGraphBuilder("lagging", false, configMaps),
+		},
+	}
+
+	status := gc.PartitionStatus()
+	if len(status) != 2 || !status["synced"] || status["lagging"] {
+		t.Fatalf("expected only the synced partition to report synced, got %v", status)
+	}
+	if gc.IsSynced(klog.Background()) {
+		t.Fatalf("expected the garbage collector not to be synced while a partition lags")
+	}
+
+	counts := gc.PartitionMonitorCounts()
+	if counts["synced"] != 2 || counts["lagging"] != 1 {
+		t.Fatalf("unexpected monitored resource counts: %v", counts)
+	}
+
+	rec := httptest.NewRecorder()
+	gc.DebuggingHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/partitions", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var partitions []partitionStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &partitions); err != nil {
+		t.Fatalf("failed to decode partitions: %v", err)
+	}
+	expected := []partitionStatus{
+		{Project: "lagging", Synced: false, Resources: 1},
+		{Project: "synced", Synced: true, Resources: 2},
+	}
+	if len(partitions) != len(expected) {
+		t.Fatalf("expected %d partitions, got %v", len(expected), partitions)
+	}
+	for i := range expected {
+		if partitions[i] != expected[i] {
+			t.Errorf("partition %d: expected %+v, got %+v", i, expected[i], partitions[i])
+		}
+	}
+}
+
+func TestRemovedPartitionItemsAreForgotten(t *testing.T) {
+	gc := &GarbageCollector{
+		dependencyGraphBuilders: []*GraphBuilder{
+			newTestGraphBuilder("removed", true),
+		},
+	}
+	gc.RemoveProject("removed")
+
+	itemFor := func(project string) *node {
+		return &node{identity: objectReference{
+			Project:        project,
+			OwnerReference: metav1.OwnerReference{Kind: "ConfigMap", Name: "cm", UID: "uid-1"},
+			Namespace:      "default",
+		}}
+	}
+
+	if action := gc.attemptToDeleteWorker(context.Background(), itemFor("removed")); action != forgetItem {
+		t.Fatalf("expected attemptToDelete item of a removed partition to be forgotten, got action %v", action)
+	}
+	if action := gc.attemptToOrphanWorker(klog.Background(), itemFor("removed")); action != forgetItem {
+		t.Fatalf("expected attemptToOrphan item of a removed partition to be forgotten, got action %v", action)
+	}
+
+	// Items of partitions that were never registered may still be waiting for registration.
+	if action := gc.attemptToDeleteWorker(context.Background(), itemFor("pending")); action != requeueItem {
+		t.Fatalf("expected item of an unregistered partition to be requeued, got action %v", action)
+	}
+}
+
+func TestRemovedPartitionsArePruned(t *testing.T) {
+	gc := &GarbageCollector{
+		removedProjects: map[string]time.Time{
+			"torn-down": time.Now().Add(-2 * removedProjectRetention),
+			"recent":    time.Now(),
+		},
+	}
+	gc.RemoveProject("removed")
+
+	if gc.isRemovedProject("torn-down") {
+		t.Errorf("expected a partition removed longer than the retention ago to be pruned")
+	}
+	for _, project := range []string{"recent", "removed"} {
+		if !gc.isRemovedProject(project) {
+			t.Errorf("expected recently removed partition %q to be remembered", project)
+		}
+	}
+}
+
+func TestAddProjectInitialSync(t *testing.T) {
+	informersStarted := make(chan struct{})
+	close(informersStarted)
+
+	for _, tc := range []struct {
+		name               string
+		requireInitialSync bool
+		wantErr            bool
+	}{
+		{name: "continues with a cold cache", requireInitialSync: false, wantErr: false},
+		{name: "fails when sync is required", requireInitialSync: true, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Discovery reports no resources, so the partition never has synced monitors.
+			gc := &GarbageCollector{}
+			discover := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+			err := gc.AddProject(ctx, "cold", nil, nil, nil, nil, informersStarted, discover, 50*time.Millisecond, tc.requireInitialSync)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("AddProject() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			_, registered := gc.PartitionStatus()["cold"]
+			if registered == tc.wantErr {
+				t.Errorf("partition registered = %v after AddProject() error = %v", registered, err)
+			}
+			gc.RemoveProject("cold")
+		})
+	}
+}
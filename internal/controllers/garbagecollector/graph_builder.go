@@ -357,6 +357,13 @@ func (gb *GraphBuilder) IsResourceSynced(resource schema.GroupVersionResource) b
 	return ok && monitor.controller.HasSynced()
 }
 
+// monitorCount returns the number of resources the GraphBuilder monitors.
+func (gb *GraphBuilder) monitorCount() int {
+	gb.monitorLock.Lock()
+	defer gb.monitorLock.Unlock()
+	return len(gb.monitors)
+}
+
 // IsSynced returns true if any monitors exist AND all those monitors'
 // controllers HasSynced functions return true. This means IsSynced could return
 // true at one time, and then later return false if all monitors were
@@ -0,0 +1,72 @@
+package garbagecollector
+
+import (
+	"k8s.io/component-base/metrics"
+	legacyregistry "k8s.io/component-base/metrics/legacyregistry"
+)
+
+// unknownPartitionLabel is the partition label used for items whose project has no
+// registered partition, keeping label cardinality bounded by the registered partitions.
+const unknownPartitionLabel = "unknown"
+
+const (
+	attemptToDeleteQueueLabel = "attempt_to_delete"
+	attemptToOrphanQueueLabel = "attempt_to_orphan"
+)
+
+var (
+	partitionDeleteAttempts = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      "milo_garbage_collector",
+			Name:           "partition_delete_attempts_total",
+			Help:           "Items processed by the attemptToDelete workers, labeled by partition.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"project"},
+	)
+
+	partitionOrphanOperations = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      "milo_garbage_collector",
+			Name:           "partition_orphan_operations_total",
+			Help:           "Items processed by the attemptToOrphan workers, labeled by partition.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"project"},
+	)
+
+	partitionRequeues = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      "milo_garbage_collector",
+			Name:           "partition_requeues_total",
+			Help:           "Items requeued after a failed attempt, labeled by partition and queue.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"project", "queue"}, // queue: attempt_to_delete|attempt_to_orphan
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(partitionDeleteAttempts)
+	legacyregistry.MustRegister(partitionOrphanOperations)
+	legacyregistry.MustRegister(partitionRequeues)
+}
+
+// partitionMetricLabel returns the partition label value for project, or
+// unknownPartitionLabel when no partition is registered for it.
+func (gc *GarbageCollector) partitionMetricLabel(project string) string {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	if gc.builderForProject(project) == nil {
+		return unknownPartitionLabel
+	}
+	return project
+}
+
+// forgetPartitionMetrics drops the series of a removed partition.
+func forgetPartitionMetrics(project string) {
+	partitionDeleteAttempts.DeleteLabelValues(project)
+	partitionOrphanOperations.DeleteLabelValues(project)
+	partitionRequeues.DeleteLabelValues(project, attemptToDeleteQueueLabel)
+	partitionRequeues.DeleteLabelValues(project, attemptToOrphanQueueLabel)
+}
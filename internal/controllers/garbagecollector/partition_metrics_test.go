@@ -0,0 +1,52 @@
+package garbagecollector
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestAttemptToDeleteWorkerLabelsMetricsByPartition(t *testing.T) {
+	gc := &GarbageCollector{
+		dependencyGraphBuilders: []*GraphBuilder{
+			{project: "tenant", uidToNode: &concurrentUIDToNode{uidToNode: map[types.UID]*node{}}},
+		},
+	}
+
+	attempts := partitionDeleteAttempts.WithLabelValues("tenant")
+	unknownRequeues := partitionRequeues.WithLabelValues(unknownPartitionLabel, attemptToDeleteQueueLabel)
+	attemptsBefore, _ := testutil.GetCounterMetricValue(attempts)
+	requeuesBefore, _ := testutil.GetCounterMetricValue(unknownRequeues)
+
+	// A virtual node that is no longer in its partition's graph is processed and forgotten.
+	n := &node{
+		identity: objectReference{
+			Project:        "tenant",
+			OwnerReference: metav1.OwnerReference{Kind: "ConfigMap", Name: "cm", UID: "uid-1"},
+			Namespace:      "default",
+		},
+		virtual: true,
+	}
+	if action := gc.attemptToDeleteWorker(context.Background(), n); action != forgetItem {
+		t.Fatalf("expected item to be forgotten, got action %v", action)
+	}
+	if after, _ := testutil.GetCounterMetricValue(attempts); after-attemptsBefore != 1 {
+		t.Fatalf("expected one delete attempt labeled with the node's project, got %v", after-attemptsBefore)
+	}
+
+	// A node whose partition is not registered is requeued under the unknown label.
+	orphaned := &node{identity: objectReference{
+		Project:        "removed",
+		OwnerReference: metav1.OwnerReference{Kind: "ConfigMap", Name: "cm", UID: "uid-2"},
+		Namespace:      "default",
+	}}
+	if action := gc.attemptToDeleteWorker(context.Background(), orphaned); action != requeueItem {
+		t.Fatalf("expected item to be requeued, got action %v", action)
+	}
+	if after, _ := testutil.GetCounterMetricValue(unknownRequeues); after-requeuesBefore != 1 {
+		t.Fatalf("expected one requeue labeled %q, got %v", unknownPartitionLabel, after-requeuesBefore)
+	}
+}
@@ -0,0 +1,79 @@
+package garbagecollector
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// partitionDeletionLimiter paces attemptToDelete work independently for each
+// partition so that a burst of garbage in one project cannot overwhelm that
+// project's API server, while other partitions continue unthrottled.
+//
+// A nil limiter, or one configured with a non-positive QPS, never delays work.
+type partitionDeletionLimiter struct {
+	mu       sync.Mutex
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+// newPartitionDeletionLimiter returns a limiter allowing qps deletions per
+// second with the given burst for every partition. A non-positive qps disables
+// rate limiting.
+func newPartitionDeletionLimiter(qps float64, burst int) *partitionDeletionLimiter {
+	limit := rate.Inf
+	if qps > 0 {
+		limit = rate.Limit(qps)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &partitionDeletionLimiter{
+		limit:    limit,
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// delay reserves a deletion token for the partition and returns how long the
+// caller must wait before the deletion may be attempted. When the returned
+// duration is non-zero the reservation is released so the caller can requeue
+// the item without consuming capacity.
+func (l *partitionDeletionLimiter) delay(project string, now time.Time) time.Duration {
+	if l == nil || l.limit == rate.Inf {
+		return 0
+	}
+
+	r := l.limiterFor(project).ReserveN(now, 1)
+	if !r.OK() {
+		return 0
+	}
+	d := r.DelayFrom(now)
+	if d > 0 {
+		r.CancelAt(now)
+	}
+	return d
+}
+
+// forget drops the limiter state for a partition that is no longer tracked.
+func (l *partitionDeletionLimiter) forget(project string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	delete(l.limiters, project)
+	l.mu.Unlock()
+}
+
+func (l *partitionDeletionLimiter) limiterFor(project string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lim, ok := l.limiters[project]
+	if !ok {
+		lim = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[project] = lim
+	}
+	return lim
+}
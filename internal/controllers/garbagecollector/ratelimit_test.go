@@ -0,0 +1,92 @@
+package garbagecollector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestPartitionDeletionLimiter(t *testing.T) {
+	now := time.Now()
+	l := newPartitionDeletionLimiter(1, 2)
+
+	// Burst is consumed by the noisy partition.
+	for i := 0; i < 2; i++ {
+		if d := l.delay("noisy", now); d != 0 {
+			t.Fatalf("call %d: expected no delay within burst, got %v", i, d)
+		}
+	}
+	if d := l.delay("noisy", now); d <= 0 {
+		t.Fatalf("expected noisy partition to be delayed once burst is exhausted")
+	}
+
+	// A different partition has its own budget.
+	for i := 0; i < 2; i++ {
+		if d := l.delay("quiet", now); d != 0 {
+			t.Fatalf("call %d: expected quiet partition to proceed unthrottled, got %v", i, d)
+		}
+	}
+
+	// Delayed reservations are released, so capacity is available again after one interval.
+	if d := l.delay("noisy", now.Add(time.Second)); d != 0 {
+		t.Fatalf("expected noisy partition to recover after one interval, got %v", d)
+	}
+
+	// Forgetting a partition resets its budget.
+	l.forget("noisy")
+	if d := l.delay("noisy", now.Add(time.Second)); d != 0 {
+		t.Fatalf("expected forgotten partition to start with a full burst, got %v", d)
+	}
+}
+
+func TestPartitionDeletionLimiterDisabled(t *testing.T) {
+	var nilLimiter *partitionDeletionLimiter
+	if d := nilLimiter.delay("p", time.Now()); d != 0 {
+		t.Fatalf("expected nil limiter to never delay, got %v", d)
+	}
+
+	l := newPartitionDeletionLimiter(0, 0)
+	for i := 0; i < 100; i++ {
+		if d := l.delay("p", time.Now()); d != 0 {
+			t.Fatalf("expected disabled limiter to never delay, got %v", d)
+		}
+	}
+}
+
+func TestAttemptToDeleteWorkerRateLimitsPerPartition(t *testing.T) {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[*node]())
+	defer queue.ShutDown()
+
+	gc := &GarbageCollector{
+		attemptToDelete: queue,
+		dependencyGraphBuilders: []*GraphBuilder{
+			{project: "noisy"},
+			{project: "quiet"},
+		},
+	}
+	gc.SetPartitionDeletionRateLimit(0.001, 1)
+
+	// Exhaust the noisy partition's burst and confirm the quiet partition still has budget.
+	if d := gc.deletionLimiter.delay("noisy", time.Now()); d != 0 {
+		t.Fatalf("expected first noisy deletion to proceed, got delay %v", d)
+	}
+	if d := gc.deletionLimiter.delay("quiet", time.Now()); d != 0 {
+		t.Fatalf("expected quiet partition to proceed unthrottled, got delay %v", d)
+	}
+
+	n := &node{identity: objectReference{
+		Project:        "noisy",
+		OwnerReference: metav1.OwnerReference{Kind: "ConfigMap", Name: "cm", UID: "uid-1"},
+		Namespace:      "default",
+	}}
+
+	if action := gc.attemptToDeleteWorker(context.Background(), n); action != delayedItem {
+		t.Fatalf("expected throttled item to be delayed, got action %v", action)
+	}
+	if got := queue.NumRequeues(n); got != 0 {
+		t.Fatalf("expected delayed item not to count as a failure requeue, got %d", got)
+	}
+}
@@ -24,6 +24,9 @@ type GCSink struct {
 	Ignored           map[schema.GroupResource]struct{}
 	InformersStarted  <-chan struct{}
 	InitialSyncPeriod time.Duration
+	// RequireInitialSync fails AddProject when the partition's monitors do not
+	// sync within InitialSyncPeriod, instead of continuing with a cold cache.
+	RequireInitialSync bool
 }
 
 func (s *GCSink) AddProject(ctx context.Context, id string, cfg *rest.Config) error {
@@ -46,6 +49,7 @@ func (s *GCSink) AddProject(ctx context.Context, id string, cfg *rest.Config) er
 		s.InformersStarted,
 		discProj,
 		s.InitialSyncPeriod,
+		s.RequireInitialSync,
 	)
 }
 
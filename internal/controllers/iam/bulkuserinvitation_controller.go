@@ -0,0 +1,170 @@
+package iam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	iamv1alpha1 "go.miloapis.com/milo/pkg/apis/iam/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// BulkUserInvitationController creates a UserInvitation for every entry of a BulkUserInvitation and
+// reports the outcome of each in its status. The UserInvitations are controlled by the
+// BulkUserInvitation, so they are garbage collected when it is deleted.
+type BulkUserInvitationController struct {
+	Client client.Client
+}
+
+// +kubebuilder:rbac:groups=iam.miloapis.com,resources=bulkuserinvitations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=iam.miloapis.com,resources=bulkuserinvitations/status,verbs=update
+// +kubebuilder:rbac:groups=iam.miloapis.com,resources=userinvitations,verbs=get;list;watch;create
+
+func (r *BulkUserInvitationController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx).WithValues("controller", "BulkUserInvitationController", "trigger", req.NamespacedName)
+	log.Info("Starting reconciliation", "name", req.Name)
+
+	bui := &iamv1alpha1.BulkUserInvitation{}
+	if err := r.Client.Get(ctx, req.NamespacedName, bui); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("BulkUserInvitation not found, probably deleted. Skipping reconciliation")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get BulkUserInvitation")
+		return ctrl.Result{}, fmt.Errorf("failed to get BulkUserInvitation: %w", err)
+	}
+
+	if !bui.DeletionTimestamp.IsZero() {
+		// The UserInvitations are removed by the garbage collector through their owner references
+		return ctrl.Result{}, nil
+	}
+
+	oldStatus := bui.Status.DeepCopy()
+
+	previous := map[string]iamv1alpha1.BulkUserInvitationResult{}
+	for _, result := range bui.Status.Invitations {
+		previous[strings.ToLower(result.Email)] = result
+	}
+
+	results := make([]iamv1alpha1.BulkUserInvitationResult, 0, len(bui.Spec.Invitations))
+	var failed []string
+	for _, entry := range bui.Spec.Invitations {
+		result, err := r.ensureUserInvitation(ctx, bui, entry, previous[strings.ToLower(entry.Email)])
+		if err != nil {
+			log.Error(err, "Failed to create UserInvitation", "email", entry.Email)
+			return ctrl.Result{}, fmt.Errorf("failed to create UserInvitation for %s: %w", entry.Email, err)
+		}
+		if result.State == iamv1alpha1.BulkUserInvitationResultFailed {
+			failed = append(failed, entry.Email)
+		}
+		results = append(results, result)
+	}
+	bui.Status.Invitations = results
+
+	condition := metav1.Condition{
+		Type:    iamv1alpha1.BulkUserInvitationReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  iamv1alpha1.BulkUserInvitationCreatedReason,
+		Message: fmt.Sprintf("Created %d UserInvitations", len(results)),
+	}
+	if len(failed) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = iamv1alpha1.BulkUserInvitationFailedReason
+		condition.Message = fmt.Sprintf("Failed to create UserInvitations for %d of %d emails: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	meta.SetStatusCondition(&bui.Status.Conditions, condition)
+
+	if !equality.Semantic.DeepEqual(&bui.Status, oldStatus) {
+		if err := r.Client.Status().Update(ctx, bui); err != nil {
+			log.Error(err, "Failed to update BulkUserInvitation status")
+			return ctrl.Result{}, fmt.Errorf("failed to update BulkUserInvitation status: %w", err)
+		}
+	}
+
+	log.Info("BulkUserInvitation reconciled", "invitations", len(results), "failed", len(failed))
+	return ctrl.Result{}, nil
+}
+
+// ensureUserInvitation creates the UserInvitation for one entry of the BulkUserInvitation unless it
+// already exists. Requests the UserInvitation webhooks or API reject are reported as failed results,
+// while other errors are returned so the BulkUserInvitation is reconciled again.
+func (r *BulkUserInvitationController) ensureUserInvitation(ctx context.Context, bui *iamv1alpha1.BulkUserInvitation, entry iamv1alpha1.BulkUserInvitationEntry, previous iamv1alpha1.BulkUserInvitationResult) (iamv1alpha1.BulkUserInvitationResult, error) {
+	name := getBulkUserInvitationChildName(bui, entry.Email)
+	result := iamv1alpha1.BulkUserInvitationResult{
+		Email:              entry.Email,
+		UserInvitationName: name,
+		State:              iamv1alpha1.BulkUserInvitationResultCreated,
+	}
+
+	existing := &iamv1alpha1.UserInvitation{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: bui.Namespace, Name: name}, existing)
+	switch {
+	case err == nil:
+		if !metav1.IsControlledBy(existing, bui) {
+			result.State = iamv1alpha1.BulkUserInvitationResultFailed
+			result.Message = fmt.Sprintf("UserInvitation %s already exists and is not controlled by this BulkUserInvitation", name)
+		}
+		return result, nil
+	case !errors.IsNotFound(err):
+		return result, fmt.Errorf("failed to get UserInvitation %s: %w", name, err)
+	case previous.State == iamv1alpha1.BulkUserInvitationResultCreated:
+		// The UserInvitation was created before and deleted since, so it is not recreated
+		return previous, nil
+	}
+
+	ui := &iamv1alpha1.UserInvitation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: bui.Namespace,
+		},
+		Spec: iamv1alpha1.UserInvitationSpec{
+			OrganizationRef: bui.Spec.OrganizationRef,
+			Email:           entry.Email,
+			GivenName:       entry.GivenName,
+			FamilyName:      entry.FamilyName,
+			Roles:           entry.Roles,
+			InvitedBy:       bui.Spec.InvitedBy,
+			ExpirationDate:  bui.Spec.ExpirationDate,
+			State:           iamv1alpha1.UserInvitationStatePending,
+		},
+	}
+	if err := controllerutil.SetControllerReference(bui, ui, r.Client.Scheme()); err != nil {
+		return result, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	if err := r.Client.Create(ctx, ui); err != nil {
+		if errors.IsInvalid(err) || errors.IsForbidden(err) || errors.IsBadRequest(err) {
+			result.State = iamv1alpha1.BulkUserInvitationResultFailed
+			result.Message = err.Error()
+			return result, nil
+		}
+		return result, err
+	}
+
+	return result, nil
+}
+
+// getBulkUserInvitationChildName generates a deterministic name for the UserInvitation created for an
+// email of the BulkUserInvitation. The email is hashed as it may include characters that are not
+// allowed in resource names.
+func getBulkUserInvitationChildName(bui *iamv1alpha1.BulkUserInvitation, email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	return fmt.Sprintf("%s-%s", string(bui.GetUID()), hex.EncodeToString(sum[:])[:10])
+}
+
+func (r *BulkUserInvitationController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&iamv1alpha1.BulkUserInvitation{}).
+		Named("bulkuserinvitation").
+		Complete(r)
+}
@@ -0,0 +1,137 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	iamv1alpha1 "go.miloapis.com/milo/pkg/apis/iam/v1alpha1"
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newTestBulkUserInvitation(emails ...string) *iamv1alpha1.BulkUserInvitation {
+	bui := &iamv1alpha1.BulkUserInvitation{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "organization-org", UID: types.UID("bui-uid")},
+		Spec: iamv1alpha1.BulkUserInvitationSpec{
+			OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "org"},
+			InvitedBy:       iamv1alpha1.UserReference{Name: "inviter"},
+		},
+	}
+	for _, email := range emails {
+		bui.Spec.Invitations = append(bui.Spec.Invitations, iamv1alpha1.BulkUserInvitationEntry{
+			Email: email,
+			Roles: []iamv1alpha1.RoleReference{{Name: "org-viewer", Namespace: "milo-system"}},
+		})
+	}
+	return bui
+}
+
+// TestBulkUserInvitationController_Reconcile_CreatesInvitations verifies that a UserInvitation is created for
+// every email, controlled by the BulkUserInvitation, and that reconciling again is idempotent.
+func TestBulkUserInvitationController_Reconcile_CreatesInvitations(t *testing.T) {
+	ctx := context.TODO()
+	bui := newTestBulkUserInvitation("first@example.com", "second@example.com")
+
+	c := fake.NewClientBuilder().WithScheme(getTestScheme()).
+		WithStatusSubresource(&iamv1alpha1.BulkUserInvitation{}).
+		WithObjects(bui).
+		Build()
+	r := &BulkUserInvitationController{Client: c}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: bui.Name, Namespace: bui.Namespace}}
+	for i := range 2 {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("reconcile %d error: %v", i, err)
+		}
+	}
+
+	var invitations iamv1alpha1.UserInvitationList
+	if err := c.List(ctx, &invitations, client.InNamespace(bui.Namespace)); err != nil {
+		t.Fatalf("list UserInvitations: %v", err)
+	}
+	if len(invitations.Items) != 2 {
+		t.Fatalf("expected 2 UserInvitations, got %d", len(invitations.Items))
+	}
+	for _, ui := range invitations.Items {
+		if !metav1.IsControlledBy(&ui, bui) {
+			t.Errorf("expected UserInvitation %s to be controlled by the BulkUserInvitation", ui.Name)
+		}
+		if ui.Spec.State != iamv1alpha1.UserInvitationStatePending || ui.Spec.InvitedBy.Name != "inviter" || ui.Spec.OrganizationRef.Name != "org" {
+			t.Errorf("unexpected UserInvitation spec: %+v", ui.Spec)
+		}
+	}
+
+	updated := &iamv1alpha1.BulkUserInvitation{}
+	if err := c.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("failed to get BulkUserInvitation: %v", err)
+	}
+	if len(updated.Status.Invitations) != 2 {
+		t.Fatalf("expected 2 invitation results, got %+v", updated.Status.Invitations)
+	}
+	for i, result := range updated.Status.Invitations {
+		if result.Email != bui.Spec.Invitations[i].Email || result.State != iamv1alpha1.BulkUserInvitationResultCreated {
+			t.Errorf("unexpected result %d: %+v", i, result)
+		}
+		if result.UserInvitationName != getBulkUserInvitationChildName(bui, result.Email) {
+			t.Errorf("unexpected UserInvitation name %q", result.UserInvitationName)
+		}
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, iamv1alpha1.BulkUserInvitationReadyCondition) {
+		t.Errorf("expected BulkUserInvitation to be Ready, got %+v", updated.Status.Conditions)
+	}
+}
+
+// TestBulkUserInvitationController_Reconcile_ReportsRejectedInvitations verifies that an invitation the API
+// rejects is reported as failed without blocking the others.
+func TestBulkUserInvitationController_Reconcile_ReportsRejectedInvitations(t *testing.T) {
+	ctx := context.TODO()
+	bui := newTestBulkUserInvitation("member@example.com", "new@example.com")
+
+	c := fake.NewClientBuilder().WithScheme(getTestScheme()).
+		WithStatusSubresource(&iamv1alpha1.BulkUserInvitation{}).
+		WithObjects(bui).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if ui, ok := obj.(*iamv1alpha1.UserInvitation); ok && ui.Spec.Email == "member@example.com" {
+					return apierr.NewInvalid(iamv1alpha1.SchemeGroupVersion.WithKind("UserInvitation").GroupKind(), ui.Name, field.ErrorList{
+						field.Invalid(field.NewPath("spec").Child("email"), ui.Spec.Email, "the user is already a member of the organization"),
+					})
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &BulkUserInvitationController{Client: c}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: bui.Name, Namespace: bui.Namespace}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("reconcile error: %v", err)
+	}
+
+	updated := &iamv1alpha1.BulkUserInvitation{}
+	if err := c.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("failed to get BulkUserInvitation: %v", err)
+	}
+	if len(updated.Status.Invitations) != 2 {
+		t.Fatalf("expected 2 invitation results, got %+v", updated.Status.Invitations)
+	}
+	if rejected := updated.Status.Invitations[0]; rejected.State != iamv1alpha1.BulkUserInvitationResultFailed || rejected.Message == "" {
+		t.Errorf("expected the member's invitation to fail with a message, got %+v", rejected)
+	}
+	if created := updated.Status.Invitations[1]; created.State != iamv1alpha1.BulkUserInvitationResultCreated {
+		t.Errorf("expected the new user's invitation to be created, got %+v", created)
+	}
+	ready := meta.FindStatusCondition(updated.Status.Conditions, iamv1alpha1.BulkUserInvitationReadyCondition)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != iamv1alpha1.BulkUserInvitationFailedReason {
+		t.Errorf("expected Ready to be False with reason %s, got %+v", iamv1alpha1.BulkUserInvitationFailedReason, ready)
+	}
+}
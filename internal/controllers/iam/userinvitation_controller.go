@@ -3,15 +3,20 @@ package iam
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	iamv1alpha1 "go.miloapis.com/milo/pkg/apis/iam/v1alpha1"
 	notificationv1alpha1 "go.miloapis.com/milo/pkg/apis/notification/v1alpha1"
 	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -31,12 +36,22 @@ const (
 	userInvitationFinalizerKey   = "iam.miloapis.com/userinvitation"
 	uiPlatformAccessApprovalKey  = "iam.miloapis.com/ui-platform-access-approval"
 	uiPlatformAccessRejectionKey = "iam.miloapis.com/ui-platform-access-rejection"
+	uiOrganizationRefKey         = "iam.miloapis.com/ui-organization-ref"
+)
+
+const (
+	organizationDisplayNameAnnotation = "kubernetes.io/display-name"
 )
 
 const (
 	inviteeUserStatusUpdateConditionType = "InviteeUserStatusUpdate"
+	rolesResolvedConditionType           = "RolesResolved"
 )
 
+// missingRolesRequeueInterval is how often an invitation that references missing roles is
+// checked again, since Roles are not watched.
+const missingRolesRequeueInterval = time.Minute
+
 type UserInvitationController struct {
 	Client                          client.Client
 	finalizer                       finalizer.Finalizers
@@ -44,7 +59,12 @@ type UserInvitationController struct {
 	GetInvitationRoleName           string
 	AcceptInvitationRoleName        string
 	UserInvitationEmailTemplateName string
-	uiRelatedRoles                  []iamv1alpha1.RoleReference
+	// UserInvitationEmailTemplatesByLocale maps locales to the invitation email template used for
+	// invitees that prefer them, see iamv1alpha1.UserInvitationLocaleAnnotation.
+	UserInvitationEmailTemplatesByLocale map[string]string
+	InvitationBaseURL                    string
+	Recorder                             record.EventRecorder
+	uiRelatedRoles                       []iamv1alpha1.RoleReference
 }
 
 type userInvitationFinalizer struct {
@@ -100,6 +120,7 @@ const (
 // +kubebuilder:rbac:groups=notification.miloapis.com,resources=emailtemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=iam.miloapis.com,resources=platformaccessrejections,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups=iam.miloapis.com,resources=platformaccessapprovals,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *UserInvitationController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx).WithName("userinvitation-reconciler")
@@ -160,6 +181,9 @@ func (r *UserInvitationController) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
+	// Snapshot the status so changes to the referenced Organization and inviter can be detected
+	originalStatus := ui.Status.DeepCopy()
+
 	// Get the display name of the Organization referenced by the UserInvitation
 	organizationDisplayName, err := r.getReferencedOrganizationDisplayName(ctx, ui.Spec.OrganizationRef)
 	if err != nil {
@@ -217,10 +241,23 @@ func (r *UserInvitationController) Reconcile(ctx context.Context, req ctrl.Reque
 		log.Error(err, "Failed to send invitation email to user", "userInvitation", ui.GetName())
 		return ctrl.Result{}, fmt.Errorf("failed to send invitation email to user: %w", err)
 	}
+	if ui.Spec.State == iamv1alpha1.UserInvitationStatePending {
+		if err := r.resendInvitationEmail(ctx, ui); err != nil {
+			log.Error(err, "Failed to resend invitation email to user", "userInvitation", ui.GetName())
+			return ctrl.Result{}, fmt.Errorf("failed to resend invitation email to user: %w", err)
+		}
+	}
 
 	if user == nil {
+		// Persist the status gathered so far, e.g. a resent invitation email
+		if !equality.Semantic.DeepEqual(&ui.Status, originalStatus) {
+			if err := r.Client.Status().Update(ctx, ui); err != nil {
+				log.Error(err, "Failed to update UserInvitation status")
+				return ctrl.Result{}, fmt.Errorf("failed to update UserInvitation status: %w", err)
+			}
+		}
 		log.Info("Invitee User not found, skipping reconciliation. Reconciliation will be triggered again when the User is created.")
-		return ctrl.Result{}, nil
+		return requeueAtExpiration(ui), nil
 	}
 
 	// Grant roles to the invitee user for the organization if the invitation is accepted
@@ -270,9 +307,47 @@ func (r *UserInvitationController) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Check if the UserInvitation is pending
 	if meta.IsStatusConditionTrue(ui.Status.Conditions, string(iamv1alpha1.UserInvitationPendingCondition)) {
+		// Keep the organization and inviter details current, e.g. after the Organization is renamed
+		if !equality.Semantic.DeepEqual(&ui.Status, originalStatus) {
+			log.Info("Updating pending UserInvitation status with current organization and inviter information")
+			if err := r.Client.Status().Update(ctx, ui); err != nil {
+				log.Error(err, "Failed to update pending UserInvitation status")
+				return ctrl.Result{}, fmt.Errorf("failed to update pending UserInvitation status: %w", err)
+			}
+		}
 		log.Info("UserInvitation is pending, skipping reconciliation")
-		return ctrl.Result{}, nil
+		return requeueAtExpiration(ui), nil
+	}
+
+	// Make sure the invitation can be accepted before letting the invitee accept it, as
+	// the roles are only bound when the OrganizationMembership is created on acceptance.
+	missingRoles, err := r.findMissingRoles(ctx, ui)
+	if err != nil {
+		log.Error(err, "Failed to validate UserInvitation roles")
+		return ctrl.Result{}, fmt.Errorf("failed to validate UserInvitation roles: %w", err)
+	}
+	if len(missingRoles) > 0 {
+		message := fmt.Sprintf("Referenced roles do not exist: %s", strings.Join(missingRoles, ", "))
+		if r.Recorder != nil && !meta.IsStatusConditionFalse(ui.Status.Conditions, rolesResolvedConditionType) {
+			r.Recorder.Event(ui, corev1.EventTypeWarning, "RolesNotFound", message)
+		}
+		if err := r.updateUserInvitationStatus(ctx, ui.DeepCopy(), metav1.Condition{
+			Type:    rolesResolvedConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "RolesNotFound",
+			Message: message,
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update UserInvitation status: %w", err)
+		}
+		log.Info("UserInvitation references missing roles, not marking it as pending", "roles", missingRoles)
+		return ctrl.Result{RequeueAfter: missingRolesRequeueInterval}, nil
 	}
+	meta.SetStatusCondition(&ui.Status.Conditions, metav1.Condition{
+		Type:    rolesResolvedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "RolesFound",
+		Message: "All referenced roles exist.",
+	})
 
 	// Grant permissions to the invitee user so they can accept the invitation
 	for _, role := range r.uiRelatedRoles {
@@ -298,13 +373,25 @@ func (r *UserInvitationController) Reconcile(ctx context.Context, req ctrl.Reque
 
 	log.Info("UserInvitation reconciled", "userInvitation", ui.GetName())
 
-	return ctrl.Result{}, nil
+	return requeueAtExpiration(ui), nil
 }
 
 func (r *UserInvitationController) SetupWithManager(mgr ctrl.Manager) error {
 	log := logf.FromContext(context.Background()).WithName("userinvitation-setup-with-manager")
 	log.Info("Setting up UserInvitationController with Manager")
 
+	if r.InvitationBaseURL != "" {
+		if err := validateInvitationBaseURL(r.InvitationBaseURL); err != nil {
+			return fmt.Errorf("invalid invitation base URL: %w", err)
+		}
+	}
+	if err := validateEmailTemplatesByLocale(r.UserInvitationEmailTemplatesByLocale); err != nil {
+		return fmt.Errorf("invalid invitation email templates by locale: %w", err)
+	}
+	if err := validateEmailTemplatesExist(context.Background(), mgr.GetAPIReader(), r.UserInvitationEmailTemplatesByLocale); err != nil {
+		return fmt.Errorf("invalid invitation email templates by locale: %w", err)
+	}
+
 	r.uiRelatedRoles = append(r.uiRelatedRoles, iamv1alpha1.RoleReference{
 		Name:      r.GetInvitationRoleName,
 		Namespace: r.SystemNamespace,
@@ -313,6 +400,10 @@ func (r *UserInvitationController) SetupWithManager(mgr ctrl.Manager) error {
 		Namespace: r.SystemNamespace,
 	})
 
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("userinvitation-controller")
+	}
+
 	r.finalizer = finalizer.NewFinalizers()
 	if err := r.finalizer.Register(userInvitationFinalizerKey, &userInvitationFinalizer{
 		client:         r.Client,
@@ -363,6 +454,16 @@ func (r *UserInvitationController) SetupWithManager(mgr ctrl.Manager) error {
 		return fmt.Errorf("failed to set field index on PlatformAccessRejection by .spec.userRef.name: %w", err)
 	}
 
+	// Register field indexer for UserInvitation organization for efficient lookup
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(),
+		&iamv1alpha1.UserInvitation{}, uiOrganizationRefKey,
+		func(obj client.Object) []string {
+			ui := obj.(*iamv1alpha1.UserInvitation)
+			return []string{ui.Spec.OrganizationRef.Name}
+		}); err != nil {
+		return fmt.Errorf("failed to set field index on UserInvitation by .spec.organizationRef.name: %w", err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&iamv1alpha1.UserInvitation{}).
 		Watches(
@@ -370,6 +471,11 @@ func (r *UserInvitationController) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(r.findUserInvitationsForUser),
 			builder.WithPredicates(userCreateOnlyPredicate),
 		).
+		Watches(
+			&resourcemanagerv1alpha1.Organization{},
+			handler.EnqueueRequestsFromMapFunc(r.findUserInvitationsForOrganization),
+			builder.WithPredicates(organizationDisplayNameChangedPredicate),
+		).
 		Named("userinvitation").
 		Complete(r)
 }
@@ -535,6 +641,28 @@ func deletePolicyBinding(ctx context.Context, c client.Client, roleRef *iamv1alp
 	return nil
 }
 
+// findMissingRoles returns the roles referenced by the UserInvitation that do not exist, as
+// namespace/name. Roles without a namespace are resolved in the Organization's namespace, as
+// they are for the OrganizationMembership created on acceptance.
+func (r *UserInvitationController) findMissingRoles(ctx context.Context, ui *iamv1alpha1.UserInvitation) ([]string, error) {
+	var missing []string
+	for _, roleRef := range ui.Spec.Roles {
+		namespace := roleRef.Namespace
+		if namespace == "" {
+			namespace = fmt.Sprintf("organization-%s", ui.Spec.OrganizationRef.Name)
+		}
+
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: roleRef.Name, Namespace: namespace}, &iamv1alpha1.Role{}); err != nil {
+			if errors.IsNotFound(err) {
+				missing = append(missing, fmt.Sprintf("%s/%s", namespace, roleRef.Name))
+				continue
+			}
+			return nil, fmt.Errorf("failed to get role %s/%s: %w", namespace, roleRef.Name, err)
+		}
+	}
+	return missing, nil
+}
+
 // createOrganizationMembership creates an OrganizationMembership for the invitee user with roles from the invitation. This is an idempotent operation.
 func (r *UserInvitationController) createOrganizationMembership(ctx context.Context, user *iamv1alpha1.User, ui *iamv1alpha1.UserInvitation) error {
 	log := logf.FromContext(ctx).WithName("userinvitation-create-organization-membership")
@@ -630,13 +758,96 @@ var userCreateOnlyPredicate = predicate.Funcs{
 	GenericFunc: func(e event.GenericEvent) bool { return false },
 }
 
+// findUserInvitationsForOrganization finds the pending UserInvitation resources that reference a given Organization.
+// This is used to reconcile the UserInvitation resources when the Organization's display name changes, so that
+// the organization information in their status stays current. Accepted and declined invitations are skipped,
+// as their status is no longer refreshed.
+func (r *UserInvitationController) findUserInvitationsForOrganization(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := logf.FromContext(ctx).WithName("find-userinvitations-for-organization")
+
+	org, ok := obj.(*resourcemanagerv1alpha1.Organization)
+	if !ok {
+		log.Error(fmt.Errorf("unexpected object type %T, expected *resourcemanagerv1alpha1.Organization", obj), "unexpected object type")
+		return nil
+	}
+
+	var uiList iamv1alpha1.UserInvitationList
+	if err := r.Client.List(ctx, &uiList, client.MatchingFields{uiOrganizationRefKey: org.Name}); err != nil {
+		log.Error(err, "failed to list UserInvitations by organization")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(uiList.Items))
+	for i := range uiList.Items {
+		ui := uiList.Items[i]
+		if ui.Spec.State != iamv1alpha1.UserInvitationStatePending {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: ui.GetName(), Namespace: ui.GetNamespace()}})
+	}
+
+	log.Info("Found UserInvitations for organization", "Number of UserInvitations", len(requests), "organization", org.GetName())
+
+	return requests
+}
+
+// organizationDisplayNameChangedPredicate triggers only when an Organization's display name changes.
+var organizationDisplayNameChangedPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetAnnotations()[organizationDisplayNameAnnotation] != e.ObjectNew.GetAnnotations()[organizationDisplayNameAnnotation]
+	},
+	DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+	GenericFunc: func(e event.GenericEvent) bool { return false },
+}
+
 // createInvitationEmail creates an email to the invitee user to accept the invitation.
 // This is an idempotent operation.
 func (r *UserInvitationController) createInvitationEmail(ctx context.Context, ui *iamv1alpha1.UserInvitation) error {
+	return r.createInvitationEmailNamed(ctx, ui, getDeterministicEmailName(*ui))
+}
+
+// resendInvitationEmail sends the invitation email again when the resend-email annotation is
+// bumped past the last processed value, and records the value in the in-memory status for the
+// reconcile's status write, so the email is sent only once per request. Each resend creates its
+// own Email named after the counter, so a resend whose status write fails is not sent twice.
+func (r *UserInvitationController) resendInvitationEmail(ctx context.Context, ui *iamv1alpha1.UserInvitation) error {
+	log := logf.FromContext(ctx).WithName("userinvitation-resend-invitation-email")
+
+	value, ok := ui.GetAnnotations()[iamv1alpha1.UserInvitationResendEmailAnnotation]
+	if !ok {
+		return nil
+	}
+	requested, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || requested < 1 {
+		// Retrying will not fix the annotation, so report it instead of returning an error
+		log.Info("Ignoring invalid resend email request", "value", value)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(ui, corev1.EventTypeWarning, "InvalidResendRequest",
+				"The %s annotation must be a positive integer, got %q", iamv1alpha1.UserInvitationResendEmailAnnotation, value)
+		}
+		return nil
+	}
+	if int32(requested) <= ui.Status.LastEmailResend {
+		return nil
+	}
+
+	emailName := fmt.Sprintf("%s-resend-%d", getDeterministicEmailName(*ui), requested)
+	if err := r.createInvitationEmailNamed(ctx, ui, emailName); err != nil {
+		return err
+	}
+
+	ui.Status.LastEmailResend = int32(requested)
+	log.Info("Invitation email resent", "email", emailName, "resend", requested)
+
+	return nil
+}
+
+// createInvitationEmailNamed creates the invitation email as an Email resource with the given
+// name, doing nothing if it already exists.
+func (r *UserInvitationController) createInvitationEmailNamed(ctx context.Context, ui *iamv1alpha1.UserInvitation, emailName string) error {
 	log := logf.FromContext(ctx).WithName("userinvitation-create-invitation-email")
 	log.Info("Creating invitation email to user", "userInvitation", ui.GetName())
-
-	emailName := getDeterministicEmailName(*ui)
 	log.Info("Email name", "emailName", emailName)
 
 	// Check if the Email already exists (idempotency)
@@ -661,6 +872,29 @@ func (r *UserInvitationController) createInvitationEmail(ctx context.Context, ui
 			Name:  "InviterDisplayName",
 			Value: ui.Status.InviterUser.DisplayName,
 		},
+		{
+			Name:  "InviteeGivenName",
+			Value: inviteeGivenName(ui),
+		},
+		{
+			Name:  "InviteeFamilyName",
+			Value: ui.Spec.FamilyName,
+		},
+	}
+	if r.InvitationBaseURL != "" {
+		inviteLink, err := invitationAcceptURL(r.InvitationBaseURL, ui.GetName())
+		if err != nil {
+			return fmt.Errorf("failed to build invitation link: %w", err)
+		}
+		variables = append(variables, notificationv1alpha1.EmailVariable{
+			Name:  "InviteLink",
+			Value: inviteLink,
+		})
+	}
+
+	templateName, err := r.invitationEmailTemplateName(ctx, ui)
+	if err != nil {
+		return err
 	}
 
 	// Compose the Email resource
@@ -674,7 +908,7 @@ func (r *UserInvitationController) createInvitationEmail(ctx context.Context, ui
 		},
 		Spec: notificationv1alpha1.EmailSpec{
 			TemplateRef: notificationv1alpha1.TemplateReference{
-				Name: r.UserInvitationEmailTemplateName,
+				Name: templateName,
 			},
 			Recipient: notificationv1alpha1.EmailRecipient{
 				EmailAddress: ui.Spec.Email,
@@ -694,6 +928,109 @@ func (r *UserInvitationController) createInvitationEmail(ctx context.Context, ui
 	return nil
 }
 
+// invitationEmailTemplateName returns the name of the email template for the invitee's preferred
+// locale. The default template is used when the invitee has no locale, no template is configured
+// for it, or the configured template does not exist.
+func (r *UserInvitationController) invitationEmailTemplateName(ctx context.Context, ui *iamv1alpha1.UserInvitation) (string, error) {
+	log := logf.FromContext(ctx).WithName("userinvitation-invitation-email-template")
+
+	if len(r.UserInvitationEmailTemplatesByLocale) == 0 {
+		return r.UserInvitationEmailTemplateName, nil
+	}
+
+	locale, err := r.inviteeLocale(ctx, ui)
+	if err != nil {
+		return "", err
+	}
+	templateName, ok := templateForLocale(r.UserInvitationEmailTemplatesByLocale, locale)
+	if !ok {
+		return r.UserInvitationEmailTemplateName, nil
+	}
+
+	template := &notificationv1alpha1.EmailTemplate{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: templateName}, template); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get EmailTemplate %s: %w", templateName, err)
+		}
+		log.Info("Email template for locale not found, using the default template", "locale", locale, "template", templateName)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(ui, corev1.EventTypeWarning, "EmailTemplateNotFound",
+				"EmailTemplate %s configured for locale %q does not exist, using %s", templateName, locale, r.UserInvitationEmailTemplateName)
+		}
+		return r.UserInvitationEmailTemplateName, nil
+	}
+
+	return templateName, nil
+}
+
+// inviteeLocale returns the preferred locale of the invitee. The locale annotation of the invitation
+// takes precedence over the locale of an existing User with the invited email.
+func (r *UserInvitationController) inviteeLocale(ctx context.Context, ui *iamv1alpha1.UserInvitation) (string, error) {
+	if locale := ui.GetAnnotations()[iamv1alpha1.UserInvitationLocaleAnnotation]; locale != "" {
+		return locale, nil
+	}
+
+	users, err := r.getUsersByEmail(ctx, ui.Spec.Email)
+	if err != nil {
+		return "", err
+	}
+	for _, user := range users.Items {
+		if user.Spec.Locale != "" {
+			return user.Spec.Locale, nil
+		}
+	}
+	return "", nil
+}
+
+// templateForLocale returns the template configured for the locale, or for its language when no
+// template is configured for the full locale. Locales are compared case-insensitively.
+func templateForLocale(templates map[string]string, locale string) (string, bool) {
+	if locale == "" {
+		return "", false
+	}
+	language, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	for _, candidate := range []string{locale, language} {
+		for key, name := range templates {
+			if strings.EqualFold(key, candidate) {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// validateEmailTemplatesByLocale checks that every locale maps to a template name.
+func validateEmailTemplatesByLocale(templates map[string]string) error {
+	for locale, name := range templates {
+		if strings.TrimSpace(locale) == "" {
+			return fmt.Errorf("locale of template %q must not be empty", name)
+		}
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("template for locale %q must not be empty", locale)
+		}
+	}
+	return nil
+}
+
+// validateEmailTemplatesExist checks that every template configured for a locale exists.
+func validateEmailTemplatesExist(ctx context.Context, reader client.Reader, templates map[string]string) error {
+	var missing []string
+	for locale, name := range templates {
+		template := &notificationv1alpha1.EmailTemplate{}
+		if err := reader.Get(ctx, client.ObjectKey{Name: name}, template); err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to get EmailTemplate %s: %w", name, err)
+			}
+			missing = append(missing, fmt.Sprintf("%s (locale %q)", name, locale))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("EmailTemplates not found: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 func (r *UserInvitationController) getUsersByEmail(ctx context.Context, email string) (*iamv1alpha1.UserList, error) {
 	log := logf.FromContext(ctx).WithName("userinvitation-get-user-by-email")
 	// Get the User that was invited by the UserInvitation
@@ -746,7 +1083,7 @@ func (r *UserInvitationController) getReferencedOrganizationDisplayName(ctx cont
 	if err := r.Client.Get(ctx, client.ObjectKey{Name: organizationRef.Name}, org); err != nil {
 		return "", fmt.Errorf("failed to get Organization: %w", err)
 	}
-	organizationDisplayName := org.Annotations["kubernetes.io/display-name"]
+	organizationDisplayName := org.Annotations[organizationDisplayNameAnnotation]
 	if organizationDisplayName == "" {
 		organizationDisplayName = org.Name
 	}
@@ -754,12 +1091,47 @@ func (r *UserInvitationController) getReferencedOrganizationDisplayName(ctx cont
 	return organizationDisplayName, nil
 }
 
+// inviteeGivenName returns the name the invitation email greets the invitee with: the given name
+// of the invitation, or the local part of the invitee's email address when it has none.
+func inviteeGivenName(ui *iamv1alpha1.UserInvitation) string {
+	if givenName := strings.TrimSpace(ui.Spec.GivenName); givenName != "" {
+		return givenName
+	}
+	localPart, _, _ := strings.Cut(ui.Spec.Email, "@")
+	return localPart
+}
+
 // getDeterministicEmailName generates a deterministic name for the Email resource to create based on the UserInvitation.
 func getDeterministicEmailName(ui iamv1alpha1.UserInvitation) string {
 	// We do not use the email, givenName or FamilyName as the may include forbidden characters for the Email resource name
 	return getDeterministicResourceName("user-invitation", ui)
 }
 
+// invitationAcceptURL returns the link where the invitee accepts the named invitation, which the
+// invitation email includes as InviteLink when an InvitationBaseURL is configured.
+func invitationAcceptURL(baseURL, name string) (string, error) {
+	return url.JoinPath(baseURL, "invitation", name, "accept")
+}
+
+// validateInvitationBaseURL checks that the invitation base URL is an absolute http(s) URL that
+// invitation paths can be appended to.
+func validateInvitationBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must use the http or https scheme", baseURL)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q must include a host", baseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("%q must not include a query or fragment", baseURL)
+	}
+	return nil
+}
+
 // getDeterministicRoleName generates a deterministic name for the Role resource to create based on the UserInvitation.
 func getDeterministicRoleName(role *iamv1alpha1.RoleReference, ui iamv1alpha1.UserInvitation) string {
 	return getDeterministicResourceName(role.Name, ui)
@@ -784,6 +1156,17 @@ func isUserInvitationExpired(ui *iamv1alpha1.UserInvitation) bool {
 	return false
 }
 
+// requeueAtExpiration returns a result that reconciles the UserInvitation again when it
+// expires, so it is marked Expired even if nothing else changes. Invitations without an
+// expiration date are not requeued.
+func requeueAtExpiration(ui *iamv1alpha1.UserInvitation) ctrl.Result {
+	if ui.Spec.ExpirationDate == nil {
+		return ctrl.Result{}
+	}
+	// Expiration is strict, so wake up just past it
+	return ctrl.Result{RequeueAfter: time.Until(ui.Spec.ExpirationDate.Time) + time.Second}
+}
+
 // grantAccessApproval grants the access to the invitee user for the organization.
 func (r *UserInvitationController) grantAccessApproval(ctx context.Context, user *iamv1alpha1.User, ui *iamv1alpha1.UserInvitation) error {
 	log := logf.FromContext(ctx).WithName("userinvitation-grant-access-approval").WithValues("userInvitation", ui.GetName())
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	iamv1alpha1 "go.miloapis.com/milo/pkg/apis/iam/v1alpha1"
 	notificationv1alpha1 "go.miloapis.com/milo/pkg/apis/notification/v1alpha1"
@@ -14,10 +15,14 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	ctrlfinalizer "sigs.k8s.io/controller-runtime/pkg/finalizer"
 )
 
@@ -201,6 +206,18 @@ func TestUserInvitationController_createOrganizationMembership(t *testing.T) {
 		t.Errorf("OrganizationMembership has unexpected OrganizationRef: %+v", om.Spec.OrganizationRef)
 	}
 
+	// Verify the owner reference points at the user with a full group/version so GC can resolve it
+	if len(om.OwnerReferences) != 1 {
+		t.Fatalf("expected 1 owner reference, got %d", len(om.OwnerReferences))
+	}
+	ownerRef := om.OwnerReferences[0]
+	if gv, err := schema.ParseGroupVersion(ownerRef.APIVersion); err != nil || gv != iamv1alpha1.SchemeGroupVersion {
+		t.Errorf("owner reference has invalid apiVersion %q, want %q", ownerRef.APIVersion, iamv1alpha1.SchemeGroupVersion.String())
+	}
+	if ownerRef.Kind != "User" || ownerRef.Name != user.Name || ownerRef.UID != user.UID {
+		t.Errorf("unexpected owner reference: %+v", ownerRef)
+	}
+
 	// Verify roles are set
 	if len(om.Spec.Roles) != 2 {
 		t.Errorf("expected 2 roles, got %d", len(om.Spec.Roles))
@@ -647,13 +664,16 @@ func TestUserInvitationController_Reconcile_StateTransitionCreatesBindings(t *te
 		ObjectMeta: metav1.ObjectMeta{Name: "org", UID: types.UID("org-uid"), Annotations: map[string]string{"kubernetes.io/display-name": "Organization Display Name"}},
 	}
 
+	// Role referenced by the invitation, which must exist before the invitation is marked Pending.
+	orgAdminRole := &iamv1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "org-admin", Namespace: "milo-system"}}
+
 	// Invitation-related role needed so that controller grants access to accept invitation.
 	invitationRoleRef := iamv1alpha1.RoleReference{Name: "get-invitation-role", Namespace: "milo-system"}
 
 	// Build fake client with status subresource enabled for UserInvitation so status updates work.
 	builder := fake.NewClientBuilder().WithScheme(scheme).
 		WithStatusSubresource(&iamv1alpha1.UserInvitation{}).
-		WithObjects(user.DeepCopy(), ui.DeepCopy(), org.DeepCopy(), inviter.DeepCopy())
+		WithObjects(user.DeepCopy(), ui.DeepCopy(), org.DeepCopy(), inviter.DeepCopy(), orgAdminRole.DeepCopy())
 
 	// add indexes required by reconciler
 	builder = builder.WithIndex(&iamv1alpha1.User{}, userEmailIndexKey, func(obj client.Object) []string {
@@ -801,11 +821,13 @@ func TestUserInvitationController_Reconcile_UserCreatedLater(t *testing.T) {
 
 	org := &resourcemanagerv1alpha1.Organization{ObjectMeta: metav1.ObjectMeta{Name: "org", UID: types.UID("org-uid")}}
 
+	orgAdminRole := &iamv1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "org-admin", Namespace: "milo-system"}}
+
 	invitationRoleRef := iamv1alpha1.RoleReference{Name: "get-invitation-role", Namespace: "milo-system"}
 
 	builder := fake.NewClientBuilder().WithScheme(scheme).
 		WithStatusSubresource(&iamv1alpha1.UserInvitation{}).
-		WithObjects(ui.DeepCopy(), org.DeepCopy(), inviter.DeepCopy())
+		WithObjects(ui.DeepCopy(), org.DeepCopy(), inviter.DeepCopy(), orgAdminRole.DeepCopy())
 
 	// indexes
 	builder = builder.WithIndex(&iamv1alpha1.User{}, userEmailIndexKey, func(obj client.Object) []string {
@@ -992,6 +1014,12 @@ func TestUserInvitationController_createInvitationEmail(t *testing.T) {
 	if vars["UserInvitationName"] != "inv" {
 		t.Errorf("UserInvitationName variable mismatch, got %s", vars["UserInvitationName"])
 	}
+	if vars["InviteeGivenName"] != "Invite" {
+		t.Errorf("InviteeGivenName variable mismatch, got %s", vars["InviteeGivenName"])
+	}
+	if vars["InviteeFamilyName"] != "E" {
+		t.Errorf("InviteeFamilyName variable mismatch, got %s", vars["InviteeFamilyName"])
+	}
 
 	// Idempotency: second call should not error and should not create duplicate Email (still one)
 	if err := uic.createInvitationEmail(ctx, ui); err != nil {
@@ -1240,3 +1268,580 @@ func TestUserInvitationController_Reconcile_PolicyBindingsGCOnDeletion(t *testin
 		}
 	}
 }
+
+// TestUserInvitationController_Reconcile_OrganizationDisplayNameChange verifies that a pending invitation picks up
+// a new Organization display name, and that Organization display name changes are mapped to its invitations.
+func TestUserInvitationController_Reconcile_OrganizationDisplayNameChange(t *testing.T) {
+	ctx := context.TODO()
+	scheme := getTestScheme()
+
+	user := &iamv1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-user", UID: types.UID("u-uid")},
+		Spec:       iamv1alpha1.UserSpec{Email: "test@example.com"},
+	}
+	inviter := &iamv1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "inviter", UID: types.UID("inviter-uid")}, Spec: iamv1alpha1.UserSpec{GivenName: "John", FamilyName: "Doe", Email: "inviter@example.com"}}
+
+	ui := &iamv1alpha1.UserInvitation{
+		ObjectMeta: metav1.ObjectMeta{Name: "inv", Namespace: "default", UID: types.UID("ui-uid"), Finalizers: []string{userInvitationFinalizerKey}},
+		Spec: iamv1alpha1.UserInvitationSpec{
+			Email:           user.Spec.Email,
+			OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "org"},
+			State:           iamv1alpha1.UserInvitationStatePending,
+			InvitedBy:       iamv1alpha1.UserReference{Name: inviter.Name},
+		},
+		Status: iamv1alpha1.UserInvitationStatus{
+			Conditions: []metav1.Condition{{
+				Type:               string(iamv1alpha1.UserInvitationPendingCondition),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(iamv1alpha1.UserInvitationStatePendingReason),
+				LastTransitionTime: metav1.Now(),
+			}},
+			InviteeUser:  &iamv1alpha1.UserInvitationInviteeUserStatus{Name: user.Name},
+			Organization: iamv1alpha1.UserInvitationOrganizationStatus{DisplayName: "Old Name"},
+			InviterUser:  iamv1alpha1.UserInvitationUserStatus{DisplayName: "John Doe", EmailAddress: "inviter@example.com"},
+		},
+	}
+	otherUI := &iamv1alpha1.UserInvitation{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-inv", Namespace: "default"},
+		Spec: iamv1alpha1.UserInvitationSpec{
+			Email:           "other@example.com",
+			OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "other-org"},
+		},
+	}
+	acceptedUI := &iamv1alpha1.UserInvitation{
+		ObjectMeta: metav1.ObjectMeta{Name: "accepted-inv", Namespace: "default"},
+		Spec: iamv1alpha1.UserInvitationSpec{
+			Email:           "accepted@example.com",
+			OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "org"},
+			State:           iamv1alpha1.UserInvitationStateAccepted,
+		},
+	}
+
+	org := &resourcemanagerv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{Name: "org", UID: types.UID("org-uid"), Annotations: map[string]string{organizationDisplayNameAnnotation: "New Name"}},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&iamv1alpha1.UserInvitation{}).
+		WithObjects(user, inviter, ui, otherUI, acceptedUI, org).
+		WithIndex(&iamv1alpha1.User{}, userEmailIndexKey, func(obj client.Object) []string {
+			return []string{strings.ToLower(obj.(*iamv1alpha1.User).Spec.Email)}
+		}).
+		WithIndex(&iamv1alpha1.UserInvitation{}, uiOrganizationRefKey, func(obj client.Object) []string {
+			return []string{obj.(*iamv1alpha1.UserInvitation).Spec.OrganizationRef.Name}
+		}).
+		WithIndex(&iamv1alpha1.PlatformAccessRejection{}, uiPlatformAccessRejectionKey, func(obj client.Object) []string {
+			return []string{obj.(*iamv1alpha1.PlatformAccessRejection).Spec.UserRef.Name}
+		}).
+		WithIndex(&iamv1alpha1.PlatformAccessApproval{}, uiPlatformAccessApprovalKey, func(obj client.Object) []string {
+			return []string{buildPlatformAccessApprovalIndexKey(&obj.(*iamv1alpha1.PlatformAccessApproval).Spec.SubjectRef)}
+		}).
+		Build()
+
+	uic := &UserInvitationController{Client: c, SystemNamespace: "milo-system"}
+	initFinalizer(t, uic)
+
+	// Only display name changes should trigger reconciliation
+	renamed := org.DeepCopy()
+	renamed.Annotations[organizationDisplayNameAnnotation] = "Newer Name"
+	if !organizationDisplayNameChangedPredicate.Update(event.UpdateEvent{ObjectOld: org, ObjectNew: renamed}) {
+		t.Errorf("expected display name change to trigger reconciliation")
+	}
+	relabeled := org.DeepCopy()
+	relabeled.Labels = map[string]string{"foo": "bar"}
+	if organizationDisplayNameChangedPredicate.Update(event.UpdateEvent{ObjectOld: org, ObjectNew: relabeled}) {
+		t.Errorf("expected unrelated Organization update to be ignored")
+	}
+
+	// The Organization maps only to the pending invitations that reference it
+	requests := uic.findUserInvitationsForOrganization(ctx, org)
+	if len(requests) != 1 || requests[0].Name != ui.Name || requests[0].Namespace != ui.Namespace {
+		t.Fatalf("expected a single request for %s/%s, got %+v", ui.Namespace, ui.Name, requests)
+	}
+
+	if _, err := uic.Reconcile(ctx, requests[0]); err != nil {
+		t.Fatalf("reconcile error: %v", err)
+	}
+
+	updated := &iamv1alpha1.UserInvitation{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ui.Name, Namespace: ui.Namespace}, updated); err != nil {
+		t.Fatalf("failed to get UserInvitation: %v", err)
+	}
+	if updated.Status.Organization.DisplayName != "New Name" {
+		t.Errorf("expected organization display name to be New Name, got %s", updated.Status.Organization.DisplayName)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, string(iamv1alpha1.UserInvitationPendingCondition)) {
+		t.Errorf("expected invitation to remain pending")
+	}
+}
+
+// TestUserInvitationController_Reconcile_ValidatesRoles verifies that an invitation is only marked Pending
+// once every role it grants exists, so that it cannot be accepted only to fail binding the roles.
+func TestUserInvitationController_Reconcile_ValidatesRoles(t *testing.T) {
+	tests := []struct {
+		name        string
+		roles       []client.Object
+		wantPending bool
+		wantEvent   bool
+	}{
+		{
+			name: "all roles exist",
+			roles: []client.Object{
+				&iamv1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "org-admin", Namespace: "milo-system"}},
+				&iamv1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "org-viewer", Namespace: "organization-org"}},
+			},
+			wantPending: true,
+		},
+		{
+			name: "missing role",
+			roles: []client.Object{
+				&iamv1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: "org-admin", Namespace: "milo-system"}},
+			},
+			wantEvent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			user := &iamv1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-user", UID: types.UID("u-uid")},
+				Spec:       iamv1alpha1.UserSpec{Email: "test@example.com"},
+			}
+			inviter := &iamv1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "inviter", UID: types.UID("inviter-uid")}, Spec: iamv1alpha1.UserSpec{Email: "inviter@example.com"}}
+			ui := &iamv1alpha1.UserInvitation{
+				ObjectMeta: metav1.ObjectMeta{Name: "inv", Namespace: "default", UID: types.UID("ui-uid"), Finalizers: []string{userInvitationFinalizerKey}},
+				Spec: iamv1alpha1.UserInvitationSpec{
+					Email:           user.Spec.Email,
+					OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "org"},
+					State:           iamv1alpha1.UserInvitationStatePending,
+					// A role without a namespace is resolved in the Organization's namespace
+					Roles:     []iamv1alpha1.RoleReference{{Name: "org-admin", Namespace: "milo-system"}, {Name: "org-viewer"}},
+					InvitedBy: iamv1alpha1.UserReference{Name: inviter.Name},
+				},
+			}
+			org := &resourcemanagerv1alpha1.Organization{ObjectMeta: metav1.ObjectMeta{Name: "org", UID: types.UID("org-uid")}}
+
+			c := fake.NewClientBuilder().WithScheme(getTestScheme()).
+				WithStatusSubresource(&iamv1alpha1.UserInvitation{}).
+				WithObjects(append(tt.roles, user, inviter, ui, org)...).
+				WithIndex(&iamv1alpha1.User{}, userEmailIndexKey, func(obj client.Object) []string {
+					return []string{strings.ToLower(obj.(*iamv1alpha1.User).Spec.Email)}
+				}).
+				WithIndex(&iamv1alpha1.PlatformAccessRejection{}, uiPlatformAccessRejectionKey, func(obj client.Object) []string {
+					return []string{obj.(*iamv1alpha1.PlatformAccessRejection).Spec.UserRef.Name}
+				}).
+				WithIndex(&iamv1alpha1.PlatformAccessApproval{}, uiPlatformAccessApprovalKey, func(obj client.Object) []string {
+					return []string{buildPlatformAccessApprovalIndexKey(&obj.(*iamv1alpha1.PlatformAccessApproval).Spec.SubjectRef)}
+				}).
+				Build()
+
+			recorder := record.NewFakeRecorder(10)
+			invitationRoleRef := iamv1alpha1.RoleReference{Name: "get-invitation-role", Namespace: "milo-system"}
+			uic := &UserInvitationController{Client: c, SystemNamespace: "milo-system", Recorder: recorder, uiRelatedRoles: []iamv1alpha1.RoleReference{invitationRoleRef}}
+			initFinalizer(t, uic)
+
+			result, err := uic.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: ui.Name, Namespace: ui.Namespace}})
+			if err != nil {
+				t.Fatalf("reconcile error: %v", err)
+			}
+
+			updated := &iamv1alpha1.UserInvitation{}
+			if err := c.Get(ctx, types.NamespacedName{Name: ui.Name, Namespace: ui.Namespace}, updated); err != nil {
+				t.Fatalf("failed to get UserInvitation: %v", err)
+			}
+			if got := meta.IsStatusConditionTrue(updated.Status.Conditions, string(iamv1alpha1.UserInvitationPendingCondition)); got != tt.wantPending {
+				t.Errorf("Pending condition true = %v, want %v", got, tt.wantPending)
+			}
+			if got := meta.IsStatusConditionTrue(updated.Status.Conditions, rolesResolvedConditionType); got != tt.wantPending {
+				t.Errorf("%s condition true = %v, want %v", rolesResolvedConditionType, got, tt.wantPending)
+			}
+
+			pbErr := c.Get(ctx, types.NamespacedName{Name: getDeterministicRoleName(&invitationRoleRef, *ui), Namespace: invitationRoleRef.Namespace}, &iamv1alpha1.PolicyBinding{})
+			if tt.wantPending && pbErr != nil {
+				t.Errorf("expected invitation PolicyBinding created: %v", pbErr)
+			}
+
+			if !tt.wantPending {
+				cond := meta.FindStatusCondition(updated.Status.Conditions, rolesResolvedConditionType)
+				if cond == nil || !strings.Contains(cond.Message, "organization-org/org-viewer") {
+					t.Errorf("expected %s condition to name the missing role, got %+v", rolesResolvedConditionType, cond)
+				}
+				if !apierr.IsNotFound(pbErr) {
+					t.Errorf("expected no invitation PolicyBinding while roles are missing, got %v", pbErr)
+				}
+				if result.RequeueAfter == 0 {
+					t.Errorf("expected invitation with missing roles to be requeued")
+				}
+			}
+
+			select {
+			case e := <-recorder.Events:
+				if !tt.wantEvent {
+					t.Errorf("unexpected event: %s", e)
+				} else if !strings.Contains(e, "RolesNotFound") {
+					t.Errorf("expected RolesNotFound event, got %s", e)
+				}
+			default:
+				if tt.wantEvent {
+					t.Errorf("expected RolesNotFound event")
+				}
+			}
+		})
+	}
+}
+
+// TestUserInvitationController_Reconcile_RequeuesAtExpiration verifies that a pending invitation is
+// reconciled again when it expires, so it becomes Expired without any other event.
+func TestUserInvitationController_Reconcile_RequeuesAtExpiration(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn time.Duration // zero leaves the expiration date unset
+	}{
+		{name: "expiration date set", expiresIn: time.Hour},
+		{name: "no expiration date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			scheme := getTestScheme()
+
+			user := &iamv1alpha1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-user", UID: types.UID("u-uid")},
+				Spec:       iamv1alpha1.UserSpec{Email: "test@example.com"},
+			}
+			inviter := &iamv1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "inviter", UID: types.UID("inviter-uid")}, Spec: iamv1alpha1.UserSpec{GivenName: "John", FamilyName: "Doe", Email: "inviter@example.com"}}
+
+			ui := &iamv1alpha1.UserInvitation{
+				ObjectMeta: metav1.ObjectMeta{Name: "inv", Namespace: "default", UID: types.UID("ui-uid"), Finalizers: []string{userInvitationFinalizerKey}},
+				Spec: iamv1alpha1.UserInvitationSpec{
+					Email:           user.Spec.Email,
+					OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "org"},
+					State:           iamv1alpha1.UserInvitationStatePending,
+					InvitedBy:       iamv1alpha1.UserReference{Name: inviter.Name},
+				},
+			}
+			if tt.expiresIn != 0 {
+				ui.Spec.ExpirationDate = &metav1.Time{Time: time.Now().Add(tt.expiresIn)}
+			}
+
+			org := &resourcemanagerv1alpha1.Organization{
+				ObjectMeta: metav1.ObjectMeta{Name: "org", UID: types.UID("org-uid")},
+			}
+
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&iamv1alpha1.UserInvitation{}).
+				WithObjects(user, inviter, ui, org).
+				WithIndex(&iamv1alpha1.User{}, userEmailIndexKey, func(obj client.Object) []string {
+					return []string{strings.ToLower(obj.(*iamv1alpha1.User).Spec.Email)}
+				}).
+				WithIndex(&iamv1alpha1.UserInvitation{}, uiOrganizationRefKey, func(obj client.Object) []string {
+					return []string{obj.(*iamv1alpha1.UserInvitation).Spec.OrganizationRef.Name}
+				}).
+				WithIndex(&iamv1alpha1.PlatformAccessRejection{}, uiPlatformAccessRejectionKey, func(obj client.Object) []string {
+					return []string{obj.(*iamv1alpha1.PlatformAccessRejection).Spec.UserRef.Name}
+				}).
+				WithIndex(&iamv1alpha1.PlatformAccessApproval{}, uiPlatformAccessApprovalKey, func(obj client.Object) []string {
+					return []string{buildPlatformAccessApprovalIndexKey(&obj.(*iamv1alpha1.PlatformAccessApproval).Spec.SubjectRef)}
+				}).
+				Build()
+
+			uic := &UserInvitationController{Client: c, SystemNamespace: "milo-system"}
+			initFinalizer(t, uic)
+
+			result, err := uic.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: ui.Name, Namespace: ui.Namespace}})
+			if err != nil {
+				t.Fatalf("reconcile error: %v", err)
+			}
+
+			if tt.expiresIn == 0 {
+				if result.RequeueAfter != 0 {
+					t.Errorf("expected no requeue without an expiration date, got RequeueAfter %v", result.RequeueAfter)
+				}
+				return
+			}
+			if result.RequeueAfter <= 0 {
+				t.Fatalf("expected a requeue at expiration, got RequeueAfter %v", result.RequeueAfter)
+			}
+			if diff := result.RequeueAfter - tt.expiresIn; diff < -time.Minute || diff > time.Minute {
+				t.Errorf("expected RequeueAfter close to %v, got %v", tt.expiresIn, result.RequeueAfter)
+			}
+		})
+	}
+}
+
+// TestUserInvitationController_Reconcile_ResendsEmail verifies that bumping the resend-email annotation
+// sends the invitation email once more, recorded with a single status write, and that reconciling again
+// at the same value sends nothing.
+func TestUserInvitationController_Reconcile_ResendsEmail(t *testing.T) {
+	ctx := context.TODO()
+	scheme := getTestScheme()
+
+	user := &iamv1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-user", UID: types.UID("u-uid")},
+		Spec:       iamv1alpha1.UserSpec{Email: "test@example.com"},
+	}
+	inviter := &iamv1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "inviter", UID: types.UID("inviter-uid")}, Spec: iamv1alpha1.UserSpec{GivenName: "John", FamilyName: "Doe", Email: "inviter@example.com"}}
+
+	ui := &iamv1alpha1.UserInvitation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "inv",
+			Namespace:   "default",
+			UID:         types.UID("ui-uid"),
+			Finalizers:  []string{userInvitationFinalizerKey},
+			Annotations: map[string]string{iamv1alpha1.UserInvitationResendEmailAnnotation: "1"},
+		},
+		Spec: iamv1alpha1.UserInvitationSpec{
+			Email:           user.Spec.Email,
+			OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "org"},
+			State:           iamv1alpha1.UserInvitationStatePending,
+			InvitedBy:       iamv1alpha1.UserReference{Name: inviter.Name},
+		},
+		Status: iamv1alpha1.UserInvitationStatus{
+			Conditions: []metav1.Condition{{
+				Type:               string(iamv1alpha1.UserInvitationPendingCondition),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(iamv1alpha1.UserInvitationStatePendingReason),
+				LastTransitionTime: metav1.Now(),
+			}},
+			InviteeUser: &iamv1alpha1.UserInvitationInviteeUserStatus{Name: user.Name},
+		},
+	}
+
+	org := &resourcemanagerv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{Name: "org", UID: types.UID("org-uid")},
+	}
+
+	var statusWrites int
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&iamv1alpha1.UserInvitation{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				statusWrites++
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		WithObjects(user, inviter, ui, org).
+		WithIndex(&iamv1alpha1.User{}, userEmailIndexKey, func(obj client.Object) []string {
+			return []string{strings.ToLower(obj.(*iamv1alpha1.User).Spec.Email)}
+		}).
+		WithIndex(&iamv1alpha1.UserInvitation{}, uiOrganizationRefKey, func(obj client.Object) []string {
+			return []string{obj.(*iamv1alpha1.UserInvitation).Spec.OrganizationRef.Name}
+		}).
+		WithIndex(&iamv1alpha1.PlatformAccessRejection{}, uiPlatformAccessRejectionKey, func(obj client.Object) []string {
+			return []string{obj.(*iamv1alpha1.PlatformAccessRejection).Spec.UserRef.Name}
+		}).
+		WithIndex(&iamv1alpha1.PlatformAccessApproval{}, uiPlatformAccessApprovalKey, func(obj client.Object) []string {
+			return []string{buildPlatformAccessApprovalIndexKey(&obj.(*iamv1alpha1.PlatformAccessApproval).Spec.SubjectRef)}
+		}).
+		Build()
+
+	uic := &UserInvitationController{Client: c, SystemNamespace: "milo-system", UserInvitationEmailTemplateName: "template"}
+	initFinalizer(t, uic)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: ui.Name, Namespace: ui.Namespace}}
+	for i := range 2 {
+		statusWrites = 0
+		if _, err := uic.Reconcile(ctx, req); err != nil {
+			t.Fatalf("reconcile %d error: %v", i, err)
+		}
+		if i == 0 && statusWrites != 1 {
+			t.Errorf("expected the resend to be recorded with a single status write, got %d", statusWrites)
+		}
+
+		var emailList notificationv1alpha1.EmailList
+		if err := c.List(ctx, &emailList); err != nil {
+			t.Fatalf("list emails: %v", err)
+		}
+		if len(emailList.Items) != 2 {
+			t.Fatalf("reconcile %d: expected the original and one resent Email, got %d", i, len(emailList.Items))
+		}
+	}
+
+	resent := &notificationv1alpha1.Email{}
+	if err := c.Get(ctx, types.NamespacedName{Name: getDeterministicEmailName(*ui) + "-resend-1", Namespace: ui.Namespace}, resent); err != nil {
+		t.Fatalf("expected resent Email: %v", err)
+	}
+	if resent.Spec.Recipient.EmailAddress != user.Spec.Email {
+		t.Errorf("unexpected resent Recipient.EmailAddress, got %s", resent.Spec.Recipient.EmailAddress)
+	}
+
+	updated := &iamv1alpha1.UserInvitation{}
+	if err := c.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("failed to get UserInvitation: %v", err)
+	}
+	if updated.Status.LastEmailResend != 1 {
+		t.Errorf("expected lastEmailResend 1, got %d", updated.Status.LastEmailResend)
+	}
+}
+
+// TestUserInvitationController_createInvitationEmail_InviteLink verifies that the invitation link is built
+// from the configured base URL and the invitation name.
+func TestUserInvitationController_createInvitationEmail_InviteLink(t *testing.T) {
+	ctx := context.TODO()
+
+	ui := &iamv1alpha1.UserInvitation{
+		ObjectMeta: metav1.ObjectMeta{Name: "inv", Namespace: "default", UID: types.UID("ui-uid")},
+		Spec:       iamv1alpha1.UserInvitationSpec{Email: "invitee@example.com"},
+	}
+
+	for _, baseURL := range []string{"https://app.example.com", "https://app.example.com/"} {
+		c := fake.NewClientBuilder().WithScheme(getTestScheme()).Build()
+		uic := &UserInvitationController{
+			Client:                          c,
+			UserInvitationEmailTemplateName: "template",
+			InvitationBaseURL:               baseURL,
+		}
+		if err := uic.createInvitationEmail(ctx, ui); err != nil {
+			t.Fatalf("createInvitationEmail error: %v", err)
+		}
+
+		email := &notificationv1alpha1.Email{}
+		if err := c.Get(ctx, types.NamespacedName{Name: getDeterministicEmailName(*ui), Namespace: ui.Namespace}, email); err != nil {
+			t.Fatalf("expected Email created: %v", err)
+		}
+		var inviteLink string
+		for _, v := range email.Spec.Variables {
+			if v.Name == "InviteLink" {
+				inviteLink = v.Value
+			}
+		}
+		if want := "https://app.example.com/invitation/inv/accept"; inviteLink != want {
+			t.Errorf("base URL %q: expected InviteLink %s, got %q", baseURL, want, inviteLink)
+		}
+	}
+}
+
+// TestUserInvitationController_createInvitationEmail_Locale verifies that the invitation email uses the
+// template of the invitee's locale, and the default template when the localized one does not exist.
+func TestUserInvitationController_createInvitationEmail_Locale(t *testing.T) {
+	ctx := context.TODO()
+	german := &notificationv1alpha1.EmailTemplate{ObjectMeta: metav1.ObjectMeta{Name: "template-de"}}
+
+	tests := []struct {
+		name         string
+		locale       string
+		userLocale   string
+		wantTemplate string
+	}{
+		{name: "language", locale: "de", wantTemplate: "template-de"},
+		{name: "region falls back to language", locale: "de-AT", wantTemplate: "template-de"},
+		{name: "missing template", locale: "fr", wantTemplate: "template"},
+		{name: "unconfigured locale", locale: "es", wantTemplate: "template"},
+		{name: "no locale", wantTemplate: "template"},
+		{name: "user locale", userLocale: "de", wantTemplate: "template-de"},
+		{name: "annotation takes precedence over user locale", locale: "es", userLocale: "de", wantTemplate: "template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui := &iamv1alpha1.UserInvitation{
+				ObjectMeta: metav1.ObjectMeta{Name: "inv", Namespace: "default", UID: types.UID("ui-uid")},
+				Spec:       iamv1alpha1.UserInvitationSpec{Email: "invitee@example.com"},
+			}
+			if tt.locale != "" {
+				ui.Annotations = map[string]string{iamv1alpha1.UserInvitationLocaleAnnotation: tt.locale}
+			}
+
+			objects := []client.Object{german.DeepCopy()}
+			if tt.userLocale != "" {
+				objects = append(objects, &iamv1alpha1.User{
+					ObjectMeta: metav1.ObjectMeta{Name: "invitee"},
+					Spec:       iamv1alpha1.UserSpec{Email: "Invitee@example.com", Locale: tt.userLocale},
+				})
+			}
+			c := fake.NewClientBuilder().WithScheme(getTestScheme()).WithObjects(objects...).
+				WithIndex(&iamv1alpha1.User{}, userEmailIndexKey, func(obj client.Object) []string {
+					return []string{strings.ToLower(obj.(*iamv1alpha1.User).Spec.Email)}
+				}).Build()
+			uic := &UserInvitationController{
+				Client:                          c,
+				UserInvitationEmailTemplateName: "template",
+				UserInvitationEmailTemplatesByLocale: map[string]string{
+					"de": german.Name,
+					"fr": "template-fr",
+				},
+			}
+			if err := uic.createInvitationEmail(ctx, ui); err != nil {
+				t.Fatalf("createInvitationEmail error: %v", err)
+			}
+
+			email := &notificationv1alpha1.Email{}
+			if err := c.Get(ctx, types.NamespacedName{Name: getDeterministicEmailName(*ui), Namespace: ui.Namespace}, email); err != nil {
+				t.Fatalf("expected Email created: %v", err)
+			}
+			if email.Spec.TemplateRef.Name != tt.wantTemplate {
+				t.Errorf("expected TemplateRef.Name %s, got %s", tt.wantTemplate, email.Spec.TemplateRef.Name)
+			}
+		})
+	}
+}
+
+func TestValidateEmailTemplatesByLocale(t *testing.T) {
+	if err := validateEmailTemplatesByLocale(map[string]string{"de": "template-de", "pt-BR": "template-pt"}); err != nil {
+		t.Errorf("expected valid templates, got %v", err)
+	}
+	if err := validateEmailTemplatesByLocale(map[string]string{"de": ""}); err == nil {
+		t.Error("expected an error for an empty template name")
+	}
+	if err := validateEmailTemplatesByLocale(map[string]string{"": "template"}); err == nil {
+		t.Error("expected an error for an empty locale")
+	}
+}
+
+func TestValidateEmailTemplatesExist(t *testing.T) {
+	ctx := context.TODO()
+	c := fake.NewClientBuilder().WithScheme(getTestScheme()).WithObjects(
+		&notificationv1alpha1.EmailTemplate{ObjectMeta: metav1.ObjectMeta{Name: "template-de"}},
+	).Build()
+
+	if err := validateEmailTemplatesExist(ctx, c, map[string]string{"de": "template-de"}); err != nil {
+		t.Errorf("expected existing templates to be valid, got %v", err)
+	}
+	err := validateEmailTemplatesExist(ctx, c, map[string]string{"de": "template-de", "fr": "template-fr"})
+	if err == nil {
+		t.Fatal("expected an error for a missing template")
+	}
+	if !strings.Contains(err.Error(), "template-fr") {
+		t.Errorf("expected the error to name the missing template, got %v", err)
+	}
+}
+
+func TestInviteeGivenName(t *testing.T) {
+	tests := []struct {
+		givenName string
+		email     string
+		want      string
+	}{
+		{givenName: "Jane", email: "jane.doe@example.com", want: "Jane"},
+		{email: "jane.doe@example.com", want: "jane.doe"},
+		{givenName: "  ", email: "jane.doe@example.com", want: "jane.doe"},
+	}
+	for _, tt := range tests {
+		ui := &iamv1alpha1.UserInvitation{Spec: iamv1alpha1.UserInvitationSpec{GivenName: tt.givenName, Email: tt.email}}
+		if got := inviteeGivenName(ui); got != tt.want {
+			t.Errorf("inviteeGivenName(%q, %q) = %q, want %q", tt.givenName, tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestValidateInvitationBaseURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		wantErr bool
+	}{
+		{baseURL: "https://app.example.com"},
+		{baseURL: "http://localhost:8080/portal"},
+		{baseURL: "app.example.com", wantErr: true},
+		{baseURL: "ftp://app.example.com", wantErr: true},
+		{baseURL: "https://", wantErr: true},
+		{baseURL: "https://app.example.com?tenant=a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if err := validateInvitationBaseURL(tt.baseURL); (err != nil) != tt.wantErr {
+			t.Errorf("validateInvitationBaseURL(%q) error = %v, wantErr %v", tt.baseURL, err, tt.wantErr)
+		}
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 )
 
@@ -29,6 +30,9 @@ type Provider struct {
 	dyn        dynamic.Interface
 	sink       Sink
 	projectGVR schema.GroupVersionResource
+	// queue holds projects waiting to be added to the sink. Failed additions are
+	// retried with backoff until they succeed or the project is deleted.
+	queue workqueue.TypedRateLimitingInterface[string]
 }
 
 func New(root *rest.Config, sink Sink) (*Provider, error) {
@@ -40,7 +44,14 @@ func New(root *rest.Config, sink Sink) (*Provider, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Provider{root: root, dyn: dyn, sink: sink, projectGVR: gvr}, nil
+	return &Provider{root: root, dyn: dyn, sink: sink, projectGVR: gvr, queue: newProjectQueue()}, nil
+}
+
+func newProjectQueue() workqueue.TypedRateLimitingInterface[string] {
+	return workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[string](),
+		workqueue.TypedRateLimitingQueueConfig[string]{Name: "project_provider"},
+	)
 }
 
 func (p *Provider) cfgForProject(id string) *rest.Config {
@@ -62,24 +73,47 @@ func (p *Provider) Run(ctx context.Context) error {
 
 	inf.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(o interface{}) {
-			id := o.(*unstructured.Unstructured).GetName()
-			err := p.sink.AddProject(ctx, id, p.cfgForProject(id))
-			if err != nil {
-				// Log the error but continue processing other projects
-				klog.Errorf("Failed to add project %q: %v", id, err)
-			}
+			p.queue.Add(o.(*unstructured.Unstructured).GetName())
 		},
 		DeleteFunc: func(o interface{}) {
 			id := o.(*unstructured.Unstructured).GetName()
+			p.queue.Forget(id)
 			p.sink.RemoveProject(id)
 		},
 	})
 
 	go inf.Run(ctx.Done())
+	go func() {
+		for p.processNextProject(ctx, inf.GetStore()) {
+		}
+	}()
 	<-ctx.Done()
+	p.queue.ShutDown()
 	return nil
 }
 
+// processNextProject adds the next queued project to the sink. Projects that were
+// deleted while queued are dropped; failed additions are requeued with backoff.
+func (p *Provider) processNextProject(ctx context.Context, projects cache.Store) bool {
+	id, shutdown := p.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer p.queue.Done(id)
+
+	if _, exists, _ := projects.GetByKey(id); !exists {
+		p.queue.Forget(id)
+		return true
+	}
+	if err := p.sink.AddProject(ctx, id, p.cfgForProject(id)); err != nil {
+		klog.Errorf("Failed to add project %q, retrying: %v", id, err)
+		p.queue.AddRateLimited(id)
+		return true
+	}
+	p.queue.Forget(id)
+	return true
+}
+
 func resolveProjectGVR(cfg *rest.Config, group, preferredVersion string) (schema.GroupVersionResource, error) {
 	disc, err := discovery.NewDiscoveryClientForConfig(cfg)
 	if err != nil {
@@ -0,0 +1,77 @@
+package projectprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+// fakeSink fails the first `failures` additions of each project.
+type fakeSink struct {
+	failures int
+	added    map[string]int
+}
+
+func (s *fakeSink) AddProject(_ context.Context, id string, _ *rest.Config) error {
+	s.added[id]++
+	if s.added[id] <= s.failures {
+		return errors.New("partition monitors did not sync")
+	}
+	return nil
+}
+
+func (s *fakeSink) RemoveProject(string) {}
+
+func newTestProvider(sink Sink) *Provider {
+	return &Provider{root: &rest.Config{Host: "https://milo"}, sink: sink, queue: newProjectQueue()}
+}
+
+func newProjectStore(t *testing.T, names ...string) cache.Store {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, name := range names {
+		project := &unstructured.Unstructured{}
+		project.SetName(name)
+		if err := store.Add(project); err != nil {
+			t.Fatalf("failed to add project to store: %v", err)
+		}
+	}
+	return store
+}
+
+func TestFailedProjectAdditionIsRetried(t *testing.T) {
+	sink := &fakeSink{failures: 1, added: map[string]int{}}
+	p := newTestProvider(sink)
+	defer p.queue.ShutDown()
+	projects := newProjectStore(t, "web")
+
+	p.queue.Add("web")
+	p.processNextProject(context.Background(), projects)
+	if sink.added["web"] != 1 || p.queue.NumRequeues("web") != 1 {
+		t.Fatalf("expected the failed addition to be requeued, got %d attempts and %d requeues", sink.added["web"], p.queue.NumRequeues("web"))
+	}
+
+	// The requeued project is handed out again once its backoff expires.
+	p.processNextProject(context.Background(), projects)
+	if sink.added["web"] != 2 || p.queue.NumRequeues("web") != 0 {
+		t.Fatalf("expected the retried addition to succeed, got %d attempts and %d requeues", sink.added["web"], p.queue.NumRequeues("web"))
+	}
+	if p.queue.Len() != 0 {
+		t.Errorf("expected an empty queue after a successful addition, got %d items", p.queue.Len())
+	}
+}
+
+func TestDeletedProjectIsNotAdded(t *testing.T) {
+	sink := &fakeSink{added: map[string]int{}}
+	p := newTestProvider(sink)
+	defer p.queue.ShutDown()
+
+	p.queue.Add("gone")
+	p.processNextProject(context.Background(), newProjectStore(t))
+	if sink.added["gone"] != 0 {
+		t.Errorf("expected a deleted project not to be added, got %d attempts", sink.added["gone"])
+	}
+}
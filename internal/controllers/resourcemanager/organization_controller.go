@@ -2,10 +2,15 @@ package resourcemanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,13 +20,34 @@ import (
 	resourcemanagerv1alpha "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
 )
 
+const organizationFinalizer = "resourcemanager.miloapis.com/organization-controller"
+
+const (
+	// OrganizationTeardown indicates that the organization's memberships, projects and
+	// namespace are being deleted as part of organization deletion.
+	OrganizationTeardown = "Teardown"
+	// OrganizationTeardownInProgressReason indicates that delete commands have been issued
+	// and the controller is waiting for the resources to be removed.
+	OrganizationTeardownInProgressReason = "TeardownInProgress"
+	// OrganizationTeardownBlockedReason indicates that a resource could not be deleted.
+	OrganizationTeardownBlockedReason = "TeardownBlocked"
+)
+
+// organizationTeardownRequeueInterval is how often an organization being deleted is checked
+// again while its resources are removed.
+const organizationTeardownRequeueInterval = 5 * time.Second
+
 // OrganizationController reconciles an Organization object
 type OrganizationController struct {
 	Client client.Client
 }
 
-// +kubebuilder:rbac:groups=resourcemanager.miloapis.com,resources=organizations,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=resourcemanager.miloapis.com,resources=organizations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=resourcemanager.miloapis.com,resources=organizations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=resourcemanager.miloapis.com,resources=organizations/finalizers,verbs=update
+// +kubebuilder:rbac:groups=resourcemanager.miloapis.com,resources=organizationmemberships,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=resourcemanager.miloapis.com,resources=projects,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 func (r *OrganizationController) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
@@ -34,8 +60,22 @@ func (r *OrganizationController) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, fmt.Errorf("failed to get organization: %w", err)
 	}
 
-	// Don't need to continue if the organization is being deleted from the cluster.
+	// Deletion path: tear down the organization's resources, then remove the finalizer
 	if !organization.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(&organization, organizationFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		return r.finalizeOrganization(ctx, &organization)
+	}
+
+	// Ensure finalizer present
+	if !controllerutil.ContainsFinalizer(&organization, organizationFinalizer) {
+		before := organization.DeepCopy()
+		controllerutil.AddFinalizer(&organization, organizationFinalizer)
+		if err := r.Client.Patch(ctx, &organization, client.MergeFrom(before)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("add finalizer: %w", err)
+		}
+		// trigger another reconcile after patch
 		return ctrl.Result{}, nil
 	}
 
@@ -76,6 +116,110 @@ func (r *OrganizationController) Reconcile(ctx context.Context, req ctrl.Request
 	return ctrl.Result{}, nil
 }
 
+// finalizeOrganization deletes the organization's memberships, projects and namespace, and removes
+// the finalizer once they are gone. Progress, and deletions that are refused, are reported through
+// the Teardown condition.
+func (r *OrganizationController) finalizeOrganization(ctx context.Context, organization *resourcemanagerv1alpha.Organization) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	remaining, err := r.teardownOrganization(ctx, organization)
+	if err != nil {
+		logger.Error(err, "organization teardown blocked")
+		if statusErr := r.setTeardownCondition(ctx, organization, OrganizationTeardownBlockedReason,
+			fmt.Sprintf("Organization teardown is blocked: %v", err)); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{}, fmt.Errorf("teardown organization: %w", err)
+	}
+
+	if len(remaining) > 0 {
+		logger.Info("waiting for organization resources to be deleted", "remaining", remaining)
+		if err := r.setTeardownCondition(ctx, organization, OrganizationTeardownInProgressReason,
+			fmt.Sprintf("Waiting for %s to be deleted", strings.Join(remaining, ", "))); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: organizationTeardownRequeueInterval}, nil
+	}
+
+	logger.Info("organization teardown complete, removing finalizer")
+	before := organization.DeepCopy()
+	controllerutil.RemoveFinalizer(organization, organizationFinalizer)
+	if err := r.Client.Patch(ctx, organization, client.MergeFrom(before)); err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("remove finalizer: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// teardownOrganization issues deletes for the organization's memberships, projects and namespace,
+// and returns the resources that still exist. Deletes that fail, e.g. because a webhook refuses
+// them, are returned as an error.
+func (r *OrganizationController) teardownOrganization(ctx context.Context, organization *resourcemanagerv1alpha.Organization) ([]string, error) {
+	namespaceName := fmt.Sprintf("organization-%s", organization.Name)
+
+	var remaining []string
+	var errs []error
+	deleteObject := func(obj client.Object, description string) {
+		remaining = append(remaining, description)
+		if !obj.GetDeletionTimestamp().IsZero() {
+			return
+		}
+		if err := r.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", description, err))
+		}
+	}
+
+	var memberships resourcemanagerv1alpha.OrganizationMembershipList
+	if err := r.Client.List(ctx, &memberships, client.InNamespace(namespaceName)); err != nil {
+		return nil, fmt.Errorf("failed to list organization memberships: %w", err)
+	}
+	for i := range memberships.Items {
+		membership := &memberships.Items[i]
+		if membership.Spec.OrganizationRef.Name != organization.Name {
+			continue
+		}
+		deleteObject(membership, fmt.Sprintf("OrganizationMembership %s", membership.Name))
+	}
+
+	var projects resourcemanagerv1alpha.ProjectList
+	if err := r.Client.List(ctx, &projects); err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	for i := range projects.Items {
+		project := &projects.Items[i]
+		if project.Spec.OwnerRef.Kind != "Organization" || project.Spec.OwnerRef.Name != organization.Name {
+			continue
+		}
+		deleteObject(project, fmt.Sprintf("Project %s", project.Name))
+	}
+
+	var namespace corev1.Namespace
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: namespaceName}, &namespace); err == nil {
+		deleteObject(&namespace, fmt.Sprintf("Namespace %s", namespaceName))
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get organization namespace: %w", err)
+	}
+
+	return remaining, errors.Join(errs...)
+}
+
+// setTeardownCondition records the progress of the organization teardown in its status.
+func (r *OrganizationController) setTeardownCondition(ctx context.Context, organization *resourcemanagerv1alpha.Organization, reason, message string) error {
+	changed := apimeta.SetStatusCondition(&organization.Status.Conditions, metav1.Condition{
+		Type:               OrganizationTeardown,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: organization.Generation,
+	})
+	if !changed {
+		return nil
+	}
+	if err := r.Client.Status().Update(ctx, organization); err != nil {
+		return fmt.Errorf("update teardown status: %w", err)
+	}
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *OrganizationController) SetupWithManager(mgr ctrl.Manager) error {
 	r.Client = mgr.GetClient()
@@ -0,0 +1,170 @@
+package resourcemanager
+
+import (
+	"context"
+	"testing"
+
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const testMembershipFinalizer = "test.miloapis.com/linger"
+
+func newDeletingOrganization() *resourcemanagerv1alpha1.Organization {
+	now := metav1.Now()
+	return &resourcemanagerv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "acme",
+			UID:               types.UID("org-uid"),
+			Finalizers:        []string{organizationFinalizer},
+			DeletionTimestamp: &now,
+		},
+	}
+}
+
+func newOrganizationTeardownClient(t *testing.T, funcs interceptor.Funcs, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := getTestScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add core types to scheme: %v", err)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&resourcemanagerv1alpha1.Organization{}).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+// TestOrganizationController_Reconcile_AddsFinalizer tests that organizations are protected by the finalizer
+func TestOrganizationController_Reconcile_AddsFinalizer(t *testing.T) {
+	ctx := context.TODO()
+	organization := &resourcemanagerv1alpha1.Organization{ObjectMeta: metav1.ObjectMeta{Name: "acme"}}
+	c := newOrganizationTeardownClient(t, interceptor.Funcs{}, organization)
+	controller := &OrganizationController{Client: c}
+
+	if _, err := controller.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "acme"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var updated resourcemanagerv1alpha1.Organization
+	if err := c.Get(ctx, types.NamespacedName{Name: "acme"}, &updated); err != nil {
+		t.Fatalf("Failed to get Organization: %v", err)
+	}
+	if !controllerutil.ContainsFinalizer(&updated, organizationFinalizer) {
+		t.Errorf("Expected finalizer %s, got %v", organizationFinalizer, updated.Finalizers)
+	}
+}
+
+// TestOrganizationController_Reconcile_DeletionWaitsForMemberships tests that an organization is only
+// removed once its memberships and namespace are gone
+func TestOrganizationController_Reconcile_DeletionWaitsForMemberships(t *testing.T) {
+	ctx := context.TODO()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "organization-acme"}}
+	membership := &resourcemanagerv1alpha1.OrganizationMembership{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "member",
+			Namespace:  "organization-acme",
+			Finalizers: []string{testMembershipFinalizer},
+		},
+		Spec: resourcemanagerv1alpha1.OrganizationMembershipSpec{
+			OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "acme"},
+			UserRef:         resourcemanagerv1alpha1.MemberReference{Name: "user"},
+		},
+	}
+	c := newOrganizationTeardownClient(t, interceptor.Funcs{}, newDeletingOrganization(), namespace, membership)
+	controller := &OrganizationController{Client: c}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "acme"}}
+
+	// The membership lingers, so deletion waits for it
+	for range 2 {
+		result, err := controller.Reconcile(ctx, req)
+		if err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+		if result.RequeueAfter == 0 {
+			t.Fatalf("Expected Reconcile to requeue while the membership exists")
+		}
+	}
+
+	var organization resourcemanagerv1alpha1.Organization
+	if err := c.Get(ctx, req.NamespacedName, &organization); err != nil {
+		t.Fatalf("Expected Organization to remain while the membership exists: %v", err)
+	}
+	teardown := apimeta.FindStatusCondition(organization.Status.Conditions, OrganizationTeardown)
+	if teardown == nil || teardown.Reason != OrganizationTeardownInProgressReason {
+		t.Fatalf("Expected Teardown condition with reason %s, got %+v", OrganizationTeardownInProgressReason, teardown)
+	}
+
+	var lingering resourcemanagerv1alpha1.OrganizationMembership
+	if err := c.Get(ctx, client.ObjectKeyFromObject(membership), &lingering); err != nil {
+		t.Fatalf("Failed to get OrganizationMembership: %v", err)
+	}
+	if lingering.DeletionTimestamp.IsZero() {
+		t.Fatalf("Expected OrganizationMembership to be deleted")
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(namespace), &corev1.Namespace{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected organization namespace to be deleted, got %v", err)
+	}
+
+	// Once the membership is gone the finalizer is removed
+	controllerutil.RemoveFinalizer(&lingering, testMembershipFinalizer)
+	if err := c.Update(ctx, &lingering); err != nil {
+		t.Fatalf("Failed to release OrganizationMembership: %v", err)
+	}
+	result, err := controller.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue once teardown completes, got %v", result.RequeueAfter)
+	}
+	if err := c.Get(ctx, req.NamespacedName, &organization); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected Organization to be deleted, got %v", err)
+	}
+}
+
+// TestOrganizationController_Reconcile_DeletionBlocked tests that a refused delete is reported in the status
+func TestOrganizationController_Reconcile_DeletionBlocked(t *testing.T) {
+	ctx := context.TODO()
+	membership := &resourcemanagerv1alpha1.OrganizationMembership{
+		ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "organization-acme"},
+		Spec: resourcemanagerv1alpha1.OrganizationMembershipSpec{
+			OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "acme"},
+			UserRef:         resourcemanagerv1alpha1.MemberReference{Name: "user"},
+		},
+	}
+	c := newOrganizationTeardownClient(t, interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if _, ok := obj.(*resourcemanagerv1alpha1.OrganizationMembership); ok {
+				return apierrors.NewForbidden(resourcemanagerv1alpha1.GroupVersion.WithResource("organizationmemberships").GroupResource(), obj.GetName(), nil)
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+	}, newDeletingOrganization(), membership)
+	controller := &OrganizationController{Client: c}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "acme"}}
+
+	if _, err := controller.Reconcile(ctx, req); err == nil {
+		t.Fatalf("Expected Reconcile to fail while teardown is blocked")
+	}
+
+	var organization resourcemanagerv1alpha1.Organization
+	if err := c.Get(ctx, req.NamespacedName, &organization); err != nil {
+		t.Fatalf("Expected Organization to remain while teardown is blocked: %v", err)
+	}
+	teardown := apimeta.FindStatusCondition(organization.Status.Conditions, OrganizationTeardown)
+	if teardown == nil || teardown.Reason != OrganizationTeardownBlockedReason {
+		t.Errorf("Expected Teardown condition with reason %s, got %+v", OrganizationTeardownBlockedReason, teardown)
+	}
+}
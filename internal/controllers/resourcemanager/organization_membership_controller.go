@@ -3,6 +3,7 @@ package resourcemanager
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -198,23 +199,10 @@ func (r *OrganizationMembershipController) Reconcile(ctx context.Context, req ct
 
 	apimeta.SetStatusCondition(&organizationMembership.Status.Conditions, *readyCondition)
 
-	// Reconcile roles if any are specified
-	if len(organizationMembership.Spec.Roles) > 0 {
-		if err := r.reconcileRoles(ctx, &organizationMembership, &organization, &user); err != nil {
-			logger.Error(err, "failed to reconcile roles")
-			return ctrl.Result{}, fmt.Errorf("failed to reconcile roles: %w", err)
-		}
-	} else {
-		// No roles specified, ensure RolesApplied condition reflects this
-		rolesAppliedCondition := metav1.Condition{
-			Type:               RolesApplied,
-			Status:             metav1.ConditionTrue,
-			Reason:             NoRolesSpecifiedReason,
-			Message:            "No roles specified for this membership",
-			ObservedGeneration: organizationMembership.Generation,
-		}
-		apimeta.SetStatusCondition(&organizationMembership.Status.Conditions, rolesAppliedCondition)
-		organizationMembership.Status.AppliedRoles = []resourcemanagerv1alpha.AppliedRole{}
+	// Reconcile roles even when none are specified, so the bindings of removed roles are deleted
+	if err := r.reconcileRoles(ctx, &organizationMembership, &organization, &user); err != nil {
+		logger.Error(err, "failed to reconcile roles")
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile roles: %w", err)
 	}
 
 	// Update the status only if something changed
@@ -320,18 +308,36 @@ func (r *OrganizationMembershipController) reconcileRoles(
 		appliedRoles = append(appliedRoles, appliedRole)
 	}
 
-	// Delete PolicyBindings that are no longer desired
+	// Delete PolicyBindings that are no longer desired. A binding that cannot be deleted still
+	// grants the removed role, so the failure is returned to retry the reconciliation.
+	var deleteErrs []error
 	for _, binding := range existingBindingMap {
 		logger.Info("deleting policy binding for removed role", "policyBinding", binding.Name)
 		if err := r.Client.Delete(ctx, binding); err != nil && !apierrors.IsNotFound(err) {
 			logger.Error(err, "failed to delete policy binding", "policyBinding", binding.Name)
-			// Continue with other deletions
+			// Continue with other deletions before retrying
+			deleteErrs = append(deleteErrs, fmt.Errorf("failed to delete policy binding %s: %w", binding.Name, err))
 		}
 	}
+	if len(deleteErrs) > 0 {
+		return errors.Join(deleteErrs...)
+	}
 
 	// Update status with applied roles
 	membership.Status.AppliedRoles = appliedRoles
 
+	if len(membership.Spec.Roles) == 0 {
+		// No roles specified, ensure RolesApplied condition reflects this
+		apimeta.SetStatusCondition(&membership.Status.Conditions, metav1.Condition{
+			Type:               RolesApplied,
+			Status:             metav1.ConditionTrue,
+			Reason:             NoRolesSpecifiedReason,
+			Message:            "No roles specified for this membership",
+			ObservedGeneration: membership.Generation,
+		})
+		return nil
+	}
+
 	// Set RolesApplied condition
 	rolesAppliedCondition := metav1.Condition{
 		Type:               RolesApplied,
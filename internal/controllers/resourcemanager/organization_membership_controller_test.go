@@ -2,6 +2,7 @@ package resourcemanager
 
 import (
 	"context"
+	"slices"
 	"testing"
 
 	iamv1alpha1 "go.miloapis.com/milo/pkg/apis/iam/v1alpha1"
@@ -11,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -181,6 +183,85 @@ func TestOrganizationMembershipController_ReconcileRoles(t *testing.T) {
 	})
 }
 
+// TestOrganizationMembershipController_ReconcileRoles_RoleChanges tests that roles added to or removed from
+// the membership spec are reflected in its PolicyBindings, including removing the last role
+func TestOrganizationMembershipController_ReconcileRoles_RoleChanges(t *testing.T) {
+	ctx := context.TODO()
+
+	organization := &resourcemanagerv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-org", UID: types.UID("org-uid-123")},
+	}
+	user := &iamv1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-user", UID: types.UID("user-uid-456")},
+		Spec:       iamv1alpha1.UserSpec{Email: "test@example.com"},
+	}
+	viewer := resourcemanagerv1alpha1.RoleReference{Name: "org-viewer", Namespace: "organization-test-org"}
+	editor := resourcemanagerv1alpha1.RoleReference{Name: "org-editor", Namespace: "organization-test-org"}
+	membership := &resourcemanagerv1alpha1.OrganizationMembership{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-membership", Namespace: "organization-test-org", UID: types.UID("membership-uid-789")},
+		Spec: resourcemanagerv1alpha1.OrganizationMembershipSpec{
+			OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "test-org"},
+			UserRef:         resourcemanagerv1alpha1.MemberReference{Name: "test-user"},
+			Roles:           []resourcemanagerv1alpha1.RoleReference{viewer},
+		},
+	}
+
+	objs := []client.Object{organization, user, membership}
+	for _, role := range []resourcemanagerv1alpha1.RoleReference{viewer, editor} {
+		objs = append(objs, &iamv1alpha1.Role{ObjectMeta: metav1.ObjectMeta{Name: role.Name, Namespace: role.Namespace}})
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(getTestScheme()).
+		WithObjects(objs...).
+		Build()
+	controller := &OrganizationMembershipController{Client: c}
+
+	boundRoles := func() []string {
+		t.Helper()
+		var bindings iamv1alpha1.PolicyBindingList
+		if err := c.List(ctx, &bindings); err != nil {
+			t.Fatalf("Failed to list PolicyBindings: %v", err)
+		}
+		roles := []string{}
+		for _, binding := range bindings.Items {
+			roles = append(roles, binding.Spec.RoleRef.Name)
+		}
+		slices.Sort(roles)
+		return roles
+	}
+
+	steps := []struct {
+		name  string
+		roles []resourcemanagerv1alpha1.RoleReference
+		want  []string
+	}{
+		{name: "initial role", roles: []resourcemanagerv1alpha1.RoleReference{viewer}, want: []string{"org-viewer"}},
+		{name: "role added", roles: []resourcemanagerv1alpha1.RoleReference{viewer, editor}, want: []string{"org-editor", "org-viewer"}},
+		{name: "role removed", roles: []resourcemanagerv1alpha1.RoleReference{editor}, want: []string{"org-editor"}},
+		{name: "last role removed", roles: nil, want: []string{}},
+	}
+	for _, step := range steps {
+		membership.Spec.Roles = step.roles
+		// Reconciling twice must not create duplicate bindings
+		for range 2 {
+			if err := controller.reconcileRoles(ctx, membership, organization, user); err != nil {
+				t.Fatalf("%s: reconcileRoles failed: %v", step.name, err)
+			}
+		}
+		if got := boundRoles(); !slices.Equal(got, step.want) {
+			t.Errorf("%s: expected PolicyBindings for roles %v, got %v", step.name, step.want, got)
+		}
+		if len(membership.Status.AppliedRoles) != len(step.roles) {
+			t.Errorf("%s: expected %d applied roles, got %d", step.name, len(step.roles), len(membership.Status.AppliedRoles))
+		}
+	}
+
+	rolesAppliedCondition := apimeta.FindStatusCondition(membership.Status.Conditions, RolesApplied)
+	if rolesAppliedCondition == nil || rolesAppliedCondition.Reason != NoRolesSpecifiedReason {
+		t.Errorf("Expected RolesApplied reason %s once all roles are removed, got %+v", NoRolesSpecifiedReason, rolesAppliedCondition)
+	}
+}
+
 // TestOrganizationMembershipController_ReconcileRoles_NonexistentRole tests handling of nonexistent roles
 func TestOrganizationMembershipController_ReconcileRoles_NonexistentRole(t *testing.T) {
 	ctx := context.TODO()
@@ -0,0 +1,98 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	milorequest "go.miloapis.com/milo/pkg/request"
+)
+
+// acquireClaimSlot reserves one of the request's project's claim wait slots, queueing
+// for up to the configured timeout when they are all taken. The returned release frees
+// the slot and must be called once the claim wait is over. When no slot frees up in
+// time the request is rejected as overloaded rather than left to hold a request
+// handler for the length of a claim wait.
+func (p *ResourceQuotaEnforcementPlugin) acquireClaimSlot(ctx context.Context) (func(), error) {
+	if p.config == nil || p.config.ClaimConcurrency.MaxInFlight <= 0 {
+		return func() {}, nil
+	}
+	limits := p.config.ClaimConcurrency
+
+	projectID, _ := milorequest.ProjectID(ctx)
+	pool := p.getClaimSlotPool(projectID, limits.MaxInFlight)
+	release := func() {
+		<-pool.slots
+		p.putClaimSlotPool(projectID, pool)
+	}
+
+	select {
+	case pool.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	if limits.QueueTimeout > 0 {
+		claimWaitQueueDepth.Inc()
+		timer := time.NewTimer(limits.QueueTimeout)
+		select {
+		case pool.slots <- struct{}{}:
+			timer.Stop()
+			claimWaitQueueDepth.Dec()
+			return release, nil
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+		claimWaitQueueDepth.Dec()
+	}
+
+	p.putClaimSlotPool(projectID, pool)
+	claimWaitRejections.Inc()
+	p.logger.Info("No claim wait slot available, rejecting request as overloaded",
+		"project", projectMetricLabel(projectID),
+		"maxInFlight", limits.MaxInFlight,
+		"queueTimeout", limits.QueueTimeout)
+	return nil, &claimPendingError{
+		overloaded: true,
+		message:    fmt.Sprintf("%d ResourceClaim waits already in flight for project %s", limits.MaxInFlight, projectMetricLabel(projectID)),
+	}
+}
+
+// claimSlotPool is a project's claim wait semaphore. refs counts the requests holding
+// or queueing for one of its slots, so the pool can be dropped once none are left.
+type claimSlotPool struct {
+	slots chan struct{}
+	refs  int
+}
+
+// getClaimSlotPool returns the project's claim wait semaphore, creating it on first
+// use. Every call must be matched by a putClaimSlotPool.
+func (p *ResourceQuotaEnforcementPlugin) getClaimSlotPool(projectID string, size int) *claimSlotPool {
+	p.claimSlotsMu.Lock()
+	defer p.claimSlotsMu.Unlock()
+
+	if p.claimSlots == nil {
+		p.claimSlots = make(map[string]*claimSlotPool)
+	}
+	pool, ok := p.claimSlots[projectID]
+	if !ok {
+		pool = &claimSlotPool{slots: make(chan struct{}, size)}
+		p.claimSlots[projectID] = pool
+	}
+	pool.refs++
+	return pool
+}
+
+// putClaimSlotPool drops a reference to the project's claim wait semaphore, removing
+// it once no request holds or waits for a slot, so semaphores of projects without
+// claim waits in flight do not accumulate.
+func (p *ResourceQuotaEnforcementPlugin) putClaimSlotPool(projectID string, pool *claimSlotPool) {
+	p.claimSlotsMu.Lock()
+	defer p.claimSlotsMu.Unlock()
+
+	pool.refs--
+	if pool.refs == 0 {
+		delete(p.claimSlots, projectID)
+	}
+}
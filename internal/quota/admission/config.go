@@ -2,6 +2,8 @@ package admission
 
 import (
 	"time"
+
+	"go.miloapis.com/milo/internal/quota/engine"
 )
 
 // TTLConfig holds configuration for watch manager TTL-based lifecycle management
@@ -36,7 +38,8 @@ type WatchManagerConfig struct {
 	// DefaultTimeout is the default timeout for waiting for ResourceClaim results
 	DefaultTimeout time.Duration
 
-	// MaxWaiters is the maximum number of concurrent waiters (0 = unlimited)
+	// MaxWaiters is the maximum number of concurrent waiters per watch manager (0 = unlimited).
+	// Requests beyond the limit are rejected with a retryable error instead of queuing.
 	MaxWaiters int
 
 	// TTL configuration for watch manager lifecycle
@@ -65,15 +68,99 @@ func DefaultWatchManagerConfig() *WatchManagerConfig {
 	}
 }
 
+// ClaimCreateRetryConfig holds configuration for retrying ResourceClaim creation
+// after transient API errors (conflicts, server timeouts, throttling).
+type ClaimCreateRetryConfig struct {
+	// MaxRetries is the number of retries after the initial attempt (0 = no retries)
+	MaxRetries int
+
+	// InitialDelay is the backoff delay before the first retry; it doubles on each retry
+	InitialDelay time.Duration
+
+	// MaxDelay is the maximum backoff delay between retries
+	MaxDelay time.Duration
+}
+
+// ClaimConcurrencyConfig limits how many requests per project may be creating and
+// waiting on a ResourceClaim at once, so a burst cannot tie up every apiserver
+// request handler until the claim wait times out
+type ClaimConcurrencyConfig struct {
+	// MaxInFlight is the maximum number of concurrent claim waits per project (0 = unlimited)
+	MaxInFlight int
+
+	// QueueTimeout is how long a request beyond the limit waits for a free slot before
+	// it is rejected as overloaded (0 = reject immediately)
+	QueueTimeout time.Duration
+}
+
+// ClaimTimeoutBehavior controls how admission responds when a ResourceClaim is not
+// resolved before the wait times out
+type ClaimTimeoutBehavior string
+
+const (
+	// ClaimTimeoutRetry rejects the request with a retryable 503, since the claim may
+	// still be granted later
+	ClaimTimeoutRetry ClaimTimeoutBehavior = "Retry"
+
+	// ClaimTimeoutDeny rejects the request with a 403, as if quota were exceeded
+	ClaimTimeoutDeny ClaimTimeoutBehavior = "Deny"
+)
+
 // AdmissionPluginConfig holds configuration for the ClaimCreationPlugin
 type AdmissionPluginConfig struct {
 	// WatchManager configuration
 	WatchManager *WatchManagerConfig
+
+	// ClaimCreateRetry configuration for transient ResourceClaim creation failures
+	ClaimCreateRetry ClaimCreateRetryConfig
+
+	// ClaimConcurrency limits concurrent ResourceClaim waits per project
+	ClaimConcurrency ClaimConcurrencyConfig
+
+	// PolicyCacheTTL is how long ClaimCreationPolicy lookups are cached per GVK (0 = disabled).
+	// The cache is also invalidated whenever the policy engine observes a policy change.
+	PolicyCacheTTL time.Duration
+
+	// DefaultClaimTTL is applied as spec.ttlSecondsAfterCreation to auto-created claims
+	// whose policy template does not set one (0 = claims never expire)
+	DefaultClaimTTL time.Duration
+
+	// PendingClaimRetryAfter is the delay suggested to clients when a request is rejected
+	// because its ResourceClaim could not be resolved, e.g. while quota granting is paused
+	PendingClaimRetryAfter time.Duration
+
+	// ClaimTimeoutBehavior selects whether a claim wait that times out is rejected as
+	// retryable or as a quota denial
+	ClaimTimeoutBehavior ClaimTimeoutBehavior
+
+	// CELProgramCacheSize is the number of compiled CEL programs kept for trigger
+	// constraint evaluation (0 = engine default)
+	CELProgramCacheSize int
+
+	// VersionInsensitivePolicyMatching applies a ClaimCreationPolicy to every API version
+	// of the group and kind it triggers on, so the same resource created through
+	// different versions consumes the same quota
+	VersionInsensitivePolicyMatching bool
 }
 
 // DefaultAdmissionPluginConfig returns the default configuration for the admission plugin
 func DefaultAdmissionPluginConfig() *AdmissionPluginConfig {
 	return &AdmissionPluginConfig{
 		WatchManager: DefaultWatchManagerConfig(),
+		ClaimCreateRetry: ClaimCreateRetryConfig{
+			MaxRetries:   3,
+			InitialDelay: 50 * time.Millisecond,
+			MaxDelay:     1 * time.Second,
+		},
+		ClaimConcurrency: ClaimConcurrencyConfig{
+			MaxInFlight:  500,
+			QueueTimeout: 2 * time.Second,
+		},
+		PolicyCacheTTL:                   5 * time.Second,
+		DefaultClaimTTL:                  10 * time.Minute,
+		PendingClaimRetryAfter:           10 * time.Second,
+		ClaimTimeoutBehavior:             ClaimTimeoutRetry,
+		CELProgramCacheSize:              engine.DefaultProgramCacheSize,
+		VersionInsensitivePolicyMatching: true,
 	}
 }
@@ -0,0 +1,233 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/klog/v2"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	milorequest "go.miloapis.com/milo/pkg/request"
+)
+
+// Decision is the outcome of quota admission for a single request.
+type Decision string
+
+const (
+	// DecisionGranted means a ResourceClaim was granted and the request was admitted.
+	DecisionGranted Decision = "Granted"
+	// DecisionDenied means the request was rejected for insufficient quota.
+	DecisionDenied Decision = "Denied"
+	// DecisionExempt means a policy applies but the request was admitted without a claim.
+	DecisionExempt Decision = "Exempt"
+	// DecisionSkipped means no policy applied to the request.
+	DecisionSkipped Decision = "Skipped"
+	// DecisionPending means the ResourceClaim was not resolved and the request was
+	// rejected with a retryable error rather than denied.
+	DecisionPending Decision = "Pending"
+	// DecisionAudited means the ResourceClaim was not granted but the request was
+	// admitted because its policy is in Audit mode.
+	DecisionAudited Decision = "Audited"
+)
+
+// Reasons recorded on exempt and skipped decisions.
+const (
+	DecisionReasonDryRun                     = "DryRun"
+	DecisionReasonPolicyDisabled             = "PolicyDisabled"
+	DecisionReasonNoPolicy                   = "NoPolicy"
+	DecisionReasonConstraintsNotMet          = "ConstraintsNotMet"
+	DecisionReasonConstraintEvaluationFailed = "ConstraintEvaluationFailed"
+	DecisionReasonSelectorsNotMatched        = "SelectorsNotMatched"
+	DecisionReasonSubresourceNotMatched      = "SubresourceNotMatched"
+	DecisionReasonNotScaledUp                = "NotScaledUp"
+	DecisionReasonSelectorEvaluationFailed   = "SelectorEvaluationFailed"
+	DecisionReasonGrantingPaused             = "GrantingPaused"
+	DecisionReasonClaimTimeout               = "ClaimTimeout"
+	DecisionReasonWaiterLimitReached         = "WaiterLimitReached"
+	DecisionReasonQuotaSystemOverloaded      = "QuotaSystemOverloaded"
+	DecisionReasonAlreadyExists              = "AlreadyExists"
+	DecisionReasonNothingToClaim             = "NothingToClaim"
+	DecisionReasonPolicyNotReady             = "PolicyNotReady"
+	DecisionReasonTemplateRenderFailed       = "TemplateRenderFailed"
+	DecisionReasonResourceTypeNotRegistered  = "ResourceTypeNotRegistered"
+	DecisionReasonExemptSubject              = "ExemptSubject"
+	DecisionReasonNoQuotaAllocated           = "NoQuotaAllocated"
+	DecisionReasonAuditMode                  = "AuditMode"
+	DecisionReasonParentUnresolved           = "ParentUnresolved"
+)
+
+// DecisionRecord is a structured description of a quota admission decision, written
+// to the configured DecisionSink as JSON.
+type DecisionRecord struct {
+	Time            time.Time       `json:"time"`
+	Decision        Decision        `json:"decision"`
+	Reason          string          `json:"reason,omitempty"`
+	Message         string          `json:"message,omitempty"`
+	Project         string          `json:"project,omitempty"`
+	Operation       string          `json:"operation"`
+	Group           string          `json:"group"`
+	Version         string          `json:"version"`
+	Kind            string          `json:"kind"`
+	Namespace       string          `json:"namespace,omitempty"`
+	Name            string          `json:"name,omitempty"`
+	User            string          `json:"user,omitempty"`
+	Policy          string          `json:"policy,omitempty"`
+	PolicyNamespace string          `json:"policyNamespace,omitempty"`
+	DeniedRequests  []RequestDenial `json:"deniedRequests,omitempty"`
+}
+
+// DecisionSink receives a record of every quota admission decision. Implementations
+// must not block admission; delivery is best-effort.
+type DecisionSink interface {
+	Record(ctx context.Context, record DecisionRecord)
+}
+
+// noopDecisionSink discards all decision records.
+type noopDecisionSink struct{}
+
+func (noopDecisionSink) Record(context.Context, DecisionRecord) {}
+
+// NewDecisionSink builds a sink from a target: http:// and https:// URLs receive
+// each record as a JSON POST, any other value is a file that records are appended
+// to as newline-delimited JSON. An empty target discards records.
+func NewDecisionSink(target string) (DecisionSink, error) {
+	switch {
+	case target == "":
+		return noopDecisionSink{}, nil
+	case strings.HasPrefix(target, "http://"), strings.HasPrefix(target, "https://"):
+		return NewHTTPDecisionSink(target, &http.Client{Timeout: 5 * time.Second}), nil
+	default:
+		f, err := os.OpenFile(target, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open decision sink file: %w", err)
+		}
+		return NewWriterDecisionSink(f), nil
+	}
+}
+
+// writerDecisionSink writes records as newline-delimited JSON.
+type writerDecisionSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewWriterDecisionSink returns a sink that writes each record to w as a line of JSON.
+func NewWriterDecisionSink(w io.Writer) DecisionSink {
+	return &writerDecisionSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *writerDecisionSink) Record(_ context.Context, record DecisionRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.encoder.Encode(record); err != nil {
+		klog.V(2).InfoS("Failed to write quota admission decision", "err", err)
+	}
+}
+
+// decisionQueueSize bounds how many records an HTTP sink holds while the endpoint is
+// slow; further records are dropped.
+const decisionQueueSize = 1000
+
+// httpDecisionSink POSTs records to an HTTP endpoint from a background worker, so
+// admission never waits on the endpoint.
+type httpDecisionSink struct {
+	url     string
+	client  *http.Client
+	records chan DecisionRecord
+}
+
+// NewHTTPDecisionSink returns a sink that POSTs each record as JSON to url.
+func NewHTTPDecisionSink(url string, client *http.Client) DecisionSink {
+	s := &httpDecisionSink{
+		url:     url,
+		client:  client,
+		records: make(chan DecisionRecord, decisionQueueSize),
+	}
+	go s.run()
+	return s
+}
+
+func (s *httpDecisionSink) Record(_ context.Context, record DecisionRecord) {
+	select {
+	case s.records <- record:
+	default:
+		klog.V(2).InfoS("Dropping quota admission decision, sink queue is full", "url", s.url)
+	}
+}
+
+func (s *httpDecisionSink) run() {
+	for record := range s.records {
+		if err := s.post(record); err != nil {
+			klog.V(2).InfoS("Failed to send quota admission decision", "url", s.url, "err", err)
+		}
+	}
+}
+
+func (s *httpDecisionSink) post(record DecisionRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// DecisionSinkInitializer injects a DecisionSink into admission plugins that accept one.
+type DecisionSinkInitializer struct {
+	Sink DecisionSink
+}
+
+func (i DecisionSinkInitializer) Initialize(p admission.Interface) {
+	if w, ok := p.(interface{ SetDecisionSink(DecisionSink) }); ok && i.Sink != nil {
+		w.SetDecisionSink(i.Sink)
+	}
+}
+
+// recordDecision writes a decision about attrs to the plugin's decision sink.
+func (p *ResourceQuotaEnforcementPlugin) recordDecision(ctx context.Context, attrs admission.Attributes, gvk schema.GroupVersionKind, policy *quotav1alpha1.ClaimCreationPolicy, decision Decision, reason, message string, denied []RequestDenial) {
+	if p.decisionSink == nil {
+		return
+	}
+
+	projectID, _ := milorequest.ProjectID(ctx)
+	record := DecisionRecord{
+		Time:           time.Now().UTC(),
+		Decision:       decision,
+		Reason:         reason,
+		Message:        message,
+		Project:        projectID,
+		Operation:      string(attrs.GetOperation()),
+		Group:          gvk.Group,
+		Version:        gvk.Version,
+		Kind:           gvk.Kind,
+		Namespace:      attrs.GetNamespace(),
+		Name:           attrs.GetName(),
+		DeniedRequests: denied,
+	}
+	if userInfo := attrs.GetUserInfo(); userInfo != nil {
+		record.User = userInfo.GetName()
+	}
+	if policy != nil {
+		record.Policy = policy.Name
+		record.PolicyNamespace = policy.Namespace
+	}
+	p.decisionSink.Record(ctx, record)
+}
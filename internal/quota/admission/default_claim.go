@@ -0,0 +1,58 @@
+package admission
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"go.miloapis.com/milo/internal/quota/validation"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// lookupDefaultClaimPolicy returns a policy synthesized from the default claim of a
+// ResourceRegistration that lists gvk as a claiming resource, or nil when none does.
+// It is consulted only when no ClaimCreationPolicy triggers on gvk.
+func (p *ResourceQuotaEnforcementPlugin) lookupDefaultClaimPolicy(gvk schema.GroupVersionKind) *quotav1alpha1.ClaimCreationPolicy {
+	if p.resourceTypeValidator == nil {
+		return nil
+	}
+	defaultClaim, ok := p.resourceTypeValidator.GetDefaultClaim(gvk.Group, gvk.Kind)
+	if !ok {
+		return nil
+	}
+	return defaultClaimPolicy(gvk, defaultClaim)
+}
+
+// defaultClaimPolicy builds the ClaimCreationPolicy a registration's default claim stands
+// for, so that registration-driven claims are rendered, created and awaited exactly like
+// policy-driven ones. The policy is named after the registration, which is what claims
+// and decision records report as their policy.
+func defaultClaimPolicy(gvk schema.GroupVersionKind, defaultClaim validation.DefaultClaim) *quotav1alpha1.ClaimCreationPolicy {
+	return &quotav1alpha1.ClaimCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: defaultClaim.RegistrationName,
+		},
+		Spec: quotav1alpha1.ClaimCreationPolicySpec{
+			Trigger: quotav1alpha1.ClaimTriggerSpec{
+				Resource: &quotav1alpha1.ClaimTriggerResource{
+					APIVersion: gvk.GroupVersion().String(),
+					Kind:       gvk.Kind,
+				},
+			},
+			Target: quotav1alpha1.ClaimTargetSpec{
+				ResourceClaimTemplate: quotav1alpha1.ResourceClaimTemplate{
+					Spec: quotav1alpha1.ResourceClaimSpec{
+						ConsumerRef: quotav1alpha1.ConsumerRef{
+							APIGroup: defaultClaim.ConsumerType.APIGroup,
+							Kind:     defaultClaim.ConsumerType.Kind,
+							Name:     defaultClaim.ConsumerName,
+						},
+						Requests: []quotav1alpha1.ResourceRequest{{
+							ResourceType: defaultClaim.ResourceType,
+							Amount:       defaultClaim.Amount,
+						}},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package admission
+
+import (
+	"strings"
+
+	"go.miloapis.com/milo/internal/quota/validation"
+)
+
+// renderDenialMessage renders the denialMessageTemplate of each denied request's
+// ResourceRegistration. It returns false, so the default message is used, unless every
+// denied request has a template that renders.
+func (p *ResourceQuotaEnforcementPlugin) renderDenialMessage(denials []RequestDenial) (string, bool) {
+	if p.resourceTypeValidator == nil || len(denials) == 0 {
+		return "", false
+	}
+
+	messages := make([]string, 0, len(denials))
+	for _, denial := range denials {
+		text, ok := p.resourceTypeValidator.GetDenialMessageTemplate(denial.ResourceType)
+		if !ok {
+			return "", false
+		}
+
+		data := validation.DenialMessageData{ResourceType: denial.ResourceType}
+		if denial.Details != nil {
+			data.Requested = denial.Details.Requested
+			data.Limit = denial.Details.Limit
+			data.Used = denial.Details.Allocated
+			data.Available = denial.Details.Available
+		}
+
+		message, err := validation.RenderDenialMessage(text, data)
+		if err != nil {
+			p.logger.Error(err, "Failed to render denial message template, using the default message",
+				"resourceType", denial.ResourceType)
+			return "", false
+		}
+		messages = append(messages, message)
+	}
+
+	return strings.Join(messages, " "), true
+}
@@ -0,0 +1,73 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+
+	"go.miloapis.com/milo/internal/quota/bucketutil"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	milorequest "go.miloapis.com/milo/pkg/request"
+)
+
+// recordDenialEvents records Warning Events describing a quota denial on the consumer
+// and, for requests to the root control plane, on the triggering namespace. The
+// recorder queues events without blocking, so admission never waits on delivery.
+func (p *ResourceQuotaEnforcementPlugin) recordDenialEvents(ctx context.Context, attrs admission.Attributes, gvk schema.GroupVersionKind, deniedErr *claimDeniedError) {
+	if p.eventRecorder == nil {
+		return
+	}
+
+	message := denialEventMessage(attrs, gvk, deniedErr)
+
+	// The recorder writes to the root control plane, which does not hold the
+	// namespaces of project control planes
+	if projectID, _ := milorequest.ProjectID(ctx); projectID == "" && attrs.GetNamespace() != "" {
+		p.eventRecorder.Event(&corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+			Name:       attrs.GetNamespace(),
+			Namespace:  attrs.GetNamespace(),
+		}, corev1.EventTypeWarning, quotav1alpha1.ResourceClaimDeniedReason, message)
+	}
+
+	consumer := deniedErr.consumer
+	if consumer.Kind != "" && consumer.Name != "" {
+		p.eventRecorder.Event(&corev1.ObjectReference{
+			APIVersion: consumer.APIGroup,
+			Kind:       consumer.Kind,
+			Name:       consumer.Name,
+			Namespace:  consumer.Namespace,
+		}, corev1.EventTypeWarning, quotav1alpha1.ResourceClaimDeniedReason, message)
+	}
+}
+
+// denialEventMessage describes the denied resource and, for each exhausted resource
+// type, the AllowanceBucket that could not satisfy it.
+func denialEventMessage(attrs admission.Attributes, gvk schema.GroupVersionKind, deniedErr *claimDeniedError) string {
+	target := fmt.Sprintf("%s %s", gvk.Kind, attrs.GetName())
+	if attrs.GetNamespace() != "" {
+		target = fmt.Sprintf("%s %s/%s", gvk.Kind, attrs.GetNamespace(), attrs.GetName())
+	}
+
+	if len(deniedErr.requests) == 0 {
+		return fmt.Sprintf("Quota denied creation of %s: %s", target, deniedErr.reason)
+	}
+
+	parts := make([]string, 0, len(deniedErr.requests))
+	for _, denial := range deniedErr.requests {
+		part := denial.ResourceType
+		if deniedErr.consumer.Kind != "" {
+			part = fmt.Sprintf("%s in AllowanceBucket %s", part, bucketutil.Name(denial.ResourceType, deniedErr.consumer))
+		}
+		if denial.Message != "" {
+			part = fmt.Sprintf("%s (%s)", part, denial.Message)
+		}
+		parts = append(parts, part)
+	}
+	return fmt.Sprintf("Quota denied creation of %s: exhausted %s", target, strings.Join(parts, "; "))
+}
@@ -0,0 +1,45 @@
+package admission
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	"go.miloapis.com/milo/internal/quota/validation"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	milorequest "go.miloapis.com/milo/pkg/request"
+)
+
+// systemGroupPrefix marks groups assigned by the platform, which tenants cannot add
+// their own users to.
+const systemGroupPrefix = "system:"
+
+// exemptSubject returns the subject through which the requester is exempt from the
+// policy, if any. Users in project control planes are not necessarily platform
+// operators, so there only system groups are honored. Groups that tenants belong to or
+// control are never honored, even on policies admitted before they were rejected.
+func exemptSubject(ctx context.Context, policy *quotav1alpha1.ClaimCreationPolicy, userInfo user.Info) (string, bool) {
+	exempt := policy.Spec.ExemptSubjects
+	if exempt == nil || userInfo == nil {
+		return "", false
+	}
+	_, projectPlane := milorequest.ProjectID(ctx)
+
+	for _, group := range userInfo.GetGroups() {
+		if projectPlane && !strings.HasPrefix(group, systemGroupPrefix) {
+			continue
+		}
+		if validation.IsTenantControlledGroup(group) {
+			continue
+		}
+		if slices.Contains(exempt.Groups, group) {
+			return "group " + group, true
+		}
+	}
+	if !projectPlane && slices.Contains(exempt.Users, userInfo.GetName()) {
+		return "user " + userInfo.GetName(), true
+	}
+	return "", false
+}
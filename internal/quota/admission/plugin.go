@@ -2,7 +2,12 @@ package admission
 
 import (
 	"context"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -10,12 +15,14 @@ import (
 	"github.com/go-logr/logr"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/admission/initializer"
 	"k8s.io/apiserver/pkg/endpoints/request"
@@ -23,12 +30,15 @@ import (
 	"k8s.io/apiserver/pkg/warning"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/component-base/metrics"
 	legacyregistry "k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/klog/v2"
 
 	"k8s.io/kubernetes/pkg/api/legacyscheme"
 
+	"go.miloapis.com/milo/internal/quota/bucketutil"
+	quotacel "go.miloapis.com/milo/internal/quota/cel"
 	"go.miloapis.com/milo/internal/quota/engine"
 	"go.miloapis.com/milo/internal/quota/validation"
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
@@ -49,16 +59,73 @@ var (
 		&metrics.CounterOpts{
 			Subsystem:      "milo_quota",
 			Name:           "admission_result_total",
-			Help:           "Total quota admission decisions by outcome, policy, policy namespace, and resource type.",
+			Help:           "Total quota admission decisions by outcome, reason, policy, policy namespace, and resource type.",
 			StabilityLevel: metrics.ALPHA,
 		},
-		[]string{"result", "policy_name", "policy_namespace", "resource_group", "resource_kind"},
+		[]string{"result", "reason", "policy_name", "policy_namespace", "resource_group", "resource_kind"},
+	)
+
+	claimCreateRetries = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      "milo_quota",
+			Name:           "claim_create_retries_total",
+			Help:           "Total ResourceClaim creation retries after transient API errors, by error reason.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"reason"},
 	)
 )
 
+// Values of the admission_result_total reason label. The set is fixed to keep the
+// metric's cardinality bounded.
+const (
+	resultReasonNone           = "none"
+	resultReasonQuotaExceeded  = "quota_exceeded"
+	resultReasonTimeout        = "timeout"
+	resultReasonGrantingPaused = "granting_paused"
+	resultReasonWaiterLimit    = "waiter_limit"
+	resultReasonOverloaded     = "overloaded"
+	resultReasonClaimDeleted   = "claim_deleted"
+	resultReasonNoGrant        = "no_grant"
+	resultReasonError          = "error"
+)
+
+// admissionResultReason classifies an error from createAndWaitForResourceClaim into a
+// reason label value, separating quota exhaustion from controller lag and failures.
+func admissionResultReason(err error) string {
+	var deniedErr *claimDeniedError
+	var pendingErr *claimPendingError
+	var deletedErr *claimDeletedError
+	var noQuotaErr *noQuotaAllocatedError
+	switch {
+	case err == nil:
+		return resultReasonNone
+	case goerrors.As(err, &deniedErr):
+		return resultReasonQuotaExceeded
+	case goerrors.As(err, &pendingErr):
+		switch {
+		case pendingErr.paused:
+			return resultReasonGrantingPaused
+		case pendingErr.saturated:
+			return resultReasonWaiterLimit
+		case pendingErr.overloaded:
+			return resultReasonOverloaded
+		default:
+			return resultReasonTimeout
+		}
+	case goerrors.As(err, &deletedErr):
+		return resultReasonClaimDeleted
+	case goerrors.As(err, &noQuotaErr):
+		return resultReasonNoGrant
+	default:
+		return resultReasonError
+	}
+}
+
 func init() {
 	// Register metrics with Kubernetes legacy registry so they are exposed on the apiserver /metrics.
 	legacyregistry.MustRegister(admissionResultTotal)
+	legacyregistry.MustRegister(claimCreateRetries)
 }
 
 // ResourceQuotaEnforcementPlugin enforces quota by creating ResourceClaims for applicable resources.
@@ -69,7 +136,7 @@ type ResourceQuotaEnforcementPlugin struct {
 	*admission.Handler
 	dynamicClient                 dynamic.Interface
 	loopbackConfig                *rest.Config
-	projectClients                sync.Map // map[string]dynamic.Interface (cached project clients)
+	projectClients                sync.Map // map[string]*projectClient (cached project clients)
 	policyEngine                  engine.PolicyEngine
 	templateEngine                engine.TemplateEngine
 	resourceClaimValidator        validation.ResourceClaimValidator
@@ -83,8 +150,21 @@ type ResourceQuotaEnforcementPlugin struct {
 	resourceTypeValidator validation.ResourceTypeValidator
 
 	watchManagers sync.Map // map[string]ClaimWatchManager (projectID -> watch manager, "" = root)
+	policyCache   *policyLookupCache
 	config        *AdmissionPluginConfig
 	logger        logr.Logger
+
+	// claimSlotsMu guards claimSlots, the claim wait semaphores of the projects with
+	// claim waits in flight ("" = root).
+	claimSlotsMu sync.Mutex
+	claimSlots   map[string]*claimSlotPool
+
+	// eventRecorder records quota denials as Events in the root control plane. Events
+	// are best-effort and skipped when no recorder is set.
+	eventRecorder record.EventRecorder
+
+	// decisionSink receives a structured record of every quota admission decision.
+	decisionSink DecisionSink
 }
 
 // Ensure ResourceQuotaEnforcementPlugin implements the required initializer interfaces
@@ -92,16 +172,34 @@ var _ initializer.WantsDynamicClient = &ResourceQuotaEnforcementPlugin{}
 var _ admission.ValidationInterface = &ResourceQuotaEnforcementPlugin{}
 var _ admission.InitializationValidator = &ResourceQuotaEnforcementPlugin{}
 
-// NewResourceQuotaEnforcementPlugin creates a new ResourceQuotaEnforcementPlugin.
+// NewResourceQuotaEnforcementPlugin creates a new ResourceQuotaEnforcementPlugin with the
+// default configuration.
 func NewResourceQuotaEnforcementPlugin() (*ResourceQuotaEnforcementPlugin, error) {
+	return NewResourceQuotaEnforcementPluginWithConfig(DefaultAdmissionPluginConfig())
+}
+
+// NewResourceQuotaEnforcementPluginWithConfig creates a new instance of the admission
+// plugin with the given configuration.
+func NewResourceQuotaEnforcementPluginWithConfig(config *AdmissionPluginConfig) (*ResourceQuotaEnforcementPlugin, error) {
 	logger := klog.NewKlogr().WithName("resource-quota-enforcement-plugin")
 	klog.V(1).InfoS("Creating ResourceQuotaEnforcement admission plugin instance")
 
+	if config.CELProgramCacheSize < 0 {
+		return nil, fmt.Errorf("CEL program cache size must not be negative, got %d", config.CELProgramCacheSize)
+	}
+	switch config.ClaimTimeoutBehavior {
+	case ClaimTimeoutRetry, ClaimTimeoutDeny:
+	default:
+		return nil, fmt.Errorf("claim timeout behavior must be %q or %q, got %q", ClaimTimeoutRetry, ClaimTimeoutDeny, config.ClaimTimeoutBehavior)
+	}
+
 	// Create the admission plugin - tracer will be initialized when TracerProvider is injected
 	plugin := &ResourceQuotaEnforcementPlugin{
-		Handler: admission.NewHandler(admission.Create),
-		config:  DefaultAdmissionPluginConfig(),
-		logger:  logger,
+		Handler:      admission.NewHandler(admission.Create, admission.Update, admission.Delete),
+		config:       config,
+		policyCache:  newPolicyLookupCache(config.PolicyCacheTTL),
+		logger:       logger,
+		decisionSink: noopDecisionSink{},
 	}
 
 	return plugin, nil
@@ -124,6 +222,18 @@ func (p *ResourceQuotaEnforcementPlugin) SetLoopbackConfig(cfg *rest.Config) {
 	p.logger.V(2).Info("Loopback config injected", "plugin", PluginName)
 }
 
+// SetEventRecorder sets the recorder used to report quota denials as Events.
+func (p *ResourceQuotaEnforcementPlugin) SetEventRecorder(recorder record.EventRecorder) {
+	p.eventRecorder = recorder
+	p.logger.V(2).Info("Event recorder set", "plugin", PluginName)
+}
+
+// SetDecisionSink sets the sink that receives a record of each quota admission decision.
+func (p *ResourceQuotaEnforcementPlugin) SetDecisionSink(sink DecisionSink) {
+	p.decisionSink = sink
+	p.logger.V(2).Info("Decision sink set", "plugin", PluginName)
+}
+
 // ValidateInitialization implements admission.InitializationValidator
 func (p *ResourceQuotaEnforcementPlugin) ValidateInitialization() error {
 	if p.dynamicClient == nil {
@@ -157,14 +267,19 @@ func (p *ResourceQuotaEnforcementPlugin) ValidateInitialization() error {
 func (p *ResourceQuotaEnforcementPlugin) initializeEngines() {
 	p.logger.V(2).Info("Initializing engines for admission plugin")
 
-	celEngine, err := engine.NewCELEngine()
+	celEngine, err := p.newCELEngine()
 	if err != nil {
 		p.logger.Error(err, "Failed to create CEL engine")
 		return
 	}
 
 	p.templateEngine = engine.NewTemplateEngine(celEngine, p.logger.WithName("template"))
-	p.policyEngine = engine.NewPolicyEngine(p.dynamicClient, p.logger)
+	p.policyEngine = engine.NewPolicyEngineWithOptions(p.dynamicClient, p.logger, engine.PolicyEngineOptions{
+		MatchAnyVersion: p.config.VersionInsensitivePolicyMatching,
+	})
+	if notifier, ok := p.policyEngine.(engine.PolicyChangeNotifier); ok {
+		notifier.AddPolicyChangeHandler(p.policyCache.invalidate)
+	}
 	p.resourceTypeValidator = validation.NewResourceTypeValidator(p.dynamicClient)
 	p.resourceClaimValidator = validation.NewResourceClaimValidator(p.dynamicClient, p.resourceTypeValidator)
 	p.resourceRegistrationValidator = validation.NewResourceRegistrationValidator(p.resourceTypeValidator)
@@ -182,7 +297,9 @@ func (p *ResourceQuotaEnforcementPlugin) initializeEngines() {
 	}
 
 	p.claimCreationPolicyValidator = validation.NewClaimCreationPolicyValidator(p.resourceTypeValidator)
+	p.claimCreationPolicyValidator.ClaimRenderer = engine.NewSampleClaimRenderer(p.templateEngine)
 	p.grantCreationPolicyValidator = validation.NewGrantCreationPolicyValidator(celValidator, grantTemplateValidator)
+	p.grantCreationPolicyValidator.GrantRenderer = engine.NewSampleGrantRenderer(p.templateEngine)
 	p.resourceGrantValidator = validation.NewResourceGrantValidator(p.resourceTypeValidator)
 
 	go func() {
@@ -194,6 +311,14 @@ func (p *ResourceQuotaEnforcementPlugin) initializeEngines() {
 	p.logger.V(2).Info("Engines initialized successfully")
 }
 
+// newCELEngine creates the CEL engine that evaluates trigger constraints and claim
+// templates. Organizations live in the root control plane, so orgTier() reads them with
+// the root client whichever plane the request was made in.
+func (p *ResourceQuotaEnforcementPlugin) newCELEngine() (engine.CELEngine, error) {
+	return engine.NewCELEngineWithCacheSize(p.config.CELProgramCacheSize,
+		quotacel.WithOrganizationTiers(engine.NewDynamicOrganizationTierLister(p.dynamicClient)))
+}
+
 // getClient routes to infrastructure or project-scoped clients based on request context.
 func (p *ResourceQuotaEnforcementPlugin) getClient(ctx context.Context) (dynamic.Interface, error) {
 	projectID, ok := milorequest.ProjectID(ctx)
@@ -203,10 +328,41 @@ func (p *ResourceQuotaEnforcementPlugin) getClient(ctx context.Context) (dynamic
 	return p.getProjectClient(projectID)
 }
 
+// projectClient is a cached project control-plane client. Like watch managers, it is
+// evicted once it goes unused for the watch manager TTL, so clients for deleted projects
+// do not accumulate.
+type projectClient struct {
+	client dynamic.Interface
+
+	// mu guards timer, which is set after the entry is already visible to other requests
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+// touch postpones the client's eviction by ttl.
+func (c *projectClient) touch(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.timer != nil {
+		c.timer.Reset(ttl)
+	}
+}
+
+// projectClientTTL returns how long an unused project client stays cached (0 = forever).
+func (p *ResourceQuotaEnforcementPlugin) projectClientTTL() time.Duration {
+	if p.config == nil || p.config.WatchManager == nil {
+		return 0
+	}
+	return p.config.WatchManager.TTL.DefaultTTL
+}
+
 // getProjectClient creates or retrieves a cached client for a project's virtual control plane.
 func (p *ResourceQuotaEnforcementPlugin) getProjectClient(projectID string) (dynamic.Interface, error) {
+	ttl := p.projectClientTTL()
 	if cached, ok := p.projectClients.Load(projectID); ok {
-		return cached.(dynamic.Interface), nil
+		entry := cached.(*projectClient)
+		entry.touch(ttl)
+		return entry.client, nil
 	}
 
 	if p.loopbackConfig == nil {
@@ -216,22 +372,68 @@ func (p *ResourceQuotaEnforcementPlugin) getProjectClient(projectID string) (dyn
 	cfg := rest.CopyConfig(p.loopbackConfig)
 
 	// Host field supports URL paths to route requests to project control planes
-	projectPath := fmt.Sprintf("/apis/resourcemanager.miloapis.com/v1alpha1/projects/%s/control-plane", projectID)
-
-	if strings.HasPrefix(cfg.Host, "http://") || strings.HasPrefix(cfg.Host, "https://") {
-		cfg.Host = cfg.Host + projectPath
-	} else {
-		cfg.Host = cfg.Host + projectPath
+	host, err := projectControlPlaneHost(cfg, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build control plane host for project %s: %w", projectID, err)
 	}
+	cfg.Host = host
 
 	client, err := dynamic.NewForConfig(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create project dynamic client for project %s: %w", projectID, err)
 	}
 
-	actual, _ := p.projectClients.LoadOrStore(projectID, client)
-	p.logger.V(3).Info("Created project-specific dynamic client", "project", projectID, "path", projectPath)
-	return actual.(dynamic.Interface), nil
+	entry := &projectClient{client: client}
+	actual, loaded := p.projectClients.LoadOrStore(projectID, entry)
+	if loaded {
+		existing := actual.(*projectClient)
+		existing.touch(ttl)
+		return existing.client, nil
+	}
+
+	if ttl > 0 {
+		// Only remove this entry; a later client for the same project has its own timer.
+		entry.mu.Lock()
+		entry.timer = time.AfterFunc(ttl, func() {
+			if p.projectClients.CompareAndDelete(projectID, entry) {
+				p.logger.V(2).Info("Project client TTL expired, removing from cache", "project", projectID)
+				projectClientEvictions.Inc()
+			}
+		})
+		entry.mu.Unlock()
+	}
+
+	p.logger.V(3).Info("Created project-specific dynamic client", "project", projectID, "host", host)
+	return client, nil
+}
+
+// projectControlPlaneHost returns cfg.Host rewritten to address a project's control
+// plane. A host without a scheme (e.g. "localhost:8080") gets https when the config
+// carries TLS settings and http otherwise; any existing path keeps its prefix with
+// trailing slashes removed.
+func projectControlPlaneHost(cfg *rest.Config, projectID string) (string, error) {
+	host := cfg.Host
+	if !strings.Contains(host, "://") {
+		scheme := "http"
+		if rest.IsConfigTransportTLS(*cfg) {
+			scheme = "https"
+		} else if t, ok := cfg.Transport.(*http.Transport); ok && t.TLSClientConfig != nil {
+			scheme = "https"
+		}
+		host = scheme + "://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", cfg.Host, err)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid host %q: missing host name", cfg.Host)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/") + fmt.Sprintf("/apis/resourcemanager.miloapis.com/v1alpha1/projects/%s/control-plane", projectID)
+	u.RawPath = ""
+	return u.String(), nil
 }
 
 // getWatchManager returns a project-scoped watch manager, blocking until ready.
@@ -258,13 +460,14 @@ func (p *ResourceQuotaEnforcementPlugin) getWatchManager(ctx context.Context) (C
 		logger = logger.WithValues("project", projectID)
 	}
 
-	wm := NewWatchManager(client, logger, projectID)
+	wm := NewWatchManagerWithConfig(client, logger, projectID, p.config.WatchManager)
 
 	if wmWithCallback, ok := wm.(*watchManager); ok {
 		wmWithCallback.SetTTLExpiredCallback(func() {
 			p.logger.Info("Watch manager TTL expired, removing from cache",
 				"project", projectID)
 			p.watchManagers.Delete(projectID)
+			watchManagerEvictions.Inc()
 		})
 	}
 
@@ -313,6 +516,11 @@ func (p *ResourceQuotaEnforcementPlugin) Validate(ctx context.Context, attrs adm
 		"dryRun", attrs.IsDryRun(),
 	)
 
+	// Any object may be a quota consumer, so the override annotation is guarded on all of them
+	if err := validateQuotaOverrideChange(ctx, attrs); err != nil {
+		return err
+	}
+
 	// Route to appropriate handler based on resource type
 	if attrs.GetKind().Group == "quota.miloapis.com" {
 		switch attrs.GetKind().Kind {
@@ -329,14 +537,16 @@ func (p *ResourceQuotaEnforcementPlugin) Validate(ctx context.Context, attrs adm
 		}
 	}
 
-	// Only handle CREATE operations for other resources
-	if attrs.GetOperation() != admission.Create {
-		p.logger.V(4).Info("Skipping non-CREATE operation", "operation", attrs.GetOperation())
+	// Only handle CREATE operations for other resources, and UPDATE operations of
+	// subresources, which policies may enforce quota on (e.g. scale)
+	if attrs.GetOperation() != admission.Create && (attrs.GetOperation() != admission.Update || attrs.GetSubresource() == "") {
+		p.logger.V(4).Info("Skipping operation", "operation", attrs.GetOperation(), "subresource", attrs.GetSubresource())
 		return nil
 	}
 
 	// Skip dry run requests to avoid creating ResourceClaims during validation
 	if attrs.IsDryRun() {
+		p.recordDecision(ctx, attrs, attrs.GetKind(), nil, DecisionExempt, DecisionReasonDryRun, "", nil)
 		return nil
 	}
 
@@ -372,6 +582,8 @@ func (p *ResourceQuotaEnforcementPlugin) handleResourceQuotaEnforcement(ctx cont
 	ctx, span := p.startSpan(ctx, "quota.admission.ResourceQuotaEnforcement", spanAttrs...)
 	defer span.End()
 
+	ctx = withParentOrganization(ctx)
+
 	gvk := schema.GroupVersionKind{
 		Group:   attrs.GetKind().Group,
 		Version: attrs.GetKind().Version,
@@ -386,21 +598,51 @@ func (p *ResourceQuotaEnforcementPlugin) handleResourceQuotaEnforcement(ctx cont
 		return err
 	}
 
+	// Subresource requests, e.g. every status update of a covered kind, are only of
+	// interest to a policy that triggers on that subresource; all others are ignored
+	// before any fallback so they are neither warned about nor recorded
+	if subresource := attrs.GetSubresource(); subresource != "" && !p.triggersOnSubresource(policy, gvk, subresource) {
+		return nil
+	}
+
 	if policy == nil {
+		// Fall back to a default claim declared on the resource type's registration
+		policy = p.lookupDefaultClaimPolicy(gvk)
+	}
+
+	if policy == nil {
+		// A policy that triggers on this resource type but is not Ready cannot charge it
+		if unready := p.lookupUnreadyPolicy(gvk); unready != nil {
+			p.admitWithoutEnforcement(ctx, attrs, gvk, unready, DecisionReasonPolicyNotReady, policyNotReadyCause(unready))
+			return nil
+		}
+
 		// No policy for this resource type - allow without ResourceClaim creation
 		p.logger.V(3).Info("No policy found for GVK, skipping ResourceClaim creation", "gvk", gvk)
+		p.recordDecision(ctx, attrs, gvk, nil, DecisionSkipped, DecisionReasonNoPolicy, "", nil)
 		return nil
 	}
 
 	// Check if policy is disabled
 	if policy.Spec.Disabled != nil && *policy.Spec.Disabled {
 		// Record policy disabled decision with full context
-		admissionResultTotal.WithLabelValues("policy_disabled", policy.Name, policy.Namespace,
+		admissionResultTotal.WithLabelValues("policy_disabled", resultReasonNone, policy.Name, policy.Namespace,
 			gvk.Group, gvk.Kind).Inc()
 
 		p.logger.V(3).Info("Policy is disabled, skipping ResourceClaim creation",
 			"policy", policy.Name,
 			"gvk", gvk)
+		p.recordDecision(ctx, attrs, gvk, policy, DecisionExempt, DecisionReasonPolicyDisabled, "", nil)
+		return nil
+	}
+
+	// A policy for a subresource does not apply to the resource itself
+	if policy.Spec.Trigger.Subresource != attrs.GetSubresource() {
+		p.logger.V(3).Info("Policy subresource did not match, skipping ResourceClaim creation",
+			"policy", policy.Name,
+			"gvk", gvk,
+			"subresource", attrs.GetSubresource())
+		p.recordDecision(ctx, attrs, gvk, policy, DecisionSkipped, DecisionReasonSubresourceNotMatched, "", nil)
 		return nil
 	}
 
@@ -408,6 +650,16 @@ func (p *ResourceQuotaEnforcementPlugin) handleResourceQuotaEnforcement(ctx cont
 	return p.processResourceWithPolicy(ctx, attrs, policy, gvk)
 }
 
+// triggersOnSubresource reports whether the policy found for gvk, or else a policy for
+// gvk that is not Ready, triggers on the given subresource. Default claims of
+// registrations only charge the resource itself.
+func (p *ResourceQuotaEnforcementPlugin) triggersOnSubresource(policy *quotav1alpha1.ClaimCreationPolicy, gvk schema.GroupVersionKind, subresource string) bool {
+	if policy == nil {
+		policy = p.lookupUnreadyPolicy(gvk)
+	}
+	return policy != nil && policy.Spec.Trigger.Subresource == subresource
+}
+
 // lookupPolicyForResource retrieves the policy for a given GVK with tracing
 func (p *ResourceQuotaEnforcementPlugin) lookupPolicyForResource(ctx context.Context, gvk schema.GroupVersionKind) (*quotav1alpha1.ClaimCreationPolicy, error) {
 
@@ -420,15 +672,21 @@ func (p *ResourceQuotaEnforcementPlugin) lookupPolicyForResource(ctx context.Con
 		))
 	defer policySpan.End()
 
-	policy, err := p.policyEngine.GetPolicyForGVK(gvk)
-	if err != nil {
-		policySpan.RecordError(err)
-		policySpan.SetStatus(codes.Error, fmt.Sprintf("Failed to get policy for GVK: %v", err))
-		return nil, err
+	policy, generation, cached := p.policyCache.get(gvk)
+	if !cached {
+		var err error
+		policy, err = p.policyEngine.GetPolicyForGVK(gvk)
+		if err != nil {
+			policySpan.RecordError(err)
+			policySpan.SetStatus(codes.Error, fmt.Sprintf("Failed to get policy for GVK: %v", err))
+			return nil, err
+		}
+		p.policyCache.store(gvk, policy, generation)
 	}
 
 	policySpan.SetAttributes(
 		attribute.Bool("policy.found", policy != nil),
+		attribute.Bool("policy.cached", cached),
 	)
 	if policy != nil {
 		policySpan.SetAttributes(
@@ -448,45 +706,91 @@ func (p *ResourceQuotaEnforcementPlugin) processResourceWithPolicy(ctx context.C
 	if obj == nil {
 		return fmt.Errorf("admission object is nil")
 	}
+	unstructuredObj, err := toUnstructured(obj, gvk)
+	if err != nil {
+		return err
+	}
 
-	// Convert to unstructured if needed
-	var unstructuredObj *unstructured.Unstructured
-	var err error
+	// Build evaluation context
+	evalContext := p.buildEvaluationContext(attrs, unstructuredObj)
 
-	switch v := obj.(type) {
-	case *unstructured.Unstructured:
-		// Already unstructured (CRDs from apiextensions-apiserver)
-		unstructuredObj = v
-	default:
-		// Structured type (native k8s types) — the admission handler decodes
-		// these as internal Go types (e.g. pkg/apis/discovery.EndpointSlice),
-		// not the external versioned types (e.g. api/discovery/v1.EndpointSlice).
-		// Internal types inline ObjectMeta without a "metadata" wrapper, so
-		// both json.Marshal and ToUnstructured produce maps without a "metadata"
-		// key. Convert to the external versioned type first using the scheme,
-		// then use ToUnstructured to get proper Kubernetes JSON structure.
-		toConvert := obj
-		targetGV := schema.GroupVersion{Group: gvk.Group, Version: gvk.Version}
-		if versioned, convErr := legacyscheme.Scheme.ConvertToVersion(obj, targetGV); convErr == nil {
-			toConvert = versioned
+	// Scaling down releases the claims of the removed replicas, whoever requests it
+	var scale *scaleChange
+	if attrs.GetSubresource() != "" {
+		change, err := p.scaleChangeOf(attrs, gvk, unstructuredObj)
+		if err != nil {
+			return fmt.Errorf("failed to read scale change: %w", err)
 		}
-		unstructuredMap, convErr := runtime.DefaultUnstructuredConverter.ToUnstructured(toConvert)
-		if convErr != nil {
-			return fmt.Errorf("failed to convert %T to unstructured: %w", toConvert, convErr)
+		if change.replicas <= change.oldReplicas {
+			if change.replicas < change.oldReplicas {
+				if parent, err := p.getScaleTarget(ctx, attrs); err != nil {
+					p.logger.Error(err, "Failed to release ResourceClaims of removed replicas", "policy", policy.Name)
+				} else {
+					p.releaseScaleClaims(ctx, policy, evalContext, parent, change.replicas)
+				}
+			}
+			p.recordDecision(ctx, attrs, gvk, policy, DecisionSkipped, DecisionReasonNotScaledUp, "", nil)
+			return nil
 		}
-		unstructuredObj = &unstructured.Unstructured{Object: unstructuredMap}
+		scale = &change
 	}
 
-	// Build evaluation context
-	evalContext := p.buildEvaluationContext(attrs, unstructuredObj)
+	// Exempt requesters bypass quota entirely, so operators can act while a consumer is out of quota
+	if subject, ok := exemptSubject(ctx, policy, attrs.GetUserInfo()); ok {
+		admissionResultTotal.WithLabelValues("exempt", resultReasonNone, policy.Name, policy.Namespace,
+			gvk.Group, gvk.Kind).Inc()
+
+		p.logger.Info("Requester is exempt from policy, skipping ResourceClaim creation",
+			"policy", policy.Name,
+			"resourceName", attrs.GetName(),
+			"gvk", gvk,
+			"subject", subject)
+		p.recordDecision(ctx, attrs, gvk, policy, DecisionExempt, DecisionReasonExemptSubject,
+			fmt.Sprintf("requester is exempt through %s", subject), nil)
+		return nil
+	}
+
+	// The parent organization is fetched at most once per request, and only for policies
+	// that use it, so constraints and templates share the same object. A policy that
+	// depends on the parent cannot be evaluated without it.
+	if policyReferencesParent(policy) {
+		parent, err := p.parentOrganization(ctx)
+		if err != nil {
+			p.logger.Error(err, "Failed to resolve parent organization", "policy", policy.Name)
+			return p.denyUnenforceable(ctx, attrs, gvk, policy, DecisionReasonParentUnresolved,
+				fmt.Sprintf("could not resolve the parent Organization: %v", err))
+		}
+		evalContext.Parent = parent
+	}
+
+	// Namespace and object selectors narrow the policy before any CEL is evaluated
+	selectorsMatch, err := p.triggerSelectorsMatch(ctx, policy, gvk, attrs.GetNamespace(), unstructuredObj)
+	if err != nil {
+		p.logger.Error(err, "Failed to evaluate policy selectors",
+			"policy", policy.Name,
+			"resourceName", attrs.GetName())
+		p.admitWithoutEnforcement(ctx, attrs, gvk, policy, DecisionReasonSelectorEvaluationFailed,
+			fmt.Sprintf("could not evaluate its trigger selectors: %v", err))
+		return nil // Don't block resource creation on selector evaluation errors
+	}
+
+	if !selectorsMatch {
+		p.logger.V(3).Info("Policy selectors did not match, skipping ResourceClaim creation",
+			"policy", policy.Name,
+			"resourceName", attrs.GetName(),
+			"gvk", gvk)
+		p.recordDecision(ctx, attrs, gvk, policy, DecisionSkipped, DecisionReasonSelectorsNotMatched, "", nil)
+		return nil
+	}
 
 	// Evaluate trigger constraints to determine if this resource should trigger the policy
-	constraintsMet, err := p.templateEngine.EvaluateConditions(policy.Spec.Trigger.Constraints, unstructuredObj)
+	constraintsMet, err := p.templateEngine.EvaluateClaimConditions(policy.Spec.Trigger.Constraints, p.convertToEngineContext(evalContext))
 	if err != nil {
 		p.logger.Error(err, "Failed to evaluate policy constraints",
 			"policy", policy.Name,
 			"resourceName", attrs.GetName())
-		warning.AddWarning(ctx, "", fmt.Sprintf("Failed to evaluate policy constraints: %v", err))
+		p.admitWithoutEnforcement(ctx, attrs, gvk, policy, DecisionReasonConstraintEvaluationFailed,
+			fmt.Sprintf("could not evaluate its trigger constraints: %v", err))
 		return nil // Don't block resource creation on constraint evaluation errors
 	}
 
@@ -496,6 +800,7 @@ func (p *ResourceQuotaEnforcementPlugin) processResourceWithPolicy(ctx context.C
 			"policy", policy.Name,
 			"resourceName", attrs.GetName(),
 			"gvk", gvk)
+		p.recordDecision(ctx, attrs, gvk, policy, DecisionSkipped, DecisionReasonConstraintsNotMet, "", nil)
 		return nil
 	}
 
@@ -503,12 +808,115 @@ func (p *ResourceQuotaEnforcementPlugin) processResourceWithPolicy(ctx context.C
 		"policy", policy.Name,
 		"resourceName", attrs.GetName())
 
-	// Create the ResourceClaim and wait for it to be granted
-	if err := p.createAndWaitForResourceClaim(ctx, attrs, policy, evalContext); err != nil {
+	// Create the ResourceClaim and wait for it to be granted; a scale-up is charged per added replica
+	if scale != nil {
+		err = p.createAndWaitForScaleClaims(ctx, attrs, policy, evalContext, *scale)
+	} else {
+		err = p.createAndWaitForResourceClaim(ctx, attrs, policy, evalContext, nil)
+	}
+	if err != nil {
+		// Amount expressions may leave nothing to charge, e.g. for a Deployment without replicas
+		if goerrors.Is(err, errNothingToClaim) {
+			p.logger.V(2).Info("Claim template requests no quota for resource, skipping ResourceClaim creation",
+				"policy", policy.Name,
+				"resourceName", attrs.GetName(),
+				"gvk", gvk)
+			p.recordDecision(ctx, attrs, gvk, policy, DecisionExempt, DecisionReasonNothingToClaim, "", nil)
+			return nil
+		}
+		if goerrors.Is(err, errObjectAlreadyExists) {
+			p.logger.V(2).Info("Resource already exists, skipping ResourceClaim creation",
+				"policy", policy.Name,
+				"resourceName", attrs.GetName(),
+				"gvk", gvk)
+			p.recordDecision(ctx, attrs, gvk, policy, DecisionExempt, DecisionReasonAlreadyExists, "", nil)
+			return nil
+		}
+
+		gr := schema.GroupResource{Group: gvk.Group, Resource: attrs.GetResource().Resource}
+		resultReason := admissionResultReason(err)
+
+		// Policies being rolled out only report what they would have rejected
+		if policy.Spec.EnforcementMode == quotav1alpha1.ClaimEnforcementModeAudit {
+			p.admitInAuditMode(ctx, attrs, gvk, policy, resultReason, err)
+			return nil
+		}
+
+		// A claim that cannot be built or never be granted would leave the resource
+		// uncharged, so it is rejected and the client told which policy is at fault
+		var templateErr *claimTemplateError
+		if goerrors.As(err, &templateErr) {
+			return p.denyUnenforceable(ctx, attrs, gvk, policy, DecisionReasonTemplateRenderFailed,
+				fmt.Sprintf("could not render its ResourceClaim template: %v", templateErr.err))
+		}
+		var unregisteredErr *unregisteredResourceTypeError
+		if goerrors.As(err, &unregisteredErr) {
+			return p.denyUnenforceable(ctx, attrs, gvk, policy, DecisionReasonResourceTypeNotRegistered,
+				fmt.Sprintf("requests resource type %s, which has no active ResourceRegistration", unregisteredErr.resourceType))
+		}
+
+		// A consumer without any grant would only be denied after the full claim wait
+		var noQuotaErr *noQuotaAllocatedError
+		if goerrors.As(err, &noQuotaErr) {
+			admissionResultTotal.WithLabelValues("denied", resultReason, policy.Name, policy.Namespace,
+				evalContext.GVK.Group, evalContext.GVK.Kind).Inc()
+
+			p.logger.Info("Consumer has no ResourceGrant for the requested resource type, denying resource creation",
+				"policy", policy.Name,
+				"resourceName", attrs.GetName(),
+				"gvk", gvk,
+				"resourceType", noQuotaErr.resourceType)
+
+			p.recordDecision(ctx, attrs, gvk, policy, DecisionDenied, DecisionReasonNoQuotaAllocated, err.Error(), nil)
+			return errors.NewForbidden(gr, attrs.GetName(), fmt.Errorf("no quota allocated for %s; contact an administrator", noQuotaErr.resourceType))
+		}
+
+		// An unresolved claim is not a denial; the client should retry once it resolves
+		var pendingErr *claimPendingError
+		if goerrors.As(err, &pendingErr) && pendingErr.timedOut() && p.config.ClaimTimeoutBehavior == ClaimTimeoutDeny {
+			admissionResultTotal.WithLabelValues("denied", resultReason, policy.Name, policy.Namespace,
+				evalContext.GVK.Group, evalContext.GVK.Kind).Inc()
+
+			p.logger.Info("ResourceClaim wait timed out, denying resource creation as configured",
+				"policy", policy.Name,
+				"resourceName", attrs.GetName(),
+				"gvk", gvk,
+				"reason", pendingErr.message)
+
+			p.recordDecision(ctx, attrs, gvk, policy, DecisionDenied, DecisionReasonClaimTimeout, err.Error(), nil)
+			//lint:ignore ST1005 "Error message intentionally capitalized for user-facing display"
+			return errors.NewForbidden(gr, attrs.GetName(), fmt.Errorf("Quota evaluation did not complete in time. Review your quota usage and reach out to support if the problem persists."))
+		}
+		if pendingErr != nil {
+			admissionResultTotal.WithLabelValues("pending", resultReason, policy.Name, policy.Namespace,
+				evalContext.GVK.Group, evalContext.GVK.Kind).Inc()
+
+			p.logger.Info("ResourceClaim not resolved, asking client to retry",
+				"policy", policy.Name,
+				"resourceName", attrs.GetName(),
+				"gvk", gvk,
+				"paused", pendingErr.paused,
+				"saturated", pendingErr.saturated,
+				"overloaded", pendingErr.overloaded,
+				"reason", pendingErr.message)
+
+			reason := DecisionReasonClaimTimeout
+			switch {
+			case pendingErr.paused:
+				reason = DecisionReasonGrantingPaused
+			case pendingErr.saturated:
+				reason = DecisionReasonWaiterLimitReached
+			case pendingErr.overloaded:
+				reason = DecisionReasonQuotaSystemOverloaded
+			}
+			p.recordDecision(ctx, attrs, gvk, policy, DecisionPending, reason, err.Error(), nil)
+			return p.pendingClaimStatusError(gr, attrs.GetName(), pendingErr)
+		}
+
 		// ResourceClaim creation or granting failed - block the resource creation
 
 		// Record denied admission decision with full context
-		admissionResultTotal.WithLabelValues("denied", policy.Name, policy.Namespace,
+		admissionResultTotal.WithLabelValues("denied", resultReason, policy.Name, policy.Namespace,
 			evalContext.GVK.Group, evalContext.GVK.Kind).Inc()
 
 		p.logger.Error(err, "ResourceClaim not granted, denying resource creation",
@@ -518,26 +926,111 @@ func (p *ResourceQuotaEnforcementPlugin) processResourceWithPolicy(ctx context.C
 
 		// Return quota exceeded error using Forbidden (403) - consistent with K8s core
 		// The error message clearly indicates it's a quota issue, not an auth failure
-		gr := schema.GroupResource{Group: gvk.Group, Resource: attrs.GetResource().Resource}
+
+		var deniedErr *claimDeniedError
+		if goerrors.As(err, &deniedErr) {
+			p.recordDenialEvents(ctx, attrs, gvk, deniedErr)
+			p.recordDecision(ctx, attrs, gvk, policy, DecisionDenied, quotav1alpha1.ResourceClaimDeniedReason, err.Error(), deniedErr.requests)
+		} else {
+			p.recordDecision(ctx, attrs, gvk, policy, DecisionDenied, "", err.Error(), nil)
+		}
+		if deniedErr != nil {
+			// Registrations may replace the message with their own for the denied resource types
+			if message, ok := p.renderDenialMessage(deniedErr.requests); ok {
+				return errors.NewForbidden(gr, attrs.GetName(), goerrors.New(message))
+			}
+		}
+		if deniedErr != nil && len(deniedErr.requests) > 0 && deniedErr.reason != "" {
+			// The claim's Granted condition message names the requested amount,
+			// allocation, and limit of each denied request
+			return errors.NewForbidden(gr, attrs.GetName(), goerrors.New(deniedErr.reason))
+		}
+		if deniedErr != nil && len(deniedErr.requests) > 0 {
+			//lint:ignore ST1005 "Error message intentionally capitalized for user-facing display"
+			return errors.NewForbidden(gr, attrs.GetName(), fmt.Errorf("Insufficient quota resources available: %s. Review your quota usage and reach out to support if you need additional resources.",
+				formatRequestDenials(deniedErr.requests)))
+		}
 
 		//lint:ignore ST1005 "Error message intentionally capitalized for user-facing display"
 		return errors.NewForbidden(gr, attrs.GetName(), fmt.Errorf("Insufficient quota resources available. Review your quota usage and reach out to support if you need additional resources."))
 	}
 
 	// Record granted admission decision with full context
-	admissionResultTotal.WithLabelValues("granted", policy.Name, policy.Namespace,
+	admissionResultTotal.WithLabelValues("granted", resultReasonNone, policy.Name, policy.Namespace,
 		evalContext.GVK.Group, evalContext.GVK.Kind).Inc()
 
 	p.logger.V(2).Info("ResourceClaim granted, allowing resource creation",
 		"policy", policy.Name,
 		"resourceName", attrs.GetName())
+	p.recordDecision(ctx, attrs, gvk, policy, DecisionGranted, "", "", nil)
 
 	return nil // Allow original resource creation only if claim is granted
 }
 
+// toUnstructured converts an admission object to unstructured. Objects may be structured
+// (native k8s types) or unstructured (CRDs).
+func toUnstructured(obj runtime.Object, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		// Already unstructured (CRDs from apiextensions-apiserver)
+		return u, nil
+	}
+
+	// Structured type (native k8s types) — the admission handler decodes
+	// these as internal Go types (e.g. pkg/apis/discovery.EndpointSlice),
+	// not the external versioned types (e.g. api/discovery/v1.EndpointSlice).
+	// Internal types inline ObjectMeta without a "metadata" wrapper, so
+	// both json.Marshal and ToUnstructured produce maps without a "metadata"
+	// key. Convert to the external versioned type first using the scheme,
+	// then use ToUnstructured to get proper Kubernetes JSON structure.
+	toConvert := obj
+	targetGV := schema.GroupVersion{Group: gvk.Group, Version: gvk.Version}
+	if versioned, convErr := legacyscheme.Scheme.ConvertToVersion(obj, targetGV); convErr == nil {
+		toConvert = versioned
+	}
+	unstructuredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(toConvert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T to unstructured: %w", toConvert, err)
+	}
+	return &unstructured.Unstructured{Object: unstructuredMap}, nil
+}
+
+// objectAlreadyExists reports whether the object named by a CREATE request is already
+// stored. Only keyed creates are checked: a request relying on generateName cannot
+// collide, and subresource creates never store the parent object. Lookup failures other
+// than NotFound are treated as "does not exist" so enforcement is never bypassed.
+func (p *ResourceQuotaEnforcementPlugin) objectAlreadyExists(ctx context.Context, attrs admission.Attributes) bool {
+	gvr := attrs.GetResource()
+	if attrs.GetOperation() != admission.Create || attrs.GetName() == "" || attrs.GetSubresource() != "" || gvr.Resource == "" {
+		return false
+	}
+
+	client, err := p.getClient(ctx)
+	if err != nil {
+		p.logger.V(3).Info("Unable to check for an existing resource, enforcing quota",
+			"resourceName", attrs.GetName(),
+			"error", err)
+		return false
+	}
+
+	_, err = client.Resource(gvr).Namespace(attrs.GetNamespace()).Get(ctx, attrs.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			p.logger.V(3).Info("Unable to check for an existing resource, enforcing quota",
+				"resourceName", attrs.GetName(),
+				"gvr", gvr,
+				"error", err)
+		}
+		return false
+	}
+	return true
+}
+
 // createAndWaitForResourceClaim creates a ResourceClaim and blocks until the claim is resolved.
-// The waiter is registered before claim creation to prevent missed events.
-func (p *ResourceQuotaEnforcementPlugin) createAndWaitForResourceClaim(ctx context.Context, attrs admission.Attributes, policy *quotav1alpha1.ClaimCreationPolicy, evalContext *EvaluationContext) error {
+// The waiter is registered before claim creation to prevent missed events. A create of an
+// object that already exists is not charged again before the apiserver rejects it as
+// AlreadyExists: a deterministic claim name waits on the existing claim, and an object
+// whose claim name is generated is looked up before its claim is created.
+func (p *ResourceQuotaEnforcementPlugin) createAndWaitForResourceClaim(ctx context.Context, attrs admission.Attributes, policy *quotav1alpha1.ClaimCreationPolicy, evalContext *EvaluationContext, target *claimTarget) (err error) {
 	ctx, span := p.startSpan(ctx, "quota.admission.ResourceQuotaEnforcement.createAndWaitForResourceClaim",
 		trace.WithAttributes(
 			attribute.String("policy.name", policy.Name),
@@ -546,6 +1039,14 @@ func (p *ResourceQuotaEnforcementPlugin) createAndWaitForResourceClaim(ctx conte
 		))
 	defer span.End()
 
+	release, err := p.acquireClaimSlot(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "No claim wait slot available")
+		return err
+	}
+	defer release()
+
 	watchManager, err := p.getWatchManager(ctx)
 	if err != nil {
 		span.RecordError(err)
@@ -553,13 +1054,20 @@ func (p *ResourceQuotaEnforcementPlugin) createAndWaitForResourceClaim(ctx conte
 		return fmt.Errorf("failed to get watch manager: %w", err)
 	}
 
-	// Determine claim name (must be deterministic to pre-register waiter before claim creation).
-	claimName, err := p.determineClaimName(evalContext, policy)
+	// Determine claim name (must be known up front to pre-register waiter before claim creation).
+	claimName, deterministic, err := p.determineClaimName(evalContext, policy, target)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to determine claim name")
 		return fmt.Errorf("failed to determine claim name: %w", err)
 	}
+
+	// A generated claim name differs on every request, so a repeated create would not
+	// find the claim of the first one; look for the object itself instead
+	if !deterministic && p.objectAlreadyExists(ctx, attrs) {
+		span.SetAttributes(attribute.Bool("resource.already_exists", true))
+		return errObjectAlreadyExists
+	}
 	namespace := p.getClaimNamespace(policy, evalContext)
 
 	span.SetAttributes(
@@ -588,7 +1096,7 @@ func (p *ResourceQuotaEnforcementPlugin) createAndWaitForResourceClaim(ctx conte
 		"namespace", namespace,
 		"timeout", timeout)
 
-	err = p.createResourceClaim(ctx, attrs, policy, evalContext, claimName, namespace)
+	consumer, existingResult, err := p.createResourceClaim(ctx, attrs, policy, evalContext, target, claimName, namespace)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to create ResourceClaim")
@@ -599,47 +1107,194 @@ func (p *ResourceQuotaEnforcementPlugin) createAndWaitForResourceClaim(ctx conte
 		"claimName", claimName,
 		"namespace", namespace)
 
-	// Wait for result from watch stream.
-	select {
-	case result, ok := <-resultChan:
-		if !ok {
-			span.SetStatus(codes.Error, "Result channel closed")
-			return fmt.Errorf("result channel closed unexpectedly")
-		}
+	// A resource admitted in Audit mode is not charged, so its claim must not be left
+	// to be granted later or show up in usage
+	if policy.Spec.EnforcementMode == quotav1alpha1.ClaimEnforcementModeAudit {
+		defer func() {
+			if err != nil {
+				p.releaseAuditClaim(ctx, policy, namespace, claimName)
+			}
+		}()
+	}
 
-		// result.Error is only set for genuine errors (timeout, claim deleted)
-		// not for denials which use Granted=false
-		if result.Error != nil {
-			span.RecordError(result.Error)
-			span.SetStatus(codes.Error, "Wait failed")
-			return result.Error
+	// A claim left by an earlier attempt of this request may already be decided, in
+	// which case no further watch event will arrive for it.
+	var result ClaimResult
+	if existingResult != nil {
+		result = *existingResult
+	} else {
+		// Wait for result from watch stream.
+		select {
+		case r, ok := <-resultChan:
+			if !ok {
+				span.SetStatus(codes.Error, "Result channel closed")
+				return fmt.Errorf("result channel closed unexpectedly")
+			}
+			result = r
+		case <-ctx.Done():
+			span.SetStatus(codes.Error, "Context cancelled")
+			watchManager.UnregisterClaimWaiter(claimName, namespace)
+			// The request deadline passing is a timeout like the waiter's own, not a denial
+			if goerrors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return &claimPendingError{message: fmt.Sprintf("request deadline exceeded waiting for ResourceClaim %s/%s", namespace, claimName)}
+			}
+			return ctx.Err()
 		}
+	}
 
-		if result.Granted {
-			span.SetAttributes(
-				attribute.String("claim.result", "granted"),
-			)
-			p.logger.V(2).Info("ResourceClaim granted",
-				"claimName", claimName,
-				"namespace", namespace)
-			return nil
-		} else {
-			span.SetAttributes(
-				attribute.String("claim.result", "denied"),
-				attribute.String("claim.denial_reason", result.Reason),
-			)
-			p.logger.Info("ResourceClaim denied",
-				"claimName", claimName,
-				"namespace", namespace,
-				"reason", result.Reason)
-			return fmt.Errorf("ResourceClaim was denied: %s", result.Reason)
+	// result.Error is only set for genuine errors (timeout, claim deleted)
+	// not for denials which use Granted=false
+	if result.Error != nil {
+		span.RecordError(result.Error)
+		span.SetStatus(codes.Error, "Wait failed")
+		return result.Error
+	}
+
+	if result.Paused {
+		span.SetAttributes(
+			attribute.String("claim.result", "paused"),
+		)
+		p.logger.Info("ResourceClaim held while quota granting is paused",
+			"claimName", claimName,
+			"namespace", namespace)
+		return &claimPendingError{paused: true, message: result.Reason}
+	}
+
+	if result.Granted {
+		span.SetAttributes(
+			attribute.String("claim.result", "granted"),
+		)
+		p.logger.V(2).Info("ResourceClaim granted",
+			"claimName", claimName,
+			"namespace", namespace)
+		return nil
+	} else {
+		span.SetAttributes(
+			attribute.String("claim.result", "denied"),
+			attribute.String("claim.denial_reason", result.Reason),
+		)
+		deniedTypes := make([]string, 0, len(result.DeniedRequests))
+		for _, denial := range result.DeniedRequests {
+			deniedTypes = append(deniedTypes, denial.ResourceType)
 		}
+		span.SetAttributes(
+			attribute.StringSlice("claim.denied_resource_types", deniedTypes),
+		)
+		p.logger.Info("ResourceClaim denied",
+			"claimName", claimName,
+			"namespace", namespace,
+			"reason", result.Reason,
+			"deniedResourceTypes", deniedTypes)
+		return &claimDeniedError{reason: result.Reason, requests: result.DeniedRequests, consumer: consumer}
+	}
+}
+
+// errNothingToClaim is returned when every request of a rendered ResourceClaim was
+// dropped because its amount expression evaluated to zero.
+var errNothingToClaim = goerrors.New("claim template rendered no resource requests")
+
+// errObjectAlreadyExists is returned when the object of a CREATE request is already
+// stored, so the request will be rejected and must not be charged.
+var errObjectAlreadyExists = goerrors.New("object already exists")
 
-	case <-ctx.Done():
-		span.SetStatus(codes.Error, "Context cancelled")
-		watchManager.UnregisterClaimWaiter(claimName, namespace)
-		return ctx.Err()
+// claimDeniedError is returned when a ResourceClaim is denied, carrying the
+// individual requests that could not be satisfied and the claim's consumer.
+type claimDeniedError struct {
+	reason   string
+	requests []RequestDenial
+	consumer quotav1alpha1.ConsumerRef
+}
+
+func (e *claimDeniedError) Error() string {
+	if len(e.requests) == 0 {
+		return fmt.Sprintf("ResourceClaim was denied: %s", e.reason)
 	}
+	return fmt.Sprintf("ResourceClaim was denied: %s", formatRequestDenials(e.requests))
+}
+
+// claimDeletedError is returned when a ResourceClaim is deleted while a request is
+// waiting for it to be granted.
+type claimDeletedError struct {
+	namespace string
+	name      string
+}
+
+func (e *claimDeletedError) Error() string {
+	return fmt.Sprintf("ResourceClaim %s/%s was deleted", e.namespace, e.name)
+}
+
+// noQuotaAllocatedError is returned when the consumer of a ResourceClaim has no ResourceGrant
+// for a requested resource type, so the claim could only be denied once the wait ran out.
+type noQuotaAllocatedError struct {
+	resourceType string
+}
+
+func (e *noQuotaAllocatedError) Error() string {
+	return fmt.Sprintf("no ResourceGrant allocates quota for resource type %s", e.resourceType)
+}
+
+// claimPendingError is returned when a ResourceClaim could not be resolved, either
+// because quota granting is paused, because the wait timed out, because the watch
+// manager already has as many waiters as it allows, or because the project has no free
+// claim wait slot. The request may succeed later, so it is rejected as retryable, not
+// denied.
+type claimPendingError struct {
+	paused     bool
+	saturated  bool
+	overloaded bool
+	message    string
+}
+
+func (e *claimPendingError) Error() string {
+	switch {
+	case e.paused:
+		return fmt.Sprintf("quota granting is paused: %s", e.message)
+	case e.overloaded:
+		return fmt.Sprintf("quota system overloaded: %s", e.message)
+	}
+	return e.message
+}
+
+// timedOut reports whether the claim wait ended because it ran out of time.
+func (e *claimPendingError) timedOut() bool {
+	return !e.paused && !e.saturated && !e.overloaded
+}
+
+// pendingClaimStatusError builds a 503 that asks the client to retry after the
+// configured delay, for requests whose ResourceClaim could not be resolved.
+func (p *ResourceQuotaEnforcementPlugin) pendingClaimStatusError(gr schema.GroupResource, name string, pendingErr *claimPendingError) *errors.StatusError {
+	message := "Quota evaluation did not complete in time. Retry the request shortly."
+	switch {
+	case pendingErr.paused:
+		message = "Quota evaluation is temporarily paused for maintenance. Retry the request shortly."
+	case pendingErr.saturated:
+		message = "Quota evaluation is handling too many requests. Retry the request shortly."
+	case pendingErr.overloaded:
+		message = "The quota system is overloaded. Retry the request shortly."
+	}
+
+	statusErr := errors.NewServiceUnavailable(message)
+	statusErr.ErrStatus.Details = &metav1.StatusDetails{
+		Group:             gr.Group,
+		Kind:              gr.Resource,
+		Name:              name,
+		RetryAfterSeconds: int32(p.config.PendingClaimRetryAfter / time.Second),
+	}
+	return statusErr
+}
+
+// formatRequestDenials renders per-request denials for user-facing messages,
+// e.g. "resourcemanager.miloapis.com/projects (Resource quota exceeded: requested 1, available 0)".
+func formatRequestDenials(denials []RequestDenial) string {
+	parts := make([]string, 0, len(denials))
+	for _, denial := range denials {
+		if denial.Message == "" {
+			parts = append(parts, denial.ResourceType)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", denial.ResourceType, denial.Message))
+	}
+	return strings.Join(parts, "; ")
 }
 
 // getClaimNamespace determines the namespace for a ResourceClaim.
@@ -664,7 +1319,8 @@ func (p *ResourceQuotaEnforcementPlugin) getClaimNamespace(policy *quotav1alpha1
 
 // createResourceClaim creates a ResourceClaim with the specified name and namespace.
 // The claim name must be predetermined to allow waiter registration before creation.
-func (p *ResourceQuotaEnforcementPlugin) createResourceClaim(ctx context.Context, attrs admission.Attributes, policy *quotav1alpha1.ClaimCreationPolicy, evalContext *EvaluationContext, claimName, namespace string) error {
+// The claim's consumer is returned so that denials can be reported against it.
+func (p *ResourceQuotaEnforcementPlugin) createResourceClaim(ctx context.Context, attrs admission.Attributes, policy *quotav1alpha1.ClaimCreationPolicy, evalContext *EvaluationContext, target *claimTarget, claimName, namespace string) (quotav1alpha1.ConsumerRef, *ClaimResult, error) {
 	ctx, span := p.startSpan(ctx, "quota.admission.ResourceQuotaEnforcement.createResourceClaim",
 		trace.WithAttributes(
 			attribute.String("policy.name", policy.Name),
@@ -676,7 +1332,20 @@ func (p *ResourceQuotaEnforcementPlugin) createResourceClaim(ctx context.Context
 	engineContext := p.convertToEngineContext(evalContext)
 	claim, err := p.templateEngine.RenderClaim(policy, engineContext)
 	if err != nil {
-		return fmt.Errorf("failed to render ResourceClaim: %w", err)
+		return quotav1alpha1.ConsumerRef{}, nil, &claimTemplateError{err: err}
+	}
+	if len(claim.Spec.Requests) == 0 {
+		return quotav1alpha1.ConsumerRef{}, nil, errNothingToClaim
+	}
+
+	// A claim for an unregistered resource type would wait out its timeout and then be
+	// denied; only trusted once the registration cache has synced
+	if p.resourceTypeValidator != nil && p.resourceTypeValidator.HasSynced() {
+		for _, request := range claim.Spec.Requests {
+			if !p.resourceTypeValidator.IsResourceTypeRegistered(request.ResourceType) {
+				return quotav1alpha1.ConsumerRef{}, nil, &unregisteredResourceTypeError{resourceType: request.ResourceType}
+			}
+		}
 	}
 
 	// Use predetermined name/namespace to ensure waiter receives events
@@ -691,6 +1360,11 @@ func (p *ResourceQuotaEnforcementPlugin) createResourceClaim(ctx context.Context
 		Namespace: attrs.GetNamespace(),
 	}
 
+	// Expire the claim if it is never granted, e.g. when the triggering create fails
+	if ttlSeconds := int64(p.config.DefaultClaimTTL / time.Second); claim.Spec.TTLSecondsAfterCreation == nil && ttlSeconds > 0 {
+		claim.Spec.TTLSecondsAfterCreation = &ttlSeconds
+	}
+
 	// Derive consumer from project context when template doesn't specify one
 	if claim.Spec.ConsumerRef.Kind == "" || claim.Spec.ConsumerRef.Name == "" {
 		projectID, ok := milorequest.ProjectID(ctx)
@@ -724,6 +1398,17 @@ func (p *ResourceQuotaEnforcementPlugin) createResourceClaim(ctx context.Context
 	claim.Annotations["quota.miloapis.com/resource-name"] = evalContext.Object.GetName()
 	claim.Annotations["quota.miloapis.com/policy"] = policy.Name
 
+	if target != nil && target.parent != nil {
+		applyClaimTarget(claim, target)
+	}
+
+	// Let the controllers evaluating the claim continue this request's trace
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	if traceParent := carrier.Get("traceparent"); traceParent != "" {
+		claim.Annotations[quotav1alpha1.ResourceClaimTraceParentAnnotation] = traceParent
+	}
+
 	gvr := schema.GroupVersionResource{
 		Group:    "quota.miloapis.com",
 		Version:  "v1alpha1",
@@ -732,18 +1417,75 @@ func (p *ResourceQuotaEnforcementPlugin) createResourceClaim(ctx context.Context
 
 	unstructuredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(claim)
 	if err != nil {
-		return fmt.Errorf("failed to convert ResourceClaim to unstructured: %w", err)
+		return quotav1alpha1.ConsumerRef{}, nil, fmt.Errorf("failed to convert ResourceClaim to unstructured: %w", err)
 	}
 	unstructuredObj := &unstructured.Unstructured{Object: unstructuredMap}
 
 	client, err := p.getClient(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get client for context: %w", err)
+		return quotav1alpha1.ConsumerRef{}, nil, fmt.Errorf("failed to get client for context: %w", err)
 	}
 
-	_, err = client.Resource(gvr).Namespace(namespace).Create(ctx, unstructuredObj, metav1.CreateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to create ResourceClaim: %w", err)
+	for _, request := range claim.Spec.Requests {
+		if p.consumerHasNoGrants(ctx, client, claim.Spec.ConsumerRef, request.ResourceType) &&
+			!p.consumerHasQuotaOverride(ctx, claim.Spec.ConsumerRef, request.ResourceType) {
+			span.SetAttributes(attribute.String("claim.no_grant_resource_type", request.ResourceType))
+			return quotav1alpha1.ConsumerRef{}, nil, &noQuotaAllocatedError{resourceType: request.ResourceType}
+		}
+	}
+
+	retryConfig := p.config.ClaimCreateRetry
+	delay := retryConfig.InitialDelay
+	for attempt := 0; ; attempt++ {
+		_, err = client.Resource(gvr).Namespace(namespace).Create(ctx, unstructuredObj.DeepCopy(), metav1.CreateOptions{})
+		if err == nil {
+			break
+		}
+
+		// The claim name is deterministic, so the claim already exists when an earlier
+		// create of this request was persisted, either by a previous attempt that reported
+		// a transient error or by an admission retry. Wait on it instead of failing.
+		if errors.IsAlreadyExists(err) {
+			span.SetAttributes(attribute.Int("claim.create_attempts", attempt+1))
+			existingResult, existingErr := p.adoptExistingResourceClaim(ctx, client, gvr, claim)
+			if existingErr != nil {
+				return quotav1alpha1.ConsumerRef{}, nil, existingErr
+			}
+			p.logger.V(2).Info("ResourceClaim already exists, waiting on the existing claim",
+				"claimName", claimName,
+				"namespace", namespace,
+				"attempt", attempt+1)
+			return claim.Spec.ConsumerRef, existingResult, nil
+		}
+
+		if !isTransientCreateError(err) || attempt >= retryConfig.MaxRetries {
+			span.SetAttributes(attribute.Int("claim.create_attempts", attempt+1))
+			return quotav1alpha1.ConsumerRef{}, nil, fmt.Errorf("failed to create ResourceClaim: %w", err)
+		}
+
+		wait := delay
+		if suggested, ok := errors.SuggestsClientDelay(err); ok && time.Duration(suggested)*time.Second > wait {
+			wait = time.Duration(suggested) * time.Second
+		}
+		if retryConfig.MaxDelay > 0 && wait > retryConfig.MaxDelay {
+			wait = retryConfig.MaxDelay
+		}
+
+		claimCreateRetries.WithLabelValues(string(errors.ReasonForError(err))).Inc()
+		p.logger.V(2).Info("Transient error creating ResourceClaim, retrying",
+			"claimName", claimName,
+			"namespace", namespace,
+			"attempt", attempt+1,
+			"backoff", wait,
+			"error", err.Error())
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return quotav1alpha1.ConsumerRef{}, nil, fmt.Errorf("failed to create ResourceClaim: %w", ctx.Err())
+		}
+
+		delay *= 2
 	}
 
 	p.logger.V(2).Info("ResourceClaim created successfully",
@@ -752,7 +1494,91 @@ func (p *ResourceQuotaEnforcementPlugin) createResourceClaim(ctx context.Context
 		"policy", policy.Name,
 	)
 
-	return nil
+	return claim.Spec.ConsumerRef, nil, nil
+}
+
+// consumerHasNoGrants reports whether the consumer's AllowanceBucket for resourceType shows
+// that no ResourceGrant, active or pending, allocates it any quota. Only a bucket the quota
+// system has already reconciled is trusted: a missing bucket may just not have been created
+// yet, and lookup failures never short-circuit the claim.
+func (p *ResourceQuotaEnforcementPlugin) consumerHasNoGrants(ctx context.Context, client dynamic.Interface, consumer quotav1alpha1.ConsumerRef, resourceType string) bool {
+	gvr := quotav1alpha1.GroupVersion.WithResource("allowancebuckets")
+	obj, err := client.Resource(gvr).Namespace(bucketutil.Namespace(consumer)).Get(ctx, bucketutil.Name(resourceType, consumer), metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+
+	bucket := &quotav1alpha1.AllowanceBucket{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, bucket); err != nil {
+		return false
+	}
+	if bucket.Status.LastReconciliation == nil {
+		return false
+	}
+	return bucket.Status.GrantCount == 0 && bucket.Status.Limit == 0 && bucket.Status.ProjectedLimit == 0 && len(bucket.Status.Borrowed) == 0
+}
+
+// consumerHasQuotaOverride reports whether the consumer's QuotaOverrideAnnotation lists
+// resourceType, in which case its claims are granted without any ResourceGrant. Consumers
+// live in the core control plane. Only a consumer that is known not to carry the override
+// lets its claim be denied before the wait; any consumer that cannot be read is assumed
+// to carry it.
+func (p *ResourceQuotaEnforcementPlugin) consumerHasQuotaOverride(ctx context.Context, consumer quotav1alpha1.ConsumerRef, resourceType string) bool {
+	if consumer.APIGroup != "resourcemanager.miloapis.com" {
+		return true
+	}
+	var gvr schema.GroupVersionResource
+	switch consumer.Kind {
+	case "Project":
+		gvr = projectGVR
+	case "Organization":
+		gvr = organizationGVR
+	default:
+		return true
+	}
+	obj, err := p.dynamicClient.Resource(gvr).Namespace(consumer.Namespace).Get(ctx, consumer.Name, metav1.GetOptions{})
+	if err != nil {
+		return !errors.IsNotFound(err)
+	}
+
+	for _, overridden := range strings.Split(obj.GetAnnotations()[quotav1alpha1.QuotaOverrideAnnotation], ",") {
+		if strings.TrimSpace(overridden) == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// adoptExistingResourceClaim returns the result of an already existing ResourceClaim with the
+// name of claim, or nil when it is still pending. The existing claim must have been created for
+// the same resource, otherwise the name collides with an unrelated claim and an error is returned.
+func (p *ResourceQuotaEnforcementPlugin) adoptExistingResourceClaim(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, claim *quotav1alpha1.ResourceClaim) (*ClaimResult, error) {
+	existing, err := client.Resource(gvr).Namespace(claim.Namespace).Get(ctx, claim.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing ResourceClaim: %w", err)
+	}
+
+	existingClaim := &quotav1alpha1.ResourceClaim{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(existing.Object, existingClaim); err != nil {
+		return nil, fmt.Errorf("failed to convert existing ResourceClaim: %w", err)
+	}
+	if existingClaim.Spec.ResourceRef != claim.Spec.ResourceRef {
+		return nil, fmt.Errorf("ResourceClaim %s/%s already exists for a different resource %s %s/%s",
+			claim.Namespace, claim.Name, existingClaim.Spec.ResourceRef.Kind,
+			existingClaim.Spec.ResourceRef.Namespace, existingClaim.Spec.ResourceRef.Name)
+	}
+
+	return evaluateClaimStatus(existing), nil
+}
+
+// isTransientCreateError reports whether a ResourceClaim create error is worth retrying.
+// AlreadyExists is deliberately excluded: the claim name is deterministic, so a
+// conflicting claim will not go away by retrying.
+func isTransientCreateError(err error) bool {
+	return errors.IsConflict(err) ||
+		errors.IsServerTimeout(err) ||
+		errors.IsTimeout(err) ||
+		errors.IsTooManyRequests(err)
 }
 
 // validateResourceClaim validates ResourceClaim objects when they are created directly
@@ -818,9 +1644,84 @@ func (p *ResourceQuotaEnforcementPlugin) validateResourceClaim(ctx context.Conte
 
 	span.SetAttributes(attribute.String("validation.status", "passed"))
 
+	// Dry-run creates are never persisted, so report the projected outcome
+	// against current bucket availability instead of leaving it to the controllers.
+	if attrs.IsDryRun() {
+		p.simulateResourceClaim(ctx, claim)
+	}
+
 	return nil
 }
 
+// simulateResourceClaim evaluates each request in a dry-run ResourceClaim against the
+// current availability of its AllowanceBucket and reports the projected result as
+// admission warnings. It only reads buckets; nothing is reserved or written.
+func (p *ResourceQuotaEnforcementPlugin) simulateResourceClaim(ctx context.Context, claim *quotav1alpha1.ResourceClaim) {
+	ctx, span := p.startSpan(ctx, "quota.admission.ResourceClaimValidation.simulate",
+		trace.WithAttributes(
+			attribute.String("claim.name", claim.Name),
+			attribute.String("claim.namespace", claim.Namespace),
+		))
+	defer span.End()
+
+	client, err := p.getClient(ctx)
+	if err != nil {
+		span.RecordError(err)
+		warning.AddWarning(ctx, "", fmt.Sprintf("Unable to simulate quota for dry-run ResourceClaim: %v", err))
+		return
+	}
+
+	gvr := quotav1alpha1.GroupVersion.WithResource("allowancebuckets")
+	granted := true
+
+	for _, request := range claim.Spec.Requests {
+		bucketName := bucketutil.Name(request.ResourceType, claim.Spec.ConsumerRef)
+		bucketNamespace := bucketutil.Namespace(claim.Spec.ConsumerRef)
+
+		var available int64
+		obj, err := client.Resource(gvr).Namespace(bucketNamespace).Get(ctx, bucketName, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			// No bucket yet means no grants have been aggregated for this consumer
+			// and resource type, so nothing is available.
+		case err != nil:
+			span.RecordError(err)
+			warning.AddWarning(ctx, "", fmt.Sprintf("Unable to simulate quota for %s: failed to get AllowanceBucket %s/%s: %v",
+				request.ResourceType, bucketNamespace, bucketName, err))
+			granted = false
+			continue
+		default:
+			bucket := &quotav1alpha1.AllowanceBucket{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, bucket); err != nil {
+				span.RecordError(err)
+				warning.AddWarning(ctx, "", fmt.Sprintf("Unable to simulate quota for %s: failed to convert AllowanceBucket: %v",
+					request.ResourceType, err))
+				granted = false
+				continue
+			}
+			available = bucket.Status.Available
+		}
+
+		outcome := "would be granted"
+		switch {
+		case request.Amount <= available:
+		case claim.Spec.AllowPartial && available > 0:
+			outcome = "would be partially granted"
+		default:
+			outcome = "would be denied"
+			granted = false
+		}
+		warning.AddWarning(ctx, "", fmt.Sprintf("Dry-run: request for %s %s (requested %d, available %d)",
+			request.ResourceType, outcome, request.Amount, available))
+	}
+
+	span.SetAttributes(attribute.Bool("simulation.granted", granted))
+	p.logger.V(3).Info("Simulated dry-run ResourceClaim",
+		"name", claim.Name,
+		"namespace", claim.Namespace,
+		"granted", granted)
+}
+
 // startSpan safely starts a span using the tracer provider from the context
 func (p *ResourceQuotaEnforcementPlugin) startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
 	// Get the tracer provider from the existing span context
@@ -866,6 +1767,89 @@ func (p *ResourceQuotaEnforcementPlugin) buildEvaluationContext(attrs admission.
 	}
 }
 
+var (
+	projectGVR      = schema.GroupVersionResource{Group: "resourcemanager.miloapis.com", Version: "v1alpha1", Resource: "projects"}
+	organizationGVR = schema.GroupVersionResource{Group: "resourcemanager.miloapis.com", Version: "v1alpha1", Resource: "organizations"}
+)
+
+// parentVariablePattern matches references to the parent variable in CEL expressions.
+var parentVariablePattern = regexp.MustCompile(`\bparent\b`)
+
+// policyReferencesParent reports whether any constraint or claim template of the policy
+// refers to the parent variable.
+func policyReferencesParent(policy *quotav1alpha1.ClaimCreationPolicy) bool {
+	for _, constraint := range policy.Spec.Trigger.Constraints {
+		if parentVariablePattern.MatchString(constraint.Expression) {
+			return true
+		}
+	}
+	target, err := json.Marshal(policy.Spec.Target)
+	if err != nil {
+		return false
+	}
+	return parentVariablePattern.Match(target)
+}
+
+// parentOrganizationKey is the context key of a request's parentOrganizationResult.
+type parentOrganizationKey struct{}
+
+// parentOrganizationResult holds the parent Organization of a request once it has
+// been resolved.
+type parentOrganizationResult struct {
+	once         sync.Once
+	organization *unstructured.Unstructured
+	err          error
+}
+
+// withParentOrganization returns a context in which the parent Organization is
+// resolved at most once.
+func withParentOrganization(ctx context.Context) context.Context {
+	return context.WithValue(ctx, parentOrganizationKey{}, &parentOrganizationResult{})
+}
+
+// parentOrganization returns the parent Organization of the request, resolving it on
+// first use. Contexts without a result are resolved on every call.
+func (p *ResourceQuotaEnforcementPlugin) parentOrganization(ctx context.Context) (*unstructured.Unstructured, error) {
+	result, ok := ctx.Value(parentOrganizationKey{}).(*parentOrganizationResult)
+	if !ok {
+		return p.resolveParentOrganization(ctx)
+	}
+	result.once.Do(func() {
+		result.organization, result.err = p.resolveParentOrganization(ctx)
+	})
+	return result.organization, result.err
+}
+
+// resolveParentOrganization returns the Organization that owns the project the request was
+// made in. Requests outside a project and projects not owned by an Organization have no
+// parent.
+func (p *ResourceQuotaEnforcementPlugin) resolveParentOrganization(ctx context.Context) (*unstructured.Unstructured, error) {
+	projectID, ok := milorequest.ProjectID(ctx)
+	if !ok || projectID == "" {
+		return nil, fmt.Errorf("request was not made in a project")
+	}
+	if p.dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client not initialized")
+	}
+
+	project, err := p.dynamicClient.Resource(projectGVR).Get(ctx, projectID, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", projectID, err)
+	}
+
+	kind, _, _ := unstructured.NestedString(project.Object, "spec", "ownerRef", "kind")
+	name, _, _ := unstructured.NestedString(project.Object, "spec", "ownerRef", "name")
+	if kind != "Organization" || name == "" {
+		return nil, fmt.Errorf("project %s is not owned by an Organization", projectID)
+	}
+
+	organization, err := p.dynamicClient.Resource(organizationGVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization %s of project %s: %w", name, projectID, err)
+	}
+	return organization, nil
+}
+
 // convertToEngineContext converts admission EvaluationContext to engine EvaluationContext
 func (p *ResourceQuotaEnforcementPlugin) convertToEngineContext(admissionCtx *EvaluationContext) *engine.EvaluationContext {
 	return &engine.EvaluationContext{
@@ -877,6 +1861,7 @@ func (p *ResourceQuotaEnforcementPlugin) convertToEngineContext(admissionCtx *Ev
 			Extra:  admissionCtx.User.Extra,
 		},
 		RequestInfo: admissionCtx.RequestInfo,
+		Parent:      admissionCtx.Parent,
 		Namespace:   admissionCtx.Namespace,
 		GVK: struct {
 			Group   string
@@ -891,37 +1876,45 @@ func (p *ResourceQuotaEnforcementPlugin) convertToEngineContext(admissionCtx *Ev
 }
 
 // determineClaimName determines the claim name.
-// Renders the template first, then uses the name if specified, or generates
-// a name using Kubernetes standard name generation for generateName.
+// A claim target's name is used as is. Otherwise the template is rendered first,
+// then its name is used if specified, or a name is generated using Kubernetes
+// standard name generation for generateName. The boolean reports whether the name is
+// deterministic, i.e. the same for every request for the same object.
 func (p *ResourceQuotaEnforcementPlugin) determineClaimName(
 	evalContext *EvaluationContext,
 	policy *quotav1alpha1.ClaimCreationPolicy,
-) (string, error) {
+	target *claimTarget,
+) (string, bool, error) {
+	if target != nil {
+		return target.name, true, nil
+	}
+
 	// Render template to get name/generateName after CEL evaluation
 	engineContext := p.convertToEngineContext(evalContext)
 	claim, err := p.templateEngine.RenderClaim(policy, engineContext)
 	if err != nil {
-		return "", fmt.Errorf("failed to render claim template: %w", err)
+		return "", false, &claimTemplateError{err: err}
 	}
 
 	// If name is specified in template (after rendering), use it directly
 	if claim.Name != "" {
-		return claim.Name, nil
+		return claim.Name, true, nil
 	}
 
 	// If generateName is specified, use Kubernetes standard name generation
 	if claim.GenerateName != "" {
-		return names.SimpleNameGenerator.GenerateName(claim.GenerateName), nil
+		return names.SimpleNameGenerator.GenerateName(claim.GenerateName), false, nil
 	}
 
 	// Neither name nor generateName - generate base name from resource and kind
 	baseName := fmt.Sprintf("%s-%s-claim-",
 		evalContext.Object.GetName(),
 		strings.ToLower(evalContext.GVK.Kind))
-	return names.SimpleNameGenerator.GenerateName(baseName), nil
+	return names.SimpleNameGenerator.GenerateName(baseName), false, nil
 }
 
-// validateResourceRegistration validates ResourceRegistration objects for cross-resource duplicates.
+// validateResourceRegistration validates ResourceRegistration objects for cross-resource
+// duplicates, and blocks deleting registrations whose resource type is still in use.
 func (p *ResourceQuotaEnforcementPlugin) validateResourceRegistration(ctx context.Context, attrs admission.Attributes) error {
 	ctx, span := p.startSpan(ctx, "quota.admission.ResourceRegistrationValidation",
 		trace.WithAttributes(
@@ -931,6 +1924,10 @@ func (p *ResourceQuotaEnforcementPlugin) validateResourceRegistration(ctx contex
 		))
 	defer span.End()
 
+	if attrs.GetOperation() == admission.Delete {
+		return p.validateResourceRegistrationDelete(ctx, attrs)
+	}
+
 	// Only validate on CREATE to check for duplicate resourceType
 	// Updates are handled by CEL immutability rules
 	if attrs.GetOperation() != admission.Create {
@@ -1015,7 +2012,9 @@ func (p *ResourceQuotaEnforcementPlugin) validateClaimCreationPolicy(ctx context
 		attribute.String("policy.namespace", policy.Namespace),
 	)
 
-	if validationErrs := p.claimCreationPolicyValidator.Validate(ctx, policy, validation.AdmissionValidationOptions()); len(validationErrs) > 0 {
+	validationErrs := p.claimCreationPolicyValidator.Validate(ctx, policy, validation.AdmissionValidationOptions())
+	validationErrs = append(validationErrs, p.validateClaimCreationPolicyTriggers(policy)...)
+	if len(validationErrs) > 0 {
 		span.SetAttributes(attribute.String("validation.status", "failed"))
 		span.SetStatus(codes.Error, "ClaimCreationPolicy validation failed")
 
@@ -1031,10 +2030,43 @@ func (p *ResourceQuotaEnforcementPlugin) validateClaimCreationPolicy(ctx context
 		))
 	}
 
+	for _, overlap := range p.claimCreationPolicyOverlapWarnings(policy) {
+		warning.AddWarning(ctx, "", overlap)
+	}
+
 	span.SetAttributes(attribute.String("validation.status", "passed"))
 	return nil
 }
 
+// validateClaimCreationPolicyTriggers rejects trigger kinds that another active
+// ClaimCreationPolicy already claims, as only one policy may apply to each GVK.
+func (p *ResourceQuotaEnforcementPlugin) validateClaimCreationPolicyTriggers(policy *quotav1alpha1.ClaimCreationPolicy) field.ErrorList {
+	if p.policyEngine == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	triggerPath := field.NewPath("spec", "trigger")
+	for i, trigger := range policy.Spec.Trigger.GetResources() {
+		path := triggerPath.Child("resources").Index(i)
+		if policy.Spec.Trigger.Resource != nil {
+			path = triggerPath.Child("resource")
+		}
+
+		gvk := trigger.GetGVK()
+		existing, err := p.policyEngine.GetPolicyForGVK(gvk)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(path, fmt.Errorf("failed to look up policies for %s: %w", gvk, err)))
+			continue
+		}
+		if existing != nil && existing.Name != policy.Name {
+			allErrs = append(allErrs, field.Duplicate(path,
+				fmt.Sprintf("%s is already targeted by ClaimCreationPolicy '%s'", gvk.GroupKind(), existing.Name)))
+		}
+	}
+	return allErrs
+}
+
 // validateGrantCreationPolicy validates GrantCreationPolicy objects for CEL expressions and template syntax.
 func (p *ResourceQuotaEnforcementPlugin) validateGrantCreationPolicy(ctx context.Context, attrs admission.Attributes) error {
 	ctx, span := p.startSpan(ctx, "quota.admission.GrantCreationPolicyValidation",
@@ -1134,7 +2166,7 @@ func (p *ResourceQuotaEnforcementPlugin) validateResourceGrant(ctx context.Conte
 		attribute.Int("grant.allowances_count", len(grant.Spec.Allowances)),
 	)
 
-	if validationErrs := p.resourceGrantValidator.Validate(ctx, grant, validation.AdmissionValidationOptions()); len(validationErrs) > 0 {
+	if validationErrs := p.resourceGrantValidator.ValidateCreate(ctx, grant, validation.AdmissionValidationOptions()); len(validationErrs) > 0 {
 		span.SetAttributes(attribute.String("validation.status", "failed"))
 		span.SetStatus(codes.Error, "ResourceGrant validation failed")
 
@@ -1,13 +1,23 @@
 package admission
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -16,12 +26,17 @@ import (
 	"k8s.io/apiserver/pkg/admission"
 	"k8s.io/apiserver/pkg/apis/audit"
 	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/warning"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/component-base/metrics/testutil"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	"go.miloapis.com/milo/internal/quota/bucketutil"
 	"go.miloapis.com/milo/internal/quota/engine"
 	"go.miloapis.com/milo/internal/quota/validation"
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
@@ -30,7 +45,9 @@ import (
 
 // testResourceTypeValidator provides deterministic resource type validation for tests.
 type testResourceTypeValidator struct {
-	validResourceTypes map[string]bool
+	validResourceTypes     map[string]bool
+	defaultClaims          map[schema.GroupKind]validation.DefaultClaim
+	denialMessageTemplates map[string]string
 }
 
 func (t *testResourceTypeValidator) ValidateResourceType(ctx context.Context, resourceType string) error {
@@ -48,7 +65,29 @@ func (t *testResourceTypeValidator) IsClaimingResourceAllowed(ctx context.Contex
 }
 
 func (t *testResourceTypeValidator) IsResourceTypeRegistered(resourceType string) bool {
-	return false
+	return t.validResourceTypes[resourceType]
+}
+
+func (t *testResourceTypeValidator) HasExactRegistration(resourceType string) bool {
+	return t.validResourceTypes[resourceType]
+}
+
+func (t *testResourceTypeValidator) GetMeasurement(resourceType string) (string, int64, bool) {
+	return "", 0, false
+}
+
+func (t *testResourceTypeValidator) GetMaxGrantAmount(resourceType string) (int64, bool) {
+	return 0, false
+}
+
+func (t *testResourceTypeValidator) GetDefaultClaim(claimingAPIGroup, claimingKind string) (validation.DefaultClaim, bool) {
+	defaultClaim, ok := t.defaultClaims[schema.GroupKind{Group: claimingAPIGroup, Kind: claimingKind}]
+	return defaultClaim, ok
+}
+
+func (t *testResourceTypeValidator) GetDenialMessageTemplate(resourceType string) (string, bool) {
+	denialMessageTemplate, ok := t.denialMessageTemplates[resourceType]
+	return denialMessageTemplate, ok
 }
 
 func (t *testResourceTypeValidator) HasSynced() bool { return true }
@@ -70,7 +109,7 @@ func TestResourceQuotaEnforcementPlugin_Validate(t *testing.T) {
 			policy: &quotav1alpha1.ClaimCreationPolicy{
 				Spec: quotav1alpha1.ClaimCreationPolicySpec{
 					Trigger: quotav1alpha1.ClaimTriggerSpec{
-						Resource: quotav1alpha1.ClaimTriggerResource{
+						Resource: &quotav1alpha1.ClaimTriggerResource{
 							APIVersion: "networking.datumapis.com/v1alpha",
 							Kind:       "HTTPProxy",
 						},
@@ -168,7 +207,7 @@ func TestResourceQuotaEnforcementPlugin_Validate(t *testing.T) {
 			policy: &quotav1alpha1.ClaimCreationPolicy{
 				Spec: quotav1alpha1.ClaimCreationPolicySpec{
 					Trigger: quotav1alpha1.ClaimTriggerSpec{
-						Resource: quotav1alpha1.ClaimTriggerResource{
+						Resource: &quotav1alpha1.ClaimTriggerResource{
 							APIVersion: "apps/v1",
 							Kind:       "Deployment",
 						},
@@ -234,7 +273,7 @@ func TestResourceQuotaEnforcementPlugin_Validate(t *testing.T) {
 			policy: &quotav1alpha1.ClaimCreationPolicy{
 				Spec: quotav1alpha1.ClaimCreationPolicySpec{
 					Trigger: quotav1alpha1.ClaimTriggerSpec{
-						Resource: quotav1alpha1.ClaimTriggerResource{
+						Resource: &quotav1alpha1.ClaimTriggerResource{
 							APIVersion: "apps/v1",
 							Kind:       "Deployment",
 						},
@@ -301,7 +340,7 @@ func TestResourceQuotaEnforcementPlugin_Validate(t *testing.T) {
 			policy: &quotav1alpha1.ClaimCreationPolicy{
 				Spec: quotav1alpha1.ClaimCreationPolicySpec{
 					Trigger: quotav1alpha1.ClaimTriggerSpec{
-						Resource: quotav1alpha1.ClaimTriggerResource{
+						Resource: &quotav1alpha1.ClaimTriggerResource{
 							APIVersion: "apps/v1",
 							Kind:       "Deployment",
 						},
@@ -446,8 +485,9 @@ func TestResourceQuotaEnforcementPlugin_Validate(t *testing.T) {
 }
 
 type testPolicyEngine struct {
-	policy *quotav1alpha1.ClaimCreationPolicy
-	gvk    schema.GroupVersionKind
+	policy  *quotav1alpha1.ClaimCreationPolicy
+	gvk     schema.GroupVersionKind
+	unready *quotav1alpha1.ClaimCreationPolicy
 }
 
 func (e *testPolicyEngine) GetPolicyForGVK(gvk schema.GroupVersionKind) (*quotav1alpha1.ClaimCreationPolicy, error) {
@@ -457,6 +497,20 @@ func (e *testPolicyEngine) GetPolicyForGVK(gvk schema.GroupVersionKind) (*quotav
 	return nil, nil
 }
 
+func (e *testPolicyEngine) ListPolicies() []*quotav1alpha1.ClaimCreationPolicy {
+	if e.policy == nil {
+		return nil
+	}
+	return []*quotav1alpha1.ClaimCreationPolicy{e.policy}
+}
+
+func (e *testPolicyEngine) GetUnreadyPolicyForGVK(gvk schema.GroupVersionKind) *quotav1alpha1.ClaimCreationPolicy {
+	if e.unready != nil && e.unready.Spec.Trigger.Resource.GetGVK() == gvk {
+		return e.unready
+	}
+	return nil
+}
+
 func (e *testPolicyEngine) Start(ctx context.Context) error { return nil }
 func (e *testPolicyEngine) Close()                          {}
 
@@ -474,22 +528,24 @@ func (e *testPolicyEngine) removePolicyForTest(policyName string) {
 type testAdmissionAttributes struct {
 	operation   admission.Operation
 	object      runtime.Object
+	oldObject   runtime.Object
 	gvk         schema.GroupVersionKind
 	name        string
 	namespace   string
 	userInfo    user.Info
 	dryRun      bool
 	subResource string
+	resource    schema.GroupVersionResource
 }
 
 func (a *testAdmissionAttributes) GetOperation() admission.Operation { return a.operation }
 func (a *testAdmissionAttributes) GetObject() runtime.Object         { return a.object }
-func (a *testAdmissionAttributes) GetOldObject() runtime.Object      { return nil }
+func (a *testAdmissionAttributes) GetOldObject() runtime.Object      { return a.oldObject }
 func (a *testAdmissionAttributes) GetKind() schema.GroupVersionKind  { return a.gvk }
 func (a *testAdmissionAttributes) GetName() string                   { return a.name }
 func (a *testAdmissionAttributes) GetNamespace() string              { return a.namespace }
 func (a *testAdmissionAttributes) GetResource() schema.GroupVersionResource {
-	return schema.GroupVersionResource{}
+	return a.resource
 }
 func (a *testAdmissionAttributes) GetSubresource() string                { return a.subResource }
 func (a *testAdmissionAttributes) GetUserInfo() user.Info                { return a.userInfo }
@@ -892,6 +948,164 @@ func TestResourceQuotaEnforcementPlugin_InitializationValidation(t *testing.T) {
 	}
 }
 
+// capturingPluginInitializer records the admission plugin it is asked to initialize.
+type capturingPluginInitializer struct {
+	plugin admission.Interface
+}
+
+func (c *capturingPluginInitializer) Initialize(plugin admission.Interface) {
+	c.plugin = plugin
+}
+
+func TestRegisterAppliesConfiguration(t *testing.T) {
+	plugins := admission.NewPlugins()
+	Register(plugins, func(config *AdmissionPluginConfig) {
+		config.CELProgramCacheSize = 16
+		config.ClaimTimeoutBehavior = ClaimTimeoutDeny
+	})
+
+	initializer := &capturingPluginInitializer{}
+	// The plugin fails initialization validation without a dynamic client; only the
+	// configuration it was created with matters here.
+	_, _ = plugins.InitPlugin(PluginName, nil, initializer)
+
+	plugin, ok := initializer.plugin.(*ResourceQuotaEnforcementPlugin)
+	if !ok {
+		t.Fatalf("expected a *ResourceQuotaEnforcementPlugin, got %T", initializer.plugin)
+	}
+	if plugin.config.CELProgramCacheSize != 16 {
+		t.Errorf("expected CEL program cache size 16, got %d", plugin.config.CELProgramCacheSize)
+	}
+	if plugin.config.ClaimTimeoutBehavior != ClaimTimeoutDeny {
+		t.Errorf("expected claim timeout behavior %q, got %q", ClaimTimeoutDeny, plugin.config.ClaimTimeoutBehavior)
+	}
+	if plugin.config.PolicyCacheTTL != DefaultAdmissionPluginConfig().PolicyCacheTTL {
+		t.Errorf("expected unconfigured fields to keep their defaults, got policy cache TTL %s", plugin.config.PolicyCacheTTL)
+	}
+
+	config := DefaultAdmissionPluginConfig()
+	config.CELProgramCacheSize = -1
+	if _, err := NewResourceQuotaEnforcementPluginWithConfig(config); err == nil {
+		t.Error("expected a negative CEL program cache size to be rejected")
+	}
+
+	config = DefaultAdmissionPluginConfig()
+	config.ClaimTimeoutBehavior = "Ignore"
+	if _, err := NewResourceQuotaEnforcementPluginWithConfig(config); err == nil {
+		t.Error("expected an unknown claim timeout behavior to be rejected")
+	}
+}
+
+func TestProjectControlPlaneHost(t *testing.T) {
+	const projectPath = "/apis/resourcemanager.miloapis.com/v1alpha1/projects/p1/control-plane"
+
+	tests := []struct {
+		name    string
+		cfg     *rest.Config
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "http host",
+			cfg:  &rest.Config{Host: "http://localhost:8080"},
+			want: "http://localhost:8080" + projectPath,
+		},
+		{
+			name: "https host with trailing slash",
+			cfg:  &rest.Config{Host: "https://milo.example.com/"},
+			want: "https://milo.example.com" + projectPath,
+		},
+		{
+			name: "host with path prefix",
+			cfg:  &rest.Config{Host: "https://milo.example.com/prefix//"},
+			want: "https://milo.example.com/prefix" + projectPath,
+		},
+		{
+			name: "host:port without TLS",
+			cfg:  &rest.Config{Host: "localhost:8080"},
+			want: "http://localhost:8080" + projectPath,
+		},
+		{
+			name: "host:port with TLS config",
+			cfg:  &rest.Config{Host: "localhost:6443", TLSClientConfig: rest.TLSClientConfig{Insecure: true}},
+			want: "https://localhost:6443" + projectPath,
+		},
+		{
+			name: "host:port with TLS transport",
+			cfg:  &rest.Config{Host: "localhost:6443", Transport: &http.Transport{TLSClientConfig: &tls.Config{}}},
+			want: "https://localhost:6443" + projectPath,
+		},
+		{
+			name:    "missing host",
+			cfg:     &rest.Config{Host: "https://"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := projectControlPlaneHost(tt.cfg, "p1")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got host %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("projectControlPlaneHost() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("projectControlPlaneHost() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectClientEviction(t *testing.T) {
+	config := DefaultAdmissionPluginConfig()
+	config.WatchManager.TTL.DefaultTTL = 20 * time.Millisecond
+
+	plugin := &ResourceQuotaEnforcementPlugin{
+		loopbackConfig: &rest.Config{Host: "http://localhost:8080"},
+		config:         config,
+		logger:         zap.New(zap.UseDevMode(true)),
+	}
+
+	first, err := plugin.getProjectClient("p1")
+	if err != nil {
+		t.Fatalf("getProjectClient() error = %v", err)
+	}
+	cached, err := plugin.getProjectClient("p1")
+	if err != nil {
+		t.Fatalf("getProjectClient() error = %v", err)
+	}
+	if cached != first {
+		t.Fatal("expected the project client to be reused while in use")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := plugin.projectClients.Load("p1"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the unused project client to be evicted")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	recreated, err := plugin.getProjectClient("p1")
+	if err != nil {
+		t.Fatalf("getProjectClient() error = %v", err)
+	}
+	if recreated == first {
+		t.Error("expected a new project client after eviction")
+	}
+	if _, ok := plugin.projectClients.Load("p1"); !ok {
+		t.Error("expected the recreated project client to be cached")
+	}
+}
+
 func TestResourceQuotaEnforcementPlugin_PolicyEngineFailure(t *testing.T) {
 	failingPolicyEngine := &failingPolicyEngine{
 		err: errors.New("policy engine failure"),
@@ -972,6 +1186,18 @@ func TestClaimWaitScenarios(t *testing.T) {
 			expectError:   true,
 			errorSubstr:   "Insufficient quota resources available",
 		},
+		{
+			name:          "claim denied with denial details",
+			claimBehavior: "denied-partial",
+			expectError:   true,
+			errorSubstr:   "networking.datumapis.com/httpproxies (requested 1, allocated 5 of limit 5, available 0)",
+		},
+		{
+			name:          "claim denied with per-request reasons",
+			claimBehavior: "denied-requests",
+			expectError:   true,
+			errorSubstr:   "networking.datumapis.com/httpproxies (Resource quota exceeded: requested 1, available 0)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1006,7 +1232,7 @@ func TestClaimWaitScenarios(t *testing.T) {
 				},
 				Spec: quotav1alpha1.ClaimCreationPolicySpec{
 					Trigger: quotav1alpha1.ClaimTriggerSpec{
-						Resource: quotav1alpha1.ClaimTriggerResource{
+						Resource: &quotav1alpha1.ClaimTriggerResource{
 							APIVersion: "apps/v1",
 							Kind:       "Deployment",
 						},
@@ -1067,6 +1293,10 @@ func TestClaimWaitScenarios(t *testing.T) {
 				watchManager = &testWatchManager{behavior: "grant"}
 			} else if tt.claimBehavior == "denied" {
 				watchManager = &testWatchManager{behavior: "deny"}
+			} else if tt.claimBehavior == "denied-partial" {
+				watchManager = &testWatchManager{behavior: "deny-partial"}
+			} else if tt.claimBehavior == "denied-requests" {
+				watchManager = &testWatchManager{behavior: "deny-requests"}
 			} else {
 				watchManager = &testWatchManager{behavior: "timeout"}
 			}
@@ -1263,16 +1493,70 @@ type testWatchManager struct {
 }
 
 func (m *testWatchManager) RegisterClaimWaiter(ctx context.Context, claimName, namespace string, timeout time.Duration) (<-chan ClaimResult, context.CancelFunc, error) {
+	if m.behavior == "saturated" {
+		return nil, nil, &claimPendingError{saturated: true, message: "maximum number of concurrent claim waiters (1) reached"}
+	}
 	resultChan := make(chan ClaimResult, 1)
 	cancel := func() {}
 
 	go func() {
+		if m.behavior == "hang" {
+			return // never resolve, leaving the request deadline to end the wait
+		}
 		time.Sleep(10 * time.Millisecond)
 		switch m.behavior {
 		case "grant":
 			resultChan <- ClaimResult{Granted: true, Reason: "test granted"}
 		case "deny":
 			resultChan <- ClaimResult{Granted: false, Reason: "quota exceeded", Error: fmt.Errorf("ResourceClaim was denied: quota exceeded")}
+		case "deny-partial":
+			resultChan <- ClaimResult{
+				Granted: false,
+				Reason: "Insufficient quota resources for networking.datumapis.com/httpproxies (requested 1, allocated 5 of limit 5, available 0). " +
+					"Contact your account administrator to review quota limits and usage.",
+				DeniedRequests: []RequestDenial{{
+					ResourceType: "networking.datumapis.com/httpproxies",
+					Reason:       quotav1alpha1.ResourceClaimDeniedReason,
+					Message:      "Resource quota exceeded: requested 1, available 0",
+				}},
+			}
+		case "deny-details":
+			resultChan <- ClaimResult{
+				Granted: false,
+				DeniedRequests: []RequestDenial{{
+					ResourceType: "networking.datumapis.com/httpproxies",
+					Reason:       quotav1alpha1.ResourceClaimDeniedReason,
+					Message:      "Resource quota exceeded: requested 3, available 2",
+					Details: &quotav1alpha1.ResourceClaimDenialDetail{
+						ResourceType: "networking.datumapis.com/httpproxies",
+						Requested:    3,
+						Allocated:    3,
+						Limit:        5,
+						Available:    2,
+					},
+				}},
+			}
+		case "deny-requests":
+			resultChan <- ClaimResult{
+				Granted: false,
+				DeniedRequests: []RequestDenial{{
+					ResourceType: "networking.datumapis.com/httpproxies",
+					Reason:       quotav1alpha1.ResourceClaimDeniedReason,
+					Message:      "Resource quota exceeded: requested 1, available 0",
+				}},
+			}
+		case "paused":
+			resultChan <- ClaimResult{
+				Granted: false,
+				Paused:  true,
+				Reason:  "Quota granting is paused for maintenance: 0 granted, 1 pending. The claim will be evaluated when granting resumes.",
+			}
+		case "expire":
+			resultChan <- ClaimResult{
+				Granted: false,
+				Reason:  "timeout",
+				Error:   &claimPendingError{message: "timeout waiting for ResourceClaim default/test-claim after 30s"},
+			}
 		}
 		close(resultChan)
 	}()
@@ -1293,7 +1577,7 @@ func newDeterministicClaimPolicy() *quotav1alpha1.ClaimCreationPolicy {
 		},
 		Spec: quotav1alpha1.ClaimCreationPolicySpec{
 			Trigger: quotav1alpha1.ClaimTriggerSpec{
-				Resource: quotav1alpha1.ClaimTriggerResource{
+				Resource: &quotav1alpha1.ClaimTriggerResource{
 					APIVersion: "discovery.k8s.io/v1",
 					Kind:       "EndpointSlice",
 				},
@@ -1583,6 +1867,34 @@ func TestGetClientForContext(t *testing.T) {
 	}
 }
 
+// TestProjectClientCacheConcurrentAccess exercises concurrent lookups of a project client
+// while it is being created and evicted; run with -race to catch unsynchronized access.
+func TestProjectClientCacheConcurrentAccess(t *testing.T) {
+	config := DefaultAdmissionPluginConfig()
+	config.WatchManager.TTL.DefaultTTL = time.Millisecond
+
+	plugin := &ResourceQuotaEnforcementPlugin{
+		loopbackConfig: &rest.Config{Host: "http://localhost:8080"},
+		config:         config,
+		logger:         zap.New(zap.UseDevMode(true)),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if _, err := plugin.getProjectClient("p1"); err != nil {
+					t.Errorf("getProjectClient() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func TestProjectClientCaching(t *testing.T) {
 	plugin := &ResourceQuotaEnforcementPlugin{
 		Handler: admission.NewHandler(admission.Create),
@@ -1628,3 +1940,3095 @@ func TestProjectClientCaching(t *testing.T) {
 		t.Error("getProjectClient() returned same client for different project")
 	}
 }
+
+// notifyingPolicyEngine counts lookups and notifies registered handlers on policy changes.
+type notifyingPolicyEngine struct {
+	testPolicyEngine
+	lookups  int
+	handlers []func()
+}
+
+var _ engine.PolicyChangeNotifier = &notifyingPolicyEngine{}
+
+func (e *notifyingPolicyEngine) GetPolicyForGVK(gvk schema.GroupVersionKind) (*quotav1alpha1.ClaimCreationPolicy, error) {
+	e.lookups++
+	return e.testPolicyEngine.GetPolicyForGVK(gvk)
+}
+
+func (e *notifyingPolicyEngine) AddPolicyChangeHandler(handler func()) {
+	e.handlers = append(e.handlers, handler)
+}
+
+func (e *notifyingPolicyEngine) updatePolicyForTest(policy *quotav1alpha1.ClaimCreationPolicy) error {
+	if err := e.testPolicyEngine.updatePolicyForTest(policy); err != nil {
+		return err
+	}
+	for _, handler := range e.handlers {
+		handler()
+	}
+	return nil
+}
+
+func TestPolicyLookupCache(t *testing.T) {
+	gvk := endpointSliceGVK()
+	logger := zap.New(zap.UseDevMode(true))
+
+	t.Run("cache hit avoids policy engine lookup", func(t *testing.T) {
+		policyEngine := &notifyingPolicyEngine{}
+		if err := policyEngine.updatePolicyForTest(newDeterministicClaimPolicy()); err != nil {
+			t.Fatalf("failed to set policy: %v", err)
+		}
+		plugin := &ResourceQuotaEnforcementPlugin{
+			policyEngine: policyEngine,
+			policyCache:  newPolicyLookupCache(time.Minute),
+			logger:       logger,
+		}
+
+		for i := 0; i < 3; i++ {
+			policy, err := plugin.lookupPolicyForResource(context.Background(), gvk)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if policy == nil {
+				t.Fatalf("expected policy to be found")
+			}
+		}
+		if policyEngine.lookups != 1 {
+			t.Errorf("expected 1 policy engine lookup, got %d", policyEngine.lookups)
+		}
+	})
+
+	t.Run("negative results are cached", func(t *testing.T) {
+		policyEngine := &notifyingPolicyEngine{}
+		plugin := &ResourceQuotaEnforcementPlugin{
+			policyEngine: policyEngine,
+			policyCache:  newPolicyLookupCache(time.Minute),
+			logger:       logger,
+		}
+
+		for i := 0; i < 2; i++ {
+			policy, err := plugin.lookupPolicyForResource(context.Background(), gvk)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if policy != nil {
+				t.Fatalf("expected no policy, got %s", policy.Name)
+			}
+		}
+		if policyEngine.lookups != 1 {
+			t.Errorf("expected 1 policy engine lookup, got %d", policyEngine.lookups)
+		}
+	})
+
+	t.Run("entries expire after ttl", func(t *testing.T) {
+		policyEngine := &notifyingPolicyEngine{}
+		cache := newPolicyLookupCache(5 * time.Second)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+		plugin := &ResourceQuotaEnforcementPlugin{
+			policyEngine: policyEngine,
+			policyCache:  cache,
+			logger:       logger,
+		}
+
+		if _, err := plugin.lookupPolicyForResource(context.Background(), gvk); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		now = now.Add(6 * time.Second)
+		if _, err := plugin.lookupPolicyForResource(context.Background(), gvk); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if policyEngine.lookups != 2 {
+			t.Errorf("expected expired entry to trigger a second lookup, got %d lookups", policyEngine.lookups)
+		}
+	})
+
+	t.Run("disabled policy update bypasses the cache", func(t *testing.T) {
+		policyEngine := &notifyingPolicyEngine{}
+		if err := policyEngine.updatePolicyForTest(newDeterministicClaimPolicy()); err != nil {
+			t.Fatalf("failed to set policy: %v", err)
+		}
+		plugin := &ResourceQuotaEnforcementPlugin{
+			Handler:      admission.NewHandler(admission.Create),
+			policyEngine: policyEngine,
+			policyCache:  newPolicyLookupCache(time.Minute),
+			config:       DefaultAdmissionPluginConfig(),
+			logger:       logger,
+		}
+		policyEngine.AddPolicyChangeHandler(plugin.policyCache.invalidate)
+
+		policy, err := plugin.lookupPolicyForResource(context.Background(), gvk)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if policy == nil || (policy.Spec.Disabled != nil && *policy.Spec.Disabled) {
+			t.Fatalf("expected enabled policy to be cached")
+		}
+
+		// Disable the policy mid-flight; the engine notifies the plugin.
+		disabled := newDeterministicClaimPolicy()
+		disabled.Spec.Disabled = ptr.To(true)
+		if err := policyEngine.updatePolicyForTest(disabled); err != nil {
+			t.Fatalf("failed to disable policy: %v", err)
+		}
+
+		policy, err = plugin.lookupPolicyForResource(context.Background(), gvk)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if policy == nil || policy.Spec.Disabled == nil || !*policy.Spec.Disabled {
+			t.Fatalf("expected disabled policy after invalidation, got stale cached policy")
+		}
+		if policyEngine.lookups != 2 {
+			t.Errorf("expected invalidation to force a second lookup, got %d lookups", policyEngine.lookups)
+		}
+
+		// Enforcement must skip claim creation for the disabled policy. No watch manager
+		// or dynamic client is configured, so reaching claim creation would fail.
+		attrs := newEndpointSliceAttrs(newEndpointSliceObject(), gvk)
+		if err := plugin.handleResourceQuotaEnforcement(context.Background(), attrs); err != nil {
+			t.Fatalf("expected disabled policy to allow admission, got: %v", err)
+		}
+	})
+
+	t.Run("invalidation during lookup prevents stale store", func(t *testing.T) {
+		cache := newPolicyLookupCache(time.Minute)
+		_, generation, found := cache.get(gvk)
+		if found {
+			t.Fatalf("expected empty cache")
+		}
+		cache.invalidate()
+		cache.store(gvk, newDeterministicClaimPolicy(), generation)
+		if _, _, found := cache.get(gvk); found {
+			t.Errorf("expected store with stale generation to be dropped")
+		}
+	})
+}
+
+func TestEvaluateClaimStatusDeniedRequests(t *testing.T) {
+	claim := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"allocations": []interface{}{
+					map[string]interface{}{
+						"resourceType": "resourcemanager.miloapis.com/projects",
+						"status":       quotav1alpha1.ResourceClaimAllocationStatusGranted,
+					},
+					map[string]interface{}{
+						"resourceType": "networking.datumapis.com/httpproxies",
+						"status":       quotav1alpha1.ResourceClaimAllocationStatusDenied,
+						"reason":       quotav1alpha1.ResourceClaimDeniedReason,
+						"message":      "Resource quota exceeded: requested 1, available 0",
+					},
+				},
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":    quotav1alpha1.ResourceClaimGranted,
+						"status":  string(metav1.ConditionFalse),
+						"reason":  quotav1alpha1.ResourceClaimDeniedReason,
+						"message": "Insufficient quota resources for networking.datumapis.com/httpproxies.",
+					},
+				},
+			},
+		},
+	}
+
+	result := evaluateClaimStatus(claim)
+	if result == nil {
+		t.Fatal("expected a final result for a denied claim")
+	}
+	if result.Granted {
+		t.Fatal("expected claim to be denied")
+	}
+
+	expected := []RequestDenial{{
+		ResourceType: "networking.datumapis.com/httpproxies",
+		Reason:       quotav1alpha1.ResourceClaimDeniedReason,
+		Message:      "Resource quota exceeded: requested 1, available 0",
+	}}
+	if !reflect.DeepEqual(result.DeniedRequests, expected) {
+		t.Errorf("expected denied requests %v, got %v", expected, result.DeniedRequests)
+	}
+}
+
+func TestValidateClaimCreationPolicyTriggersRejectsClaimedKinds(t *testing.T) {
+	existing := newDeterministicClaimPolicy()
+	existing.Name = "endpointslice-policy"
+	p := &ResourceQuotaEnforcementPlugin{
+		policyEngine: &testPolicyEngine{policy: existing, gvk: endpointSliceGVK()},
+	}
+
+	policy := &quotav1alpha1.ClaimCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "networking-policy"},
+		Spec: quotav1alpha1.ClaimCreationPolicySpec{
+			Trigger: quotav1alpha1.ClaimTriggerSpec{
+				Resources: []quotav1alpha1.ClaimTriggerResource{
+					{APIVersion: "v1", Kind: "Service"},
+					{APIVersion: "discovery.k8s.io/v1", Kind: "EndpointSlice"},
+				},
+			},
+		},
+	}
+
+	errs := p.validateClaimCreationPolicyTriggers(policy)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if errs[0].Field != "spec.trigger.resources[1]" {
+		t.Errorf("expected error on spec.trigger.resources[1], got %s", errs[0].Field)
+	}
+	if !contains(errs[0].Error(), "endpointslice-policy") {
+		t.Errorf("expected error to name the existing policy, got %q", errs[0].Error())
+	}
+
+	existing.Name = "networking-policy"
+	if errs := p.validateClaimCreationPolicyTriggers(policy); len(errs) != 0 {
+		t.Errorf("expected a policy's own kinds to be accepted on update, got %v", errs)
+	}
+}
+
+func TestClaimCreationPolicyOverlapWarnings(t *testing.T) {
+	existing := newDeterministicClaimPolicy()
+	existing.Name = "endpointslice-policy"
+	existing.Spec.Trigger.ObjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "premium"}}
+	p := &ResourceQuotaEnforcementPlugin{
+		policyEngine: &testPolicyEngine{policy: existing, gvk: endpointSliceGVK()},
+	}
+
+	newPolicy := func(apiVersion string, objectSelector *metav1.LabelSelector) *quotav1alpha1.ClaimCreationPolicy {
+		return &quotav1alpha1.ClaimCreationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "endpointslice-beta-policy"},
+			Spec: quotav1alpha1.ClaimCreationPolicySpec{
+				Trigger: quotav1alpha1.ClaimTriggerSpec{
+					Resource:       &quotav1alpha1.ClaimTriggerResource{APIVersion: apiVersion, Kind: "EndpointSlice"},
+					ObjectSelector: objectSelector,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		policy       *quotav1alpha1.ClaimCreationPolicy
+		wantWarnings int
+	}{
+		{
+			name:         "same kind at another version with overlapping selectors",
+			policy:       newPolicy("discovery.k8s.io/v1beta1", nil),
+			wantWarnings: 1,
+		},
+		{
+			name:   "same kind at another version with disjoint selectors",
+			policy: newPolicy("discovery.k8s.io/v1beta1", &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "basic"}}),
+		},
+		{
+			name:   "different kind",
+			policy: newPolicy("v1", nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := p.claimCreationPolicyOverlapWarnings(tt.policy)
+			if len(warnings) != tt.wantWarnings {
+				t.Fatalf("expected %d warnings, got %v", tt.wantWarnings, warnings)
+			}
+			for _, w := range warnings {
+				if !contains(w, "endpointslice-policy") {
+					t.Errorf("expected warning to name the existing policy, got %q", w)
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateClaimStatusGrantingPaused(t *testing.T) {
+	claim := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":    quotav1alpha1.ResourceClaimGranted,
+						"status":  string(metav1.ConditionFalse),
+						"reason":  quotav1alpha1.ResourceClaimGrantingPausedReason,
+						"message": "Quota granting is paused for maintenance.",
+					},
+				},
+			},
+		},
+	}
+
+	result := evaluateClaimStatus(claim)
+	if result == nil {
+		t.Fatal("expected a final result for a paused claim")
+	}
+	if !result.Paused || result.Granted {
+		t.Errorf("expected a paused, ungranted result, got %+v", result)
+	}
+}
+
+type recordingWarningRecorder struct {
+	warnings []string
+}
+
+func (r *recordingWarningRecorder) AddWarning(_, text string) {
+	r.warnings = append(r.warnings, text)
+}
+
+func TestResourceClaimDryRunSimulation(t *testing.T) {
+	consumerRef := quotav1alpha1.ConsumerRef{
+		APIGroup: "resourcemanager.miloapis.com",
+		Kind:     "Organization",
+		Name:     "test-org",
+	}
+
+	bucket := &quotav1alpha1.AllowanceBucket{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bucketutil.Name("apps/Deployment", consumerRef),
+			Namespace: bucketutil.Namespace(consumerRef),
+		},
+		Spec: quotav1alpha1.AllowanceBucketSpec{
+			ConsumerRef:  consumerRef,
+			ResourceType: "apps/Deployment",
+		},
+		Status: quotav1alpha1.AllowanceBucketStatus{
+			Limit:     10,
+			Allocated: 7,
+			Available: 3,
+		},
+	}
+
+	claim := &quotav1alpha1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-claim",
+			Namespace: "default",
+		},
+		Spec: quotav1alpha1.ResourceClaimSpec{
+			ConsumerRef: consumerRef,
+			Requests: []quotav1alpha1.ResourceRequest{
+				{ResourceType: "apps/Deployment", Amount: 2},
+				{ResourceType: "apps/StatefulSet", Amount: 1},
+			},
+			ResourceRef: quotav1alpha1.UnversionedObjectReference{
+				APIGroup:  "apps",
+				Kind:      "Deployment",
+				Name:      "test-deployment",
+				Namespace: "default",
+			},
+		},
+	}
+
+	organization := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+		"kind":       "Organization",
+		"metadata":   map[string]interface{}{"name": "test-org"},
+	}}
+
+	scheme := runtime.NewScheme()
+	quotav1alpha1.AddToScheme(scheme)
+	fakeDynamicClient := fake.NewSimpleDynamicClient(scheme, bucket, organization)
+
+	mockValidator := &testResourceTypeValidator{
+		validResourceTypes: map[string]bool{
+			"apps/Deployment":  true,
+			"apps/StatefulSet": true,
+		},
+	}
+
+	logger := zap.New(zap.UseDevMode(true))
+	plugin := &ResourceQuotaEnforcementPlugin{
+		Handler:                admission.NewHandler(admission.Create),
+		dynamicClient:          fakeDynamicClient,
+		resourceTypeValidator:  mockValidator,
+		resourceClaimValidator: validation.NewResourceClaimValidator(fakeDynamicClient, mockValidator),
+		config:                 DefaultAdmissionPluginConfig(),
+		logger:                 logger.WithName("plugin"),
+	}
+
+	unstructuredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(claim)
+	if err != nil {
+		t.Fatalf("Failed to convert claim to unstructured: %v", err)
+	}
+
+	attrs := &testAdmissionAttributes{
+		operation: admission.Create,
+		object:    &unstructured.Unstructured{Object: unstructuredMap},
+		gvk: schema.GroupVersionKind{
+			Group:   "quota.miloapis.com",
+			Version: "v1alpha1",
+			Kind:    "ResourceClaim",
+		},
+		name:      claim.Name,
+		namespace: claim.Namespace,
+		userInfo: &user.DefaultInfo{
+			Name: "test-user",
+		},
+		dryRun: true,
+	}
+
+	recorder := &recordingWarningRecorder{}
+	ctx := warning.WithWarningRecorder(context.Background(), recorder)
+
+	if err := plugin.Validate(ctx, attrs, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"Dry-run: request for apps/Deployment would be granted (requested 2, available 3)",
+		"Dry-run: request for apps/StatefulSet would be denied (requested 1, available 0)",
+	}
+	if !reflect.DeepEqual(recorder.warnings, expected) {
+		t.Errorf("expected warnings %v, got %v", expected, recorder.warnings)
+	}
+
+	for _, action := range fakeDynamicClient.Actions() {
+		if verb := action.GetVerb(); verb != "get" && verb != "list" {
+			t.Errorf("expected dry-run simulation to be read-only, got %s %s", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+}
+
+func TestCreateResourceClaimRetries(t *testing.T) {
+	claimGR := schema.GroupResource{Group: "quota.miloapis.com", Resource: "resourceclaims"}
+
+	tests := []struct {
+		name             string
+		failures         int
+		err              error
+		expectError      bool
+		expectedAttempts int
+	}{
+		{
+			name:             "transient conflict succeeds on retry",
+			failures:         1,
+			err:              apierrors.NewConflict(claimGR, "claim", fmt.Errorf("conflict")),
+			expectedAttempts: 2,
+		},
+		{
+			name:             "throttled request succeeds on retry",
+			failures:         2,
+			err:              apierrors.NewTooManyRequests("slow down", 0),
+			expectedAttempts: 3,
+		},
+		{
+			name:             "persistent server timeout fails after retries",
+			failures:         100,
+			err:              apierrors.NewServerTimeout(claimGR, "create", 0),
+			expectError:      true,
+			expectedAttempts: 4,
+		},
+		{
+			name:             "non-transient error is not retried",
+			failures:         100,
+			err:              apierrors.NewForbidden(claimGR, "claim", fmt.Errorf("denied")),
+			expectError:      true,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+			attempts := 0
+			fakeDynClient.PrependReactor("create", "resourceclaims", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				attempts++
+				if attempts <= tt.failures {
+					return true, nil, tt.err
+				}
+				return false, nil, nil
+			})
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			config := DefaultAdmissionPluginConfig()
+			config.ClaimCreateRetry = ClaimCreateRetryConfig{
+				MaxRetries:   3,
+				InitialDelay: time.Millisecond,
+				MaxDelay:     5 * time.Millisecond,
+			}
+
+			policy := newDeterministicClaimPolicy()
+			gvk := endpointSliceGVK()
+
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: policy, gvk: gvk},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         config,
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion":  "discovery.k8s.io/v1",
+					"kind":        "EndpointSlice",
+					"metadata":    map[string]interface{}{"name": "test-eps", "namespace": "default"},
+					"addressType": "FQDN",
+				},
+			}
+
+			err = plugin.Validate(context.Background(), newEndpointSliceAttrs(obj, gvk), nil)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("Expected %d create attempts, got %d", tt.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+// TestCreateResourceClaimAdoptsExistingClaim verifies that a retried admission request whose
+// deterministic ResourceClaim already exists waits on that claim instead of failing.
+func TestCreateResourceClaimAdoptsExistingClaim(t *testing.T) {
+	claimGVR := schema.GroupVersionResource{Group: "quota.miloapis.com", Version: "v1alpha1", Resource: "resourceclaims"}
+
+	scheme := runtime.NewScheme()
+	quotav1alpha1.AddToScheme(scheme)
+	fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+	logger := zap.New(zap.UseDevMode(true))
+	celEngine, err := engine.NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+
+	policy := newDeterministicClaimPolicy()
+	gvk := endpointSliceGVK()
+
+	plugin := &ResourceQuotaEnforcementPlugin{
+		Handler:        admission.NewHandler(admission.Create),
+		dynamicClient:  fakeDynClient,
+		policyEngine:   &testPolicyEngine{policy: policy, gvk: gvk},
+		templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+		config:         DefaultAdmissionPluginConfig(),
+		logger:         logger.WithName("plugin"),
+	}
+	plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+	attrs := newEndpointSliceAttrs(newEndpointSliceObject(), gvk)
+	if err := plugin.Validate(context.Background(), attrs, nil); err != nil {
+		t.Fatalf("Unexpected error creating the claim: %v", err)
+	}
+
+	claims := fakeDynClient.Resource(claimGVR).Namespace("default")
+	claim, err := claims.Get(context.Background(), "endpointslice-test-eps-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get the created claim: %v", err)
+	}
+	if err := unstructured.SetNestedSlice(claim.Object, []interface{}{
+		map[string]interface{}{
+			"type":   string(quotav1alpha1.ResourceClaimGranted),
+			"status": string(metav1.ConditionTrue),
+			"reason": "QuotaAvailable",
+		},
+	}, "status", "conditions"); err != nil {
+		t.Fatalf("Failed to set claim status: %v", err)
+	}
+	if _, err := claims.Update(context.Background(), claim, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to grant the claim: %v", err)
+	}
+
+	// The granted claim produces no further watch events, so the retry must use its status
+	plugin.watchManagers.Store("", &testWatchManager{behavior: "hang"})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := plugin.Validate(ctx, attrs, nil); err != nil {
+		t.Fatalf("Expected the retried request to be admitted on the existing claim, got: %v", err)
+	}
+
+	// A claim of the same name created for another resource is not adopted
+	if err := unstructured.SetNestedField(claim.Object, "other-eps", "spec", "resourceRef", "name"); err != nil {
+		t.Fatalf("Failed to set claim resourceRef: %v", err)
+	}
+	if _, err := claims.Update(context.Background(), claim, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update the claim: %v", err)
+	}
+	if err := plugin.Validate(ctx, attrs, nil); err == nil {
+		t.Fatal("Expected an error when the existing claim belongs to a different resource")
+	}
+}
+
+// TestDenialMessageTemplate verifies that a denial is reported with the message rendered from
+// the denied resource type's registration, and with the default message otherwise.
+func TestDenialMessageTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		templates      map[string]string
+		expectedSubstr string
+	}{
+		{
+			name: "custom message with the available count",
+			templates: map[string]string{
+				"networking.datumapis.com/httpproxies": "Only {{ .Available }} of {{ .Limit }} HTTP proxies remain, {{ .Requested }} were requested.",
+			},
+			expectedSubstr: "Only 2 of 5 HTTP proxies remain, 3 were requested.",
+		},
+		{
+			name:           "default message without a template",
+			expectedSubstr: "Insufficient quota resources available",
+		},
+		{
+			name: "default message when the template fails to render",
+			templates: map[string]string{
+				"networking.datumapis.com/httpproxies": "{{ .Remaining }} remain",
+			},
+			expectedSubstr: "Insufficient quota resources available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			policy := newDeterministicClaimPolicy()
+			gvk := endpointSliceGVK()
+
+			resourceTypes := &testResourceTypeValidator{
+				validResourceTypes:     map[string]bool{"discovery.miloapis.com/endpointslices": true},
+				denialMessageTemplates: tt.templates,
+			}
+
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:               admission.NewHandler(admission.Create),
+				dynamicClient:         fakeDynClient,
+				policyEngine:          &testPolicyEngine{policy: policy, gvk: gvk},
+				templateEngine:        engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				resourceTypeValidator: resourceTypes,
+				config:                DefaultAdmissionPluginConfig(),
+				logger:                logger.WithName("plugin"),
+			}
+			plugin.watchManagers.Store("", &testWatchManager{behavior: "deny-details"})
+
+			err = plugin.Validate(context.Background(), newEndpointSliceAttrs(newEndpointSliceObject(), gvk), nil)
+			if err == nil {
+				t.Fatal("Expected the request to be denied")
+			}
+			if !strings.Contains(err.Error(), tt.expectedSubstr) {
+				t.Errorf("Expected error to contain %q, got: %v", tt.expectedSubstr, err)
+			}
+		})
+	}
+}
+
+func TestQuotaDenialRecordsEvents(t *testing.T) {
+	tests := []struct {
+		name           string
+		projectID      string
+		watchBehavior  string
+		expectedEvents int
+	}{
+		{
+			name:           "denied in root control plane",
+			watchBehavior:  "deny-partial",
+			expectedEvents: 2,
+		},
+		{
+			name:           "denied in project control plane",
+			projectID:      "test-project",
+			watchBehavior:  "deny-partial",
+			expectedEvents: 1,
+		},
+		{
+			name:          "granted",
+			watchBehavior: "grant",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			gvk := endpointSliceGVK()
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: newDeterministicClaimPolicy(), gvk: gvk},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetEventRecorder(recorder)
+			plugin.watchManagers.Store(tt.projectID, &testWatchManager{behavior: tt.watchBehavior})
+			plugin.projectClients.Store("test-project", &projectClient{client: fakeDynClient})
+
+			ctx := context.Background()
+			if tt.projectID != "" {
+				ctx = milorequest.WithProject(ctx, tt.projectID)
+			}
+
+			obj := newEndpointSliceObject()
+			_ = plugin.Validate(ctx, newEndpointSliceAttrs(obj, gvk), nil)
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			if len(events) != tt.expectedEvents {
+				t.Fatalf("Expected %d events, got %d: %v", tt.expectedEvents, len(events), events)
+			}
+
+			bucketName := bucketutil.Name("networking.datumapis.com/httpproxies", quotav1alpha1.ConsumerRef{
+				APIGroup: "resourcemanager.miloapis.com",
+				Kind:     "Project",
+				Name:     "test-project",
+			})
+			for _, event := range events {
+				if !contains(event, "Warning QuotaExceeded") || !contains(event, "EndpointSlice default/test-eps-1") ||
+					!contains(event, "networking.datumapis.com/httpproxies in AllowanceBucket "+bucketName) {
+					t.Errorf("Unexpected event: %s", event)
+				}
+			}
+		})
+	}
+}
+
+// capturingDecisionSink records decisions for assertions.
+type capturingDecisionSink struct {
+	records []DecisionRecord
+}
+
+func (s *capturingDecisionSink) Record(_ context.Context, record DecisionRecord) {
+	s.records = append(s.records, record)
+}
+
+func TestQuotaDecisionsWrittenToSink(t *testing.T) {
+	disabledPolicy := newDeterministicClaimPolicy()
+	disabledPolicy.Spec.Disabled = ptr.To(true)
+
+	constrainedPolicy := newDeterministicClaimPolicy()
+	constrainedPolicy.Spec.Trigger.Constraints = []quotav1alpha1.ConditionExpression{
+		{Expression: `trigger.metadata.name == "other"`},
+	}
+
+	tests := []struct {
+		name             string
+		policy           *quotav1alpha1.ClaimCreationPolicy
+		policyGVK        schema.GroupVersionKind
+		watchBehavior    string
+		dryRun           bool
+		expectedDecision Decision
+		expectedReason   string
+		expectedPolicy   string
+		expectedDenied   []string
+	}{
+		{
+			name:             "granted",
+			policy:           newDeterministicClaimPolicy(),
+			policyGVK:        endpointSliceGVK(),
+			watchBehavior:    "grant",
+			expectedDecision: DecisionGranted,
+			expectedPolicy:   "endpointslice-quota-policy",
+		},
+		{
+			name:             "denied",
+			policy:           newDeterministicClaimPolicy(),
+			policyGVK:        endpointSliceGVK(),
+			watchBehavior:    "deny-partial",
+			expectedDecision: DecisionDenied,
+			expectedReason:   quotav1alpha1.ResourceClaimDeniedReason,
+			expectedPolicy:   "endpointslice-quota-policy",
+			expectedDenied:   []string{"networking.datumapis.com/httpproxies"},
+		},
+		{
+			name:             "exempt as dry run",
+			policy:           newDeterministicClaimPolicy(),
+			policyGVK:        endpointSliceGVK(),
+			dryRun:           true,
+			expectedDecision: DecisionExempt,
+			expectedReason:   DecisionReasonDryRun,
+		},
+		{
+			name:             "exempt by disabled policy",
+			policy:           disabledPolicy,
+			policyGVK:        endpointSliceGVK(),
+			expectedDecision: DecisionExempt,
+			expectedReason:   DecisionReasonPolicyDisabled,
+			expectedPolicy:   "endpointslice-quota-policy",
+		},
+		{
+			name:             "skipped without policy",
+			expectedDecision: DecisionSkipped,
+			expectedReason:   DecisionReasonNoPolicy,
+		},
+		{
+			name:             "skipped when constraints not met",
+			policy:           constrainedPolicy,
+			policyGVK:        endpointSliceGVK(),
+			expectedDecision: DecisionSkipped,
+			expectedReason:   DecisionReasonConstraintsNotMet,
+			expectedPolicy:   "endpointslice-quota-policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: tt.policy, gvk: tt.policyGVK},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: tt.watchBehavior})
+
+			attrs := newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK())
+			attrs.dryRun = tt.dryRun
+			_ = plugin.Validate(context.Background(), attrs, nil)
+
+			if len(sink.records) != 1 {
+				t.Fatalf("Expected 1 decision record, got %d: %+v", len(sink.records), sink.records)
+			}
+			record := sink.records[0]
+			if record.Decision != tt.expectedDecision || record.Reason != tt.expectedReason {
+				t.Errorf("Expected decision %s/%s, got %s/%s", tt.expectedDecision, tt.expectedReason, record.Decision, record.Reason)
+			}
+			if record.Policy != tt.expectedPolicy {
+				t.Errorf("Expected policy %q, got %q", tt.expectedPolicy, record.Policy)
+			}
+			if record.Kind != "EndpointSlice" || record.Namespace != "default" || record.Name != "test-eps-1" || record.User != "test-user" {
+				t.Errorf("Unexpected resource details in record: %+v", record)
+			}
+
+			var denied []string
+			for _, request := range record.DeniedRequests {
+				denied = append(denied, request.ResourceType)
+			}
+			if !reflect.DeepEqual(denied, tt.expectedDenied) {
+				t.Errorf("Expected denied requests %v, got %v", tt.expectedDenied, denied)
+			}
+		})
+	}
+}
+
+func TestWriterDecisionSinkWritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterDecisionSink(&buf)
+	sink.Record(context.Background(), DecisionRecord{Decision: DecisionGranted, Kind: "EndpointSlice", Name: "a"})
+	sink.Record(context.Background(), DecisionRecord{Decision: DecisionSkipped, Reason: DecisionReasonNoPolicy, Kind: "EndpointSlice", Name: "b"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	var record DecisionRecord
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+		t.Fatalf("Failed to decode record: %v", err)
+	}
+	if record.Decision != DecisionSkipped || record.Reason != DecisionReasonNoPolicy || record.Name != "b" {
+		t.Errorf("Unexpected decoded record: %+v", record)
+	}
+}
+
+func TestTriggerSelectors(t *testing.T) {
+	labeledEndpointSlice := func(labels map[string]string) *unstructured.Unstructured {
+		obj := newEndpointSliceObject()
+		obj.SetLabels(labels)
+		return obj
+	}
+	premiumNamespace := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name":   "default",
+				"labels": map[string]interface{}{"tier": "premium"},
+			},
+		},
+	}
+	tierSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "premium"}}
+
+	tests := []struct {
+		name              string
+		namespaceSelector *metav1.LabelSelector
+		objectSelector    *metav1.LabelSelector
+		object            *unstructured.Unstructured
+		namespaces        []runtime.Object
+		expectedDecision  Decision
+		expectedReason    string
+	}{
+		{
+			name:             "object labels match",
+			objectSelector:   tierSelector,
+			object:           labeledEndpointSlice(map[string]string{"tier": "premium"}),
+			expectedDecision: DecisionGranted,
+		},
+		{
+			name:             "object labels do not match",
+			objectSelector:   tierSelector,
+			object:           labeledEndpointSlice(map[string]string{"tier": "free"}),
+			expectedDecision: DecisionSkipped,
+			expectedReason:   DecisionReasonSelectorsNotMatched,
+		},
+		{
+			name: "object without labels does not match expression",
+			objectSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpExists},
+			}},
+			object:           newEndpointSliceObject(),
+			expectedDecision: DecisionSkipped,
+			expectedReason:   DecisionReasonSelectorsNotMatched,
+		},
+		{
+			name:              "namespace labels match",
+			namespaceSelector: tierSelector,
+			object:            newEndpointSliceObject(),
+			namespaces:        []runtime.Object{premiumNamespace},
+			expectedDecision:  DecisionGranted,
+		},
+		{
+			name:              "namespace labels do not match",
+			namespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "free"}},
+			object:            newEndpointSliceObject(),
+			namespaces:        []runtime.Object{premiumNamespace},
+			expectedDecision:  DecisionSkipped,
+			expectedReason:    DecisionReasonSelectorsNotMatched,
+		},
+		{
+			name:              "namespace lookup failure skips the policy",
+			namespaceSelector: tierSelector,
+			object:            newEndpointSliceObject(),
+			expectedDecision:  DecisionSkipped,
+			expectedReason:    DecisionReasonSelectorEvaluationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme, tt.namespaces...)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			policy := newDeterministicClaimPolicy()
+			policy.Spec.Trigger.NamespaceSelector = tt.namespaceSelector
+			policy.Spec.Trigger.ObjectSelector = tt.objectSelector
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: policy, gvk: endpointSliceGVK()},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+			if err := plugin.Validate(context.Background(), newEndpointSliceAttrs(tt.object, endpointSliceGVK()), nil); err != nil {
+				t.Fatalf("expected admission to succeed, got %v", err)
+			}
+
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+			}
+			record := sink.records[0]
+			if record.Decision != tt.expectedDecision {
+				t.Errorf("expected decision %s, got %s", tt.expectedDecision, record.Decision)
+			}
+			if record.Reason != tt.expectedReason {
+				t.Errorf("expected reason %q, got %q", tt.expectedReason, record.Reason)
+			}
+		})
+	}
+}
+
+// TestTriggerSubresource verifies that a policy only enforces quota on requests for the
+// subresource it names, and that a policy without one keeps matching creates only.
+// Requests for other subresources are ignored without a decision record, even while
+// the policy is not ready.
+func TestTriggerSubresource(t *testing.T) {
+	scaleGVK := schema.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "Scale"}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	tests := []struct {
+		name              string
+		policySubresource string
+		unready           bool
+		operation         admission.Operation
+		subresource       string
+		expectedDecision  Decision
+		expectedReason    string
+	}{
+		{
+			name:              "scale update matches scale policy",
+			policySubresource: "scale",
+			operation:         admission.Update,
+			subresource:       "scale",
+			expectedDecision:  DecisionGranted,
+		},
+		{
+			name:              "status update does not match scale policy",
+			policySubresource: "scale",
+			operation:         admission.Update,
+			subresource:       "status",
+		},
+		{
+			name:              "status update is ignored while the policy is not ready",
+			policySubresource: "scale",
+			unready:           true,
+			operation:         admission.Update,
+			subresource:       "status",
+		},
+		{
+			name:              "scale update matches a scale policy that is not ready",
+			policySubresource: "scale",
+			unready:           true,
+			operation:         admission.Update,
+			subresource:       "scale",
+			expectedDecision:  DecisionSkipped,
+			expectedReason:    DecisionReasonPolicyNotReady,
+		},
+		{
+			name:              "create does not match scale policy",
+			policySubresource: "scale",
+			operation:         admission.Create,
+			expectedDecision:  DecisionSkipped,
+			expectedReason:    DecisionReasonSubresourceNotMatched,
+		},
+		{
+			name:        "scale update does not match policy without subresource",
+			operation:   admission.Update,
+			subresource: "scale",
+		},
+		{
+			name:             "create matches policy without subresource",
+			operation:        admission.Create,
+			expectedDecision: DecisionGranted,
+		},
+		{
+			name:      "update of the resource itself is not enforced",
+			operation: admission.Update,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme, newTestDeployment())
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			policy := newDeterministicClaimPolicy()
+			policy.Spec.Trigger.Resource = &quotav1alpha1.ClaimTriggerResource{APIVersion: "autoscaling/v1", Kind: "Scale"}
+			policy.Spec.Trigger.Subresource = tt.policySubresource
+			policyEngine := &testPolicyEngine{policy: policy, gvk: scaleGVK}
+			if tt.unready {
+				policyEngine = &testPolicyEngine{unready: policy}
+			}
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create, admission.Update),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   policyEngine,
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+			attrs := &testAdmissionAttributes{
+				operation:   tt.operation,
+				object:      newScaleObject(3),
+				oldObject:   newScaleObject(1),
+				gvk:         scaleGVK,
+				name:        "web",
+				namespace:   "default",
+				userInfo:    &user.DefaultInfo{Name: "test-user"},
+				subResource: tt.subresource,
+				resource:    deployments,
+			}
+			if err := plugin.Validate(context.Background(), attrs, nil); err != nil {
+				t.Fatalf("expected admission to succeed, got %v", err)
+			}
+
+			if tt.expectedDecision == "" {
+				if len(sink.records) != 0 {
+					t.Fatalf("expected the request to be ignored, got %+v", sink.records)
+				}
+				return
+			}
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+			}
+			record := sink.records[0]
+			if record.Decision != tt.expectedDecision {
+				t.Errorf("expected decision %s, got %s", tt.expectedDecision, record.Decision)
+			}
+			if record.Reason != tt.expectedReason {
+				t.Errorf("expected reason %q, got %q", tt.expectedReason, record.Reason)
+			}
+		})
+	}
+}
+
+func newTestDeployment() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "web", "namespace": "default", "uid": "web-uid"},
+		},
+	}
+}
+
+func newScaleObject(replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "autoscaling/v1",
+			"kind":       "Scale",
+			"metadata":   map[string]interface{}{"name": "web", "namespace": "default"},
+			"spec":       map[string]interface{}{"replicas": replicas},
+		},
+	}
+}
+
+// TestScaleClaimsFollowReplicas verifies that a scale-up is charged one claim per added
+// replica, owned by the scaled object, and that scaling down releases the claims of the
+// removed replicas.
+func TestScaleClaimsFollowReplicas(t *testing.T) {
+	scaleGVK := schema.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "Scale"}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	scheme := runtime.NewScheme()
+	quotav1alpha1.AddToScheme(scheme)
+	fakeDynClient := fake.NewSimpleDynamicClient(scheme, newTestDeployment())
+
+	logger := zap.New(zap.UseDevMode(true))
+	celEngine, err := engine.NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+
+	policy := newDeterministicClaimPolicy()
+	policy.Spec.Trigger.Resource = &quotav1alpha1.ClaimTriggerResource{APIVersion: "autoscaling/v1", Kind: "Scale"}
+	policy.Spec.Trigger.Subresource = "scale"
+
+	watchManager := &testWatchManager{behavior: "grant"}
+	plugin := &ResourceQuotaEnforcementPlugin{
+		Handler:        admission.NewHandler(admission.Create, admission.Update),
+		dynamicClient:  fakeDynClient,
+		policyEngine:   &testPolicyEngine{policy: policy, gvk: scaleGVK},
+		templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+		config:         DefaultAdmissionPluginConfig(),
+		logger:         logger.WithName("plugin"),
+	}
+	plugin.watchManagers.Store("", watchManager)
+
+	scaleTo := func(from, to int64) error {
+		return plugin.Validate(context.Background(), &testAdmissionAttributes{
+			operation:   admission.Update,
+			object:      newScaleObject(to),
+			oldObject:   newScaleObject(from),
+			gvk:         scaleGVK,
+			name:        "web",
+			namespace:   "default",
+			userInfo:    &user.DefaultInfo{Name: "test-user"},
+			subResource: "scale",
+			resource:    deployments,
+		}, nil)
+	}
+	assertClaims := func(t *testing.T, want ...string) {
+		t.Helper()
+		list, err := fakeDynClient.Resource(resourceClaimsGVR).Namespace("default").List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("failed to list claims: %v", err)
+		}
+		var got []string
+		for _, claim := range list.Items {
+			got = append(got, claim.GetName())
+			owners := claim.GetOwnerReferences()
+			if len(owners) != 1 || owners[0].Kind != "Deployment" || owners[0].UID != "web-uid" {
+				t.Errorf("claim %s owner references = %+v, want the Deployment", claim.GetName(), owners)
+			}
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("claims = %v, want %v", got, want)
+		}
+	}
+
+	// Replicas the Deployment was created with are not charged by the scale policy
+	if err := scaleTo(1, 3); err != nil {
+		t.Fatalf("scale up failed: %v", err)
+	}
+	assertClaims(t, "web-deployments-scale-2", "web-deployments-scale-3")
+
+	if err := scaleTo(3, 2); err != nil {
+		t.Fatalf("scale down failed: %v", err)
+	}
+	assertClaims(t, "web-deployments-scale-2")
+
+	// A scale-up that is denied part way keeps none of the claims it made
+	watchManager.behavior = "deny-partial"
+	if err := scaleTo(2, 4); err == nil {
+		t.Fatal("expected the denied scale-up to be rejected")
+	}
+	assertClaims(t, "web-deployments-scale-2")
+}
+
+func TestUnresolvedClaimsAreRetryable(t *testing.T) {
+	tests := []struct {
+		name                 string
+		watchBehavior        string
+		expectedReason       string
+		expectedResultReason string
+		expectedSubstr       string
+	}{
+		{
+			name:                 "granting paused",
+			watchBehavior:        "paused",
+			expectedReason:       DecisionReasonGrantingPaused,
+			expectedResultReason: resultReasonGrantingPaused,
+			expectedSubstr:       "paused for maintenance",
+		},
+		{
+			name:                 "claim wait timed out",
+			watchBehavior:        "expire",
+			expectedReason:       DecisionReasonClaimTimeout,
+			expectedResultReason: resultReasonTimeout,
+			expectedSubstr:       "did not complete in time",
+		},
+		{
+			name:                 "waiter limit reached",
+			watchBehavior:        "saturated",
+			expectedReason:       DecisionReasonWaiterLimitReached,
+			expectedResultReason: resultReasonWaiterLimit,
+			expectedSubstr:       "too many requests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: newDeterministicClaimPolicy(), gvk: endpointSliceGVK()},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: tt.watchBehavior})
+
+			resultCounter := admissionResultTotal.WithLabelValues("pending", tt.expectedResultReason,
+				"endpointslice-quota-policy", "", "discovery.k8s.io", "EndpointSlice")
+			before, _ := testutil.GetCounterMetricValue(resultCounter)
+
+			err = plugin.Validate(context.Background(), newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil)
+			if err == nil {
+				t.Fatal("expected a retryable error")
+			}
+			if after, _ := testutil.GetCounterMetricValue(resultCounter); after-before != 1 {
+				t.Errorf("expected admission_result_total{result=pending,reason=%s} to increase by 1, got %v", tt.expectedResultReason, after-before)
+			}
+			if apierrors.IsForbidden(err) {
+				t.Fatalf("expected the request not to be denied for quota, got %v", err)
+			}
+			if !apierrors.IsServiceUnavailable(err) {
+				t.Errorf("expected a ServiceUnavailable error, got %v", err)
+			}
+			if seconds, ok := apierrors.SuggestsClientDelay(err); !ok || seconds != 10 {
+				t.Errorf("expected a 10s retry-after, got %d (ok=%v)", seconds, ok)
+			}
+			if !contains(err.Error(), tt.expectedSubstr) {
+				t.Errorf("expected error to contain %q, got %v", tt.expectedSubstr, err)
+			}
+
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+			}
+			if sink.records[0].Decision != DecisionPending || sink.records[0].Reason != tt.expectedReason {
+				t.Errorf("expected decision Pending/%s, got %s/%s", tt.expectedReason, sink.records[0].Decision, sink.records[0].Reason)
+			}
+		})
+	}
+}
+
+// TestConsumerWithoutGrantsFailsFast verifies that a claim for a consumer whose bucket shows no
+// ResourceGrant is denied right away instead of after the claim wait.
+func TestConsumerWithoutGrantsFailsFast(t *testing.T) {
+	consumerRef := quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Project", Name: "test-project"}
+	newBucket := func(grantCount int32) *quotav1alpha1.AllowanceBucket {
+		return &quotav1alpha1.AllowanceBucket{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      bucketutil.Name("discovery.miloapis.com/endpointslices", consumerRef),
+				Namespace: bucketutil.Namespace(consumerRef),
+			},
+			Spec: quotav1alpha1.AllowanceBucketSpec{
+				ConsumerRef:  consumerRef,
+				ResourceType: "discovery.miloapis.com/endpointslices",
+			},
+			Status: quotav1alpha1.AllowanceBucketStatus{
+				GrantCount:         grantCount,
+				Limit:              int64(grantCount),
+				LastReconciliation: &metav1.Time{Time: time.Now()},
+			},
+		}
+	}
+
+	newProject := func(override string) *unstructured.Unstructured {
+		project := &unstructured.Unstructured{}
+		project.SetAPIVersion("resourcemanager.miloapis.com/v1alpha1")
+		project.SetKind("Project")
+		project.SetName("test-project")
+		project.SetAnnotations(map[string]string{quotav1alpha1.QuotaOverrideAnnotation: override})
+		return project
+	}
+
+	tests := []struct {
+		name           string
+		bucket         *quotav1alpha1.AllowanceBucket
+		project        *unstructured.Unstructured
+		expectDecision Decision
+		expectReason   string
+	}{
+		{
+			name:           "no grants",
+			bucket:         newBucket(0),
+			expectDecision: DecisionDenied,
+			expectReason:   DecisionReasonNoQuotaAllocated,
+		},
+		{
+			name:           "no grants with override for another resource type",
+			bucket:         newBucket(0),
+			project:        newProject("resourcemanager.miloapis.com/projects"),
+			expectDecision: DecisionDenied,
+			expectReason:   DecisionReasonNoQuotaAllocated,
+		},
+		{
+			// The override grants the claim without any grant, so it must be waited on
+			name:           "no grants with quota override",
+			bucket:         newBucket(0),
+			project:        newProject("resourcemanager.miloapis.com/projects, discovery.miloapis.com/endpointslices"),
+			expectDecision: DecisionPending,
+			expectReason:   DecisionReasonClaimTimeout,
+		},
+		{
+			name:           "grants exist",
+			bucket:         newBucket(1),
+			expectDecision: DecisionPending,
+			expectReason:   DecisionReasonClaimTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			objects := []runtime.Object{tt.bucket}
+			if tt.project != nil {
+				objects = append(objects, tt.project)
+			}
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme, objects...)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: newDeterministicClaimPolicy(), gvk: endpointSliceGVK()},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			// The claim is never resolved, so only the request deadline ends a wait
+			plugin.watchManagers.Store("", &testWatchManager{behavior: "hang"})
+
+			resultCounter := admissionResultTotal.WithLabelValues("denied", resultReasonNoGrant,
+				"endpointslice-quota-policy", "", "discovery.k8s.io", "EndpointSlice")
+			before, _ := testutil.GetCounterMetricValue(resultCounter)
+
+			const requestTimeout = 500 * time.Millisecond
+			ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err = plugin.Validate(ctx, newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil)
+			elapsed := time.Since(start)
+			if err == nil {
+				t.Fatal("expected the request to be rejected")
+			}
+
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+			}
+			if sink.records[0].Decision != tt.expectDecision || sink.records[0].Reason != tt.expectReason {
+				t.Errorf("expected decision %s/%s, got %s/%s", tt.expectDecision, tt.expectReason, sink.records[0].Decision, sink.records[0].Reason)
+			}
+			if tt.expectDecision != DecisionDenied {
+				return
+			}
+
+			if elapsed >= requestTimeout {
+				t.Errorf("expected the request to be denied before the claim wait ended, took %v", elapsed)
+			}
+			if !apierrors.IsForbidden(err) {
+				t.Errorf("expected a Forbidden error, got %v", err)
+			}
+			if !contains(err.Error(), "no quota allocated for discovery.miloapis.com/endpointslices; contact an administrator") {
+				t.Errorf("expected the error to name the resource type without quota, got %v", err)
+			}
+			if after, _ := testutil.GetCounterMetricValue(resultCounter); after-before != 1 {
+				t.Errorf("expected admission_result_total{result=denied,reason=%s} to increase by 1, got %v", resultReasonNoGrant, after-before)
+			}
+		})
+	}
+}
+
+// TestCreateOfExistingObjectIsNotCharged verifies that repeating the create of an object
+// waits on the claim charged by the first create instead of charging a second claim.
+func TestCreateOfExistingObjectIsNotCharged(t *testing.T) {
+	claimGVR := schema.GroupVersionResource{Group: "quota.miloapis.com", Version: "v1alpha1", Resource: "resourceclaims"}
+
+	scheme := runtime.NewScheme()
+	quotav1alpha1.AddToScheme(scheme)
+	fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+	logger := zap.New(zap.UseDevMode(true))
+	celEngine, err := engine.NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+
+	sink := &capturingDecisionSink{}
+	plugin := &ResourceQuotaEnforcementPlugin{
+		Handler:        admission.NewHandler(admission.Create),
+		dynamicClient:  fakeDynClient,
+		policyEngine:   &testPolicyEngine{policy: newDeterministicClaimPolicy(), gvk: endpointSliceGVK()},
+		templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+		config:         DefaultAdmissionPluginConfig(),
+		logger:         logger.WithName("plugin"),
+	}
+	plugin.SetDecisionSink(sink)
+	plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+	attrs := newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK())
+	for i := 0; i < 2; i++ {
+		if err := plugin.Validate(context.Background(), attrs, nil); err != nil {
+			t.Fatalf("create %d: expected the request to be admitted, got %v", i+1, err)
+		}
+	}
+
+	claims, err := fakeDynClient.Resource(claimGVR).Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list claims: %v", err)
+	}
+	if len(claims.Items) != 1 {
+		t.Errorf("expected the repeated create to reuse its claim, got %d claims", len(claims.Items))
+	}
+	for _, action := range fakeDynClient.Actions() {
+		if action.GetResource().Resource == "endpointslices" {
+			t.Errorf("expected no lookup of the object being created, got %s", action.GetVerb())
+		}
+	}
+	if len(sink.records) != 2 {
+		t.Fatalf("expected 2 decision records, got %d", len(sink.records))
+	}
+	for _, record := range sink.records {
+		if record.Decision != DecisionGranted {
+			t.Errorf("expected decision %s, got %s/%s", DecisionGranted, record.Decision, record.Reason)
+		}
+	}
+}
+
+// TestCreateOfExistingObjectWithGeneratedClaimNameIsNotCharged verifies that a policy
+// whose claim name is generated looks the object up, since a repeated create would not
+// collide with the claim of the first one.
+func TestCreateOfExistingObjectWithGeneratedClaimNameIsNotCharged(t *testing.T) {
+	claimGVR := schema.GroupVersionResource{Group: "quota.miloapis.com", Version: "v1alpha1", Resource: "resourceclaims"}
+	endpointSlicesGVR := schema.GroupVersionResource{Group: "discovery.k8s.io", Version: "v1", Resource: "endpointslices"}
+
+	tests := []struct {
+		name         string
+		existing     bool
+		wantClaims   int
+		wantDecision Decision
+		wantReason   string
+	}{
+		{
+			name:         "object already exists",
+			existing:     true,
+			wantDecision: DecisionExempt,
+			wantReason:   DecisionReasonAlreadyExists,
+		},
+		{
+			name:         "object does not exist",
+			wantClaims:   1,
+			wantDecision: DecisionGranted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			var objects []runtime.Object
+			if tt.existing {
+				objects = append(objects, newEndpointSliceObject())
+			}
+			fakeDynClient := fake.NewSimpleDynamicClientWithCustomListKinds(scheme,
+				map[schema.GroupVersionResource]string{endpointSlicesGVR: "EndpointSliceList"}, objects...)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			policy := newDeterministicClaimPolicy()
+			policy.Spec.Target.ResourceClaimTemplate.Metadata = quotav1alpha1.ObjectMetaTemplate{
+				GenerateName: "endpointslice-{{ trigger.metadata.name }}-",
+			}
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: policy, gvk: endpointSliceGVK()},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+			attrs := newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK())
+			attrs.resource = endpointSlicesGVR
+			if err := plugin.Validate(context.Background(), attrs, nil); err != nil {
+				t.Fatalf("expected the request to be admitted, got %v", err)
+			}
+
+			claims := 0
+			for _, action := range fakeDynClient.Actions() {
+				if action.GetVerb() == "create" && action.GetResource() == claimGVR {
+					claims++
+				}
+			}
+			if claims != tt.wantClaims {
+				t.Errorf("expected %d claims to be created, got %d", tt.wantClaims, claims)
+			}
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+			}
+			if record := sink.records[0]; record.Decision != tt.wantDecision || record.Reason != tt.wantReason {
+				t.Errorf("expected decision %s/%s, got %s/%s", tt.wantDecision, tt.wantReason, record.Decision, record.Reason)
+			}
+		})
+	}
+}
+
+// TestZeroAmountIsNotCharged verifies that a request whose amount expression evaluates
+// to zero is left out of the claim, and that nothing is claimed when no request remains.
+func TestZeroAmountIsNotCharged(t *testing.T) {
+	scheme := runtime.NewScheme()
+	quotav1alpha1.AddToScheme(scheme)
+	fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+	logger := zap.New(zap.UseDevMode(true))
+	celEngine, err := engine.NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+
+	policy := newDeterministicClaimPolicy()
+	policy.Spec.Target.ResourceClaimTemplate.Spec.Requests[0].Amount = 0
+	policy.Spec.Target.ResourceClaimTemplate.Spec.Requests[0].AmountExpression = "has(trigger.endpoints) ? size(trigger.endpoints) : 0"
+
+	sink := &capturingDecisionSink{}
+	plugin := &ResourceQuotaEnforcementPlugin{
+		Handler:        admission.NewHandler(admission.Create),
+		dynamicClient:  fakeDynClient,
+		policyEngine:   &testPolicyEngine{policy: policy, gvk: endpointSliceGVK()},
+		templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+		config:         DefaultAdmissionPluginConfig(),
+		logger:         logger.WithName("plugin"),
+	}
+	plugin.SetDecisionSink(sink)
+	plugin.watchManagers.Store("", &testWatchManager{behavior: "deny"})
+
+	attrs := newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK())
+	if err := plugin.Validate(context.Background(), attrs, nil); err != nil {
+		t.Fatalf("expected the request to be admitted, got %v", err)
+	}
+
+	for _, action := range fakeDynClient.Actions() {
+		if action.GetVerb() == "create" && action.GetResource().Resource == "resourceclaims" {
+			t.Error("expected no ResourceClaim to be created for a zero amount")
+		}
+	}
+	if len(sink.records) != 1 {
+		t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+	}
+	if sink.records[0].Decision != DecisionExempt || sink.records[0].Reason != DecisionReasonNothingToClaim {
+		t.Errorf("expected decision %s/%s, got %s/%s", DecisionExempt, DecisionReasonNothingToClaim, sink.records[0].Decision, sink.records[0].Reason)
+	}
+}
+
+func TestRegistrationDefaultClaim(t *testing.T) {
+	scheme := runtime.NewScheme()
+	quotav1alpha1.AddToScheme(scheme)
+	fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+	logger := zap.New(zap.UseDevMode(true))
+	celEngine, err := engine.NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+
+	sink := &capturingDecisionSink{}
+	plugin := &ResourceQuotaEnforcementPlugin{
+		Handler:        admission.NewHandler(admission.Create),
+		dynamicClient:  fakeDynClient,
+		policyEngine:   &testPolicyEngine{},
+		templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+		resourceTypeValidator: &testResourceTypeValidator{
+			validResourceTypes: map[string]bool{"discovery.k8s.io/endpointslices": true},
+			defaultClaims: map[schema.GroupKind]validation.DefaultClaim{
+				{Group: "discovery.k8s.io", Kind: "EndpointSlice"}: {
+					RegistrationName: "endpointslices-per-project",
+					ResourceType:     "discovery.k8s.io/endpointslices",
+					ConsumerType:     quotav1alpha1.ConsumerType{APIGroup: "resourcemanager.miloapis.com", Kind: "Project"},
+					ConsumerName:     "{{trigger.metadata.namespace}}-project",
+					Amount:           1,
+				},
+			},
+		},
+		config: DefaultAdmissionPluginConfig(),
+		logger: logger.WithName("plugin"),
+	}
+	plugin.SetDecisionSink(sink)
+	plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+	if err := plugin.Validate(context.Background(), newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil); err != nil {
+		t.Fatalf("expected the request to be admitted, got %v", err)
+	}
+
+	claims, err := fakeDynClient.Resource(schema.GroupVersionResource{Group: "quota.miloapis.com", Version: "v1alpha1", Resource: "resourceclaims"}).
+		Namespace("default").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list claims: %v", err)
+	}
+	if len(claims.Items) != 1 {
+		t.Fatalf("expected 1 claim created from the registration, got %d", len(claims.Items))
+	}
+
+	var claim quotav1alpha1.ResourceClaim
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(claims.Items[0].Object, &claim); err != nil {
+		t.Fatalf("failed to convert claim: %v", err)
+	}
+	wantConsumer := quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Project", Name: "default-project"}
+	if claim.Spec.ConsumerRef != wantConsumer {
+		t.Errorf("claim consumerRef = %+v, want %+v", claim.Spec.ConsumerRef, wantConsumer)
+	}
+	wantRequests := []quotav1alpha1.ResourceRequest{{ResourceType: "discovery.k8s.io/endpointslices", Amount: 1}}
+	if !reflect.DeepEqual(claim.Spec.Requests, wantRequests) {
+		t.Errorf("claim requests = %+v, want %+v", claim.Spec.Requests, wantRequests)
+	}
+	if got := claim.Labels["quota.miloapis.com/policy"]; got != "endpointslices-per-project" {
+		t.Errorf("claim policy label = %q, want the registration name", got)
+	}
+
+	if len(sink.records) != 1 || sink.records[0].Decision != DecisionGranted {
+		t.Errorf("expected a single Granted decision, got %+v", sink.records)
+	}
+}
+
+func TestAdmissionResultReason(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "granted", want: resultReasonNone},
+		{name: "quota exceeded", err: &claimDeniedError{reason: "quota exceeded"}, want: resultReasonQuotaExceeded},
+		{name: "timed out", err: &claimPendingError{message: "timeout"}, want: resultReasonTimeout},
+		{name: "granting paused", err: &claimPendingError{paused: true}, want: resultReasonGrantingPaused},
+		{name: "waiter limit", err: &claimPendingError{saturated: true}, want: resultReasonWaiterLimit},
+		{name: "claim deleted", err: &claimDeletedError{namespace: "default", name: "claim"}, want: resultReasonClaimDeleted},
+		{name: "no grant", err: fmt.Errorf("failed to create ResourceClaim: %w", &noQuotaAllocatedError{resourceType: "apps/Deployment"}), want: resultReasonNoGrant},
+		{name: "wrapped creation failure", err: fmt.Errorf("failed to create ResourceClaim: %w", errors.New("boom")), want: resultReasonError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := admissionResultReason(tt.err); got != tt.want {
+				t.Errorf("admissionResultReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimTimeoutBehavior(t *testing.T) {
+	tests := []struct {
+		name            string
+		behavior        ClaimTimeoutBehavior
+		watchBehavior   string
+		requestTimeout  time.Duration
+		expectForbidden bool
+		expectDecision  Decision
+		expectReason    string
+		expectErrSubstr string
+	}{
+		{
+			name:            "timeout is retryable by default",
+			behavior:        ClaimTimeoutRetry,
+			watchBehavior:   "expire",
+			expectDecision:  DecisionPending,
+			expectReason:    DecisionReasonClaimTimeout,
+			expectErrSubstr: "did not complete in time",
+		},
+		{
+			name:            "request deadline is retryable",
+			behavior:        ClaimTimeoutRetry,
+			watchBehavior:   "hang",
+			requestTimeout:  50 * time.Millisecond,
+			expectDecision:  DecisionPending,
+			expectReason:    DecisionReasonClaimTimeout,
+			expectErrSubstr: "did not complete in time",
+		},
+		{
+			name:            "timeout denied when configured",
+			behavior:        ClaimTimeoutDeny,
+			watchBehavior:   "expire",
+			expectForbidden: true,
+			expectDecision:  DecisionDenied,
+			expectReason:    DecisionReasonClaimTimeout,
+			expectErrSubstr: "did not complete in time",
+		},
+		{
+			name:            "paused granting stays retryable when timeouts are denied",
+			behavior:        ClaimTimeoutDeny,
+			watchBehavior:   "paused",
+			expectDecision:  DecisionPending,
+			expectReason:    DecisionReasonGrantingPaused,
+			expectErrSubstr: "paused for maintenance",
+		},
+		{
+			name:            "waiter limit stays retryable when timeouts are denied",
+			behavior:        ClaimTimeoutDeny,
+			watchBehavior:   "saturated",
+			expectDecision:  DecisionPending,
+			expectReason:    DecisionReasonWaiterLimitReached,
+			expectErrSubstr: "too many requests",
+		},
+		{
+			name:            "genuine denial is not retryable",
+			behavior:        ClaimTimeoutRetry,
+			watchBehavior:   "deny-requests",
+			expectForbidden: true,
+			expectDecision:  DecisionDenied,
+			expectReason:    quotav1alpha1.ResourceClaimDeniedReason,
+			expectErrSubstr: "Insufficient quota resources available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			config := DefaultAdmissionPluginConfig()
+			config.ClaimTimeoutBehavior = tt.behavior
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: newDeterministicClaimPolicy(), gvk: endpointSliceGVK()},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         config,
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: tt.watchBehavior})
+
+			ctx := context.Background()
+			if tt.requestTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.requestTimeout)
+				defer cancel()
+			}
+
+			err = plugin.Validate(ctx, newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.expectForbidden {
+				if !apierrors.IsForbidden(err) {
+					t.Errorf("expected a Forbidden error, got %v", err)
+				}
+			} else if !apierrors.IsServiceUnavailable(err) {
+				t.Errorf("expected a ServiceUnavailable error, got %v", err)
+			}
+			if !contains(err.Error(), tt.expectErrSubstr) {
+				t.Errorf("expected error to contain %q, got %v", tt.expectErrSubstr, err)
+			}
+
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+			}
+			if sink.records[0].Decision != tt.expectDecision || sink.records[0].Reason != tt.expectReason {
+				t.Errorf("expected decision %s/%s, got %s/%s", tt.expectDecision, tt.expectReason, sink.records[0].Decision, sink.records[0].Reason)
+			}
+		})
+	}
+}
+
+func TestWatchManagerMaxWaiters(t *testing.T) {
+	scheme := runtime.NewScheme()
+	quotav1alpha1.AddToScheme(scheme)
+
+	config := DefaultWatchManagerConfig()
+	config.MaxWaiters = 2
+	wm := NewWatchManagerWithConfig(fake.NewSimpleDynamicClient(scheme), zap.New(zap.UseDevMode(true)), "", config).(*watchManager)
+	wm.started.Store(true)
+	defer wm.Stop()
+
+	ctx := context.Background()
+	_, cancelFirst, err := wm.RegisterClaimWaiter(ctx, "claim-1", "default", time.Minute)
+	if err != nil {
+		t.Fatalf("expected first waiter to register, got %v", err)
+	}
+	if _, _, err := wm.RegisterClaimWaiter(ctx, "claim-2", "default", time.Minute); err != nil {
+		t.Fatalf("expected second waiter to register, got %v", err)
+	}
+
+	_, _, err = wm.RegisterClaimWaiter(ctx, "claim-3", "default", time.Minute)
+	var pendingErr *claimPendingError
+	if !errors.As(err, &pendingErr) || !pendingErr.saturated {
+		t.Fatalf("expected a retryable waiter limit error, got %v", err)
+	}
+
+	// Resolving a waiter frees a slot for the next request
+	cancelFirst()
+	if _, _, err := wm.RegisterClaimWaiter(ctx, "claim-3", "default", time.Minute); err != nil {
+		t.Fatalf("expected waiter to register after a slot was freed, got %v", err)
+	}
+}
+
+const benchmarkConcurrentAdmissions = 100
+
+func newBenchmarkGrantedClaim(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": quotav1alpha1.GroupVersion.String(),
+		"kind":       "ResourceClaim",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{
+				"type":   string(quotav1alpha1.ResourceClaimGranted),
+				"status": string(metav1.ConditionTrue),
+				"reason": "QuotaAvailable",
+			}},
+		},
+	}}
+}
+
+// BenchmarkWatchManagerConcurrentAdmissions measures the watch connections a project's watch
+// manager opens while concurrent admissions wait for their ResourceClaims to be granted.
+func BenchmarkWatchManagerConcurrentAdmissions(b *testing.B) {
+	scheme := runtime.NewScheme()
+	if err := quotav1alpha1.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+	gvr := quotav1alpha1.GroupVersion.WithResource("resourceclaims")
+
+	var watches int
+	for i := 0; i < b.N; i++ {
+		client := fake.NewSimpleDynamicClient(scheme)
+		wm := NewWatchManager(client, zap.New(), "").(*watchManager)
+		if err := wm.Start(context.Background()); err != nil {
+			b.Fatalf("failed to start watch manager: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		for n := 0; n < benchmarkConcurrentAdmissions; n++ {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				resultChan, cancel, err := wm.RegisterClaimWaiter(context.Background(), name, "default", time.Minute)
+				if err != nil {
+					b.Errorf("failed to register waiter: %v", err)
+					return
+				}
+				defer cancel()
+				if _, err := client.Resource(gvr).Namespace("default").Create(context.Background(), newBenchmarkGrantedClaim(name), metav1.CreateOptions{}); err != nil {
+					b.Errorf("failed to create claim: %v", err)
+					return
+				}
+				if result := <-resultChan; !result.Granted {
+					b.Errorf("expected claim %s to be granted, got %+v", name, result)
+				}
+			}(fmt.Sprintf("claim-%03d", n))
+		}
+		wg.Wait()
+		wm.Stop()
+
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "watch" {
+				watches++
+			}
+		}
+	}
+
+	b.ReportMetric(float64(watches)/float64(b.N), "watches/op")
+}
+
+func TestPolicyThatCannotEnforceQuotaWarns(t *testing.T) {
+	unreadyPolicy := newDeterministicClaimPolicy()
+	unreadyPolicy.Status.Conditions = []metav1.Condition{{
+		Type:    quotav1alpha1.ClaimCreationPolicyReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  "ValidationFailed",
+		Message: "resource type is not registered",
+	}}
+
+	selectorPolicy := newDeterministicClaimPolicy()
+	selectorPolicy.Spec.Trigger.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "premium"}}
+
+	constraintPolicy := newDeterministicClaimPolicy()
+	constraintPolicy.Spec.Trigger.Constraints = []quotav1alpha1.ConditionExpression{
+		{Expression: "trigger.metadata.missing == 'value'"},
+	}
+
+	tests := []struct {
+		name           string
+		policyEngine   *testPolicyEngine
+		expectedReason string
+		expectedCause  string
+	}{
+		{
+			name:           "policy not ready",
+			policyEngine:   &testPolicyEngine{unready: unreadyPolicy},
+			expectedReason: DecisionReasonPolicyNotReady,
+			expectedCause:  "is not ready (ValidationFailed: resource type is not registered)",
+		},
+		{
+			name:           "selector evaluation failed",
+			policyEngine:   &testPolicyEngine{policy: selectorPolicy, gvk: endpointSliceGVK()},
+			expectedReason: DecisionReasonSelectorEvaluationFailed,
+			expectedCause:  "could not evaluate its trigger selectors",
+		},
+		{
+			name:           "constraint evaluation failed",
+			policyEngine:   &testPolicyEngine{policy: constraintPolicy, gvk: endpointSliceGVK()},
+			expectedReason: DecisionReasonConstraintEvaluationFailed,
+			expectedCause:  "could not evaluate its trigger constraints",
+		},
+	}
+
+	seen := make(map[string]string)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			sink := &capturingDecisionSink{}
+			watchManager := &testWatchManager{behavior: "grant"}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   tt.policyEngine,
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", watchManager)
+
+			recorder := &recordingWarningRecorder{}
+			ctx := warning.WithWarningRecorder(context.Background(), recorder)
+			if err := plugin.Validate(ctx, newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil); err != nil {
+				t.Fatalf("expected the resource to be admitted, got %v", err)
+			}
+
+			if len(recorder.warnings) != 1 {
+				t.Fatalf("expected 1 warning, got %v", recorder.warnings)
+			}
+			got := recorder.warnings[0]
+			if !strings.HasPrefix(got, "Quota was not enforced: ClaimCreationPolicy endpointslice-quota-policy "+tt.expectedCause) {
+				t.Errorf("unexpected warning %q", got)
+			}
+			if !strings.Contains(got, "created without a ResourceClaim") {
+				t.Errorf("expected warning to say no ResourceClaim was created, got %q", got)
+			}
+			if other, ok := seen[got]; ok {
+				t.Errorf("warning %q is identical to the one for %s", got, other)
+			}
+			seen[got] = tt.name
+
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+			}
+			record := sink.records[0]
+			if record.Decision != DecisionSkipped || record.Reason != tt.expectedReason {
+				t.Errorf("expected %s/%s, got %s/%s", DecisionSkipped, tt.expectedReason, record.Decision, record.Reason)
+			}
+			if record.Policy != "endpointslice-quota-policy" {
+				t.Errorf("expected decision to name the policy, got %q", record.Policy)
+			}
+
+			for _, action := range fakeDynClient.Actions() {
+				if action.GetVerb() == "create" && action.GetResource().Resource == "resourceclaims" {
+					t.Errorf("expected no ResourceClaim to be created")
+				}
+			}
+		})
+	}
+}
+
+func TestPolicyThatCannotBuildClaimDenies(t *testing.T) {
+	templatePolicy := newDeterministicClaimPolicy()
+	templatePolicy.Spec.Target.ResourceClaimTemplate.Metadata.Name = "endpointslice-{{ trigger.metadata.missing }}"
+
+	tests := []struct {
+		name           string
+		policy         *quotav1alpha1.ClaimCreationPolicy
+		registered     map[string]bool
+		expectedReason string
+		expectedCause  string
+	}{
+		{
+			name:           "claim template render failed",
+			policy:         templatePolicy,
+			expectedReason: DecisionReasonTemplateRenderFailed,
+			expectedCause:  "could not render its ResourceClaim template",
+		},
+		{
+			name:           "resource type not registered",
+			policy:         newDeterministicClaimPolicy(),
+			registered:     map[string]bool{},
+			expectedReason: DecisionReasonResourceTypeNotRegistered,
+			expectedCause:  "requests resource type discovery.miloapis.com/endpointslices, which has no active ResourceRegistration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: tt.policy, gvk: endpointSliceGVK()},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			if tt.registered != nil {
+				plugin.resourceTypeValidator = &testResourceTypeValidator{validResourceTypes: tt.registered}
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+			err = plugin.Validate(context.Background(), newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil)
+			if !apierrors.IsForbidden(err) {
+				t.Fatalf("expected the resource to be rejected as Forbidden, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "ClaimCreationPolicy endpointslice-quota-policy "+tt.expectedCause) {
+				t.Errorf("expected the rejection to name the policy and cause, got %q", err.Error())
+			}
+
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+			}
+			if record := sink.records[0]; record.Decision != DecisionDenied || record.Reason != tt.expectedReason {
+				t.Errorf("expected %s/%s, got %s/%s", DecisionDenied, tt.expectedReason, record.Decision, record.Reason)
+			}
+
+			for _, action := range fakeDynClient.Actions() {
+				if action.GetVerb() == "create" && action.GetResource().Resource == "resourceclaims" {
+					t.Errorf("expected no ResourceClaim to be created")
+				}
+			}
+		})
+	}
+}
+
+func TestPolicyChargesEveryVersionOfItsTrigger(t *testing.T) {
+	policy := newDeterministicClaimPolicy()
+	policy.Name = "gateway-quota-policy"
+	policy.Spec.Trigger.Resource = &quotav1alpha1.ClaimTriggerResource{APIVersion: "networking.miloapis.com/v1alpha1", Kind: "Gateway"}
+	policy.Spec.Target.ResourceClaimTemplate.Metadata.Name = "gateway-{{ trigger.metadata.name }}"
+	policy.Spec.Target.ResourceClaimTemplate.Spec.Requests[0].ResourceType = "networking.miloapis.com/gateways"
+
+	policyMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+	if err != nil {
+		t.Fatalf("Failed to convert policy to unstructured: %v", err)
+	}
+	policyObj := &unstructured.Unstructured{Object: policyMap}
+	policyObj.SetGroupVersionKind(quotav1alpha1.GroupVersion.WithKind("ClaimCreationPolicy"))
+
+	// The policy engine lists unstructured policies, so its client's scheme must not know the typed kind
+	policyClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		quotav1alpha1.GroupVersion.WithResource("claimcreationpolicies"): "ClaimCreationPolicyList",
+	}, policyObj)
+
+	logger := zap.New(zap.UseDevMode(true))
+	config := DefaultAdmissionPluginConfig()
+	policyEngine := engine.NewPolicyEngineWithOptions(policyClient, logger, engine.PolicyEngineOptions{
+		MatchAnyVersion: config.VersionInsensitivePolicyMatching,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := policyEngine.Start(ctx); err != nil {
+		t.Fatalf("Failed to start policy engine: %v", err)
+	}
+	defer policyEngine.Close()
+
+	alphaGVK := schema.GroupVersionKind{Group: "networking.miloapis.com", Version: "v1alpha1", Kind: "Gateway"}
+	betaGVK := schema.GroupVersionKind{Group: "networking.miloapis.com", Version: "v1beta1", Kind: "Gateway"}
+
+	// Policy events are applied to the index asynchronously after the informer syncs
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if found, _ := policyEngine.GetPolicyForGVK(alphaGVK); found != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the policy engine to index the policy")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	celEngine, err := engine.NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+	scheme := runtime.NewScheme()
+	quotav1alpha1.AddToScheme(scheme)
+	claimClient := fake.NewSimpleDynamicClient(scheme)
+	plugin := &ResourceQuotaEnforcementPlugin{
+		Handler:        admission.NewHandler(admission.Create),
+		dynamicClient:  claimClient,
+		policyEngine:   policyEngine,
+		templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+		config:         config,
+		logger:         logger.WithName("plugin"),
+	}
+	plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+	for _, gvk := range []schema.GroupVersionKind{alphaGVK, betaGVK} {
+		gateway := &unstructured.Unstructured{}
+		gateway.SetGroupVersionKind(gvk)
+		gateway.SetName("gateway-" + gvk.Version)
+		gateway.SetNamespace("default")
+
+		if err := plugin.Validate(context.Background(), newEndpointSliceAttrs(gateway, gvk), nil); err != nil {
+			t.Fatalf("expected %s Gateway to be admitted, got %v", gvk.Version, err)
+		}
+	}
+
+	var buckets []string
+	for _, action := range claimClient.Actions() {
+		create, ok := action.(clienttesting.CreateAction)
+		if !ok || action.GetResource().Resource != "resourceclaims" {
+			continue
+		}
+		var claim quotav1alpha1.ResourceClaim
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(create.GetObject().(*unstructured.Unstructured).Object, &claim); err != nil {
+			t.Fatalf("Failed to convert claim: %v", err)
+		}
+		for _, request := range claim.Spec.Requests {
+			buckets = append(buckets, bucketutil.Name(request.ResourceType, claim.Spec.ConsumerRef))
+		}
+	}
+
+	if len(buckets) != 2 {
+		t.Fatalf("expected a claim for each version, got %d bucket references", len(buckets))
+	}
+	if buckets[0] != buckets[1] {
+		t.Errorf("expected both versions to consume bucket %s, got %s", buckets[0], buckets[1])
+	}
+}
+
+func TestCreatedClaimCarriesTraceParent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	quotav1alpha1.AddToScheme(scheme)
+	fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+	logger := zap.New(zap.UseDevMode(true))
+	celEngine, err := engine.NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+
+	plugin := &ResourceQuotaEnforcementPlugin{
+		Handler:        admission.NewHandler(admission.Create),
+		dynamicClient:  fakeDynClient,
+		policyEngine:   &testPolicyEngine{policy: newDeterministicClaimPolicy(), gvk: endpointSliceGVK()},
+		templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+		config:         DefaultAdmissionPluginConfig(),
+		logger:         logger.WithName("plugin"),
+	}
+	plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	if err := plugin.Validate(ctx, newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil); err != nil {
+		t.Fatalf("expected admission to succeed, got %v", err)
+	}
+
+	claim, err := fakeDynClient.Resource(quotav1alpha1.GroupVersion.WithResource("resourceclaims")).Namespace("default").Get(context.Background(), "endpointslice-test-eps-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get created claim: %v", err)
+	}
+	traceParent := claim.GetAnnotations()[quotav1alpha1.ResourceClaimTraceParentAnnotation]
+	if !strings.HasPrefix(traceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-") || !strings.HasSuffix(traceParent, "-01") {
+		t.Errorf("expected claim to carry the admission traceparent, got %q", traceParent)
+	}
+}
+
+func TestClaimWaitConcurrencyLimit(t *testing.T) {
+	newPlugin := func(concurrency ClaimConcurrencyConfig) (*ResourceQuotaEnforcementPlugin, *capturingDecisionSink) {
+		scheme := runtime.NewScheme()
+		quotav1alpha1.AddToScheme(scheme)
+
+		logger := zap.New(zap.UseDevMode(true))
+		celEngine, err := engine.NewCELEngine()
+		if err != nil {
+			t.Fatalf("Failed to create CEL engine: %v", err)
+		}
+
+		config := DefaultAdmissionPluginConfig()
+		config.ClaimConcurrency = concurrency
+		sink := &capturingDecisionSink{}
+		plugin := &ResourceQuotaEnforcementPlugin{
+			Handler:        admission.NewHandler(admission.Create),
+			dynamicClient:  fake.NewSimpleDynamicClient(scheme),
+			policyEngine:   &testPolicyEngine{policy: newDeterministicClaimPolicy(), gvk: endpointSliceGVK()},
+			templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+			config:         config,
+			logger:         logger.WithName("plugin"),
+		}
+		plugin.SetDecisionSink(sink)
+		plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+		return plugin, sink
+	}
+
+	t.Run("rejects as overloaded when no slot is free", func(t *testing.T) {
+		plugin, sink := newPlugin(ClaimConcurrencyConfig{MaxInFlight: 1})
+		release, err := plugin.acquireClaimSlot(context.Background())
+		if err != nil {
+			t.Fatalf("expected the first slot to be free, got %v", err)
+		}
+		defer release()
+
+		before, _ := testutil.GetCounterMetricValue(claimWaitRejections)
+		err = plugin.Validate(context.Background(), newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil)
+		if !apierrors.IsServiceUnavailable(err) || !strings.Contains(err.Error(), "overloaded") {
+			t.Fatalf("expected a retryable overloaded error, got %v", err)
+		}
+		if after, _ := testutil.GetCounterMetricValue(claimWaitRejections); after-before != 1 {
+			t.Errorf("expected 1 rejection to be counted, got %v", after-before)
+		}
+		if len(sink.records) != 1 || sink.records[0].Decision != DecisionPending || sink.records[0].Reason != DecisionReasonQuotaSystemOverloaded {
+			t.Errorf("expected a pending %s decision, got %+v", DecisionReasonQuotaSystemOverloaded, sink.records)
+		}
+	})
+
+	t.Run("queued request proceeds once a slot frees", func(t *testing.T) {
+		plugin, sink := newPlugin(ClaimConcurrencyConfig{MaxInFlight: 1, QueueTimeout: 5 * time.Second})
+		release, err := plugin.acquireClaimSlot(context.Background())
+		if err != nil {
+			t.Fatalf("expected the first slot to be free, got %v", err)
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- plugin.Validate(context.Background(), newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil)
+		}()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			if depth, _ := testutil.GetGaugeMetricValue(claimWaitQueueDepth); depth == 1 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("timed out waiting for the request to queue")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		release()
+
+		if err := <-done; err != nil {
+			t.Fatalf("expected the queued request to be admitted, got %v", err)
+		}
+		if depth, _ := testutil.GetGaugeMetricValue(claimWaitQueueDepth); depth != 0 {
+			t.Errorf("expected the queue to drain, got depth %v", depth)
+		}
+		if len(sink.records) != 1 || sink.records[0].Decision != DecisionGranted {
+			t.Errorf("expected a granted decision, got %+v", sink.records)
+		}
+	})
+
+	t.Run("projects have separate slots", func(t *testing.T) {
+		plugin, _ := newPlugin(ClaimConcurrencyConfig{MaxInFlight: 1})
+		release, err := plugin.acquireClaimSlot(milorequest.WithProject(context.Background(), "project-a"))
+		if err != nil {
+			t.Fatalf("expected project-a's slot to be free, got %v", err)
+		}
+		defer release()
+
+		releaseB, err := plugin.acquireClaimSlot(milorequest.WithProject(context.Background(), "project-b"))
+		if err != nil {
+			t.Fatalf("expected project-b's slot to be free, got %v", err)
+		}
+		releaseB()
+
+		if _, err := plugin.acquireClaimSlot(milorequest.WithProject(context.Background(), "project-a")); err == nil {
+			t.Error("expected project-a to have no free slot")
+		}
+	})
+
+	t.Run("released slots are evicted", func(t *testing.T) {
+		plugin, _ := newPlugin(ClaimConcurrencyConfig{MaxInFlight: 1})
+		ctx := milorequest.WithProject(context.Background(), "project-a")
+		release, err := plugin.acquireClaimSlot(ctx)
+		if err != nil {
+			t.Fatalf("expected project-a's slot to be free, got %v", err)
+		}
+		if _, err := plugin.acquireClaimSlot(ctx); err == nil {
+			t.Fatal("expected project-a to have no free slot")
+		}
+		if len(plugin.claimSlots) != 1 {
+			t.Errorf("expected the slots of project-a to be kept while one is held, got %d projects", len(plugin.claimSlots))
+		}
+
+		release()
+		if len(plugin.claimSlots) != 0 {
+			t.Errorf("expected the slots of project-a to be evicted once released, got %d projects", len(plugin.claimSlots))
+		}
+		release, err = plugin.acquireClaimSlot(ctx)
+		if err != nil {
+			t.Fatalf("expected project-a's slot to be free again, got %v", err)
+		}
+		release()
+	})
+}
+
+func TestResourceRegistrationDeleteBlockedByReferences(t *testing.T) {
+	const resourceType = "resourcemanager.miloapis.com/projects"
+
+	newRegistration := func(annotations map[string]string) *quotav1alpha1.ResourceRegistration {
+		return &quotav1alpha1.ResourceRegistration{
+			ObjectMeta: metav1.ObjectMeta{Name: "projects", Annotations: annotations},
+			Spec:       quotav1alpha1.ResourceRegistrationSpec{ResourceType: resourceType},
+		}
+	}
+	consumer := quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Organization", Name: "acme"}
+	newClaim := func(name, reason string) *quotav1alpha1.ResourceClaim {
+		claim := &quotav1alpha1.ResourceClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: quotav1alpha1.ResourceClaimSpec{
+				ConsumerRef: consumer,
+				Requests:    []quotav1alpha1.ResourceRequest{{ResourceType: resourceType, Amount: 1}},
+			},
+		}
+		if reason != "" {
+			claim.Status.Conditions = []metav1.Condition{{Type: quotav1alpha1.ResourceClaimGranted, Reason: reason}}
+		}
+		return claim
+	}
+	grant := &quotav1alpha1.ResourceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "acme-projects", Namespace: "organization-acme"},
+		Spec: quotav1alpha1.ResourceGrantSpec{
+			ConsumerRef: consumer,
+			Allowances:  []quotav1alpha1.Allowance{{ResourceType: resourceType, Buckets: []quotav1alpha1.Bucket{{Amount: 10}}}},
+		},
+	}
+	policy := &quotav1alpha1.ClaimCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "project-policy"},
+		Spec: quotav1alpha1.ClaimCreationPolicySpec{
+			Target: quotav1alpha1.ClaimTargetSpec{
+				ResourceClaimTemplate: quotav1alpha1.ResourceClaimTemplate{
+					Spec: quotav1alpha1.ResourceClaimSpec{
+						Requests: []quotav1alpha1.ResourceRequest{{ResourceType: resourceType, Amount: 1}},
+					},
+				},
+			},
+		},
+	}
+	unrelatedGrant := grant.DeepCopy()
+	unrelatedGrant.Name = "acme-users"
+	unrelatedGrant.Spec.Allowances[0].ResourceType = "iam.miloapis.com/users"
+	wildcardRegistration := &quotav1alpha1.ResourceRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: "resourcemanager"},
+		Spec:       quotav1alpha1.ResourceRegistrationSpec{ResourceType: "resourcemanager.miloapis.com/*"},
+	}
+
+	tests := []struct {
+		name          string
+		registration  *quotav1alpha1.ResourceRegistration
+		objects       []runtime.Object
+		wantForbidden bool
+		wantListed    []string
+	}{
+		{
+			name:         "unreferenced registration",
+			registration: newRegistration(nil),
+			objects:      []runtime.Object{unrelatedGrant},
+		},
+		{
+			name:         "referenced by a policy, grant and granted claim",
+			registration: newRegistration(nil),
+			objects: []runtime.Object{policy, grant, unrelatedGrant,
+				newClaim("web-app", quotav1alpha1.ResourceClaimGrantedReason)},
+			wantForbidden: true,
+			wantListed: []string{
+				"ClaimCreationPolicy/project-policy",
+				"ResourceGrant/organization-acme/acme-projects",
+				"ResourceClaim/default/web-app",
+			},
+		},
+		{
+			name:          "referenced by a pending claim",
+			registration:  newRegistration(nil),
+			objects:       []runtime.Object{newClaim("pending", "")},
+			wantForbidden: true,
+			wantListed:    []string{"ResourceClaim/default/pending"},
+		},
+		{
+			name:         "only denied claims remain",
+			registration: newRegistration(nil),
+			objects:      []runtime.Object{newClaim("denied", quotav1alpha1.ResourceClaimDeniedReason)},
+		},
+		{
+			name:         "forced deletion",
+			registration: newRegistration(map[string]string{quotav1alpha1.ResourceRegistrationForceDeleteAnnotation: "true"}),
+			objects:      []runtime.Object{policy, grant},
+		},
+		{
+			name:          "wildcard referenced through a resource type of its group",
+			registration:  wildcardRegistration,
+			objects:       []runtime.Object{grant, unrelatedGrant, newClaim("web-app", quotav1alpha1.ResourceClaimGrantedReason)},
+			wantForbidden: true,
+			wantListed: []string{
+				"ResourceGrant/organization-acme/acme-projects",
+				"ResourceClaim/default/web-app",
+			},
+		},
+		{
+			// The resource type keeps its own registration, so its references are unaffected
+			name:         "wildcard of a group whose referenced types are registered",
+			registration: wildcardRegistration,
+			objects:      []runtime.Object{newRegistration(nil), grant, newClaim("web-app", quotav1alpha1.ResourceClaimGrantedReason)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			client := fake.NewSimpleDynamicClient(scheme, append(tt.objects, tt.registration)...)
+
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:       admission.NewHandler(admission.Create, admission.Delete),
+				dynamicClient: client,
+				config:        DefaultAdmissionPluginConfig(),
+				logger:        zap.New(zap.UseDevMode(true)).WithName("plugin"),
+			}
+			attrs := &testAdmissionAttributes{
+				operation: admission.Delete,
+				gvk:       quotav1alpha1.GroupVersion.WithKind("ResourceRegistration"),
+				name:      tt.registration.Name,
+				userInfo:  &user.DefaultInfo{Name: "admin"},
+			}
+
+			err := plugin.Validate(context.Background(), attrs, nil)
+			if !tt.wantForbidden {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want deletion allowed", err)
+				}
+				return
+			}
+			if !apierrors.IsForbidden(err) {
+				t.Fatalf("Validate() error = %v, want Forbidden", err)
+			}
+			for _, ref := range tt.wantListed {
+				if !strings.Contains(err.Error(), ref) {
+					t.Errorf("error %q does not name %s", err.Error(), ref)
+				}
+			}
+			if strings.Contains(err.Error(), "acme-users") {
+				t.Errorf("error %q names an unrelated grant", err.Error())
+			}
+		})
+	}
+}
+
+func TestExemptSubjects(t *testing.T) {
+	policy := newDeterministicClaimPolicy()
+	policy.Spec.ExemptSubjects = &quotav1alpha1.ClaimExemptSubjects{
+		Users:  []string{"oncall@example.com"},
+		Groups: []string{"system:incident-response", "operators"},
+	}
+
+	tests := []struct {
+		name             string
+		user             *user.DefaultInfo
+		expectedDecision Decision
+		expectedReason   string
+	}{
+		{
+			name:             "exempt group bypasses a denied policy",
+			user:             &user.DefaultInfo{Name: "responder", Groups: []string{"system:authenticated", "system:incident-response"}},
+			expectedDecision: DecisionExempt,
+			expectedReason:   DecisionReasonExemptSubject,
+		},
+		{
+			name:             "exempt user bypasses a denied policy",
+			user:             &user.DefaultInfo{Name: "oncall@example.com"},
+			expectedDecision: DecisionExempt,
+			expectedReason:   DecisionReasonExemptSubject,
+		},
+		{
+			name:             "other requesters are denied",
+			user:             &user.DefaultInfo{Name: "tenant", Groups: []string{"system:authenticated"}},
+			expectedDecision: DecisionDenied,
+			expectedReason:   quotav1alpha1.ResourceClaimDeniedReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fake.NewSimpleDynamicClient(scheme),
+				policyEngine:   &testPolicyEngine{policy: policy, gvk: endpointSliceGVK()},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: "deny-partial"})
+
+			attrs := newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK())
+			attrs.userInfo = tt.user
+			err = plugin.Validate(context.Background(), attrs, nil)
+			if exempt := tt.expectedDecision == DecisionExempt; exempt != (err == nil) {
+				t.Fatalf("expected admission error only when not exempt, got %v", err)
+			}
+
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d: %+v", len(sink.records), sink.records)
+			}
+			if record := sink.records[0]; record.Decision != tt.expectedDecision || record.Reason != tt.expectedReason {
+				t.Errorf("expected decision %s/%s, got %s/%s", tt.expectedDecision, tt.expectedReason, record.Decision, record.Reason)
+			}
+		})
+	}
+
+	// Project control planes only honor system groups
+	projectCtx := milorequest.WithProject(context.Background(), "tenant-project")
+	if _, ok := exemptSubject(projectCtx, policy, &user.DefaultInfo{Name: "oncall@example.com", Groups: []string{"operators"}}); ok {
+		t.Error("expected exempt users and non-system groups to be ignored in a project control plane")
+	}
+	if subject, ok := exemptSubject(projectCtx, policy, &user.DefaultInfo{Name: "responder", Groups: []string{"system:incident-response"}}); !ok || subject != "group system:incident-response" {
+		t.Errorf("expected the system group to be honored in a project control plane, got %q", subject)
+	}
+
+	// Groups tenants belong to or control never exempt, even if a policy lists them
+	tenantPolicy := newDeterministicClaimPolicy()
+	tenantPolicy.Spec.ExemptSubjects = &quotav1alpha1.ClaimExemptSubjects{
+		Groups: []string{"system:authenticated", "system:serviceaccounts:tenant-namespace"},
+	}
+	for _, ctx := range []context.Context{context.Background(), projectCtx} {
+		tenant := &user.DefaultInfo{Name: "tenant", Groups: []string{"system:authenticated", "system:serviceaccounts", "system:serviceaccounts:tenant-namespace"}}
+		if subject, ok := exemptSubject(ctx, tenantPolicy, tenant); ok {
+			t.Errorf("expected tenant-controlled groups to be ignored, got exempt through %q", subject)
+		}
+	}
+}
+
+func TestAuditEnforcementMode(t *testing.T) {
+	policy := newDeterministicClaimPolicy()
+	policy.Spec.EnforcementMode = quotav1alpha1.ClaimEnforcementModeAudit
+
+	tests := []struct {
+		name                 string
+		watchBehavior        string
+		expectedResultReason string
+		expectedWarning      bool
+	}{
+		{
+			name:                 "denied claim is admitted",
+			watchBehavior:        "deny-partial",
+			expectedResultReason: resultReasonQuotaExceeded,
+			expectedWarning:      true,
+		},
+		{
+			name:                 "unresolved claim is admitted",
+			watchBehavior:        "expire",
+			expectedResultReason: resultReasonTimeout,
+			expectedWarning:      true,
+		},
+		{
+			name:          "granted claim is admitted without a warning",
+			watchBehavior: "grant",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			fakeDynClient := fake.NewSimpleDynamicClient(scheme)
+
+			logger := zap.New(zap.UseDevMode(true))
+			celEngine, err := engine.NewCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:        admission.NewHandler(admission.Create),
+				dynamicClient:  fakeDynClient,
+				policyEngine:   &testPolicyEngine{policy: policy, gvk: endpointSliceGVK()},
+				templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+				config:         DefaultAdmissionPluginConfig(),
+				logger:         logger.WithName("plugin"),
+			}
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: tt.watchBehavior})
+
+			resultCounter := admissionResultTotal.WithLabelValues("audited", tt.expectedResultReason,
+				"endpointslice-quota-policy", "", "discovery.k8s.io", "EndpointSlice")
+			before, _ := testutil.GetCounterMetricValue(resultCounter)
+
+			recorder := &recordingWarningRecorder{}
+			ctx := warning.WithWarningRecorder(context.Background(), recorder)
+			if err := plugin.Validate(ctx, newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil); err != nil {
+				t.Fatalf("expected an Audit mode policy to admit the resource, got %v", err)
+			}
+
+			created := false
+			for _, action := range fakeDynClient.Actions() {
+				if action.GetVerb() == "create" && action.GetResource().Resource == "resourceclaims" {
+					created = true
+				}
+			}
+			if !created {
+				t.Errorf("expected the ResourceClaim to still be created")
+			}
+
+			// The claim of a would-be rejection must not linger and be granted later
+			claims, err := fakeDynClient.Resource(resourceClaimsGVR).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to list ResourceClaims: %v", err)
+			}
+			if remaining := len(claims.Items); remaining != 0 && tt.expectedWarning {
+				t.Errorf("expected the audited ResourceClaim to be deleted, %d remain", remaining)
+			} else if remaining != 1 && !tt.expectedWarning {
+				t.Errorf("expected the granted ResourceClaim to be kept, %d remain", remaining)
+			}
+
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d: %+v", len(sink.records), sink.records)
+			}
+			record := sink.records[0]
+
+			if !tt.expectedWarning {
+				if len(recorder.warnings) != 0 {
+					t.Errorf("expected no warnings, got %v", recorder.warnings)
+				}
+				if record.Decision != DecisionGranted {
+					t.Errorf("expected decision %s, got %s", DecisionGranted, record.Decision)
+				}
+				return
+			}
+
+			if len(recorder.warnings) != 1 || !strings.Contains(recorder.warnings[0], "is in Audit mode and would have rejected this EndpointSlice") {
+				t.Errorf("expected a warning describing the would-be rejection, got %v", recorder.warnings)
+			}
+			if record.Decision != DecisionAudited || record.Reason != DecisionReasonAuditMode {
+				t.Errorf("expected decision %s/%s, got %s/%s", DecisionAudited, DecisionReasonAuditMode, record.Decision, record.Reason)
+			}
+			if after, _ := testutil.GetCounterMetricValue(resultCounter); after-before != 1 {
+				t.Errorf("expected admission_result_total{result=audited,reason=%s} to increase by 1, got %v", tt.expectedResultReason, after-before)
+			}
+		})
+	}
+}
+
+func TestQuotaOverrideAnnotationRequiresPlatformAdmin(t *testing.T) {
+	organizationGVK := schema.GroupVersionKind{Group: "resourcemanager.miloapis.com", Version: "v1alpha1", Kind: "Organization"}
+	newOrganization := func(override string) *unstructured.Unstructured {
+		organization := &unstructured.Unstructured{}
+		organization.SetGroupVersionKind(organizationGVK)
+		organization.SetName("acme")
+		if override != "" {
+			organization.SetAnnotations(map[string]string{quotav1alpha1.QuotaOverrideAnnotation: override})
+		}
+		return organization
+	}
+	tenant := &user.DefaultInfo{Name: "tenant@example.com", Groups: []string{"system:authenticated"}}
+	admin := &user.DefaultInfo{Name: "admin", Groups: []string{user.SystemPrivilegedGroup}}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		user        user.Info
+		oldObject   runtime.Object
+		object      runtime.Object
+		expectError bool
+	}{
+		{
+			name:        "tenant patch setting the annotation is denied",
+			ctx:         context.Background(),
+			user:        tenant,
+			oldObject:   newOrganization(""),
+			object:      newOrganization("compute.miloapis.com/instances"),
+			expectError: true,
+		},
+		{
+			name:        "tenant patch changing the annotation is denied",
+			ctx:         context.Background(),
+			user:        tenant,
+			oldObject:   newOrganization("compute.miloapis.com/instances"),
+			object:      newOrganization("compute.miloapis.com/instances,compute.miloapis.com/disks"),
+			expectError: true,
+		},
+		{
+			name:      "tenant update leaving the annotation unchanged is allowed",
+			ctx:       context.Background(),
+			user:      tenant,
+			oldObject: newOrganization("compute.miloapis.com/instances"),
+			object:    newOrganization("compute.miloapis.com/instances"),
+		},
+		{
+			name:      "tenant update removing the annotation is allowed",
+			ctx:       context.Background(),
+			user:      tenant,
+			oldObject: newOrganization("compute.miloapis.com/instances"),
+			object:    newOrganization(""),
+		},
+		{
+			name:      "platform administrator may set the annotation",
+			ctx:       context.Background(),
+			user:      admin,
+			oldObject: newOrganization(""),
+			object:    newOrganization("compute.miloapis.com/instances"),
+		},
+		{
+			name:        "administrator group is not honored in a project control plane",
+			ctx:         milorequest.WithProject(context.Background(), "tenant-project"),
+			user:        admin,
+			oldObject:   newOrganization(""),
+			object:      newOrganization("compute.miloapis.com/instances"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:      admission.NewHandler(admission.Create, admission.Update),
+				policyEngine: &testPolicyEngine{},
+				config:       DefaultAdmissionPluginConfig(),
+				logger:       zap.New(zap.UseDevMode(true)),
+			}
+
+			attrs := &testAdmissionAttributes{
+				operation: admission.Update,
+				object:    tt.object,
+				oldObject: tt.oldObject,
+				gvk:       organizationGVK,
+				name:      "acme",
+				userInfo:  tt.user,
+			}
+			err := plugin.Validate(tt.ctx, attrs, nil)
+			if tt.expectError {
+				if !apierrors.IsForbidden(err) {
+					t.Fatalf("expected a Forbidden error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected the update to be allowed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOrganizationTierPolicyEnforcedAtAdmission(t *testing.T) {
+	policy := newDeterministicClaimPolicy()
+	policy.Spec.Trigger.Constraints = []quotav1alpha1.ConditionExpression{
+		{Expression: `orgTier({"kind": "Organization", "name": "acme"}) == "Free"`},
+	}
+
+	tests := []struct {
+		name             string
+		tier             string
+		expectedDecision Decision
+	}{
+		{
+			name:             "policy for the organization's tier is enforced",
+			tier:             "Free",
+			expectedDecision: DecisionDenied,
+		},
+		{
+			name:             "policy for another tier is skipped",
+			tier:             "Standard",
+			expectedDecision: DecisionSkipped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			organization := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+				"kind":       "Organization",
+				"metadata":   map[string]interface{}{"name": "acme"},
+				"spec":       map[string]interface{}{"type": tt.tier},
+			}}
+			scheme := runtime.NewScheme()
+			quotav1alpha1.AddToScheme(scheme)
+			organizationsGVR := schema.GroupVersionResource{Group: "resourcemanager.miloapis.com", Version: "v1alpha1", Resource: "organizations"}
+			fakeDynClient := fake.NewSimpleDynamicClientWithCustomListKinds(scheme,
+				map[schema.GroupVersionResource]string{organizationsGVR: "OrganizationList"}, organization)
+
+			logger := zap.New(zap.UseDevMode(true))
+			sink := &capturingDecisionSink{}
+			plugin := &ResourceQuotaEnforcementPlugin{
+				Handler:       admission.NewHandler(admission.Create),
+				dynamicClient: fakeDynClient,
+				policyEngine:  &testPolicyEngine{policy: policy, gvk: endpointSliceGVK()},
+				config:        DefaultAdmissionPluginConfig(),
+				logger:        logger.WithName("plugin"),
+			}
+			celEngine, err := plugin.newCELEngine()
+			if err != nil {
+				t.Fatalf("Failed to create CEL engine: %v", err)
+			}
+			plugin.templateEngine = engine.NewTemplateEngine(celEngine, logger.WithName("template"))
+			plugin.SetDecisionSink(sink)
+			plugin.watchManagers.Store("", &testWatchManager{behavior: "deny-partial"})
+
+			err = plugin.Validate(context.Background(), newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil)
+			if denied := tt.expectedDecision == DecisionDenied; denied != (err != nil) {
+				t.Fatalf("expected admission error only when enforced, got %v", err)
+			}
+
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d: %+v", len(sink.records), sink.records)
+			}
+			if record := sink.records[0]; record.Decision != tt.expectedDecision {
+				t.Errorf("expected decision %s, got %s (%s: %s)", tt.expectedDecision, record.Decision, record.Reason, record.Message)
+			}
+		})
+	}
+}
+
+func TestParentOrganizationPolicy(t *testing.T) {
+	policy := newDeterministicClaimPolicy()
+	policy.Spec.Trigger.Constraints = []quotav1alpha1.ConditionExpression{
+		{Expression: `parent.spec.type == "Standard"`},
+	}
+
+	project := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+		"kind":       "Project",
+		"metadata":   map[string]interface{}{"name": "tenant-project"},
+		"spec": map[string]interface{}{
+			"ownerRef": map[string]interface{}{"kind": "Organization", "name": "acme"},
+		},
+	}}
+	organization := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+		"kind":       "Organization",
+		"metadata":   map[string]interface{}{"name": "acme"},
+		"spec":       map[string]interface{}{"type": "Standard"},
+	}}
+
+	newPlugin := func(t *testing.T, objects ...runtime.Object) (*ResourceQuotaEnforcementPlugin, *fake.FakeDynamicClient, *capturingDecisionSink) {
+		scheme := runtime.NewScheme()
+		quotav1alpha1.AddToScheme(scheme)
+		fakeDynClient := fake.NewSimpleDynamicClient(scheme, objects...)
+
+		logger := zap.New(zap.UseDevMode(true))
+		celEngine, err := engine.NewCELEngine()
+		if err != nil {
+			t.Fatalf("Failed to create CEL engine: %v", err)
+		}
+
+		sink := &capturingDecisionSink{}
+		plugin := &ResourceQuotaEnforcementPlugin{
+			Handler:        admission.NewHandler(admission.Create),
+			dynamicClient:  fakeDynClient,
+			policyEngine:   &testPolicyEngine{policy: policy, gvk: endpointSliceGVK()},
+			templateEngine: engine.NewTemplateEngine(celEngine, logger.WithName("template")),
+			config:         DefaultAdmissionPluginConfig(),
+			logger:         logger.WithName("plugin"),
+		}
+		plugin.SetDecisionSink(sink)
+		plugin.watchManagers.Store("", &testWatchManager{behavior: "grant"})
+		return plugin, fakeDynClient, sink
+	}
+
+	t.Run("unresolvable parent denies", func(t *testing.T) {
+		for _, ctx := range []context.Context{
+			context.Background(),
+			milorequest.WithProject(context.Background(), "tenant-project"),
+		} {
+			plugin, fakeDynClient, sink := newPlugin(t)
+
+			err := plugin.Validate(ctx, newEndpointSliceAttrs(newEndpointSliceObject(), endpointSliceGVK()), nil)
+			if !apierrors.IsForbidden(err) {
+				t.Fatalf("expected the resource to be rejected as Forbidden, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "could not resolve the parent Organization") {
+				t.Errorf("expected the rejection to name the unresolved parent, got %q", err.Error())
+			}
+			if len(sink.records) != 1 {
+				t.Fatalf("expected 1 decision record, got %d", len(sink.records))
+			}
+			if record := sink.records[0]; record.Decision != DecisionDenied || record.Reason != DecisionReasonParentUnresolved {
+				t.Errorf("expected %s/%s, got %s/%s", DecisionDenied, DecisionReasonParentUnresolved, record.Decision, record.Reason)
+			}
+			for _, action := range fakeDynClient.Actions() {
+				if action.GetVerb() == "create" && action.GetResource().Resource == "resourceclaims" {
+					t.Errorf("expected no ResourceClaim to be created")
+				}
+			}
+		}
+	})
+
+	t.Run("parent is resolved once per request", func(t *testing.T) {
+		plugin, fakeDynClient, _ := newPlugin(t, project, organization)
+
+		ctx := withParentOrganization(milorequest.WithProject(context.Background(), "tenant-project"))
+		for i := 0; i < 2; i++ {
+			parent, err := plugin.parentOrganization(ctx)
+			if err != nil {
+				t.Fatalf("expected the parent to resolve, got %v", err)
+			}
+			if parent.GetName() != "acme" {
+				t.Errorf("expected parent acme, got %q", parent.GetName())
+			}
+		}
+
+		gets := 0
+		for _, action := range fakeDynClient.Actions() {
+			if action.GetVerb() == "get" {
+				gets++
+			}
+		}
+		if gets != 2 {
+			t.Errorf("expected the project and organization to be read once each, got %d reads", gets)
+		}
+	})
+}
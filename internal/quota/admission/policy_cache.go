@@ -0,0 +1,88 @@
+package admission
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// policyCacheEntry holds the result of a policy lookup, including negative results.
+type policyCacheEntry struct {
+	policy    *quotav1alpha1.ClaimCreationPolicy
+	expiresAt time.Time
+}
+
+// policyLookupCache is a short-lived GVK-keyed cache in front of the policy engine.
+//
+// Entries expire after ttl and the whole cache is invalidated whenever the policy
+// engine reports a ClaimCreationPolicy change. A generation counter prevents a lookup
+// that raced with an invalidation from repopulating the cache with a stale result.
+// A nil cache, or one with a non-positive ttl, caches nothing.
+type policyLookupCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu         sync.RWMutex
+	generation uint64
+	entries    map[schema.GroupVersionKind]policyCacheEntry
+}
+
+// newPolicyLookupCache creates a policy lookup cache with the given TTL.
+func newPolicyLookupCache(ttl time.Duration) *policyLookupCache {
+	return &policyLookupCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[schema.GroupVersionKind]policyCacheEntry),
+	}
+}
+
+// get returns the cached policy for gvk and whether a live entry was found, along
+// with the generation to pass to store if the caller falls back to the engine.
+func (c *policyLookupCache) get(gvk schema.GroupVersionKind) (*quotav1alpha1.ClaimCreationPolicy, uint64, bool) {
+	if c == nil || c.ttl <= 0 {
+		return nil, 0, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[gvk]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return nil, c.generation, false
+	}
+	return entry.policy, c.generation, true
+}
+
+// store records a lookup result unless the cache was invalidated since generation was read.
+func (c *policyLookupCache) store(gvk schema.GroupVersionKind, policy *quotav1alpha1.ClaimCreationPolicy, generation uint64) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.entries[gvk] = policyCacheEntry{
+		policy:    policy,
+		expiresAt: c.now().Add(c.ttl),
+	}
+}
+
+// invalidate drops all cached entries.
+func (c *policyLookupCache) invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = make(map[schema.GroupVersionKind]policyCacheEntry)
+}
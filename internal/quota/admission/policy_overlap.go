@@ -0,0 +1,62 @@
+package admission
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"go.miloapis.com/milo/internal/quota/engine"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// claimCreationPolicyOverlapWarnings describes active policies that may charge for the same
+// objects as policy. Triggers on the exact same GVK are rejected by
+// validateClaimCreationPolicyTriggers; this covers the same kind served at another API
+// version, which the engine indexes separately, so quota for the kind is split between the
+// policies by the version each client happens to use.
+//
+// Constraints are CEL and cannot be compared, so two policies are only considered disjoint
+// when their object or namespace selectors require different values for the same label.
+func (p *ResourceQuotaEnforcementPlugin) claimCreationPolicyOverlapWarnings(policy *quotav1alpha1.ClaimCreationPolicy) []string {
+	lister, ok := p.policyEngine.(engine.PolicyLister)
+	if !ok {
+		return nil
+	}
+
+	var warnings []string
+	for _, existing := range lister.ListPolicies() {
+		if existing.Name == policy.Name || !triggerSelectorsMayOverlap(policy.Spec.Trigger, existing.Spec.Trigger) {
+			continue
+		}
+		for _, gvk := range policy.Spec.Trigger.GetGVKs() {
+			for _, existingGVK := range existing.Spec.Trigger.GetGVKs() {
+				if gvk.GroupKind() != existingGVK.GroupKind() || gvk.Version == existingGVK.Version {
+					continue
+				}
+				warnings = append(warnings, fmt.Sprintf(
+					"ClaimCreationPolicy '%s' already charges for %s created through version %s; %s objects created through %s would be charged by this policy instead, so usage of the kind is split between both policies",
+					existing.Name, gvk.GroupKind(), existingGVK.Version, gvk.Kind, gvk.Version))
+			}
+		}
+	}
+	return warnings
+}
+
+// triggerSelectorsMayOverlap reports whether an object could satisfy the selectors of both triggers.
+func triggerSelectorsMayOverlap(a, b quotav1alpha1.ClaimTriggerSpec) bool {
+	return !matchLabelsConflict(a.ObjectSelector, b.ObjectSelector) &&
+		!matchLabelsConflict(a.NamespaceSelector, b.NamespaceSelector)
+}
+
+// matchLabelsConflict reports whether two selectors require different values for the same label.
+func matchLabelsConflict(a, b *metav1.LabelSelector) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	for key, value := range a.MatchLabels {
+		if other, ok := b.MatchLabels[key]; ok && other != value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	milorequest "go.miloapis.com/milo/pkg/request"
+)
+
+// quotaOverrideGroups are the groups whose members may set or change a consumer's
+// quota override. An override lifts the consumer's quota entirely, so it is reserved
+// for platform administrators.
+var quotaOverrideGroups = []string{user.SystemPrivilegedGroup}
+
+// validateQuotaOverrideChange rejects requests that set or change a consumer's
+// QuotaOverrideAnnotation unless they come from a platform administrator. Consumers
+// carry the annotation on themselves, so without this check anyone able to update an
+// Organization or Project could lift its quota. Removing the annotation is allowed.
+func validateQuotaOverrideChange(ctx context.Context, attrs admission.Attributes) error {
+	if attrs.GetSubresource() != "" {
+		return nil
+	}
+	if op := attrs.GetOperation(); op != admission.Create && op != admission.Update {
+		return nil
+	}
+
+	value, set := quotaOverrideAnnotation(attrs.GetObject())
+	if !set {
+		return nil
+	}
+	if oldValue, wasSet := quotaOverrideAnnotation(attrs.GetOldObject()); wasSet && oldValue == value {
+		return nil
+	}
+	if canSetQuotaOverride(ctx, attrs.GetUserInfo()) {
+		return nil
+	}
+
+	return admission.NewForbidden(attrs, fmt.Errorf("only platform administrators may set the %s annotation",
+		quotav1alpha1.QuotaOverrideAnnotation))
+}
+
+// canSetQuotaOverride reports whether the requester may set a quota override. Users in
+// project control planes are never platform administrators.
+func canSetQuotaOverride(ctx context.Context, userInfo user.Info) bool {
+	if userInfo == nil {
+		return false
+	}
+	if _, projectPlane := milorequest.ProjectID(ctx); projectPlane {
+		return false
+	}
+	for _, group := range userInfo.GetGroups() {
+		if slices.Contains(quotaOverrideGroups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// quotaOverrideAnnotation returns the object's QuotaOverrideAnnotation, if set.
+func quotaOverrideAnnotation(obj runtime.Object) (string, bool) {
+	if obj == nil {
+		return "", false
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "", false
+	}
+	value, ok := accessor.GetAnnotations()[quotav1alpha1.QuotaOverrideAnnotation]
+	return value, ok
+}
@@ -17,11 +17,17 @@ var (
 	pluginMutex    sync.RWMutex
 )
 
-// Register registers the ResourceQuotaEnforcement admission plugin for custom plugin registries
-func Register(plugins *admission.Plugins) {
+// Register registers the ResourceQuotaEnforcement admission plugin for custom plugin registries.
+// The configure functions adjust the default plugin configuration when the plugin is
+// created, after command-line flags have been parsed.
+func Register(plugins *admission.Plugins, configure ...func(*AdmissionPluginConfig)) {
 	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
 		klog.InfoS("Registered resource quota enforcement plugin with Milo apiserver")
-		plugin, err := NewResourceQuotaEnforcementPlugin()
+		pluginConfig := DefaultAdmissionPluginConfig()
+		for _, fn := range configure {
+			fn(pluginConfig)
+		}
+		plugin, err := NewResourceQuotaEnforcementPluginWithConfig(pluginConfig)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,221 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/client-go/dynamic"
+
+	"go.miloapis.com/milo/internal/quota/validation"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// maxListedReferences bounds how many referencing objects a blocked deletion names.
+const maxListedReferences = 10
+
+// validateResourceRegistrationDelete blocks deleting a ResourceRegistration while quota
+// objects in the same control plane still reference its resource type. Without the
+// registration, new claims for those objects are rejected while existing buckets and
+// grants linger. The force-delete annotation skips the check.
+func (p *ResourceQuotaEnforcementPlugin) validateResourceRegistrationDelete(ctx context.Context, attrs admission.Attributes) error {
+	span := trace.SpanFromContext(ctx)
+
+	client, err := p.getClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get client for ResourceRegistration deletion check: %w", err)
+	}
+
+	registration, err := p.registrationBeingDeleted(ctx, client, attrs)
+	if err != nil || registration == nil {
+		return err
+	}
+
+	if registration.Annotations[quotav1alpha1.ResourceRegistrationForceDeleteAnnotation] == "true" {
+		p.logger.Info("Force deleting ResourceRegistration without checking references",
+			"registration", registration.Name,
+			"resourceType", registration.Spec.ResourceType,
+			"user", attrs.GetUserInfo().GetName())
+		span.SetAttributes(attribute.String("validation.status", "forced"))
+		return nil
+	}
+
+	governs, err := registrationGoverns(ctx, client, registration.Spec.ResourceType)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	references, err := resourceTypeReferences(ctx, client, governs)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to check references to resource type %s: %w", registration.Spec.ResourceType, err)
+	}
+	if len(references) == 0 {
+		span.SetAttributes(attribute.String("validation.status", "passed"))
+		return nil
+	}
+
+	span.SetAttributes(
+		attribute.String("validation.status", "failed"),
+		attribute.Int("registration.references", len(references)),
+	)
+	span.SetStatus(codes.Error, "ResourceRegistration is still referenced")
+
+	listed := references
+	if len(listed) > maxListedReferences {
+		listed = listed[:maxListedReferences]
+	}
+	message := strings.Join(listed, ", ")
+	if more := len(references) - len(listed); more > 0 {
+		message = fmt.Sprintf("%s and %d more", message, more)
+	}
+
+	return admission.NewForbidden(attrs, fmt.Errorf(
+		"resource type %s is still referenced by %s. Remove these references before deleting the ResourceRegistration, or set the annotation %s=true to delete it anyway",
+		registration.Spec.ResourceType, message, quotav1alpha1.ResourceRegistrationForceDeleteAnnotation))
+}
+
+// registrationBeingDeleted returns the ResourceRegistration a delete request targets,
+// reading it from the API when the request does not carry the existing object.
+func (p *ResourceQuotaEnforcementPlugin) registrationBeingDeleted(ctx context.Context, client dynamic.Interface, attrs admission.Attributes) (*quotav1alpha1.ResourceRegistration, error) {
+	var obj *unstructured.Unstructured
+	if old, ok := attrs.GetOldObject().(*unstructured.Unstructured); ok {
+		obj = old
+	} else {
+		got, err := client.Resource(quotav1alpha1.GroupVersion.WithResource("resourceregistrations")).Get(ctx, attrs.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			// Let the delete itself report the missing registration
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ResourceRegistration %s: %w", attrs.GetName(), err)
+		}
+		obj = got
+	}
+
+	registration := &quotav1alpha1.ResourceRegistration{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, registration); err != nil {
+		return nil, fmt.Errorf("failed to convert to ResourceRegistration: %w", err)
+	}
+	return registration, nil
+}
+
+// registrationGoverns returns whether a resource type is governed by the registration of
+// registered. A group wildcard registration governs every resource type of its group that
+// has no registration of its own, the same way claims are validated against registrations.
+func registrationGoverns(ctx context.Context, client dynamic.Interface, registered string) (func(string) bool, error) {
+	list, err := client.Resource(quotav1alpha1.GroupVersion.WithResource("resourceregistrations")).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ResourceRegistrations: %w", err)
+	}
+	registeredTypes := sets.New[string]()
+	for _, item := range list.Items {
+		if resourceType, _, _ := unstructured.NestedString(item.Object, "spec", "resourceType"); resourceType != "" {
+			registeredTypes.Insert(resourceType)
+		}
+	}
+
+	return func(resourceType string) bool {
+		if resourceType == registered {
+			return true
+		}
+		wildcard, ok := validation.GroupWildcard(resourceType)
+		return ok && wildcard == registered && !registeredTypes.Has(resourceType)
+	}, nil
+}
+
+// resourceTypeReferences lists the ClaimCreationPolicies, GrantCreationPolicies,
+// ResourceGrants and unresolved or granted ResourceClaims that reference a resource type
+// the registration governs, formatted as Kind/name or Kind/namespace/name and sorted
+// within each kind.
+func resourceTypeReferences(ctx context.Context, client dynamic.Interface, governs func(string) bool) ([]string, error) {
+	var references []string
+
+	err := listReferences(ctx, client, "claimcreationpolicies", func(policy *quotav1alpha1.ClaimCreationPolicy) bool {
+		return slices.ContainsFunc(policy.Spec.Target.ResourceClaimTemplate.Spec.Requests, func(request quotav1alpha1.ResourceRequest) bool {
+			return governs(request.ResourceType)
+		})
+	}, "ClaimCreationPolicy", &references)
+	if err != nil {
+		return nil, err
+	}
+
+	err = listReferences(ctx, client, "grantcreationpolicies", func(policy *quotav1alpha1.GrantCreationPolicy) bool {
+		return slices.ContainsFunc(policy.Spec.Target.ResourceGrantTemplate.Spec.Allowances, func(allowance quotav1alpha1.Allowance) bool {
+			return governs(allowance.ResourceType)
+		})
+	}, "GrantCreationPolicy", &references)
+	if err != nil {
+		return nil, err
+	}
+
+	err = listReferences(ctx, client, "resourcegrants", func(grant *quotav1alpha1.ResourceGrant) bool {
+		return slices.ContainsFunc(grant.Spec.Allowances, func(allowance quotav1alpha1.Allowance) bool {
+			return governs(allowance.ResourceType)
+		})
+	}, "ResourceGrant", &references)
+	if err != nil {
+		return nil, err
+	}
+
+	err = listReferences(ctx, client, "resourceclaims", func(claim *quotav1alpha1.ResourceClaim) bool {
+		// Denied claims and claims being deleted hold no quota and are cleaned up on their own
+		if claim.DeletionTimestamp != nil {
+			return false
+		}
+		if granted := apimeta.FindStatusCondition(claim.Status.Conditions, quotav1alpha1.ResourceClaimGranted); granted != nil &&
+			granted.Reason == quotav1alpha1.ResourceClaimDeniedReason {
+			return false
+		}
+		return slices.ContainsFunc(claim.Spec.Requests, func(request quotav1alpha1.ResourceRequest) bool {
+			return governs(request.ResourceType)
+		})
+	}, "ResourceClaim", &references)
+	if err != nil {
+		return nil, err
+	}
+
+	return references, nil
+}
+
+// listReferences lists every object of a quota resource, converting each to T, and
+// appends those that match to references.
+func listReferences[T any, PT interface {
+	*T
+	metav1.Object
+}](ctx context.Context, client dynamic.Interface, resource string, matches func(PT) bool, kind string, references *[]string) error {
+	list, err := client.Resource(quotav1alpha1.GroupVersion.WithResource(resource)).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", resource, err)
+	}
+
+	var found []string
+	for _, item := range list.Items {
+		obj := PT(new(T))
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, obj); err != nil {
+			return fmt.Errorf("failed to convert %s %s: %w", kind, item.GetName(), err)
+		}
+		if !matches(obj) {
+			continue
+		}
+		if obj.GetNamespace() != "" {
+			found = append(found, fmt.Sprintf("%s/%s/%s", kind, obj.GetNamespace(), obj.GetName()))
+		} else {
+			found = append(found, fmt.Sprintf("%s/%s", kind, obj.GetName()))
+		}
+	}
+	slices.Sort(found)
+	*references = append(*references, found...)
+	return nil
+}
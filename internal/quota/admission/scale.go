@@ -0,0 +1,157 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+const (
+	// scaleTargetLabel records the UID of the object whose scale subresource a
+	// ResourceClaim charges a replica of.
+	scaleTargetLabel = "quota.miloapis.com/scale-target"
+	// scaleReplicaLabel records which replica of the scale target a ResourceClaim charges.
+	scaleReplicaLabel = "quota.miloapis.com/scale-replica"
+)
+
+var resourceClaimsGVR = quotav1alpha1.GroupVersion.WithResource("resourceclaims")
+
+// scaleChange is the replica count a scale subresource request changes from and to.
+type scaleChange struct {
+	oldReplicas int64
+	replicas    int64
+}
+
+// claimTarget overrides the claim a request is charged by. Scale-ups are charged one
+// claim per added replica, named after the replica and owned by the scaled object.
+type claimTarget struct {
+	name    string
+	replica int64
+	parent  *unstructured.Unstructured
+}
+
+// scaleChangeOf reads the replica counts of a scale subresource request. An update
+// without an old object is treated as scaling up from zero.
+func (p *ResourceQuotaEnforcementPlugin) scaleChangeOf(attrs admission.Attributes, gvk schema.GroupVersionKind, scale *unstructured.Unstructured) (scaleChange, error) {
+	replicas, _, err := unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	if err != nil {
+		return scaleChange{}, fmt.Errorf("failed to read spec.replicas: %w", err)
+	}
+	change := scaleChange{replicas: replicas}
+	if attrs.GetOldObject() == nil {
+		return change, nil
+	}
+	oldScale, err := toUnstructured(attrs.GetOldObject(), gvk)
+	if err != nil {
+		return scaleChange{}, err
+	}
+	if change.oldReplicas, _, err = unstructured.NestedInt64(oldScale.Object, "spec", "replicas"); err != nil {
+		return scaleChange{}, fmt.Errorf("failed to read old spec.replicas: %w", err)
+	}
+	return change, nil
+}
+
+// getScaleTarget returns the object whose scale subresource the request updates.
+func (p *ResourceQuotaEnforcementPlugin) getScaleTarget(ctx context.Context, attrs admission.Attributes) (*unstructured.Unstructured, error) {
+	client, err := p.getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for context: %w", err)
+	}
+	parent, err := client.Resource(attrs.GetResource()).Namespace(attrs.GetNamespace()).Get(ctx, attrs.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", attrs.GetResource().Resource, attrs.GetName(), err)
+	}
+	return parent, nil
+}
+
+// createAndWaitForScaleClaims charges a scale-up with one claim for each added replica,
+// so that scaling back down can release exactly the replicas it removes. A scale-up that
+// is not fully granted releases the claims it did get.
+func (p *ResourceQuotaEnforcementPlugin) createAndWaitForScaleClaims(ctx context.Context, attrs admission.Attributes, policy *quotav1alpha1.ClaimCreationPolicy, evalContext *EvaluationContext, change scaleChange) error {
+	parent, err := p.getScaleTarget(ctx, attrs)
+	if err != nil {
+		return err
+	}
+
+	for replica := change.oldReplicas + 1; replica <= change.replicas; replica++ {
+		target := &claimTarget{
+			name:    fmt.Sprintf("%s-%s-scale-%d", parent.GetName(), attrs.GetResource().Resource, replica),
+			replica: replica,
+			parent:  parent,
+		}
+		if err := p.createAndWaitForResourceClaim(ctx, attrs, policy, evalContext, target); err != nil {
+			p.releaseScaleClaims(ctx, policy, evalContext, parent, change.oldReplicas)
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseScaleClaims deletes the claims charging replicas of the scale target above
+// the given count. Failures are logged; the claims are released when the target is
+// deleted at the latest.
+func (p *ResourceQuotaEnforcementPlugin) releaseScaleClaims(ctx context.Context, policy *quotav1alpha1.ClaimCreationPolicy, evalContext *EvaluationContext, parent *unstructured.Unstructured, replicas int64) {
+	client, err := p.getClient(ctx)
+	if err != nil {
+		p.logger.Error(err, "Failed to get client to release scale claims", "policy", policy.Name, "target", parent.GetName())
+		return
+	}
+
+	claims := client.Resource(resourceClaimsGVR).Namespace(p.getClaimNamespace(policy, evalContext))
+	list, err := claims.List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			scaleTargetLabel:            string(parent.GetUID()),
+			"quota.miloapis.com/policy": policy.Name,
+		}).String(),
+	})
+	if err != nil {
+		p.logger.Error(err, "Failed to list scale claims", "policy", policy.Name, "target", parent.GetName())
+		return
+	}
+
+	for _, claim := range list.Items {
+		replica, err := strconv.ParseInt(claim.GetLabels()[scaleReplicaLabel], 10, 64)
+		if err != nil || replica <= replicas {
+			continue
+		}
+		if err := claims.Delete(ctx, claim.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			p.logger.Error(err, "Failed to release scale claim", "claim", claim.GetName(), "target", parent.GetName())
+			continue
+		}
+		p.logger.V(2).Info("Released ResourceClaim of removed replica",
+			"claim", claim.GetName(),
+			"target", parent.GetName(),
+			"replica", replica)
+	}
+}
+
+// applyClaimTarget points a scale claim at the scaled object rather than the Scale, and
+// lets it be garbage collected with the object when they share a namespace.
+func applyClaimTarget(claim *quotav1alpha1.ResourceClaim, target *claimTarget) {
+	parent := target.parent
+	claim.Spec.ResourceRef = quotav1alpha1.UnversionedObjectReference{
+		APIGroup:  parent.GroupVersionKind().Group,
+		Kind:      parent.GetKind(),
+		Name:      parent.GetName(),
+		Namespace: parent.GetNamespace(),
+	}
+	if parent.GetNamespace() == claim.Namespace {
+		claim.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: parent.GetAPIVersion(),
+			Kind:       parent.GetKind(),
+			Name:       parent.GetName(),
+			UID:        parent.GetUID(),
+		}}
+	}
+	claim.Labels[scaleTargetLabel] = string(parent.GetUID())
+	claim.Labels[scaleReplicaLabel] = strconv.FormatInt(target.replica, 10)
+}
@@ -0,0 +1,73 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+var namespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+// triggerSelectorsMatch reports whether obj, created in namespace, satisfies the policy's
+// namespace and object selectors. Selectors follow admission webhook semantics: cluster-scoped objects always
+// match the namespace selector, except Namespaces, which are matched on their own labels.
+func (p *ResourceQuotaEnforcementPlugin) triggerSelectorsMatch(ctx context.Context, policy *quotav1alpha1.ClaimCreationPolicy, gvk schema.GroupVersionKind, namespace string, obj *unstructured.Unstructured) (bool, error) {
+	trigger := policy.Spec.Trigger
+
+	if trigger.ObjectSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(trigger.ObjectSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid objectSelector: %w", err)
+		}
+		if !selector.Matches(labels.Set(obj.GetLabels())) {
+			return false, nil
+		}
+	}
+
+	if trigger.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(trigger.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+	if selector.Empty() {
+		return true, nil
+	}
+
+	if namespace == "" {
+		if gvk.Group == "" && gvk.Kind == "Namespace" {
+			return selector.Matches(labels.Set(obj.GetLabels())), nil
+		}
+		return true, nil
+	}
+
+	namespaceLabels, err := p.getNamespaceLabels(ctx, namespace)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(namespaceLabels)), nil
+}
+
+// getNamespaceLabels fetches the labels of a namespace from the control plane serving the request.
+func (p *ResourceQuotaEnforcementPlugin) getNamespaceLabels(ctx context.Context, namespace string) (map[string]string, error) {
+	client, err := p.getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client for namespace lookup: %w", err)
+	}
+	if client == nil {
+		return nil, fmt.Errorf("dynamic client not initialized for namespace lookup")
+	}
+
+	ns, err := client.Resource(namespaceGVR).Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	return ns.GetLabels(), nil
+}
@@ -6,6 +6,8 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apiserver/pkg/endpoints/request"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
 )
 
 // ClaimResult represents the result of waiting for a ResourceClaim to be processed.
@@ -18,6 +20,30 @@ type ClaimResult struct {
 
 	// Error contains any error that occurred during processing
 	Error error
+
+	// DeniedRequests lists the individual resource requests that were denied,
+	// so callers can tell users which resource type ran out of quota.
+	DeniedRequests []RequestDenial
+
+	// Paused indicates quota granting is paused for maintenance, so the claim will
+	// stay pending until it resumes. Granted is false and Reason holds the claim's message.
+	Paused bool
+}
+
+// RequestDenial describes why a single resource request within a ResourceClaim was denied.
+type RequestDenial struct {
+	// ResourceType is the resource type of the denied request.
+	ResourceType string `json:"resourceType"`
+
+	// Reason is the machine-readable reason recorded on the allocation (e.g., "QuotaExceeded").
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the human-readable explanation recorded on the allocation.
+	Message string `json:"message,omitempty"`
+
+	// Details holds the capacity figures recorded in the claim's status.denialDetails
+	// for the request, if any.
+	Details *quotav1alpha1.ResourceClaimDenialDetail `json:"details,omitempty"`
 }
 
 // ClaimWatchManager provides an interface for watching ResourceClaim status changes
@@ -49,8 +75,10 @@ type EvaluationContext struct {
 	Object      *unstructured.Unstructured
 	User        UserContext
 	RequestInfo *request.RequestInfo
-	Namespace   string
-	GVK         struct {
+	// Parent is the Organization that owns the project the request was made in, if resolved.
+	Parent    *unstructured.Unstructured
+	Namespace string
+	GVK       struct {
 		Group   string
 		Version string
 		Kind    string
@@ -0,0 +1,158 @@
+package admission
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/warning"
+
+	"go.miloapis.com/milo/internal/quota/engine"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// claimTemplateError is returned when a policy's ResourceClaim template cannot be
+// rendered for the triggering resource.
+type claimTemplateError struct {
+	err error
+}
+
+func (e *claimTemplateError) Error() string {
+	return fmt.Sprintf("failed to render ResourceClaim template: %v", e.err)
+}
+
+func (e *claimTemplateError) Unwrap() error {
+	return e.err
+}
+
+// unregisteredResourceTypeError is returned when a rendered ResourceClaim requests a
+// resource type that has no active ResourceRegistration, which a claim can never be
+// granted for.
+type unregisteredResourceTypeError struct {
+	resourceType string
+}
+
+func (e *unregisteredResourceTypeError) Error() string {
+	return fmt.Sprintf("resource type %s is not registered", e.resourceType)
+}
+
+// admitWithoutEnforcement admits a request that policy triggers on but cannot charge,
+// telling the client why through an admission warning. Every such warning names the
+// policy and has the same shape, so users and operators can tell a resource that was
+// not charged from one that was.
+func (p *ResourceQuotaEnforcementPlugin) admitWithoutEnforcement(ctx context.Context, attrs admission.Attributes, gvk schema.GroupVersionKind, policy *quotav1alpha1.ClaimCreationPolicy, reason, cause string) {
+	p.logger.Info("Policy cannot enforce quota, allowing resource without a ResourceClaim",
+		"policy", policy.Name,
+		"resourceName", attrs.GetName(),
+		"gvk", gvk,
+		"reason", reason,
+		"cause", cause)
+
+	warning.AddWarning(ctx, "", fmt.Sprintf("Quota was not enforced: ClaimCreationPolicy %s %s. The %s was created without a ResourceClaim; contact your platform operator if this persists.",
+		policy.Name, cause, gvk.Kind))
+	p.recordDecision(ctx, attrs, gvk, policy, DecisionSkipped, reason, cause, nil)
+}
+
+// denyUnenforceable rejects a request whose policy cannot build a ResourceClaim that
+// could ever be granted. Admitting it would let the resource through uncharged, so the
+// rejection names the policy and the cause for the operator to fix.
+func (p *ResourceQuotaEnforcementPlugin) denyUnenforceable(ctx context.Context, attrs admission.Attributes, gvk schema.GroupVersionKind, policy *quotav1alpha1.ClaimCreationPolicy, reason, cause string) error {
+	admissionResultTotal.WithLabelValues("denied", resultReasonError, policy.Name, policy.Namespace,
+		gvk.Group, gvk.Kind).Inc()
+
+	p.logger.Info("Policy cannot enforce quota, denying resource creation",
+		"policy", policy.Name,
+		"resourceName", attrs.GetName(),
+		"gvk", gvk,
+		"reason", reason,
+		"cause", cause)
+
+	p.recordDecision(ctx, attrs, gvk, policy, DecisionDenied, reason, cause, nil)
+	gr := schema.GroupResource{Group: gvk.Group, Resource: attrs.GetResource().Resource}
+	//lint:ignore ST1005 "Error message intentionally capitalized for user-facing display"
+	return apierrors.NewForbidden(gr, attrs.GetName(), fmt.Errorf("Quota could not be enforced: ClaimCreationPolicy %s %s. Contact your platform operator.",
+		policy.Name, cause))
+}
+
+// admitInAuditMode admits a request whose ResourceClaim was not granted because its
+// policy is in Audit mode. The would-be rejection is still counted, with the reason
+// it would have carried, and reported to the client so a policy's impact can be
+// observed before it is enforced. The claim itself has already been released, see
+// releaseAuditClaim.
+func (p *ResourceQuotaEnforcementPlugin) admitInAuditMode(ctx context.Context, attrs admission.Attributes, gvk schema.GroupVersionKind, policy *quotav1alpha1.ClaimCreationPolicy, resultReason string, err error) {
+	admissionResultTotal.WithLabelValues("audited", resultReason, policy.Name, policy.Namespace,
+		gvk.Group, gvk.Kind).Inc()
+
+	p.logger.Info("ResourceClaim not granted, allowing resource because the policy is in Audit mode",
+		"policy", policy.Name,
+		"resourceName", attrs.GetName(),
+		"gvk", gvk,
+		"reason", resultReason,
+		"cause", err.Error())
+
+	warning.AddWarning(ctx, "", fmt.Sprintf("Quota was not enforced: ClaimCreationPolicy %s is in Audit mode and would have rejected this %s: %v",
+		policy.Name, gvk.Kind, err))
+
+	var deniedErr *claimDeniedError
+	var denials []RequestDenial
+	if goerrors.As(err, &deniedErr) {
+		denials = deniedErr.requests
+	}
+	p.recordDecision(ctx, attrs, gvk, policy, DecisionAudited, DecisionReasonAuditMode, err.Error(), denials)
+}
+
+// auditClaimReleaseTimeout bounds deleting the ResourceClaim of a request admitted
+// in Audit mode.
+const auditClaimReleaseTimeout = 5 * time.Second
+
+// releaseAuditClaim deletes the ResourceClaim of a request that an Audit mode policy
+// would have rejected. The request's own context may already be done, so the claim
+// is deleted with a short deadline of its own. Failures are logged; denied claims are
+// removed by the cleanup controller at the latest.
+func (p *ResourceQuotaEnforcementPlugin) releaseAuditClaim(ctx context.Context, policy *quotav1alpha1.ClaimCreationPolicy, namespace, name string) {
+	client, err := p.getClient(ctx)
+	if err != nil {
+		p.logger.Error(err, "Failed to get client to release audited ResourceClaim", "policy", policy.Name, "claim", name)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditClaimReleaseTimeout)
+	defer cancel()
+	if err := client.Resource(resourceClaimsGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		p.logger.Error(err, "Failed to release audited ResourceClaim", "policy", policy.Name, "claim", name, "namespace", namespace)
+		return
+	}
+	p.logger.V(2).Info("Released ResourceClaim of request admitted in Audit mode",
+		"policy", policy.Name,
+		"claim", name,
+		"namespace", namespace)
+}
+
+// lookupUnreadyPolicy returns an enabled policy that triggers on gvk but is not Ready,
+// when the policy engine tracks them.
+func (p *ResourceQuotaEnforcementPlugin) lookupUnreadyPolicy(gvk schema.GroupVersionKind) *quotav1alpha1.ClaimCreationPolicy {
+	lookup, ok := p.policyEngine.(engine.UnreadyPolicyLookup)
+	if !ok {
+		return nil
+	}
+	return lookup.GetUnreadyPolicyForGVK(gvk)
+}
+
+// policyNotReadyCause describes why an unready policy is not enforced, using its Ready
+// condition when the policy controller has set one.
+func policyNotReadyCause(policy *quotav1alpha1.ClaimCreationPolicy) string {
+	ready := apimeta.FindStatusCondition(policy.Status.Conditions, quotav1alpha1.ClaimCreationPolicyReady)
+	if ready == nil {
+		return "has not been validated yet"
+	}
+	if ready.Message == "" {
+		return fmt.Sprintf("is not ready (%s)", ready.Reason)
+	}
+	return fmt.Sprintf("is not ready (%s: %s)", ready.Reason, ready.Message)
+}
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
@@ -36,6 +37,7 @@ type claimWaiter struct {
 // stateless operation optimized for admission plugin requirements.
 //
 // Key characteristics:
+// - Shared stream: One watch per project serves every waiter, dispatched by claim name
 // - Direct watch API: Connects to Kubernetes watch stream without LIST operation
 // - Fast startup: Begins watching from "now" using empty resourceVersion
 // - Minimal memory: No cache; tracks only active waiters
@@ -86,7 +88,15 @@ type watchManager struct {
 
 // NewWatchManager creates a new watch manager with TTL-based lifecycle management
 func NewWatchManager(dynamicClient dynamic.Interface, logger logr.Logger, projectID string) ClaimWatchManager {
-	config := DefaultWatchManagerConfig()
+	return NewWatchManagerWithConfig(dynamicClient, logger, projectID, DefaultWatchManagerConfig())
+}
+
+// NewWatchManagerWithConfig creates a new watch manager using the given configuration,
+// or the default configuration when config is nil
+func NewWatchManagerWithConfig(dynamicClient dynamic.Interface, logger logr.Logger, projectID string, config *WatchManagerConfig) ClaimWatchManager {
+	if config == nil {
+		config = DefaultWatchManagerConfig()
+	}
 
 	return &watchManager{
 		dynamicClient: dynamicClient,
@@ -194,6 +204,9 @@ func (w *watchManager) Stop() {
 		}
 		w.waitersLock.Unlock()
 
+		atomic.StoreInt32(&w.activeWaiters, 0)
+		waitersRegistered.DeleteLabelValues(projectMetricLabel(w.projectID))
+
 		watchManagersStopped.Inc()
 		watchManagersActive.Dec()
 		watchStreamsDesired.Dec()
@@ -218,14 +231,6 @@ func (w *watchManager) RegisterClaimWaiter(ctx context.Context, claimName, names
 		"timeout", timeout,
 		"project", w.projectID)
 
-	// Check if we've reached the maximum number of waiters
-	w.waitersLock.RLock()
-	if w.config.MaxWaiters > 0 && len(w.waiters) >= w.config.MaxWaiters {
-		w.waitersLock.RUnlock()
-		return nil, nil, fmt.Errorf("maximum number of waiters (%d) reached", w.config.MaxWaiters)
-	}
-	w.waitersLock.RUnlock()
-
 	// Create waiter context for cancellation
 	_, cancelFunc := context.WithCancel(ctx)
 
@@ -242,8 +247,18 @@ func (w *watchManager) RegisterClaimWaiter(ctx context.Context, claimName, names
 		startTime:  time.Now(),
 	}
 
-	// Register the waiter BEFORE checking for existing claims
+	// Register the waiter BEFORE checking for existing claims. The waiter limit is
+	// checked under the same lock so concurrent registrations cannot exceed it.
 	w.waitersLock.Lock()
+	if _, exists := w.waiters[key]; !exists && w.config.MaxWaiters > 0 && len(w.waiters) >= w.config.MaxWaiters {
+		w.waitersLock.Unlock()
+		cancelFunc()
+		waiterRejections.Inc()
+		return nil, nil, &claimPendingError{
+			saturated: true,
+			message:   fmt.Sprintf("maximum number of concurrent claim waiters (%d) reached", w.config.MaxWaiters),
+		}
+	}
 	w.waiters[key] = waiter
 	w.waitersLock.Unlock()
 
@@ -254,6 +269,7 @@ func (w *watchManager) RegisterClaimWaiter(ctx context.Context, claimName, names
 	// Metrics: track registrations and current waiter count
 	waiterRegistrations.Inc()
 	waitersCurrent.Set(float64(activeCount))
+	waitersRegistered.WithLabelValues(projectMetricLabel(w.projectID)).Set(float64(activeCount))
 
 	// Return a cancel function that cleans up the waiter
 	cancelWithCleanup := func() {
@@ -270,13 +286,14 @@ func (w *watchManager) RegisterClaimWaiter(ctx context.Context, claimName, names
 			"project", w.projectID)
 
 		// Metrics for timeout
+		waiterTimeouts.Inc()
 		waiterDuration.WithLabelValues("timeout").Observe(time.Since(waiter.startTime).Seconds())
 
 		select {
 		case resultChan <- ClaimResult{
 			Granted: false,
 			Reason:  "timeout",
-			Error:   fmt.Errorf("timeout waiting for ResourceClaim %s/%s after %v", namespace, claimName, timeout),
+			Error:   &claimPendingError{message: fmt.Sprintf("timeout waiting for ResourceClaim %s/%s after %v", namespace, claimName, timeout)},
 		}:
 		default:
 		}
@@ -320,6 +337,7 @@ func (w *watchManager) UnregisterClaimWaiter(claimName, namespace string) {
 		// Metrics: unregister and current waiter count
 		waiterCompletions.WithLabelValues("unregistered").Inc()
 		waitersCurrent.Set(float64(activeCount))
+		waitersRegistered.WithLabelValues(projectMetricLabel(w.projectID)).Set(float64(activeCount))
 
 		w.logger.V(4).Info("Claim waiter unregistered",
 			"claimName", claimName,
@@ -684,15 +702,21 @@ func (w *watchManager) handleClaimEvent(obj interface{}) {
 	}
 
 	// Evaluate the claim status
-	if result := w.evaluateClaimStatus(unstructuredObj); result != nil {
+	if result := evaluateClaimStatus(unstructuredObj); result != nil {
 		// Claim has reached a final state
 		outcome := "granted"
-		if !result.Granted {
+		if result.Paused {
+			outcome = "paused"
+		} else if !result.Granted {
 			outcome = "denied"
 		}
 
-		waiterDuration.WithLabelValues(outcome).Observe(time.Since(waiter.startTime).Seconds())
+		latency := time.Since(waiter.startTime).Seconds()
+		waiterDuration.WithLabelValues(outcome).Observe(latency)
 		watchEventsProcessed.WithLabelValues(outcome).Inc()
+		if result.Granted {
+			claimGrantLatency.Observe(latency)
+		}
 
 		// Send result to waiter.
 		// This should always succeed immediately since:
@@ -747,14 +771,14 @@ func (w *watchManager) handleClaimDeletion(obj interface{}) {
 	waiter.resultChan <- ClaimResult{
 		Granted: false,
 		Reason:  "deleted",
-		Error:   fmt.Errorf("ResourceClaim %s/%s was deleted", key.Namespace, key.Name),
+		Error:   &claimDeletedError{namespace: key.Namespace, name: key.Name},
 	}
 
 	w.UnregisterClaimWaiter(key.Name, key.Namespace)
 }
 
 // evaluateClaimStatus evaluates a ResourceClaim's status and returns a result if final
-func (w *watchManager) evaluateClaimStatus(claim *unstructured.Unstructured) *ClaimResult {
+func evaluateClaimStatus(claim *unstructured.Unstructured) *ClaimResult {
 	// Extract the status from the unstructured object
 	status, found, err := unstructured.NestedMap(claim.Object, "status")
 	if err != nil || !found {
@@ -797,8 +821,16 @@ func (w *watchManager) evaluateClaimStatus(claim *unstructured.Unstructured) *Cl
 					Reason:  reason,
 				}
 			} else if conditionStatus == string(metav1.ConditionFalse) && reason == quotav1alpha1.ResourceClaimDeniedReason {
+				return &ClaimResult{
+					Granted:        false,
+					Reason:         message,
+					DeniedRequests: deniedRequestsFromStatus(status),
+				}
+			} else if conditionStatus == string(metav1.ConditionFalse) && reason == quotav1alpha1.ResourceClaimGrantingPausedReason {
+				// The claim will not be resolved until granting resumes
 				return &ClaimResult{
 					Granted: false,
+					Paused:  true,
 					Reason:  message,
 				}
 			}
@@ -810,6 +842,56 @@ func (w *watchManager) evaluateClaimStatus(claim *unstructured.Unstructured) *Cl
 	return nil
 }
 
+// deniedRequestsFromStatus extracts the per-request denials recorded in a
+// ResourceClaim's status.allocations, in the order they appear.
+func deniedRequestsFromStatus(status map[string]interface{}) []RequestDenial {
+	allocations, found, err := unstructured.NestedSlice(status, "allocations")
+	if err != nil || !found {
+		return nil
+	}
+
+	details := map[string]*quotav1alpha1.ResourceClaimDenialDetail{}
+	if rawDetails, found, _ := unstructured.NestedSlice(status, "denialDetails"); found {
+		for _, rawDetail := range rawDetails {
+			detailMap, ok := rawDetail.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			detail := &quotav1alpha1.ResourceClaimDenialDetail{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(detailMap, detail); err != nil {
+				continue
+			}
+			details[detail.ResourceType] = detail
+		}
+	}
+
+	var denials []RequestDenial
+	for _, allocationInterface := range allocations {
+		allocation, ok := allocationInterface.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		allocationStatus, _, _ := unstructured.NestedString(allocation, "status")
+		if allocationStatus != quotav1alpha1.ResourceClaimAllocationStatusDenied {
+			continue
+		}
+
+		resourceType, _, _ := unstructured.NestedString(allocation, "resourceType")
+		reason, _, _ := unstructured.NestedString(allocation, "reason")
+		message, _, _ := unstructured.NestedString(allocation, "message")
+
+		denials = append(denials, RequestDenial{
+			ResourceType: resourceType,
+			Reason:       reason,
+			Message:      message,
+			Details:      details[resourceType],
+		})
+	}
+
+	return denials
+}
+
 // applyJitter applies random jitter to backoff duration
 func (w *watchManager) applyJitter(duration time.Duration) time.Duration {
 	jitter := w.config.Retry.Jitter
@@ -6,8 +6,9 @@ import (
 )
 
 // Metrics for watch manager
-// All metrics are aggregated at the system level to avoid cardinality explosion.
-// Use Prometheus queries to derive per-project insights from admission plugin metrics instead.
+// Metrics are aggregated at the system level to avoid cardinality explosion, with the
+// exception of waitersRegistered which is labeled by project so that stuck watch managers
+// can be identified. Series for a project are removed when its watch manager stops.
 var (
 	// Lifecycle metrics
 	watchManagersCreated = metrics.NewCounter(
@@ -155,6 +156,44 @@ var (
 		},
 	)
 
+	waitersRegistered = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      "milo_quota_admission",
+			Name:           "waiters_registered",
+			Help:           "Current number of registered claim waiters, labeled by project ('root' for the root scope).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"project"},
+	)
+
+	waiterTimeouts = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      "milo_quota_admission",
+			Name:           "waiter_timeouts_total",
+			Help:           "Total number of claim waiters that timed out before receiving a claim result.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	waiterRejections = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      "milo_quota_admission",
+			Name:           "waiter_rejections_total",
+			Help:           "Total number of claim waiter registrations rejected because the waiter limit was reached.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	claimGrantLatency = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      "milo_quota_admission",
+			Name:           "claim_grant_latency_seconds",
+			Help:           "Latency from claim waiter registration to delivery of a granted result.",
+			Buckets:        []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 20, 30, 60},
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
 	waiterDuration = metrics.NewHistogramVec(
 		&metrics.HistogramOpts{
 			Subsystem:      "milo_quota_admission",
@@ -184,6 +223,42 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		},
 	)
+
+	watchManagerEvictions = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      "milo_quota_admission",
+			Name:           "watch_manager_evictions_total",
+			Help:           "Total number of watch managers evicted from the plugin cache after their TTL expired.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	claimWaitQueueDepth = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      "milo_quota_admission",
+			Name:           "claim_wait_queue_depth",
+			Help:           "Current number of requests queued for a free claim wait slot in their project.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	claimWaitRejections = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      "milo_quota_admission",
+			Name:           "claim_wait_rejections_total",
+			Help:           "Total number of requests rejected as overloaded because their project had no free claim wait slot.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	projectClientEvictions = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      "milo_quota_admission",
+			Name:           "project_client_evictions_total",
+			Help:           "Total number of project control-plane clients evicted from the plugin cache after going unused for the watch manager TTL.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
 )
 
 func init() {
@@ -203,7 +278,23 @@ func init() {
 	legacyregistry.MustRegister(waiterRegistrations)
 	legacyregistry.MustRegister(waiterCompletions)
 	legacyregistry.MustRegister(waitersCurrent)
+	legacyregistry.MustRegister(waitersRegistered)
+	legacyregistry.MustRegister(waiterTimeouts)
+	legacyregistry.MustRegister(waiterRejections)
+	legacyregistry.MustRegister(claimGrantLatency)
 	legacyregistry.MustRegister(waiterDuration)
 	legacyregistry.MustRegister(ttlResets)
 	legacyregistry.MustRegister(ttlExpirations)
+	legacyregistry.MustRegister(watchManagerEvictions)
+	legacyregistry.MustRegister(claimWaitQueueDepth)
+	legacyregistry.MustRegister(claimWaitRejections)
+	legacyregistry.MustRegister(projectClientEvictions)
+}
+
+// projectMetricLabel returns the project label value used for per-project metrics.
+func projectMetricLabel(projectID string) string {
+	if projectID == "" {
+		return "root"
+	}
+	return projectID
 }
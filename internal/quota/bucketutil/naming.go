@@ -0,0 +1,32 @@
+// Package bucketutil provides helpers for locating the AllowanceBucket that
+// tracks quota for a consumer and resource type.
+package bucketutil
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// Name creates a deterministic name for an AllowanceBucket.
+// Buckets are global per consumer and resource type, not per claim namespace.
+//
+// Existing buckets are looked up by this name, so changing how it is derived
+// would orphan them and count their claims again in newly created buckets.
+func Name(resourceType string, consumerRef quotav1alpha1.ConsumerRef) string {
+	input := fmt.Sprintf("%s%s%s", resourceType, consumerRef.Kind, consumerRef.Name)
+	return fmt.Sprintf("bucket-%x", sha256.Sum256([]byte(input)))
+}
+
+// Namespace determines the namespace where an AllowanceBucket should be created
+// based on the consumer type:
+// - Organization consumers → organization-{name} namespace
+// - Project consumers → milo-system namespace (centralized quota tracking)
+// - Other consumers → milo-system namespace (default)
+func Namespace(consumerRef quotav1alpha1.ConsumerRef) string {
+	if consumerRef.Kind == "Organization" {
+		return fmt.Sprintf("organization-%s", consumerRef.Name)
+	}
+	return "milo-system"
+}
@@ -0,0 +1,33 @@
+package bucketutil
+
+import (
+	"testing"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestName(t *testing.T) {
+	const resourceType = "resourcemanager.miloapis.com/projects"
+	organization := quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Organization", Name: "acme"}
+
+	name := Name(resourceType, organization)
+	if name != Name(resourceType, organization) {
+		t.Fatalf("expected Name() to be deterministic")
+	}
+
+	// The name must not change across releases, as existing buckets are looked up by it.
+	if want := "bucket-beed540191cd254683bcc44b67bfa191465ba35802b816815ecbb05a0c9a022a"; name != want {
+		t.Errorf("Name() = %s, want %s", name, want)
+	}
+
+	others := map[string]string{
+		"resource type": Name("resourcemanager.miloapis.com/organizations", organization),
+		"consumer kind": Name(resourceType, quotav1alpha1.ConsumerRef{APIGroup: organization.APIGroup, Kind: "Project", Name: "acme"}),
+		"consumer name": Name(resourceType, quotav1alpha1.ConsumerRef{APIGroup: organization.APIGroup, Kind: "Organization", Name: "other"}),
+	}
+	for field, other := range others {
+		if other == name {
+			t.Errorf("expected buckets differing in %s to have different names, both got %s", field, name)
+		}
+	}
+}
@@ -3,13 +3,54 @@
 package cel
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/clock"
 )
 
+// EnvironmentOption configures the quota CEL environment.
+type EnvironmentOption func(*environmentOptions)
+
+type environmentOptions struct {
+	clock             clock.PassiveClock
+	organizationTiers OrganizationTierLister
+}
+
+// OrganizationTierLister looks up the tier of an organization for the orgTier() function.
+type OrganizationTierLister interface {
+	// OrganizationTier returns the tier of the named organization.
+	OrganizationTier(name string) (string, error)
+}
+
+// errOrganizationTiersUnavailable is returned by orgTier() when no OrganizationTierLister is configured,
+// as in environments that only type-check expressions.
+var errOrganizationTiersUnavailable = errors.New("organization tier lookups are not available")
+
+// WithClock sets the clock read by the now() function. Tests use this to evaluate
+// time-based expressions at a fixed instant. Defaults to the real clock.
+func WithClock(clk clock.PassiveClock) EnvironmentOption {
+	return func(o *environmentOptions) {
+		o.clock = clk
+	}
+}
+
+// WithOrganizationTiers sets the lister read by the orgTier() function. Without it, orgTier()
+// type-checks but fails at evaluation.
+func WithOrganizationTiers(lister OrganizationTierLister) EnvironmentOption {
+	return func(o *environmentOptions) {
+		o.organizationTiers = lister
+	}
+}
+
 // NewQuotaEnvironment creates a CEL environment with quota system variables and functions.
 // This environment is shared between validation (compile-time checks) and engine (runtime evaluation)
 // to ensure expressions validated at policy creation time work correctly during execution.
@@ -18,16 +59,39 @@ import (
 //   - trigger (dyn): The Kubernetes resource object that triggered the policy evaluation
 //   - user (dyn): The user context for the request (ClaimCreationPolicy only)
 //   - requestInfo (dyn): The admission request context (ClaimCreationPolicy only)
+//   - parent (dyn): The Organization that owns the project a request was made in (ClaimCreationPolicy
+//     only, when the request was made in a project control plane)
 //
 // Functions:
 //   - has(obj, field): Check if a nested field exists using dot notation (e.g., has(trigger, "spec.tier"))
-func NewQuotaEnvironment() (*cel.Env, error) {
+//   - lower(s): Lowercase a string
+//   - trunc(s, n): Keep the first n characters of s, or the last -n characters when n is negative
+//   - sha256sum(s): Hex-encoded SHA-256 digest of s
+//   - default(fallback, value): value, or fallback when value is null, empty, zero, or false
+//   - trimSuffix(s, suffix): Remove suffix from the end of s if present
+//   - now(): The current wall-clock time as a timestamp (e.g., now().getHours("UTC") >= 9)
+//   - quantity(s): The Kubernetes quantity s as an integer, rounded up (e.g., quantity("10Gi"))
+//   - hasLabel(obj, key): Whether the object's metadata.labels contains key
+//   - orgTier(consumerRef): The tier of the Organization a consumer reference points to
+//     (e.g., orgTier(trigger.spec.consumerRef) == "Standard")
+//
+// The string helpers follow the sprig template functions of the same name. They are pure
+// functions of their arguments: no I/O or randomness is exposed, so a template renders the
+// same way at validation time, at admission, and on every retry. now() and orgTier() are the
+// exceptions; expressions that call them may evaluate differently each time they run.
+func NewQuotaEnvironment(opts ...EnvironmentOption) (*cel.Env, error) {
+	options := environmentOptions{clock: clock.RealClock{}}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	return cel.NewEnv(
 		// Add variables as dynamic types for maximum flexibility
 		// Template validation accepts both string and dynamic types
 		cel.Variable("trigger", cel.DynType),
 		cel.Variable("user", cel.DynType),
 		cel.Variable("requestInfo", cel.DynType),
+		cel.Variable("parent", cel.DynType),
 
 		// Add custom functions for Kubernetes operations
 		cel.Function("has",
@@ -42,9 +106,156 @@ func NewQuotaEnvironment() (*cel.Env, error) {
 				}),
 			),
 		),
+		cel.Function("lower",
+			cel.Overload("lower_string", []*cel.Type{cel.StringType}, cel.StringType,
+				cel.UnaryBinding(func(s ref.Val) ref.Val {
+					return types.String(strings.ToLower(string(s.(types.String))))
+				}),
+			),
+		),
+		cel.Function("trunc",
+			cel.Overload("trunc_string_int", []*cel.Type{cel.StringType, cel.IntType}, cel.StringType,
+				cel.BinaryBinding(func(s, n ref.Val) ref.Val {
+					return types.String(truncate(string(s.(types.String)), int64(n.(types.Int))))
+				}),
+			),
+		),
+		cel.Function("sha256sum",
+			cel.Overload("sha256sum_string", []*cel.Type{cel.StringType}, cel.StringType,
+				cel.UnaryBinding(func(s ref.Val) ref.Val {
+					sum := sha256.Sum256([]byte(s.(types.String)))
+					return types.String(hex.EncodeToString(sum[:]))
+				}),
+			),
+		),
+		cel.Function("default",
+			cel.Overload("default_dyn_dyn", []*cel.Type{cel.DynType, cel.DynType}, cel.DynType,
+				cel.BinaryBinding(func(fallback, value ref.Val) ref.Val {
+					if isEmpty(value) {
+						return fallback
+					}
+					return value
+				}),
+			),
+		),
+		cel.Function("trimSuffix",
+			cel.Overload("trimSuffix_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.StringType,
+				cel.BinaryBinding(func(s, suffix ref.Val) ref.Val {
+					return types.String(strings.TrimSuffix(string(s.(types.String)), string(suffix.(types.String))))
+				}),
+			),
+		),
+		cel.Function("now",
+			cel.Overload("now_timestamp", []*cel.Type{}, cel.TimestampType,
+				cel.FunctionBinding(func(...ref.Val) ref.Val {
+					return types.Timestamp{Time: options.clock.Now().UTC()}
+				}),
+			),
+		),
+		cel.Function("quantity",
+			cel.Overload("quantity_string", []*cel.Type{cel.StringType}, cel.IntType,
+				cel.UnaryBinding(func(s ref.Val) ref.Val {
+					q, err := resource.ParseQuantity(string(s.(types.String)))
+					if err != nil {
+						return types.NewErr("quantity: %v", err)
+					}
+					return types.Int(q.Value())
+				}),
+			),
+		),
+		cel.Function("hasLabel",
+			cel.Overload("hasLabel_dyn_string", []*cel.Type{cel.DynType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(obj, key ref.Val) ref.Val {
+					objMap, ok := obj.Value().(map[string]interface{})
+					if !ok {
+						return types.False
+					}
+					labels, ok := GetNestedField(objMap, "metadata.labels")
+					if !ok {
+						return types.False
+					}
+					labelMap, ok := labels.(map[string]interface{})
+					if !ok {
+						return types.False
+					}
+					_, exists := labelMap[string(key.(types.String))]
+					return types.Bool(exists)
+				}),
+			),
+		),
+		cel.Function("orgTier",
+			cel.Overload("orgTier_dyn", []*cel.Type{cel.DynType}, cel.StringType,
+				cel.UnaryBinding(func(consumerRef ref.Val) ref.Val {
+					tier, err := organizationTier(options.organizationTiers, consumerRef)
+					if err != nil {
+						return types.NewErr("orgTier: %v", err)
+					}
+					return types.String(tier)
+				}),
+			),
+		),
 	)
 }
 
+// organizationTier looks up the tier of the Organization referenced by consumerRef, a map with
+// the kind and name of a quota consumer.
+func organizationTier(lister OrganizationTierLister, consumerRef ref.Val) (string, error) {
+	native, err := consumerRef.ConvertToNative(reflect.TypeOf(map[string]interface{}{}))
+	if err != nil {
+		return "", fmt.Errorf("consumer reference must be an object: %w", err)
+	}
+	refMap := native.(map[string]interface{})
+	if kind, _ := refMap["kind"].(string); kind != "Organization" {
+		return "", fmt.Errorf("consumer reference must refer to an Organization, got kind %q", kind)
+	}
+	name, _ := refMap["name"].(string)
+	if name == "" {
+		return "", fmt.Errorf("consumer reference has no name")
+	}
+	if lister == nil {
+		return "", errOrganizationTiersUnavailable
+	}
+	return lister.OrganizationTier(name)
+}
+
+// truncate returns the first n characters of s, or the last -n characters when n is negative,
+// matching sprig's trunc. Characters are counted as runes so multi-byte text is not split.
+func truncate(s string, n int64) string {
+	runes := []rune(s)
+	length := int64(len(runes))
+	switch {
+	case n >= 0 && n < length:
+		return string(runes[:n])
+	case n < 0 && -n < length:
+		return string(runes[length+n:])
+	default:
+		return s
+	}
+}
+
+// isEmpty reports whether a CEL value counts as empty for the default function:
+// null, false, zero numbers, and empty strings, lists, and maps.
+func isEmpty(value ref.Val) bool {
+	switch v := value.(type) {
+	case types.Null:
+		return true
+	case types.Bool:
+		return !bool(v)
+	case types.Int:
+		return v == 0
+	case types.Uint:
+		return v == 0
+	case types.Double:
+		return v == 0
+	case types.String:
+		return v == ""
+	}
+	if sizer, ok := value.(interface{ Size() ref.Val }); ok {
+		return sizer.Size() == types.IntZero
+	}
+	return false
+}
+
 // GetNestedField retrieves a nested field from a map using dot notation.
 // For example, GetNestedField(obj, "metadata.name") retrieves obj["metadata"]["name"].
 //
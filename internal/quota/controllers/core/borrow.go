@@ -0,0 +1,188 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"go.miloapis.com/milo/internal/quota/bucketutil"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// lenderBucket is a bucket for another of the consumer's resource types that a bucket
+// may borrow capacity from under its borrow policy.
+//
+// Borrowing lets a consumer's unused capacity for one resource type back claims for
+// another. The borrower reserves capacity by adding it to the lender's Lent status
+// before recording it in its own Borrowed status, and each lender recomputes Lent from
+// its borrowers on every reconcile, so a reservation that is never recorded by the
+// borrower is eventually released.
+type lenderBucket struct {
+	client client.Client
+	bucket *quotav1alpha1.AllowanceBucket
+	// capacity is how much may still be borrowed from the lender
+	capacity int64
+}
+
+// bucketAvailable returns the capacity remaining for the bucket's own claims.
+func bucketAvailable(bucket *quotav1alpha1.AllowanceBucket) int64 {
+	return max(0, bucketHeadroom(bucket))
+}
+
+// bucketHeadroom returns the bucket's remaining capacity without clamping at zero.
+// It is negative when more is allocated and lent than the bucket's limit provides.
+func bucketHeadroom(bucket *quotav1alpha1.AllowanceBucket) int64 {
+	return bucket.Status.Limit + borrowedTotal(bucket) - bucket.Status.Allocated - bucket.Status.Lent
+}
+
+// borrowedTotal returns the capacity the bucket has borrowed from all lenders.
+func borrowedTotal(bucket *quotav1alpha1.AllowanceBucket) int64 {
+	var total int64
+	for _, borrowed := range bucket.Status.Borrowed {
+		total += borrowed.Amount
+	}
+	return total
+}
+
+// borrowedFrom returns the capacity the bucket has borrowed from a lender resource type.
+func borrowedFrom(bucket *quotav1alpha1.AllowanceBucket, resourceType string) int64 {
+	for _, borrowed := range bucket.Status.Borrowed {
+		if borrowed.ResourceType == resourceType {
+			return borrowed.Amount
+		}
+	}
+	return 0
+}
+
+// mergeBorrowPolicy combines the borrow policies of a bucket's contributing allowances:
+// lender resource types are combined in order and the largest percentage applies.
+func mergeBorrowPolicy(merged, policy *quotav1alpha1.BorrowPolicy) *quotav1alpha1.BorrowPolicy {
+	if policy == nil {
+		return merged
+	}
+	if merged == nil {
+		return policy.DeepCopy()
+	}
+	for _, resourceType := range policy.From {
+		if !slices.Contains(merged.From, resourceType) {
+			merged.From = append(merged.From, resourceType)
+		}
+	}
+	merged.MaxBorrowPercentage = max(merged.MaxBorrowPercentage, policy.MaxBorrowPercentage)
+	return merged
+}
+
+// updateLent recomputes the capacity lent by the bucket from the Borrowed status of the
+// consumer's other buckets.
+func updateLent(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) error {
+	var buckets quotav1alpha1.AllowanceBucketList
+	if err := clusterClient.List(ctx, &buckets, client.InNamespace(bucket.Namespace)); err != nil {
+		return fmt.Errorf("failed to list AllowanceBuckets: %w", err)
+	}
+
+	var lent int64
+	for _, borrower := range buckets.Items {
+		if borrower.Name == bucket.Name ||
+			borrower.Spec.ConsumerRef.Kind != bucket.Spec.ConsumerRef.Kind ||
+			borrower.Spec.ConsumerRef.Name != bucket.Spec.ConsumerRef.Name {
+			continue
+		}
+		lent += borrowedFrom(&borrower, bucket.Spec.ResourceType)
+	}
+	bucket.Status.Lent = lent
+	return nil
+}
+
+// returnBorrowed releases borrowed capacity that the bucket's own claims no longer need,
+// returning it to the lenders borrowed from last first.
+func returnBorrowed(bucket *quotav1alpha1.AllowanceBucket) {
+	excess := borrowedTotal(bucket) - max(0, bucket.Status.Allocated+bucket.Status.Lent-bucket.Status.Limit)
+	for i := len(bucket.Status.Borrowed) - 1; i >= 0 && excess > 0; i-- {
+		returned := min(excess, bucket.Status.Borrowed[i].Amount)
+		bucket.Status.Borrowed[i].Amount -= returned
+		excess -= returned
+	}
+	bucket.Status.Borrowed = slices.DeleteFunc(bucket.Status.Borrowed, func(borrowed quotav1alpha1.BorrowedCapacity) bool {
+		return borrowed.Amount == 0
+	})
+}
+
+// findLenders returns the buckets the bucket may borrow from under the policy, in the
+// policy's order, with the capacity each may still lend.
+func findLenders(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket, policy *quotav1alpha1.BorrowPolicy) ([]*lenderBucket, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	var lenders []*lenderBucket
+	for _, resourceType := range policy.From {
+		if resourceType == bucket.Spec.ResourceType {
+			continue
+		}
+
+		var lender quotav1alpha1.AllowanceBucket
+		key := types.NamespacedName{Name: bucketutil.Name(resourceType, bucket.Spec.ConsumerRef), Namespace: bucket.Namespace}
+		if err := clusterClient.Get(ctx, key, &lender); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get lender AllowanceBucket %s: %w", key.Name, err)
+		}
+
+		limit := percentageOf(lender.Status.Limit, policy.MaxBorrowPercentage) - borrowedFrom(bucket, resourceType)
+		if capacity := min(limit, bucketAvailable(&lender)); capacity > 0 {
+			lenders = append(lenders, &lenderBucket{client: clusterClient, bucket: &lender, capacity: capacity})
+		}
+	}
+	return lenders, nil
+}
+
+// lendable returns the total capacity the lenders may still lend.
+func lendable(lenders []*lenderBucket) int64 {
+	var total int64
+	for _, lender := range lenders {
+		total += lender.capacity
+	}
+	return total
+}
+
+// borrow reserves the amount across the lenders in order and records it in the bucket's
+// Borrowed status. Lender updates are guarded by resourceVersion, so concurrent
+// borrowers conflict rather than overcommit a lender.
+func borrow(ctx context.Context, bucket *quotav1alpha1.AllowanceBucket, lenders []*lenderBucket, amount int64) error {
+	if available := lendable(lenders); amount > available {
+		return fmt.Errorf("lenders can only cover %d of the %d requested", available, amount)
+	}
+
+	for _, lender := range lenders {
+		if amount <= 0 {
+			break
+		}
+		taken := min(amount, lender.capacity)
+		if taken <= 0 {
+			continue
+		}
+
+		lender.bucket.Status.Lent += taken
+		lender.bucket.Status.Available = bucketAvailable(lender.bucket)
+		if err := lender.client.Status().Update(ctx, lender.bucket); err != nil {
+			return fmt.Errorf("failed to borrow capacity from AllowanceBucket %s: %w", lender.bucket.Name, err)
+		}
+		lender.capacity -= taken
+		amount -= taken
+
+		resourceType := lender.bucket.Spec.ResourceType
+		if i := slices.IndexFunc(bucket.Status.Borrowed, func(borrowed quotav1alpha1.BorrowedCapacity) bool {
+			return borrowed.ResourceType == resourceType
+		}); i >= 0 {
+			bucket.Status.Borrowed[i].Amount += taken
+		} else {
+			bucket.Status.Borrowed = append(bucket.Status.Borrowed, quotav1alpha1.BorrowedCapacity{ResourceType: resourceType, Amount: taken})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"go.miloapis.com/milo/internal/quota/bucketutil"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+const testLenderResourceType = "resourcemanager.miloapis.com/workspaces"
+
+func newLenderTestBucket(limit, allocated int64) *quotav1alpha1.AllowanceBucket {
+	bucket := newLedgerTestBucket()
+	bucket.Name = bucketutil.Name(testLenderResourceType, testConsumerRef())
+	bucket.Spec.ResourceType = testLenderResourceType
+	bucket.Status.Limit = limit
+	bucket.Status.Allocated = allocated
+	bucket.Status.Available = limit - allocated
+	return bucket
+}
+
+func TestBucketAvailable(t *testing.T) {
+	bucket := newLedgerTestBucket()
+	bucket.Status.Limit = 10
+	bucket.Status.Allocated = 8
+	bucket.Status.Lent = 3
+	bucket.Status.Borrowed = []quotav1alpha1.BorrowedCapacity{{ResourceType: testLenderResourceType, Amount: 4}}
+
+	if got := bucketAvailable(bucket); got != 3 {
+		t.Errorf("bucketAvailable() = %d, want 3", got)
+	}
+
+	bucket.Status.Lent = 10
+	if got := bucketAvailable(bucket); got != 0 {
+		t.Errorf("bucketAvailable() with overcommitted lending = %d, want 0", got)
+	}
+}
+
+func TestMergeBorrowPolicy(t *testing.T) {
+	first := &quotav1alpha1.BorrowPolicy{From: []string{"a", "b"}, MaxBorrowPercentage: 20}
+	second := &quotav1alpha1.BorrowPolicy{From: []string{"b", "c"}, MaxBorrowPercentage: 50}
+
+	merged := mergeBorrowPolicy(nil, first)
+	merged = mergeBorrowPolicy(merged, nil)
+	merged = mergeBorrowPolicy(merged, second)
+
+	if want := []string{"a", "b", "c"}; len(merged.From) != len(want) || merged.From[0] != "a" || merged.From[2] != "c" {
+		t.Errorf("From = %v, want %v", merged.From, want)
+	}
+	if merged.MaxBorrowPercentage != 50 {
+		t.Errorf("MaxBorrowPercentage = %d, want 50", merged.MaxBorrowPercentage)
+	}
+	if len(first.From) != 2 {
+		t.Errorf("mergeBorrowPolicy() modified the grant's policy: %v", first.From)
+	}
+}
+
+func TestReturnBorrowed(t *testing.T) {
+	bucket := newLedgerTestBucket()
+	bucket.Status.Limit = 10
+	bucket.Status.Allocated = 13
+	bucket.Status.Borrowed = []quotav1alpha1.BorrowedCapacity{
+		{ResourceType: "first", Amount: 4},
+		{ResourceType: "second", Amount: 2},
+	}
+
+	// Claims need 3 beyond the limit, so the last lender is repaid before the first
+	returnBorrowed(bucket)
+	if len(bucket.Status.Borrowed) != 1 || bucket.Status.Borrowed[0].ResourceType != "first" || bucket.Status.Borrowed[0].Amount != 3 {
+		t.Errorf("Borrowed = %+v, want 3 from first", bucket.Status.Borrowed)
+	}
+
+	bucket.Status.Allocated = 5
+	returnBorrowed(bucket)
+	if len(bucket.Status.Borrowed) != 0 {
+		t.Errorf("Borrowed = %+v, want all returned", bucket.Status.Borrowed)
+	}
+}
+
+func TestUpdateLent(t *testing.T) {
+	lender := newLenderTestBucket(10, 0)
+	borrower := newLedgerTestBucket()
+	borrower.Status.Borrowed = []quotav1alpha1.BorrowedCapacity{{ResourceType: testLenderResourceType, Amount: 4}}
+	otherConsumer := newLedgerTestBucket()
+	otherConsumer.Name = "other-consumer"
+	otherConsumer.Spec.ConsumerRef.Name = "other"
+	otherConsumer.Status.Borrowed = []quotav1alpha1.BorrowedCapacity{{ResourceType: testLenderResourceType, Amount: 5}}
+
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(lender, borrower, otherConsumer).Build()
+	if err := updateLent(context.Background(), c, lender); err != nil {
+		t.Fatalf("updateLent() error = %v", err)
+	}
+	if lender.Status.Lent != 4 {
+		t.Errorf("Lent = %d, want 4", lender.Status.Lent)
+	}
+}
+
+func TestFindLenders(t *testing.T) {
+	tests := []struct {
+		name     string
+		lender   *quotav1alpha1.AllowanceBucket
+		policy   *quotav1alpha1.BorrowPolicy
+		borrowed int64
+		expected int64
+	}{
+		{
+			name:     "capped by percentage",
+			lender:   newLenderTestBucket(100, 10),
+			policy:   &quotav1alpha1.BorrowPolicy{From: []string{testLenderResourceType}, MaxBorrowPercentage: 25},
+			expected: 25,
+		},
+		{
+			name:     "capped by lender availability",
+			lender:   newLenderTestBucket(100, 90),
+			policy:   &quotav1alpha1.BorrowPolicy{From: []string{testLenderResourceType}, MaxBorrowPercentage: 25},
+			expected: 10,
+		},
+		{
+			name:     "already borrowed counts against the percentage",
+			lender:   newLenderTestBucket(100, 10),
+			policy:   &quotav1alpha1.BorrowPolicy{From: []string{testLenderResourceType}, MaxBorrowPercentage: 25},
+			borrowed: 20,
+			expected: 5,
+		},
+		{
+			name:     "own resource type is ignored",
+			lender:   newLenderTestBucket(100, 10),
+			policy:   &quotav1alpha1.BorrowPolicy{From: []string{testResourceType}, MaxBorrowPercentage: 25},
+			expected: 0,
+		},
+		{
+			name:     "no policy",
+			lender:   newLenderTestBucket(100, 10),
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := newLedgerTestBucket()
+			if tt.borrowed > 0 {
+				bucket.Status.Borrowed = []quotav1alpha1.BorrowedCapacity{{ResourceType: testLenderResourceType, Amount: tt.borrowed}}
+			}
+			c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(tt.lender).Build()
+
+			lenders, err := findLenders(context.Background(), c, bucket, tt.policy)
+			if err != nil {
+				t.Fatalf("findLenders() error = %v", err)
+			}
+			if got := lendable(lenders); got != tt.expected {
+				t.Errorf("lendable() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBorrow(t *testing.T) {
+	lender := newLenderTestBucket(100, 10)
+	c := fake.NewClientBuilder().WithScheme(testScheme()).
+		WithObjects(lender).WithStatusSubresource(lender).Build()
+	ctx := context.Background()
+
+	bucket := newLedgerTestBucket()
+	policy := &quotav1alpha1.BorrowPolicy{From: []string{testLenderResourceType}, MaxBorrowPercentage: 50}
+	lenders, err := findLenders(ctx, c, bucket, policy)
+	if err != nil {
+		t.Fatalf("findLenders() error = %v", err)
+	}
+
+	if err := borrow(ctx, bucket, lenders, 60); err == nil {
+		t.Error("borrow() beyond the lendable capacity succeeded, want error")
+	}
+
+	if len(bucket.Status.Borrowed) != 0 {
+		t.Errorf("Borrowed = %+v, want nothing borrowed after a failed borrow", bucket.Status.Borrowed)
+	}
+	if err := borrow(ctx, bucket, lenders, 30); err != nil {
+		t.Fatalf("borrow() error = %v", err)
+	}
+	if got := borrowedFrom(bucket, testLenderResourceType); got != 30 {
+		t.Errorf("borrowedFrom() = %d, want 30", got)
+	}
+
+	var stored quotav1alpha1.AllowanceBucket
+	key := types.NamespacedName{Name: lender.Name, Namespace: lender.Namespace}
+	if err := c.Get(ctx, key, &stored); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if stored.Status.Lent != 30 || stored.Status.Available != 60 {
+		t.Errorf("lender lent/available = %d/%d, want 30/60", stored.Status.Lent, stored.Status.Available)
+	}
+}
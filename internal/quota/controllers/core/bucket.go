@@ -8,14 +8,21 @@
 // - Computing Limit from Active ResourceGrants (scoped to the same owner)
 // - Computing Allocated from Granted ResourceClaims (same owner + shape)
 // Buckets are created on demand when claims reference them to minimize churn
-// while still handling eventual consistency between controllers.
+// while still handling eventual consistency between controllers, and deleted
+// once no claims have referenced them for a grace period.
 package core
 
 import (
+	"cmp"
 	"context"
-	"crypto/sha256"
 	"fmt"
+	"slices"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -34,12 +41,16 @@ import (
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
+	"go.miloapis.com/milo/internal/quota/bucketutil"
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
 )
 
 const (
-	// resourceClaimConsumerRefIndex is the field index name for ResourceClaim.Spec.ConsumerRef
-	resourceClaimConsumerRefIndex = "spec.consumerRef"
+	// maxTopClaims bounds the number of claims reported in AllowanceBucket.Status.TopClaims
+	maxTopClaims = 10
+
+	// defaultBucketResyncPeriod is how often a bucket is recomputed without a triggering event
+	defaultBucketResyncPeriod = 10 * time.Minute
 )
 
 // AllowanceBucketController reconciles AllowanceBucket objects and maintains
@@ -48,6 +59,37 @@ const (
 type AllowanceBucketController struct {
 	Scheme  *runtime.Scheme
 	Manager mcmanager.Manager
+
+	// UsageNotifier, when set, is notified when a bucket's utilization crosses a
+	// configured threshold. It is started with the controller.
+	UsageNotifier *UsageNotifier
+
+	// ResyncPeriod is how often each bucket is recomputed from its grants and claims
+	// without a triggering event, correcting drift from missed events or manual edits.
+	// Defaults to defaultBucketResyncPeriod when zero.
+	ResyncPeriod time.Duration
+
+	// UsageResyncInterval bounds how long incrementally tracked bucket usage is
+	// trusted before it is rebuilt from a full ResourceClaim list. Defaults to
+	// defaultUsageResyncInterval when zero.
+	UsageResyncInterval time.Duration
+
+	// OrphanBucketGracePeriod is how long a bucket must go without any referencing
+	// ResourceClaim before it is deleted. Defaults to defaultOrphanBucketGracePeriod
+	// when zero.
+	OrphanBucketGracePeriod time.Duration
+
+	// PauseGranting stops pending claims from being granted or denied while limits
+	// and usage continue to be maintained. Claims stay pending until granting resumes.
+	PauseGranting bool
+
+	// GrantSelection decides the order in which the bucket's contributing grants are
+	// consumed, and so which grant each granted request is recorded against.
+	// Defaults to defaultGrantSelection when empty.
+	GrantSelection GrantSelectionStrategy
+
+	// usageLedger maintains per-bucket usage from ResourceClaim events
+	usageLedger *usageLedger
 }
 
 // +kubebuilder:rbac:groups=quota.miloapis.com,resources=allowancebuckets,verbs=get;list;watch;create;update;patch;delete
@@ -55,10 +97,16 @@ type AllowanceBucketController struct {
 // +kubebuilder:rbac:groups=quota.miloapis.com,resources=resourcegrants,verbs=get;list;watch
 // +kubebuilder:rbac:groups=quota.miloapis.com,resources=resourceclaims,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=quota.miloapis.com,resources=resourceclaims/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=resourcemanager.miloapis.com,resources=projects,verbs=get;list;watch
 
 // Reconcile maintains AllowanceBucket limits and usage aggregates by watching
 // ResourceGrants and ResourceClaims across all control planes.
 func (r *AllowanceBucketController) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	start := time.Now()
+	defer func() {
+		allowanceBucketReconcileDuration.Observe(time.Since(start).Seconds())
+	}()
+
 	logger := log.FromContext(ctx)
 	if req.ClusterName != "" {
 		logger = logger.WithValues("cluster", req.ClusterName)
@@ -76,6 +124,10 @@ func (r *AllowanceBucketController) Reconcile(ctx context.Context, req mcreconci
 	var bucket quotav1alpha1.AllowanceBucket
 	if err := clusterClient.Get(ctx, req.NamespacedName, &bucket); err != nil {
 		if apierrors.IsNotFound(err) {
+			r.usageLedger.forgetBucket(ledgerKey(req.ClusterName, req.Namespace, req.Name))
+			forgetBucketMetrics(ledgerKey(req.ClusterName, req.Namespace, req.Name))
+			r.UsageNotifier.Forget(ledgerKey(req.ClusterName, req.Namespace, req.Name))
+
 			// Single-writer pattern: create bucket on first claim reference
 			if err := r.ensureBucketFromClaims(ctx, clusterClient, req.NamespacedName); err != nil {
 				return ctrl.Result{}, err
@@ -91,40 +143,142 @@ func (r *AllowanceBucketController) Reconcile(ctx context.Context, req mcreconci
 
 	bucket.Status.ObservedGeneration = bucket.Generation
 
-	if err := r.updateLimitsFromGrants(ctx, clusterClient, &bucket); err != nil {
+	borrowPolicy, err := r.updateLimitsFromGrants(ctx, clusterClient, &bucket)
+	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to update limits from grants: %w", err)
 	}
 
-	if err := r.updateUsageFromClaims(ctx, clusterClient, &bucket); err != nil {
+	if err := r.refreshUsage(ctx, req.ClusterName, clusterClient, &bucket); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to update usage from claims: %w", err)
 	}
 
+	// Claims evaluated before their grants are aggregated see no capacity at all
+	if bucket.Status.GrantCount == 0 && bucket.Status.ClaimCount > 0 {
+		allowanceBucketWithoutGrants.WithLabelValues(bucket.Spec.ResourceType).Inc()
+		logger.V(1).Info("No contributing ResourceGrants found for bucket with claims",
+			"bucket", bucket.Name,
+			"claimCount", bucket.Status.ClaimCount)
+	}
+
+	// Organization buckets in the core control plane may also count their Projects' claims
+	if req.ClusterName == "" && bucket.Status.AggregationScope == quotav1alpha1.AggregationScopeOrganizationTree {
+		if err := r.addProjectUsage(ctx, clusterClient, &bucket); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update usage from project claims: %w", err)
+		}
+	}
+
+	// Capacity lent to the consumer's other buckets is unavailable, and capacity borrowed
+	// from them is returned once this bucket's claims no longer need it
+	if err := updateLent(ctx, clusterClient, &bucket); err != nil {
+		return ctrl.Result{}, err
+	}
+	returnBorrowed(&bucket)
+
+	// Buckets in Project control planes may be capped by their Organization's bucket
+	tree, err := r.organizationTreeBucket(ctx, req.ClusterName, bucket.Spec.ResourceType)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	lenders, err := findLenders(ctx, clusterClient, &bucket, borrowPolicy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// processPendingClaims performs intermediate status updates for atomic quota reservation.
-	if err := r.processPendingClaims(ctx, clusterClient, &bucket); err != nil {
+	if r.PauseGranting {
+		logger.V(1).Info("Quota granting is paused, leaving pending claims unprocessed", "bucket", bucket.Name)
+	} else if err := r.processPendingClaims(ctx, clusterClient, &bucket, tree, lenders); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed processing pending grants: %w", err)
 	}
 
-	bucket.Status.Available = max(0, bucket.Status.Limit-bucket.Status.Allocated)
+	bucket.Status.Available = bucketAvailable(&bucket)
+	setOvercommittedCondition(&bucket)
+
+	// Threshold crossings are delivered in the background; the band last delivered is
+	// recorded with this status update and forgotten once the update succeeds
+	r.UsageNotifier.Track(ledgerKey(req.ClusterName, bucket.Namespace, bucket.Name), &bucket)
+
+	result, err := r.updateStatusIfChanged(ctx, clusterClient, &bucket, originalStatus)
+	if err != nil {
+		return result, err
+	}
+	r.UsageNotifier.Recorded(ledgerKey(req.ClusterName, bucket.Namespace, bucket.Name), bucket.Status.NotifiedUsageThreshold)
+
+	recordBucketMetrics(req.ClusterName, ledgerKey(req.ClusterName, bucket.Namespace, bucket.Name), &bucket)
+
+	gcResult, deleted, err := r.collectOrphanedBucket(ctx, clusterClient, cluster.GetEventRecorderFor("allowance-bucket-controller"), &bucket, borrowPolicy, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if deleted {
+		r.usageLedger.forgetBucket(ledgerKey(req.ClusterName, bucket.Namespace, bucket.Name))
+		forgetBucketMetrics(ledgerKey(req.ClusterName, bucket.Namespace, bucket.Name))
+		r.UsageNotifier.Forget(ledgerKey(req.ClusterName, bucket.Namespace, bucket.Name))
+		return ctrl.Result{}, nil
+	}
+	if gcResult.RequeueAfter > 0 {
+		result = gcResult
+	}
+
+	// Periodic resync corrects any drift, including in incrementally tracked usage
+	if result.IsZero() {
+		result.RequeueAfter = r.periodicResyncInterval()
+	}
 
-	return r.updateStatusIfChanged(ctx, clusterClient, &bucket, originalStatus)
+	// Claim events in Project control planes cannot enqueue buckets in the core control
+	// plane, so Organization tree buckets poll to pick up released Project capacity
+	if req.ClusterName == "" && bucket.Status.AggregationScope == quotav1alpha1.AggregationScopeOrganizationTree &&
+		(result.RequeueAfter == 0 || result.RequeueAfter > organizationTreeResyncInterval) {
+		result.RequeueAfter = organizationTreeResyncInterval
+	}
+
+	return result, nil
 }
 
-// updateLimitsFromGrants calculates total quota limits from active ResourceGrants.
+// setOvercommittedCondition reports whether the bucket's allocation exceeds its limit,
+// which Available hides by never going negative. This happens when a contributing
+// grant is revoked or reduced while its capacity is still claimed.
+func setOvercommittedCondition(bucket *quotav1alpha1.AllowanceBucket) {
+	condition := metav1.Condition{
+		Type:               quotav1alpha1.AllowanceBucketOvercommitted,
+		Status:             metav1.ConditionFalse,
+		Reason:             quotav1alpha1.AllowanceBucketWithinLimitReason,
+		Message:            "Allocated quota is within the bucket's limit",
+		ObservedGeneration: bucket.Generation,
+	}
+	if deficit := -bucketHeadroom(bucket); deficit > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = quotav1alpha1.AllowanceBucketAllocationExceedsLimitReason
+		condition.Message = fmt.Sprintf("Allocated quota exceeds the bucket's limit by %d (limit %d, borrowed %d, allocated %d, lent %d)",
+			deficit, bucket.Status.Limit, borrowedTotal(bucket), bucket.Status.Allocated, bucket.Status.Lent)
+	}
+	apimeta.SetStatusCondition(&bucket.Status.Conditions, condition)
+}
+
+// updateLimitsFromGrants calculates total quota limits from active ResourceGrants, and the
+// projected limit once the pending ones activate as well.
+// It returns the combined borrow policy of the contributing allowances, if any.
 // Searches cluster-wide because buckets are centralized but grants may be distributed.
-func (r *AllowanceBucketController) updateLimitsFromGrants(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) error {
+func (r *AllowanceBucketController) updateLimitsFromGrants(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) (*quotav1alpha1.BorrowPolicy, error) {
 
-	// Centralized buckets require scanning all namespaces for grants
-	var grants quotav1alpha1.ResourceGrantList
-	if err := clusterClient.List(ctx, &grants); err != nil {
-		return fmt.Errorf("failed to list ResourceGrants: %w", err)
+	// Centralized buckets require searching all namespaces for grants
+	grants, err := listBucketGrants(ctx, clusterClient, bucket)
+	if err != nil {
+		return nil, err
 	}
+	// Contributing grants are reported in the order the bucket consumes them
+	sortGrants(grants, r.grantSelection())
 
-	var totalLimit int64
+	var totalLimit, projectedLimit int64
 	var contributingGrants []quotav1alpha1.ContributingGrantRef
+	var organizationTree bool
+	var borrowPolicy *quotav1alpha1.BorrowPolicy
 
-	for _, grant := range grants.Items {
-		// Only consider active grants
-		if !r.isResourceGrantActive(&grant) {
+	for _, grant := range grants {
+		// Only active grants are enforced; pending grants only count towards the projection
+		active := r.isResourceGrantActive(&grant)
+		if !active && !isResourceGrantPending(&grant) {
 			continue
 		}
 
@@ -140,110 +294,211 @@ func (r *AllowanceBucketController) updateLimitsFromGrants(ctx context.Context,
 				continue
 			}
 
+			if active {
+				if grant.Spec.AggregationScope == quotav1alpha1.AggregationScopeOrganizationTree {
+					organizationTree = true
+				}
+				borrowPolicy = mergeBorrowPolicy(borrowPolicy, allowance.BorrowPolicy)
+			}
+
 			// Check each bucket in the allowance
 			for _, allowanceBucket := range allowance.Buckets {
-				totalLimit += allowanceBucket.Amount
+				amount, err := r.resolveBucketAmount(ctx, clusterClient, &grant, allowance.ResourceType, allowanceBucket)
+				if err != nil {
+					return nil, err
+				}
+				projectedLimit += amount
+				if !active {
+					continue
+				}
+				totalLimit += amount
 				contributingGrants = append(contributingGrants, quotav1alpha1.ContributingGrantRef{
 					Name:                   grant.Name,
+					Namespace:              grant.Namespace,
 					LastObservedGeneration: grant.Generation,
-					Amount:                 allowanceBucket.Amount,
+					Amount:                 amount,
 				})
 			}
 		}
 	}
 
 	bucket.Status.Limit = totalLimit
+	bucket.Status.ProjectedLimit = projectedLimit
 	bucket.Status.GrantCount = int32(len(contributingGrants))
 	bucket.Status.ContributingGrantRefs = contributingGrants
 
+	bucket.Status.AggregationScope = ""
+	if organizationTree && bucket.Spec.ConsumerRef.Kind == "Organization" {
+		bucket.Status.AggregationScope = quotav1alpha1.AggregationScopeOrganizationTree
+	}
+
+	return borrowPolicy, nil
+}
+
+// refreshUsage updates the bucket's usage aggregates. Usage tracked incrementally from
+// claim events is used while fresh; otherwise claims are listed and the ledger rebuilt.
+func (r *AllowanceBucketController) refreshUsage(ctx context.Context, clusterName string, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) error {
+	if r.usageLedger == nil {
+		return r.updateUsageFromClaims(ctx, clusterClient, bucket)
+	}
+
+	key := ledgerKey(clusterName, bucket.Namespace, bucket.Name)
+	if allocated, claimAllocations, ok := r.usageLedger.usage(key, r.usageResyncInterval(), time.Now()); ok {
+		applyClaimUsage(bucket, allocated, claimAllocations)
+		return nil
+	}
+
+	log.FromContext(ctx).V(2).Info("Rebuilding bucket usage from ResourceClaims", "bucket", bucket.Name)
+	r.usageLedger.beginRebuild(key)
+	_, claimAllocations, err := r.listClaimUsage(ctx, clusterClient, bucket)
+	if err != nil {
+		r.usageLedger.abortRebuild(key)
+		return err
+	}
+	allocated, claimAllocations := r.usageLedger.replaceBucket(clusterName, key, claimAllocations, time.Now())
+	applyClaimUsage(bucket, allocated, claimAllocations)
 	return nil
 }
 
+// periodicResyncInterval returns how long a bucket waits for its next resync. Buckets
+// with incrementally tracked usage resync at least as often as that usage expires, so
+// it is rebuilt from a full list.
+func (r *AllowanceBucketController) periodicResyncInterval() time.Duration {
+	interval := r.ResyncPeriod
+	if interval <= 0 {
+		interval = defaultBucketResyncPeriod
+	}
+	if r.usageLedger != nil {
+		interval = min(interval, r.usageResyncInterval())
+	}
+	return interval
+}
+
+// grantSelection returns the configured grant selection strategy or the default.
+func (r *AllowanceBucketController) grantSelection() GrantSelectionStrategy {
+	if r.GrantSelection != "" {
+		return r.GrantSelection
+	}
+	return defaultGrantSelection
+}
+
+// usageResyncInterval returns the configured resync interval or the default.
+func (r *AllowanceBucketController) usageResyncInterval() time.Duration {
+	if r.UsageResyncInterval > 0 {
+		return r.UsageResyncInterval
+	}
+	return defaultUsageResyncInterval
+}
+
 // updateUsageFromClaims calculates the total allocated usage from ResourceClaims
 // based on individual request allocations that have been granted.
 func (r *AllowanceBucketController) updateUsageFromClaims(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) error {
-	// Find all ResourceClaims cluster-wide that reference this bucket's consumer
-	var claims quotav1alpha1.ResourceClaimList
-	if err := clusterClient.List(ctx, &claims,
-		client.MatchingFields{resourceClaimConsumerRefIndex: consumerRefKey(bucket.Spec.ConsumerRef)},
-	); err != nil {
-		return fmt.Errorf("failed to list ResourceClaims: %w", err)
+	allocated, claimAllocations, err := r.listClaimUsage(ctx, clusterClient, bucket)
+	if err != nil {
+		return err
+	}
+	applyClaimUsage(bucket, allocated, claimAllocations)
+	return nil
+}
+
+// listClaimUsage lists the ResourceClaims of the bucket's consumer and returns the total
+// granted allocation for the bucket along with each contributing claim.
+func (r *AllowanceBucketController) listClaimUsage(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) (int64, []quotav1alpha1.TopClaimRef, error) {
+	// Find all ResourceClaims cluster-wide that request this bucket's resource type for its consumer.
+	// Claims are only read here, so they are not copied out of the informer cache; a
+	// consumer with many claims would otherwise be copied in full on every resync.
+	claims, err := listBucketClaims(ctx, clusterClient, bucket, client.UnsafeDisableDeepCopy)
+	if err != nil {
+		return 0, nil, err
 	}
 
 	var totalAllocated int64
-	var claimCount int32
+	var claimAllocations []quotav1alpha1.TopClaimRef
 
-	for _, claim := range claims.Items {
-		// Track whether this claim has any granted allocations for this bucket
-		// (consumer ref already filtered by field selector)
-		hasGrantedAllocation := false
+	for _, claim := range claims {
+		// Consumer ref and resource type already filtered by the bucket index
+		claimAllocated, granted := grantedAllocation(&claim, bucket.Spec.ResourceType)
+		if !granted {
+			continue
+		}
 
-		// Check allocations for granted requests that match this bucket
-		for _, allocation := range claim.Status.Allocations {
-			if allocation.Status != quotav1alpha1.ResourceClaimAllocationStatusGranted {
-				continue
-			}
+		totalAllocated += claimAllocated
+		claimAllocations = append(claimAllocations, quotav1alpha1.TopClaimRef{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			Amount:    claimAllocated,
+		})
+	}
 
-			// Check if this allocation matches the bucket
-			if allocation.ResourceType != bucket.Spec.ResourceType {
-				continue
-			}
+	return totalAllocated, claimAllocations, nil
+}
 
-			// Find the corresponding request from the spec to check dimensions
-			var matchingRequest *quotav1alpha1.ResourceRequest
-			for _, req := range claim.Spec.Requests {
-				if req.ResourceType == allocation.ResourceType {
-					matchingRequest = &req
-					break
-				}
-			}
-			if matchingRequest == nil {
-				continue
-			}
+// grantedAllocation returns the amount granted to the claim's request for resourceType,
+// and whether such a granted allocation exists.
+func grantedAllocation(claim *quotav1alpha1.ResourceClaim, resourceType string) (int64, bool) {
+	// Only allocations backed by a request in the spec count toward usage
+	if !slices.ContainsFunc(claim.Spec.Requests, func(req quotav1alpha1.ResourceRequest) bool {
+		return req.ResourceType == resourceType
+	}) {
+		return 0, false
+	}
 
-			// Use the allocated amount from the allocation status
-			totalAllocated += allocation.AllocatedAmount
-			hasGrantedAllocation = true
+	var allocated int64
+	granted := false
+	for _, allocation := range claim.Status.Allocations {
+		if allocation.Status != quotav1alpha1.ResourceClaimAllocationStatusGranted || allocation.ResourceType != resourceType {
+			continue
 		}
+		allocated += allocation.AllocatedAmount
+		granted = true
+	}
+	return allocated, granted
+}
 
-		// Increment claim count once per claim if it has any granted allocations for this bucket
-		if hasGrantedAllocation {
-			claimCount++
-		}
+// applyClaimUsage sets the bucket's usage aggregates from its contributing claims.
+// Each claim is counted once if it has any granted allocation for the bucket.
+func applyClaimUsage(bucket *quotav1alpha1.AllowanceBucket, allocated int64, claimAllocations []quotav1alpha1.TopClaimRef) {
+	bucket.Status.Allocated = allocated
+	bucket.Status.ClaimCount = int32(len(claimAllocations))
+	bucket.Status.TopClaims = rankTopClaims(claimAllocations, maxTopClaims)
+}
+
+// rankTopClaims orders claims by allocated amount (largest first) and keeps at most limit entries.
+// Ties are broken by namespace and name so the reported list is stable across reconciles.
+func rankTopClaims(claims []quotav1alpha1.TopClaimRef, limit int) []quotav1alpha1.TopClaimRef {
+	if len(claims) == 0 || limit <= 0 {
+		return nil
 	}
 
-	bucket.Status.Allocated = totalAllocated
-	bucket.Status.ClaimCount = claimCount
+	slices.SortFunc(claims, func(a, b quotav1alpha1.TopClaimRef) int {
+		if c := cmp.Compare(b.Amount, a.Amount); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
 
-	return nil
+	if len(claims) > limit {
+		claims = claims[:limit]
+	}
+	return claims
 }
 
 // ensureBucketFromClaims creates the bucket spec from a referencing claim if found.
 // It returns true if a bucket was created, false if no referencing claim was found.
 func (r *AllowanceBucketController) ensureBucketFromClaims(ctx context.Context, clusterClient client.Client, bucketKey types.NamespacedName) error {
+	// The claims are only read, so they are not copied out of the informer cache
 	var claims quotav1alpha1.ResourceClaimList
-	if err := clusterClient.List(ctx, &claims); err != nil {
+	if err := clusterClient.List(ctx, &claims, client.UnsafeDisableDeepCopy); err != nil {
 		return fmt.Errorf("failed to list ResourceClaims: %w", err)
 	}
 	for _, claim := range claims.Items {
 		for _, request := range claim.Spec.Requests {
-			name := generateAllowanceBucketName(request.ResourceType, claim.Spec.ConsumerRef)
+			name := bucketutil.Name(request.ResourceType, claim.Spec.ConsumerRef)
 			if name == bucketKey.Name {
-				// create bucket
-				bucket := &quotav1alpha1.AllowanceBucket{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      bucketKey.Name,
-						Namespace: bucketKey.Namespace,
-						Labels: map[string]string{
-							"quota.miloapis.com/consumer-kind": claim.Spec.ConsumerRef.Kind,
-							"quota.miloapis.com/consumer-name": claim.Spec.ConsumerRef.Name,
-						},
-					},
-					Spec: quotav1alpha1.AllowanceBucketSpec{
-						ConsumerRef:  claim.Spec.ConsumerRef,
-						ResourceType: request.ResourceType,
-					},
-				}
+				bucket := newAllowanceBucket(request.ResourceType, claim.Spec.ConsumerRef)
 				if err := clusterClient.Create(ctx, bucket); err != nil && !apierrors.IsAlreadyExists(err) {
 					return fmt.Errorf("failed to create AllowanceBucket %s: %w", bucketKey.Name, err)
 				}
@@ -254,29 +509,61 @@ func (r *AllowanceBucketController) ensureBucketFromClaims(ctx context.Context,
 	return nil
 }
 
+// newAllowanceBucket returns the bucket for the consumer's use of the resource type.
+func newAllowanceBucket(resourceType string, consumer quotav1alpha1.ConsumerRef) *quotav1alpha1.AllowanceBucket {
+	return &quotav1alpha1.AllowanceBucket{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bucketutil.Name(resourceType, consumer),
+			Namespace: bucketutil.Namespace(consumer),
+			Labels: map[string]string{
+				"quota.miloapis.com/consumer-kind": consumer.Kind,
+				"quota.miloapis.com/consumer-name": consumer.Name,
+			},
+		},
+		Spec: quotav1alpha1.AllowanceBucketSpec{
+			ConsumerRef:  consumer,
+			ResourceType: resourceType,
+		},
+	}
+}
+
 // isResourceGrantActive checks if a ResourceGrant has an Active condition with status True.
 func (r *AllowanceBucketController) isResourceGrantActive(grant *quotav1alpha1.ResourceGrant) bool {
 	return apimeta.IsStatusConditionTrue(grant.Status.Conditions, quotav1alpha1.ResourceGrantActive)
 }
 
+// isResourceGrantPending checks if a ResourceGrant has not been validated yet, either because
+// it has no Active condition or because the condition reports GrantPending.
+func isResourceGrantPending(grant *quotav1alpha1.ResourceGrant) bool {
+	condition := apimeta.FindStatusCondition(grant.Status.Conditions, quotav1alpha1.ResourceGrantActive)
+	return condition == nil ||
+		(condition.Status != metav1.ConditionTrue && condition.Reason == quotav1alpha1.ResourceGrantPendingReason)
+}
+
 // processPendingClaims attempts to grant pending requests that reference this bucket.
 // For each eligible claim, it evaluates individual requests that match this bucket,
 // reserves capacity, then marks specific request allocations as Granted/Denied.
-func (r *AllowanceBucketController) processPendingClaims(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) error {
+// When tree is set, available capacity is also capped by the Organization's bucket,
+// and grants reserve capacity there first. Lenders extend the available capacity, and
+// grants beyond the bucket's own capacity borrow the remainder from them in order.
+// Requests of a consumer with a quota override are granted in full without reserving
+// capacity elsewhere.
+func (r *AllowanceBucketController) processPendingClaims(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket, tree *treeBucket, lenders []*lenderBucket) error {
 	logger := log.FromContext(ctx)
-	var claims quotav1alpha1.ResourceClaimList
-	if err := clusterClient.List(ctx, &claims,
-		client.MatchingFields{resourceClaimConsumerRefIndex: consumerRefKey(bucket.Spec.ConsumerRef)},
-	); err != nil {
-		return fmt.Errorf("failed to list ResourceClaims: %w", err)
+	claims, err := listBucketClaims(ctx, clusterClient, bucket)
+	if err != nil {
+		return err
 	}
 
-	// Current state for available calculation during this reconcile loop
-	limit := bucket.Status.Limit
-	allocated := bucket.Status.Allocated
+	// Higher priority claims are evaluated first so they win under contention
+	sortClaimsByPriority(claims)
+
+	// Overrides are looked up once, when the first pending request is found
+	var override, overrideChecked bool
+
 	fieldManagerName := fmt.Sprintf("allowance-bucket-%s", bucket.Name)
 
-	for _, claim := range claims.Items {
+	for _, claim := range claims {
 		// Process each request that matches this bucket
 		for _, request := range claim.Spec.Requests {
 			// Skip if request doesn't match this bucket
@@ -292,56 +579,166 @@ func (r *AllowanceBucketController) processPendingClaims(ctx context.Context, cl
 				continue
 			}
 
-			// Check availability using current local view
-			if limit-allocated < request.Amount {
-				logger.Info("Insufficient quota available for request",
-					"claimName", claim.Name,
-					"resourceType", request.ResourceType,
-					"requestAmount", request.Amount,
-					"available", limit-allocated)
-
-				// Mark this specific request as denied
-				if err := r.updateResourceClaimAllocation(ctx, clusterClient, &claim, request.ResourceType, quotav1alpha1.ResourceClaimAllocationStatusDenied,
-					quotav1alpha1.ResourceClaimDeniedReason,
-					fmt.Sprintf("Resource quota exceeded: requested %d, available %d", request.Amount, limit-allocated),
-					0, "", fieldManagerName); err != nil {
-					logger.Error(err, "failed to update request allocation for denial",
-						"claimName", claim.Name, "resourceType", request.ResourceType)
+			if !overrideChecked {
+				if override, err = r.quotaOverride(ctx, clusterClient, bucket); err != nil {
+					return err
 				}
-				continue
+				overrideChecked = true
 			}
 
-			// Reserve capacity and keep status fields self-consistent for validation
-			bucket.Status.Allocated = allocated + request.Amount
-			// Recompute Available with clamp to satisfy CRD validation
-			bucket.Status.Available = max(0, bucket.Status.Limit-bucket.Status.Allocated)
-			bucket.Status.ObservedGeneration = bucket.Generation
-
-			if err := clusterClient.Status().Update(ctx, bucket); err != nil {
-				if apierrors.IsConflict(err) {
-					// Controller runtime will automatically re-queue this resource
-					return nil
-				}
-				return fmt.Errorf("failed to update bucket during reservation: %w", err)
+			stop, err := r.allocateRequest(ctx, clusterClient, bucket, tree, lenders, &claim, request, override, fieldManagerName)
+			if err != nil || stop {
+				return err
 			}
+		}
+	}
+	return nil
+}
+
+// allocateRequest grants or denies a pending request of the claim against the bucket.
+// It returns true when the bucket changed underneath it and the remaining claims must
+// wait for the next reconciliation.
+func (r *AllowanceBucketController) allocateRequest(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket, tree *treeBucket, lenders []*lenderBucket,
+	claim *quotav1alpha1.ResourceClaim, request quotav1alpha1.ResourceRequest, override bool, fieldManagerName string) (_ bool, err error) {
+	logger := log.FromContext(ctx)
 
-			// Reservation successful; update local allocated for subsequent requests
-			allocated = bucket.Status.Allocated
-
-			// Mark this specific request as granted
-			if err := r.updateResourceClaimAllocation(ctx, clusterClient, &claim, request.ResourceType, quotav1alpha1.ResourceClaimAllocationStatusGranted,
-				quotav1alpha1.ResourceClaimGrantedReason,
-				"Capacity reserved",
-				request.Amount, bucket.Name, fieldManagerName); err != nil {
-				logger.Error(err, "failed to update request allocation after reservation",
-					"claimName", claim.Name, "resourceType", request.ResourceType)
-				// Don't revert the bucket allocation - the capacity has been reserved
-				return fmt.Errorf("failed to update request allocation: %w", err)
+	// Join the trace of the admission request waiting on this claim, so the time from
+	// admission to the grant decision appears in a single trace
+	var decision string
+	if parent, ok := admissionTraceContext(ctx, claim); ok {
+		var span trace.Span
+		ctx, span = otel.Tracer("go.miloapis.com/milo/quota/controllers").Start(parent, "quota.AllowanceBucketController.allocateRequest",
+			trace.WithAttributes(
+				attribute.String("claim.name", claim.Name),
+				attribute.String("claim.namespace", claim.Namespace),
+				attribute.String("bucket.name", bucket.Name),
+				attribute.String("resource.type", request.ResourceType),
+			))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			if decision != "" {
+				span.SetAttributes(attribute.String("allocation.status", decision))
 			}
+			span.End()
+		}()
+	}
 
+	// Check availability using current local view, including what may be borrowed
+	own := bucketAvailable(bucket)
+	available := own + lendable(lenders)
+	if tree != nil {
+		available = min(available, tree.available())
+	}
+	grantAmount, reason, message, ok := evaluateRequest(claim, request, available)
+	if override {
+		grantAmount, reason, message = overrideRequest(ctx, claim, request, available)
+		ok = true
+	}
+	if !ok {
+		logger.Info("Insufficient quota available for request",
+			"claimName", claim.Name,
+			"resourceType", request.ResourceType,
+			"requestAmount", request.Amount,
+			"available", available)
+
+		// Mark this specific request as denied, recording the figures behind the denial
+		denial := &quotav1alpha1.ResourceClaimDenialDetail{
+			ResourceType: request.ResourceType,
+			Requested:    request.Amount,
+			Allocated:    bucket.Status.Allocated,
+			Limit:        bucket.Status.Limit,
+			Available:    max(available, 0),
 		}
+		decision = quotav1alpha1.ResourceClaimAllocationStatusDenied
+		if err := r.updateResourceClaimAllocation(ctx, clusterClient, claim, request.ResourceType, quotav1alpha1.ResourceClaimAllocationStatusDenied,
+			reason, message, 0, "", "", denial, fieldManagerName); err != nil {
+			logger.Error(err, "failed to update request allocation for denial",
+				"claimName", claim.Name, "resourceType", request.ResourceType)
+		}
+		return false, nil
+	}
+
+	// Reserve capacity in the Organization tree and lenders before the local bucket
+	if tree != nil && !override {
+		if err := tree.reserve(ctx, grantAmount); err != nil {
+			return false, err
+		}
+	}
+	if borrowed := grantAmount - own; borrowed > 0 && !override {
+		if err := borrow(ctx, bucket, lenders, borrowed); err != nil {
+			return false, err
+		}
+		message = fmt.Sprintf("%s, including %d borrowed from other resource types", message, borrowed)
+	}
+
+	// The allocation fills the bucket's grants in selection order, starting where
+	// earlier allocations left off
+	satisfyingGrant := ""
+	if !override {
+		satisfyingGrant = selectGrant(bucket.Status.ContributingGrantRefs, bucket.Status.Allocated)
+	}
+
+	// Reserve capacity and keep status fields self-consistent for validation
+	bucket.Status.Allocated += grantAmount
+	// Recompute Available with clamp to satisfy CRD validation
+	bucket.Status.Available = bucketAvailable(bucket)
+	bucket.Status.ObservedGeneration = bucket.Generation
+
+	if err := clusterClient.Status().Update(ctx, bucket); err != nil {
+		if apierrors.IsConflict(err) {
+			// Controller runtime will automatically re-queue this resource
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to update bucket during reservation: %w", err)
+	}
+
+	// Mark this specific request as granted
+	decision = quotav1alpha1.ResourceClaimAllocationStatusGranted
+	if err := r.updateResourceClaimAllocation(ctx, clusterClient, claim, request.ResourceType, quotav1alpha1.ResourceClaimAllocationStatusGranted,
+		reason, message, grantAmount, bucket.Name, satisfyingGrant, nil, fieldManagerName); err != nil {
+		logger.Error(err, "failed to update request allocation after reservation",
+			"claimName", claim.Name, "resourceType", request.ResourceType)
+		// Don't revert the bucket allocation - the capacity has been reserved
+		return false, fmt.Errorf("failed to update request allocation: %w", err)
+	}
+	return false, nil
+}
+
+// sortClaimsByPriority orders claims from highest to lowest priority. Claims of equal
+// priority are ordered by creation time, namespace and name so the oldest is evaluated first.
+func sortClaimsByPriority(claims []quotav1alpha1.ResourceClaim) {
+	slices.SortStableFunc(claims, func(a, b quotav1alpha1.ResourceClaim) int {
+		if c := cmp.Compare(b.Spec.Priority, a.Spec.Priority); c != 0 {
+			return c
+		}
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// evaluateRequest decides how much of a request can be granted given the capacity
+// available in the bucket. Requests are granted in full when capacity allows; claims
+// that opt into partial grants receive whatever capacity remains. It returns false
+// when the request must be denied, along with the allocation reason and message.
+func evaluateRequest(claim *quotav1alpha1.ResourceClaim, request quotav1alpha1.ResourceRequest, available int64) (int64, string, string, bool) {
+	switch {
+	case request.Amount <= available:
+		return request.Amount, quotav1alpha1.ResourceClaimGrantedReason, "Capacity reserved", true
+	case claim.Spec.AllowPartial && available > 0:
+		return available, quotav1alpha1.ResourceClaimPartiallyGrantedReason,
+			fmt.Sprintf("Partial capacity reserved: granted %d of %d requested", available, request.Amount), true
+	default:
+		return 0, quotav1alpha1.ResourceClaimDeniedReason,
+			fmt.Sprintf("Resource quota exceeded: requested %d, available %d", request.Amount, available), false
 	}
-	return nil
 }
 
 // isResourceClaimAllocationProcessed checks if a specific request allocation has already been processed.
@@ -356,8 +753,10 @@ func (r *AllowanceBucketController) isResourceClaimAllocationProcessed(claim *qu
 }
 
 // updateResourceClaimAllocation updates or creates a request allocation status using Server Side Apply.
+// Granted allocations record the allocating bucket and, when known, the satisfying grant.
+// A non-nil denial is applied to status.denialDetails in the same patch.
 func (r *AllowanceBucketController) updateResourceClaimAllocation(ctx context.Context, clusterClient client.Client, claim *quotav1alpha1.ResourceClaim,
-	resourceType string, status, reason, message string, allocatedAmount int64, bucketName, fieldManagerName string) error {
+	resourceType string, status, reason, message string, allocatedAmount int64, bucketName, satisfyingGrant string, denial *quotav1alpha1.ResourceClaimDenialDetail, fieldManagerName string) error {
 
 	allocation := quotav1alpha1.ResourceClaimAllocationStatus{
 		ResourceType:       resourceType,
@@ -368,9 +767,10 @@ func (r *AllowanceBucketController) updateResourceClaimAllocation(ctx context.Co
 		LastTransitionTime: metav1.Now(),
 	}
 
-	// Set the allocating bucket reference only when status is Granted
+	// Set the allocating bucket and grant references only when status is Granted
 	if status == quotav1alpha1.ResourceClaimAllocationStatusGranted {
 		allocation.AllocatingBucket = bucketName
+		allocation.SatisfyingGrant = satisfyingGrant
 	}
 
 	// Create a minimal claim object for Server Side Apply
@@ -387,6 +787,9 @@ func (r *AllowanceBucketController) updateResourceClaimAllocation(ctx context.Co
 			Allocations: []quotav1alpha1.ResourceClaimAllocationStatus{allocation},
 		},
 	}
+	if denial != nil {
+		patchClaim.Status.DenialDetails = []quotav1alpha1.ResourceClaimDenialDetail{*denial}
+	}
 
 	// Apply the patch using Server Side Apply with our field manager
 	// The allocations list is a map-list keyed by resourceType, so SSA will merge entries correctly
@@ -401,6 +804,11 @@ func (r *AllowanceBucketController) updateResourceClaimAllocation(ctx context.Co
 // Skips the update if the status is semantically identical to prevent unnecessary
 // API server writes and audit log entries. Updates the LastReconciliation timestamp
 // only when status changes.
+//
+// Bucket status is written with Update rather than server-side apply on purpose: the
+// resourceVersion check makes a concurrent reconcile of the same bucket fail with a
+// conflict instead of merging, which is what keeps the reservations made in
+// processPendingClaims from granting the same capacity twice.
 func (r *AllowanceBucketController) updateStatusIfChanged(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket, originalStatus *quotav1alpha1.AllowanceBucketStatus) (ctrl.Result, error) {
 	if equality.Semantic.DeepEqual(&bucket.Status, originalStatus) {
 		return ctrl.Result{}, nil
@@ -415,27 +823,8 @@ func (r *AllowanceBucketController) updateStatusIfChanged(ctx context.Context, c
 	return ctrl.Result{}, nil
 }
 
-// generateAllowanceBucketName creates a deterministic name for an AllowanceBucket.
-// Buckets are global per consumer and resource type, not per claim namespace.
-func generateAllowanceBucketName(resourceType string, ownerRef quotav1alpha1.ConsumerRef) string {
-	input := fmt.Sprintf("%s%s%s", resourceType, ownerRef.Kind, ownerRef.Name)
-	return fmt.Sprintf("bucket-%x", sha256.Sum256([]byte(input)))
-}
-
-// getBucketNamespace determines the namespace where an AllowanceBucket should be created
-// based on the consumer type:
-// - Organization consumers → organization-{name} namespace
-// - Project consumers → milo-system namespace (centralized quota tracking)
-// - Other consumers → milo-system namespace (default)
-func getBucketNamespace(consumerRef quotav1alpha1.ConsumerRef) string {
-	if consumerRef.Kind == "Organization" {
-		return fmt.Sprintf("organization-%s", consumerRef.Name)
-	}
-	return "milo-system"
-}
-
-// consumerRefKey generates a consistent field index key for a ConsumerRef.
-// This key is used to efficiently query ResourceClaims by their consumer reference.
+// consumerRefKey generates a consistent key for a ConsumerRef.
+// It forms part of the bucket index values used to query ResourceClaims.
 func consumerRefKey(ref quotav1alpha1.ConsumerRef) string {
 	return fmt.Sprintf("%s/%s/%s/%s", ref.APIGroup, ref.Kind, ref.Namespace, ref.Name)
 }
@@ -443,34 +832,43 @@ func consumerRefKey(ref quotav1alpha1.ConsumerRef) string {
 // SetupWithManager sets up the controller with the Manager.
 // This controller watches AllowanceBuckets, ResourceGrants, and ResourceClaims across all control planes.
 func (r *AllowanceBucketController) SetupWithManager(mgr mcmanager.Manager) error {
-	indexFunc := func(obj client.Object) []string {
-		claim := obj.(*quotav1alpha1.ResourceClaim)
-		return []string{consumerRefKey(claim.Spec.ConsumerRef)}
-	}
+	r.usageLedger = newUsageLedger()
 
-	// Register index on both multicluster manager and local manager to support queries across all clusters
-	if err := mgr.GetFieldIndexer().IndexField(
-		context.Background(),
-		&quotav1alpha1.ResourceClaim{},
-		resourceClaimConsumerRefIndex,
-		indexFunc,
-	); err != nil {
-		return fmt.Errorf("failed to set up field index for ResourceClaim.Spec.ConsumerRef on provider clusters: %w", err)
+	if err := registerBucketIndexes(mgr); err != nil {
+		return err
 	}
 
-	if err := mgr.GetLocalManager().GetFieldIndexer().IndexField(
-		context.Background(),
-		&quotav1alpha1.ResourceClaim{},
-		resourceClaimConsumerRefIndex,
-		indexFunc,
-	); err != nil {
-		return fmt.Errorf("failed to set up field index for ResourceClaim.Spec.ConsumerRef on local cluster: %w", err)
+	if r.UsageNotifier != nil {
+		if err := mgr.GetLocalManager().Add(r.UsageNotifier); err != nil {
+			return fmt.Errorf("failed to add usage webhook notifier: %w", err)
+		}
 	}
 
 	return mcbuilder.ControllerManagedBy(mgr).
 		For(&quotav1alpha1.AllowanceBucket{},
 			mcbuilder.WithEngageWithLocalCluster(true),
 			mcbuilder.WithEngageWithProviderClusters(true)).
+		// Watch borrowing buckets so their lenders recompute the capacity they lend
+		Watches(
+			&quotav1alpha1.AllowanceBucket{},
+			mchandler.TypedEnqueueRequestsFromMapFunc(
+				func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+					return enqueueLenderBuckets(ctx, obj)
+				},
+			),
+			mcbuilder.WithEngageWithLocalCluster(true),
+			mcbuilder.WithEngageWithProviderClusters(true),
+			mcbuilder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool {
+					return false
+				},
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldBucket := e.ObjectOld.(*quotav1alpha1.AllowanceBucket)
+					newBucket := e.ObjectNew.(*quotav1alpha1.AllowanceBucket)
+					return !equality.Semantic.DeepEqual(oldBucket.Status.Borrowed, newBucket.Status.Borrowed)
+				},
+			}),
+		).
 		// Watch ResourceGrants that affect bucket limits
 		Watches(
 			&quotav1alpha1.ResourceGrant{},
@@ -487,7 +885,8 @@ func (r *AllowanceBucketController) SetupWithManager(mgr mcmanager.Manager) erro
 					return true
 				},
 				UpdateFunc: func(e event.UpdateEvent) bool {
-					// Trigger on spec (generation) changes or active status flips
+					// Trigger on spec (generation) changes, active status flips, or a pending
+					// grant settling, which changes the projected limit
 					oldGrant := e.ObjectOld.(*quotav1alpha1.ResourceGrant)
 					newGrant := e.ObjectNew.(*quotav1alpha1.ResourceGrant)
 					if oldGrant.Generation != newGrant.Generation {
@@ -495,7 +894,7 @@ func (r *AllowanceBucketController) SetupWithManager(mgr mcmanager.Manager) erro
 					}
 					oldActive := apimeta.IsStatusConditionTrue(oldGrant.Status.Conditions, quotav1alpha1.ResourceGrantActive)
 					newActive := apimeta.IsStatusConditionTrue(newGrant.Status.Conditions, quotav1alpha1.ResourceGrantActive)
-					return oldActive != newActive
+					return oldActive != newActive || isResourceGrantPending(oldGrant) != isResourceGrantPending(newGrant)
 				},
 			}),
 		).
@@ -504,6 +903,10 @@ func (r *AllowanceBucketController) SetupWithManager(mgr mcmanager.Manager) erro
 			&quotav1alpha1.ResourceClaim{},
 			mchandler.TypedEnqueueRequestsFromMapFunc(
 				func(ctx context.Context, obj client.Object) []mcreconcile.Request {
+					// Record the claim's usage before its buckets reconcile
+					if claim, ok := obj.(*quotav1alpha1.ResourceClaim); ok {
+						r.trackClaim(ctx, claim)
+					}
 					return r.enqueueAffectedBuckets(ctx, obj)
 				},
 			),
@@ -546,28 +949,35 @@ func (r *AllowanceBucketController) enqueueAffectedBuckets(ctx context.Context,
 
 	switch o := obj.(type) {
 	case *quotav1alpha1.ResourceGrant:
+		// Grants with percentage buckets resolve against this grant, so their buckets
+		// are affected too
+		grants := []quotav1alpha1.ResourceGrant{*o}
+		grants = append(grants, r.findChildGrants(ctx, clusterName, o)...)
+
 		// For each allowance in the grant, enqueue the corresponding bucket
 		// Bucket namespace is determined by consumer type (Organization namespace or milo-system)
-		for _, allowance := range o.Spec.Allowances {
-			bucketName := generateAllowanceBucketName(allowance.ResourceType, o.Spec.ConsumerRef)
-			bucketNamespace := getBucketNamespace(o.Spec.ConsumerRef)
-			requests = append(requests, mcreconcile.Request{
-				ClusterName: clusterName,
-				Request: ctrl.Request{
-					NamespacedName: types.NamespacedName{
-						Name:      bucketName,
-						Namespace: bucketNamespace,
+		for _, grant := range grants {
+			for _, allowance := range grant.Spec.Allowances {
+				bucketName := bucketutil.Name(allowance.ResourceType, grant.Spec.ConsumerRef)
+				bucketNamespace := bucketutil.Namespace(grant.Spec.ConsumerRef)
+				requests = append(requests, mcreconcile.Request{
+					ClusterName: clusterName,
+					Request: ctrl.Request{
+						NamespacedName: types.NamespacedName{
+							Name:      bucketName,
+							Namespace: bucketNamespace,
+						},
 					},
-				},
-			})
+				})
+			}
 		}
 
 	case *quotav1alpha1.ResourceClaim:
 		// For each request in the claim, enqueue the corresponding bucket
 		// Bucket namespace is determined by consumer type (Organization namespace or milo-system)
 		for _, request := range o.Spec.Requests {
-			bucketName := generateAllowanceBucketName(request.ResourceType, o.Spec.ConsumerRef)
-			bucketNamespace := getBucketNamespace(o.Spec.ConsumerRef)
+			bucketName := bucketutil.Name(request.ResourceType, o.Spec.ConsumerRef)
+			bucketNamespace := bucketutil.Namespace(o.Spec.ConsumerRef)
 			requests = append(requests, mcreconcile.Request{
 				ClusterName: clusterName,
 				Request: ctrl.Request{
@@ -582,3 +992,46 @@ func (r *AllowanceBucketController) enqueueAffectedBuckets(ctx context.Context,
 
 	return requests
 }
+
+// enqueueLenderBuckets enqueues the buckets a bucket has borrowed from. Buckets whose
+// loans were fully returned are no longer listed, and release them on their next resync.
+func enqueueLenderBuckets(ctx context.Context, obj client.Object) []mcreconcile.Request {
+	bucket, ok := obj.(*quotav1alpha1.AllowanceBucket)
+	if !ok {
+		return nil
+	}
+
+	clusterName, _ := mccontext.ClusterFrom(ctx)
+	var requests []mcreconcile.Request
+	for _, borrowed := range bucket.Status.Borrowed {
+		requests = append(requests, mcreconcile.Request{
+			ClusterName: clusterName,
+			Request: ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      bucketutil.Name(borrowed.ResourceType, bucket.Spec.ConsumerRef),
+					Namespace: bucket.Namespace,
+				},
+			},
+		})
+	}
+	return requests
+}
+
+// findChildGrants returns the grants whose percentage buckets are resolved against the
+// grant. Lookup failures are logged, as the children are corrected on their next change.
+func (r *AllowanceBucketController) findChildGrants(ctx context.Context, clusterName string, parent *quotav1alpha1.ResourceGrant) []quotav1alpha1.ResourceGrant {
+	if r.Manager == nil {
+		return nil
+	}
+	cluster, err := r.Manager.GetCluster(ctx, clusterName)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to get cluster for child grant lookup", "cluster", clusterName)
+		return nil
+	}
+	children, err := listChildGrants(ctx, cluster.GetClient(), parent)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list child grants", "grant", parent.Name)
+		return nil
+	}
+	return children
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+const (
+	// defaultOrphanBucketGracePeriod is how long a bucket must go without any
+	// referencing ResourceClaim before it is garbage collected.
+	defaultOrphanBucketGracePeriod = 5 * time.Minute
+
+	// AllowanceBucketOrphanedReason is the event reason recorded when a bucket with no
+	// remaining ResourceClaims is deleted.
+	AllowanceBucketOrphanedReason = "AllowanceBucketOrphaned"
+)
+
+// collectOrphanedBucket deletes the bucket once no ResourceClaim references it and no
+// ResourceGrant contributes to it. Buckets are created on demand from claims, so without
+// this they accumulate after their last claim is gone. Buckets backed by grants are kept,
+// since the grant controller creates them ahead of any claim and their limits are
+// reported in usage summaries. Only plain consumer buckets are collected; borrowPolicy
+// is the bucket's own borrow policy.
+//
+// Claims may be mid-creation and not yet visible in the cache, so a bucket is only
+// deleted after it has stayed empty for the grace period, measured from its last
+// status change. Until then the bucket is requeued. It returns true if the bucket
+// was deleted.
+func (r *AllowanceBucketController) collectOrphanedBucket(ctx context.Context, clusterClient client.Client, recorder record.EventRecorder, bucket *quotav1alpha1.AllowanceBucket, borrowPolicy *quotav1alpha1.BorrowPolicy, now time.Time) (ctrl.Result, bool, error) {
+	if !bucket.DeletionTimestamp.IsZero() || bucket.Status.ClaimCount > 0 || hasGrantedCapacity(bucket) {
+		return ctrl.Result{}, false, nil
+	}
+
+	plain, err := r.isPlainConsumerBucket(ctx, clusterClient, bucket, borrowPolicy)
+	if err != nil || !plain {
+		return ctrl.Result{}, false, err
+	}
+
+	// Pending and denied claims still reference the bucket even though they do not count toward usage
+	claims, err := listBucketClaims(ctx, clusterClient, bucket)
+	if err != nil {
+		return ctrl.Result{}, false, err
+	}
+	if len(claims) > 0 {
+		return ctrl.Result{}, false, nil
+	}
+
+	emptySince := bucket.CreationTimestamp.Time
+	if bucket.Status.LastReconciliation != nil && bucket.Status.LastReconciliation.After(emptySince) {
+		emptySince = bucket.Status.LastReconciliation.Time
+	}
+	if remaining := emptySince.Add(r.orphanBucketGracePeriod()).Sub(now); remaining > 0 {
+		log.FromContext(ctx).V(2).Info("AllowanceBucket has no claims; waiting before garbage collection",
+			"bucket", bucket.Name, "remaining", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, false, nil
+	}
+
+	log.FromContext(ctx).Info("Deleting AllowanceBucket with no remaining claims",
+		"bucket", bucket.Name, "resourceType", bucket.Spec.ResourceType, "limit", bucket.Status.Limit)
+	if err := clusterClient.Delete(ctx, bucket, client.Preconditions{UID: &bucket.UID, ResourceVersion: &bucket.ResourceVersion}); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return ctrl.Result{}, true, nil
+		}
+		return ctrl.Result{}, false, fmt.Errorf("failed to delete orphaned AllowanceBucket: %w", err)
+	}
+
+	if recorder != nil {
+		recorder.Eventf(bucket, corev1.EventTypeNormal, AllowanceBucketOrphanedReason,
+			"No ResourceClaims have referenced %s for consumer %s %q since %s; AllowanceBucket has been deleted",
+			bucket.Spec.ResourceType, bucket.Spec.ConsumerRef.Kind, bucket.Spec.ConsumerRef.Name, emptySince.UTC().Format(time.RFC3339))
+	}
+	return ctrl.Result{}, true, nil
+}
+
+// hasGrantedCapacity reports whether active or pending ResourceGrants contribute to the bucket.
+func hasGrantedCapacity(bucket *quotav1alpha1.AllowanceBucket) bool {
+	return bucket.Status.GrantCount > 0 || bucket.Status.Limit > 0 || bucket.Status.ProjectedLimit > 0
+}
+
+// isPlainConsumerBucket reports whether the bucket only serves its consumer's own
+// claims. OrganizationTree buckets count the claims of the Organization's Projects, and
+// buckets that borrow or may lend capacity back the claims of other resource types, so
+// none of them is orphaned by having no claims of its own.
+func (r *AllowanceBucketController) isPlainConsumerBucket(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket, borrowPolicy *quotav1alpha1.BorrowPolicy) (bool, error) {
+	if bucket.Status.AggregationScope == quotav1alpha1.AggregationScopeOrganizationTree ||
+		bucket.Status.Lent > 0 || len(bucket.Status.Borrowed) > 0 ||
+		(borrowPolicy != nil && len(borrowPolicy.From) > 0) {
+		return false, nil
+	}
+
+	lender, err := r.isLender(ctx, clusterClient, bucket)
+	if err != nil {
+		return false, err
+	}
+	return !lender, nil
+}
+
+// isLender reports whether an active grant of another of the consumer's buckets lets it
+// borrow from the bucket. Borrowers are never collected, so their buckets are found.
+func (r *AllowanceBucketController) isLender(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) (bool, error) {
+	var buckets quotav1alpha1.AllowanceBucketList
+	if err := clusterClient.List(ctx, &buckets, client.InNamespace(bucket.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list AllowanceBuckets: %w", err)
+	}
+
+	for _, borrower := range buckets.Items {
+		if borrower.Name == bucket.Name ||
+			borrower.Spec.ConsumerRef.Kind != bucket.Spec.ConsumerRef.Kind ||
+			borrower.Spec.ConsumerRef.Name != bucket.Spec.ConsumerRef.Name {
+			continue
+		}
+
+		grants, err := listBucketGrants(ctx, clusterClient, &borrower)
+		if err != nil {
+			return false, err
+		}
+		for _, grant := range grants {
+			if !r.isResourceGrantActive(&grant) {
+				continue
+			}
+			for _, allowance := range grant.Spec.Allowances {
+				if allowance.ResourceType == borrower.Spec.ResourceType && allowance.BorrowPolicy != nil &&
+					slices.Contains(allowance.BorrowPolicy.From, bucket.Spec.ResourceType) {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// orphanBucketGracePeriod returns the configured grace period or the default.
+func (r *AllowanceBucketController) orphanBucketGracePeriod() time.Duration {
+	if r.OrphanBucketGracePeriod > 0 {
+		return r.OrphanBucketGracePeriod
+	}
+	return defaultOrphanBucketGracePeriod
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestCollectOrphanedBucket(t *testing.T) {
+	now := time.Now()
+	pending := newGrantedClaim("pending", 1)
+	pending.Status = quotav1alpha1.ResourceClaimStatus{}
+
+	borrowerGrant := newActiveTestGrant("borrower", quotav1alpha1.Bucket{Amount: 10})
+	borrowerGrant.Spec.Allowances[0].ResourceType = testLenderResourceType
+	borrowerGrant.Spec.Allowances[0].BorrowPolicy = &quotav1alpha1.BorrowPolicy{From: []string{testResourceType}, MaxBorrowPercentage: 50}
+
+	tests := []struct {
+		name         string
+		emptySince   time.Time
+		claimCount   int32
+		objs         []client.Object
+		configure    func(*quotav1alpha1.AllowanceBucket)
+		borrowPolicy *quotav1alpha1.BorrowPolicy
+		wantDeleted  bool
+		wantRequeue  bool
+	}{
+		{
+			name:        "deleted after grace period without claims",
+			emptySince:  now.Add(-time.Hour),
+			wantDeleted: true,
+		},
+		{
+			name:        "requeued while within grace period",
+			emptySince:  now.Add(-time.Minute),
+			wantRequeue: true,
+		},
+		{
+			name:       "kept while granted claims contribute",
+			emptySince: now.Add(-time.Hour),
+			claimCount: 1,
+			objs:       []client.Object{newGrantedClaim("granted", 1)},
+		},
+		{
+			name:       "kept while a pending claim references the bucket",
+			emptySince: now.Add(-time.Hour),
+			objs:       []client.Object{pending},
+		},
+		{
+			name:       "bucket with grants but no claims is kept",
+			emptySince: now.Add(-time.Hour),
+			configure: func(bucket *quotav1alpha1.AllowanceBucket) {
+				bucket.Status.GrantCount = 1
+				bucket.Status.Limit = 10
+			},
+		},
+		{
+			name:       "bucket with only pending grants is kept",
+			emptySince: now.Add(-time.Hour),
+			configure: func(bucket *quotav1alpha1.AllowanceBucket) {
+				bucket.Status.ProjectedLimit = 10
+			},
+		},
+		{
+			name:       "organization tree bucket is kept",
+			emptySince: now.Add(-time.Hour),
+			configure: func(bucket *quotav1alpha1.AllowanceBucket) {
+				bucket.Status.AggregationScope = quotav1alpha1.AggregationScopeOrganizationTree
+			},
+		},
+		{
+			name:       "bucket lending capacity is kept",
+			emptySince: now.Add(-time.Hour),
+			configure: func(bucket *quotav1alpha1.AllowanceBucket) {
+				bucket.Status.Lent = 2
+			},
+		},
+		{
+			name:       "bucket holding borrowed capacity is kept",
+			emptySince: now.Add(-time.Hour),
+			configure: func(bucket *quotav1alpha1.AllowanceBucket) {
+				bucket.Status.Borrowed = []quotav1alpha1.BorrowedCapacity{{ResourceType: testLenderResourceType, Amount: 2}}
+			},
+		},
+		{
+			name:         "bucket with a borrow policy is kept",
+			emptySince:   now.Add(-time.Hour),
+			borrowPolicy: &quotav1alpha1.BorrowPolicy{From: []string{testLenderResourceType}, MaxBorrowPercentage: 50},
+		},
+		{
+			name:       "bucket another bucket may borrow from is kept",
+			emptySince: now.Add(-time.Hour),
+			objs:       []client.Object{newLenderTestBucket(10, 0), borrowerGrant},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := newLedgerTestBucket()
+			bucket.CreationTimestamp = metav1.NewTime(now.Add(-2 * time.Hour))
+			bucket.Status.LastReconciliation = &metav1.Time{Time: tt.emptySince}
+			bucket.Status.ClaimCount = tt.claimCount
+			if tt.configure != nil {
+				tt.configure(bucket)
+			}
+
+			c := newFakeClientWithClaimIndex(append(tt.objs, bucket)...)
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(bucket), bucket); err != nil {
+				t.Fatalf("failed to get bucket: %v", err)
+			}
+			recorder := record.NewFakeRecorder(1)
+			r := &AllowanceBucketController{}
+
+			result, deleted, err := r.collectOrphanedBucket(context.Background(), c, recorder, bucket, tt.borrowPolicy, now)
+			if err != nil {
+				t.Fatalf("collectOrphanedBucket() error = %v", err)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("collectOrphanedBucket() deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if (result.RequeueAfter > 0) != tt.wantRequeue {
+				t.Errorf("collectOrphanedBucket() requeueAfter = %v, want requeue %v", result.RequeueAfter, tt.wantRequeue)
+			}
+
+			err = c.Get(context.Background(), client.ObjectKeyFromObject(bucket), &quotav1alpha1.AllowanceBucket{})
+			if tt.wantDeleted {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("expected bucket to be deleted, got %v", err)
+				}
+				if len(recorder.Events) != 1 {
+					t.Errorf("expected a deletion event to be recorded")
+				}
+			} else if err != nil {
+				t.Errorf("expected bucket to be kept, got %v", err)
+			}
+		})
+	}
+}
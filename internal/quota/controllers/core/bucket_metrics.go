@@ -0,0 +1,79 @@
+package core
+
+import (
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	legacyregistry "k8s.io/component-base/metrics/legacyregistry"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+var (
+	allowanceBucketReconcileDuration = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      "milo_quota",
+			Name:           "allowance_bucket_reconcile_duration_seconds",
+			Help:           "Time taken to aggregate an AllowanceBucket's grants and claims.",
+			Buckets:        metrics.ExponentialBuckets(0.005, 2, 12),
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	allowanceBucketAvailable = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem: "milo_quota",
+			Name:      "allowance_bucket_available",
+			Help: "Capacity left in an AllowanceBucket, computed from its limit and allocation without " +
+				"clamping at zero. Negative values mean more is allocated than granted.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"cluster", "resource_type", "consumer_kind", "consumer_name"}, // cluster: root|<project control plane>
+	)
+
+	allowanceBucketWithoutGrants = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem: "milo_quota",
+			Name:      "allowance_bucket_without_grants_total",
+			Help: "AllowanceBucket reconciliations that found claims but no contributing ResourceGrants, " +
+				"e.g. when claims are evaluated before their grants are aggregated.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"resource_type"},
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(allowanceBucketReconcileDuration)
+	legacyregistry.MustRegister(allowanceBucketAvailable)
+	legacyregistry.MustRegister(allowanceBucketWithoutGrants)
+}
+
+// bucketMetricLabels remembers the series recorded for each bucket, keyed by ledgerKey,
+// so they can be removed once the bucket is gone.
+var bucketMetricLabels sync.Map
+
+// recordBucketMetrics records the available capacity of a reconciled bucket of the
+// given cluster. Buckets are labeled with their cluster, as project control planes
+// hold buckets of the same name.
+func recordBucketMetrics(clusterName, key string, bucket *quotav1alpha1.AllowanceBucket) {
+	labels := []string{clusterMetricLabel(clusterName), bucket.Spec.ResourceType, bucket.Spec.ConsumerRef.Kind, bucket.Spec.ConsumerRef.Name}
+	bucketMetricLabels.Store(key, labels)
+	allowanceBucketAvailable.WithLabelValues(labels...).Set(float64(bucketHeadroom(bucket)))
+}
+
+// forgetBucketMetrics drops the series of a deleted bucket.
+func forgetBucketMetrics(key string) {
+	if labels, ok := bucketMetricLabels.LoadAndDelete(key); ok {
+		allowanceBucketAvailable.DeleteLabelValues(labels.([]string)...)
+	}
+}
+
+// clusterMetricLabel returns the cluster label value for a cluster name, where the
+// local cluster ("") is the root control plane.
+func clusterMetricLabel(clusterName string) string {
+	if clusterName == "" {
+		return "root"
+	}
+	return clusterName
+}
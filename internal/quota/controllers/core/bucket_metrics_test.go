@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestBucketMetricsReportOverAllocation(t *testing.T) {
+	bucket := newLedgerTestBucket()
+	bucket.Status.Limit = 5
+	bucket.Status.Allocated = 7
+	key := ledgerKey("", bucket.Namespace, bucket.Name)
+	ref := bucket.Spec.ConsumerRef
+
+	recordBucketMetrics("", key, bucket)
+	defer forgetBucketMetrics(key)
+
+	gauge := allowanceBucketAvailable.WithLabelValues("root", bucket.Spec.ResourceType, ref.Kind, ref.Name)
+	if got, _ := testutil.GetGaugeMetricValue(gauge); got != -2 {
+		t.Errorf("available gauge = %v, want -2", got)
+	}
+	if got := bucketAvailable(bucket); got != 0 {
+		t.Errorf("bucketAvailable() = %d, want the status value to stay clamped at 0", got)
+	}
+
+	forgetBucketMetrics(key)
+	if _, ok := bucketMetricLabels.Load(key); ok {
+		t.Errorf("expected the series of a forgotten bucket to be dropped")
+	}
+}
+
+func TestBucketMetricsAreLabeledWithTheirCluster(t *testing.T) {
+	bucket := newLedgerTestBucket()
+	ref := bucket.Spec.ConsumerRef
+	rootKey := ledgerKey("", bucket.Namespace, bucket.Name)
+	projectKey := ledgerKey("project-a", bucket.Namespace, bucket.Name)
+
+	// Project control planes hold buckets with the same name as the root's
+	bucket.Status.Limit = 5
+	recordBucketMetrics("", rootKey, bucket)
+	defer forgetBucketMetrics(rootKey)
+	projectBucket := bucket.DeepCopy()
+	projectBucket.Status.Limit = 3
+	recordBucketMetrics("project-a", projectKey, projectBucket)
+	defer forgetBucketMetrics(projectKey)
+
+	for cluster, want := range map[string]float64{"root": 5, "project-a": 3} {
+		gauge := allowanceBucketAvailable.WithLabelValues(cluster, bucket.Spec.ResourceType, ref.Kind, ref.Name)
+		if got, _ := testutil.GetGaugeMetricValue(gauge); got != want {
+			t.Errorf("available gauge of cluster %s = %v, want %v", cluster, got, want)
+		}
+	}
+}
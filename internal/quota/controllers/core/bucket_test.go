@@ -0,0 +1,431 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+)
+
+const testResourceType = "resourcemanager.miloapis.com/projects"
+
+func testScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = quotav1alpha1.AddToScheme(scheme)
+	_ = resourcemanagerv1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func testConsumerRef() quotav1alpha1.ConsumerRef {
+	return quotav1alpha1.ConsumerRef{
+		APIGroup: "resourcemanager.miloapis.com",
+		Kind:     "Organization",
+		Name:     "acme",
+	}
+}
+
+func newFakeClientWithClaimIndex(objs ...client.Object) client.Client {
+	return fake.NewClientBuilder().
+		WithScheme(testScheme()).
+		WithObjects(objs...).
+		WithIndex(&quotav1alpha1.ResourceClaim{}, resourceClaimBucketIndex, claimBucketIndexValues).
+		WithIndex(&quotav1alpha1.ResourceGrant{}, resourceGrantBucketIndex, grantBucketIndexValues).
+		WithIndex(&quotav1alpha1.ResourceGrant{}, resourceGrantParentIndex, grantParentIndexValues).
+		Build()
+}
+
+// newClaimAllocationClient returns a fake client with the bucket indexes and status
+// subresources that merges the allocation status processPendingClaims applies to
+// ResourceClaims, since the fake client does not support server-side apply.
+func newClaimAllocationClient(objs ...client.Object) client.Client {
+	c := fake.NewClientBuilder().
+		WithScheme(testScheme()).
+		WithObjects(objs...).
+		WithStatusSubresource(&quotav1alpha1.AllowanceBucket{}, &quotav1alpha1.ResourceClaim{}).
+		WithIndex(&quotav1alpha1.ResourceClaim{}, resourceClaimBucketIndex, claimBucketIndexValues).
+		WithIndex(&quotav1alpha1.ResourceGrant{}, resourceGrantBucketIndex, grantBucketIndexValues).
+		WithIndex(&quotav1alpha1.ResourceGrant{}, resourceGrantParentIndex, grantParentIndexValues).
+		Build()
+	return interceptor.NewClient(c, interceptor.Funcs{
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			applied, ok := obj.(*quotav1alpha1.ResourceClaim)
+			if !ok || patch.Type() != types.ApplyPatchType {
+				return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+			}
+			var claim quotav1alpha1.ResourceClaim
+			if err := c.Get(ctx, client.ObjectKeyFromObject(applied), &claim); err != nil {
+				return err
+			}
+			for _, allocation := range applied.Status.Allocations {
+				claim.Status.Allocations = slices.DeleteFunc(claim.Status.Allocations, func(existing quotav1alpha1.ResourceClaimAllocationStatus) bool {
+					return existing.ResourceType == allocation.ResourceType
+				})
+				claim.Status.Allocations = append(claim.Status.Allocations, allocation)
+			}
+			claim.Status.DenialDetails = append(claim.Status.DenialDetails, applied.Status.DenialDetails...)
+			return c.Status().Update(ctx, &claim)
+		},
+	})
+}
+
+func newGrantedClaim(name string, amount int64) *quotav1alpha1.ResourceClaim {
+	return &quotav1alpha1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: quotav1alpha1.ResourceClaimSpec{
+			ConsumerRef: testConsumerRef(),
+			Requests: []quotav1alpha1.ResourceRequest{
+				{ResourceType: testResourceType, Amount: amount},
+			},
+		},
+		Status: quotav1alpha1.ResourceClaimStatus{
+			Allocations: []quotav1alpha1.ResourceClaimAllocationStatus{
+				{
+					ResourceType:    testResourceType,
+					Status:          quotav1alpha1.ResourceClaimAllocationStatusGranted,
+					AllocatedAmount: amount,
+				},
+			},
+		},
+	}
+}
+
+func TestRankTopClaims(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   []quotav1alpha1.TopClaimRef
+		limit    int
+		expected []quotav1alpha1.TopClaimRef
+	}{
+		{
+			name:     "no claims",
+			claims:   nil,
+			limit:    3,
+			expected: nil,
+		},
+		{
+			name: "ranked by amount descending",
+			claims: []quotav1alpha1.TopClaimRef{
+				{Name: "small", Amount: 1},
+				{Name: "large", Amount: 10},
+				{Name: "medium", Amount: 5},
+			},
+			limit: 3,
+			expected: []quotav1alpha1.TopClaimRef{
+				{Name: "large", Amount: 10},
+				{Name: "medium", Amount: 5},
+				{Name: "small", Amount: 1},
+			},
+		},
+		{
+			name: "bounded to limit",
+			claims: []quotav1alpha1.TopClaimRef{
+				{Name: "a", Amount: 1},
+				{Name: "b", Amount: 2},
+				{Name: "c", Amount: 3},
+				{Name: "d", Amount: 4},
+			},
+			limit: 2,
+			expected: []quotav1alpha1.TopClaimRef{
+				{Name: "d", Amount: 4},
+				{Name: "c", Amount: 3},
+			},
+		},
+		{
+			name: "ties broken by namespace then name",
+			claims: []quotav1alpha1.TopClaimRef{
+				{Name: "b", Namespace: "ns2", Amount: 5},
+				{Name: "b", Namespace: "ns1", Amount: 5},
+				{Name: "a", Namespace: "ns1", Amount: 5},
+			},
+			limit: 3,
+			expected: []quotav1alpha1.TopClaimRef{
+				{Name: "a", Namespace: "ns1", Amount: 5},
+				{Name: "b", Namespace: "ns1", Amount: 5},
+				{Name: "b", Namespace: "ns2", Amount: 5},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rankTopClaims(tt.claims, tt.limit)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("rankTopClaims() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUpdateUsageFromClaimsReportsTopClaims(t *testing.T) {
+	var objs []client.Object
+	for i := 1; i <= maxTopClaims+5; i++ {
+		objs = append(objs, newGrantedClaim(fmt.Sprintf("claim-%02d", i), int64(i)))
+	}
+
+	// Pending and denied claims must not be reported.
+	pending := newGrantedClaim("pending", 1000)
+	pending.Status.Allocations = nil
+	denied := newGrantedClaim("denied", 1000)
+	denied.Status.Allocations[0].Status = quotav1alpha1.ResourceClaimAllocationStatusDenied
+	objs = append(objs, pending, denied)
+
+	r := &AllowanceBucketController{}
+	bucket := &quotav1alpha1.AllowanceBucket{
+		Spec: quotav1alpha1.AllowanceBucketSpec{
+			ConsumerRef:  testConsumerRef(),
+			ResourceType: testResourceType,
+		},
+	}
+
+	if err := r.updateUsageFromClaims(context.Background(), newFakeClientWithClaimIndex(objs...), bucket); err != nil {
+		t.Fatalf("updateUsageFromClaims() error = %v", err)
+	}
+
+	if len(bucket.Status.TopClaims) != maxTopClaims {
+		t.Fatalf("expected %d top claims, got %d", maxTopClaims, len(bucket.Status.TopClaims))
+	}
+	for i, ref := range bucket.Status.TopClaims {
+		wantAmount := int64(maxTopClaims + 5 - i)
+		if ref.Amount != wantAmount {
+			t.Errorf("top claim %d: expected amount %d, got %d (%s)", i, wantAmount, ref.Amount, ref.Name)
+		}
+		if ref.Namespace != "default" {
+			t.Errorf("top claim %d: expected namespace default, got %q", i, ref.Namespace)
+		}
+	}
+	if bucket.Status.ClaimCount != int32(maxTopClaims+5) {
+		t.Errorf("expected claim count %d, got %d", maxTopClaims+5, bucket.Status.ClaimCount)
+	}
+}
+
+func TestEvaluateRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		allowPartial bool
+		requested    int64
+		available    int64
+		wantGranted  bool
+		wantAmount   int64
+		wantReason   string
+	}{
+		{
+			name:        "full grant when capacity allows",
+			requested:   3,
+			available:   5,
+			wantGranted: true,
+			wantAmount:  3,
+			wantReason:  quotav1alpha1.ResourceClaimGrantedReason,
+		},
+		{
+			name:        "denied without partial opt-in",
+			requested:   4,
+			available:   2,
+			wantGranted: false,
+			wantReason:  quotav1alpha1.ResourceClaimDeniedReason,
+		},
+		{
+			name:         "partial grant of remaining capacity",
+			allowPartial: true,
+			requested:    4,
+			available:    2,
+			wantGranted:  true,
+			wantAmount:   2,
+			wantReason:   quotav1alpha1.ResourceClaimPartiallyGrantedReason,
+		},
+		{
+			name:         "partial grant still receives the full request when available",
+			allowPartial: true,
+			requested:    2,
+			available:    2,
+			wantGranted:  true,
+			wantAmount:   2,
+			wantReason:   quotav1alpha1.ResourceClaimGrantedReason,
+		},
+		{
+			name:         "partial grant denied when no capacity remains",
+			allowPartial: true,
+			requested:    4,
+			available:    0,
+			wantGranted:  false,
+			wantReason:   quotav1alpha1.ResourceClaimDeniedReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := newGrantedClaim("claim", tt.requested)
+			claim.Spec.AllowPartial = tt.allowPartial
+
+			amount, reason, _, granted := evaluateRequest(claim, claim.Spec.Requests[0], tt.available)
+			if granted != tt.wantGranted {
+				t.Fatalf("evaluateRequest() granted = %v, want %v", granted, tt.wantGranted)
+			}
+			if amount != tt.wantAmount {
+				t.Errorf("evaluateRequest() amount = %d, want %d", amount, tt.wantAmount)
+			}
+			if reason != tt.wantReason {
+				t.Errorf("evaluateRequest() reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSortClaimsByPriority(t *testing.T) {
+	created := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// The best-effort claim was created first, so it would win without priorities.
+	bestEffort := newGrantedClaim("best-effort", 5)
+	bestEffort.CreationTimestamp = created
+	critical := newGrantedClaim("critical", 5)
+	critical.CreationTimestamp = metav1.NewTime(created.Add(time.Minute))
+	critical.Spec.Priority = 100
+	older := newGrantedClaim("older", 5)
+	older.CreationTimestamp = metav1.NewTime(created.Add(-time.Minute))
+
+	claims := []quotav1alpha1.ResourceClaim{*bestEffort, *critical, *older}
+	sortClaimsByPriority(claims)
+
+	var order []string
+	for _, claim := range claims {
+		order = append(order, claim.Name)
+	}
+	if want := []string{"critical", "older", "best-effort"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("sortClaimsByPriority() order = %v, want %v", order, want)
+	}
+
+}
+
+func TestProcessPendingClaimsByPriority(t *testing.T) {
+	ctx := context.Background()
+	r := &AllowanceBucketController{}
+	created := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// The best-effort claims sort and were created first, so they would win without priorities.
+	var claims []client.Object
+	for i, name := range []string{"a-best-effort", "b-best-effort", "c-critical"} {
+		claim := newGrantedClaim(name, 5)
+		claim.Status.Allocations = nil
+		claim.CreationTimestamp = metav1.NewTime(created.Add(time.Duration(i) * time.Minute))
+		claims = append(claims, claim)
+	}
+	claims[2].(*quotav1alpha1.ResourceClaim).Spec.Priority = 100
+
+	// The bucket only has capacity for one claim
+	bucket := newAllowanceBucket(testResourceType, testConsumerRef())
+	bucket.Status.Limit = 5
+	bucket.Status.Available = 5
+	c := newClaimAllocationClient(append(claims, bucket)...)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(bucket), bucket); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if err := r.processPendingClaims(ctx, c, bucket, nil, nil); err != nil {
+		t.Fatalf("processPendingClaims() error = %v", err)
+	}
+
+	want := map[string]string{
+		"a-best-effort": quotav1alpha1.ResourceClaimAllocationStatusDenied,
+		"b-best-effort": quotav1alpha1.ResourceClaimAllocationStatusDenied,
+		"c-critical":    quotav1alpha1.ResourceClaimAllocationStatusGranted,
+	}
+	for name, status := range want {
+		var claim quotav1alpha1.ResourceClaim
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &claim); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if len(claim.Status.Allocations) != 1 || claim.Status.Allocations[0].Status != status {
+			t.Errorf("claim %s allocations = %+v, want %s", name, claim.Status.Allocations, status)
+		}
+	}
+}
+
+func TestPeriodicResyncInterval(t *testing.T) {
+	tests := []struct {
+		name       string
+		controller *AllowanceBucketController
+		expected   time.Duration
+	}{
+		{
+			name:       "default",
+			controller: &AllowanceBucketController{},
+			expected:   defaultBucketResyncPeriod,
+		},
+		{
+			name:       "configured",
+			controller: &AllowanceBucketController{ResyncPeriod: 2 * time.Minute},
+			expected:   2 * time.Minute,
+		},
+		{
+			name:       "usage expires first",
+			controller: &AllowanceBucketController{ResyncPeriod: time.Hour, UsageResyncInterval: 5 * time.Minute, usageLedger: newUsageLedger()},
+			expected:   5 * time.Minute,
+		},
+		{
+			name:       "usage interval ignored without ledger",
+			controller: &AllowanceBucketController{ResyncPeriod: time.Hour, UsageResyncInterval: 5 * time.Minute},
+			expected:   time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.controller.periodicResyncInterval(); got != tt.expected {
+				t.Errorf("periodicResyncInterval() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOvercommittedCondition(t *testing.T) {
+	// The inactive grant no longer contributes, leaving the claims made under it overcommitted.
+	objs := []client.Object{
+		newActiveTestGrant("active", quotav1alpha1.Bucket{Amount: 5}),
+		newTestGrant("revoked", testResourceType, testConsumerRef()),
+		newGrantedClaim("first", 4),
+		newGrantedClaim("second", 6),
+	}
+	c := newFakeClientWithClaimIndex(objs...)
+
+	r := &AllowanceBucketController{}
+	bucket := newLedgerTestBucket()
+	if _, err := r.updateLimitsFromGrants(context.Background(), c, bucket); err != nil {
+		t.Fatalf("updateLimitsFromGrants() error = %v", err)
+	}
+	if err := r.updateUsageFromClaims(context.Background(), c, bucket); err != nil {
+		t.Fatalf("updateUsageFromClaims() error = %v", err)
+	}
+	bucket.Status.Available = bucketAvailable(bucket)
+	setOvercommittedCondition(bucket)
+
+	if bucket.Status.Available != 0 {
+		t.Errorf("Available = %d, want 0", bucket.Status.Available)
+	}
+	condition := meta.FindStatusCondition(bucket.Status.Conditions, quotav1alpha1.AllowanceBucketOvercommitted)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != quotav1alpha1.AllowanceBucketAllocationExceedsLimitReason {
+		t.Fatalf("expected Overcommitted to be True, got %+v", condition)
+	}
+	if !strings.Contains(condition.Message, "by 5") {
+		t.Errorf("expected the message to report a deficit of 5, got %q", condition.Message)
+	}
+
+	// Releasing the second claim brings the bucket back within its limit.
+	bucket.Status.Allocated = 4
+	setOvercommittedCondition(bucket)
+	if !meta.IsStatusConditionFalse(bucket.Status.Conditions, quotav1alpha1.AllowanceBucketOvercommitted) {
+		t.Errorf("expected Overcommitted to be False, got %+v", bucket.Status.Conditions)
+	}
+}
@@ -10,7 +10,10 @@ package core
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +33,10 @@ import (
 type ResourceClaimController struct {
 	Scheme  *runtime.Scheme
 	Manager mcmanager.Manager
+
+	// PauseGranting reports pending claims as GrantingPaused rather than
+	// PendingEvaluation, so admission can tell a paused claim from a slow one.
+	PauseGranting bool
 }
 
 // +kubebuilder:rbac:groups=quota.miloapis.com,resources=resourceclaims,verbs=get;list;watch;create;update;patch;delete
@@ -74,6 +81,26 @@ func (r *ResourceClaimController) Reconcile(ctx context.Context, req mcreconcile
 	return ctrl.Result{}, nil
 }
 
+// admissionTraceContext returns ctx carrying the trace context recorded on claim by the
+// admission request that created it. It reports false once the claim has been granted
+// or denied, so that later evaluations are not attached to a finished trace.
+func admissionTraceContext(ctx context.Context, claim *quotav1alpha1.ResourceClaim) (context.Context, bool) {
+	traceParent := claim.Annotations[quotav1alpha1.ResourceClaimTraceParentAnnotation]
+	if traceParent == "" {
+		return ctx, false
+	}
+	if granted := apimeta.FindStatusCondition(claim.Status.Conditions, quotav1alpha1.ResourceClaimGranted); granted != nil &&
+		granted.Reason != quotav1alpha1.ResourceClaimPendingReason && granted.Reason != quotav1alpha1.ResourceClaimGrantingPausedReason {
+		return ctx, false
+	}
+
+	parent := propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceParent})
+	if !trace.SpanContextFromContext(parent).IsValid() {
+		return ctx, false
+	}
+	return parent, true
+}
+
 // updateOverallClaimConditionFromAllocations updates the overall Granted condition
 // based on the status of individual request allocations.
 func (r *ResourceClaimController) updateOverallClaimConditionFromAllocations(ctx context.Context, clusterClient client.Client, claim *quotav1alpha1.ResourceClaim) error {
@@ -83,8 +110,13 @@ func (r *ResourceClaimController) updateOverallClaimConditionFromAllocations(ctx
 	for _, allocation := range claim.Status.Allocations {
 		allocationMap[allocation.ResourceType] = allocation
 	}
+	denialMap := make(map[string]quotav1alpha1.ResourceClaimDenialDetail)
+	for _, denial := range claim.Status.DenialDetails {
+		denialMap[denial.ResourceType] = denial
+	}
 
 	var grantedCount, deniedCount, pendingCount int
+	var deniedTypes, partialTypes []string
 	var totalRequests = len(claim.Spec.Requests)
 
 	// Check the status of each request by resource type
@@ -99,8 +131,12 @@ func (r *ResourceClaimController) updateOverallClaimConditionFromAllocations(ctx
 		switch allocation.Status {
 		case quotav1alpha1.ResourceClaimAllocationStatusGranted:
 			grantedCount++
+			if allocation.AllocatedAmount < request.Amount {
+				partialTypes = append(partialTypes, fmt.Sprintf("%s (%d of %d)", request.ResourceType, allocation.AllocatedAmount, request.Amount))
+			}
 		case quotav1alpha1.ResourceClaimAllocationStatusDenied:
 			deniedCount++
+			deniedTypes = append(deniedTypes, describeDenial(request.ResourceType, denialMap))
 		case quotav1alpha1.ResourceClaimAllocationStatusPending:
 			pendingCount++
 		default:
@@ -118,11 +154,21 @@ func (r *ResourceClaimController) updateOverallClaimConditionFromAllocations(ctx
 		conditionStatus = metav1.ConditionTrue
 		reason = quotav1alpha1.ResourceClaimGrantedReason
 		message = fmt.Sprintf("All %d resource requests have been granted", totalRequests)
+		if len(partialTypes) > 0 {
+			message = fmt.Sprintf("All %d resource requests have been granted, with reduced amounts for %s",
+				totalRequests, strings.Join(partialTypes, ", "))
+		}
 	} else if deniedCount > 0 {
 		// At least one request denied
 		conditionStatus = metav1.ConditionFalse
 		reason = quotav1alpha1.ResourceClaimDeniedReason
-		message = "Insufficient quota resources. Contact your account administrator to review quota limits and usage."
+		message = fmt.Sprintf("Insufficient quota resources for %s. Contact your account administrator to review quota limits and usage.",
+			strings.Join(deniedTypes, ", "))
+	} else if r.PauseGranting {
+		// Pending requests are held until granting resumes
+		conditionStatus = metav1.ConditionFalse
+		reason = quotav1alpha1.ResourceClaimGrantingPausedReason
+		message = fmt.Sprintf("Quota granting is paused for maintenance: %d granted, %d pending. The claim will be evaluated when granting resumes.", grantedCount, pendingCount)
 	} else {
 		// Some requests still pending
 		conditionStatus = metav1.ConditionFalse
@@ -133,6 +179,17 @@ func (r *ResourceClaimController) updateOverallClaimConditionFromAllocations(ctx
 	return r.updateOverallClaimCondition(ctx, clusterClient, claim, conditionStatus, reason, message)
 }
 
+// describeDenial names a denied resource type together with the requested amount,
+// current allocation, and limit recorded for it in status.denialDetails, if any.
+func describeDenial(resourceType string, denials map[string]quotav1alpha1.ResourceClaimDenialDetail) string {
+	denial, ok := denials[resourceType]
+	if !ok {
+		return resourceType
+	}
+	return fmt.Sprintf("%s (requested %d, allocated %d of limit %d, available %d)",
+		resourceType, denial.Requested, denial.Allocated, denial.Limit, denial.Available)
+}
+
 // updateOverallClaimCondition updates the overall Granted condition using Server Side Apply.
 func (r *ResourceClaimController) updateOverallClaimCondition(ctx context.Context, clusterClient client.Client, claim *quotav1alpha1.ResourceClaim,
 	status metav1.ConditionStatus, reason, message string) error {
@@ -143,7 +200,9 @@ func (r *ResourceClaimController) updateOverallClaimCondition(ctx context.Contex
 		Reason:  reason,
 		Message: message,
 	})
-	if !changed {
+	// Claims written before observedGeneration was recorded are patched even when the
+	// condition is unchanged, so that every claim reports the generation it reflects
+	if !changed && claim.Status.ObservedGeneration == claim.Generation {
 		return nil
 	}
 
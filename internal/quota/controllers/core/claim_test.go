@@ -0,0 +1,202 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestDescribeDenial(t *testing.T) {
+	denials := map[string]quotav1alpha1.ResourceClaimDenialDetail{
+		testResourceType: {
+			ResourceType: testResourceType,
+			Requested:    2,
+			Allocated:    9,
+			Limit:        10,
+			Available:    1,
+		},
+	}
+
+	if got, want := describeDenial(testResourceType, denials), testResourceType+" (requested 2, allocated 9 of limit 10, available 1)"; got != want {
+		t.Errorf("describeDenial() = %q, want %q", got, want)
+	}
+	if got := describeDenial("compute.miloapis.com/instances", denials); got != "compute.miloapis.com/instances" {
+		t.Errorf("describeDenial() without details = %q, want the resource type alone", got)
+	}
+}
+
+func TestPendingClaimConditionWhileGrantingPaused(t *testing.T) {
+	tests := []struct {
+		name           string
+		pauseGranting  bool
+		expectedReason string
+	}{
+		{
+			name:           "granting active",
+			expectedReason: quotav1alpha1.ResourceClaimPendingReason,
+		},
+		{
+			name:           "granting paused",
+			pauseGranting:  true,
+			expectedReason: quotav1alpha1.ResourceClaimGrantingPausedReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &quotav1alpha1.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "claim", Namespace: "default"},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					Requests: []quotav1alpha1.ResourceRequest{{ResourceType: "apps/Deployment", Amount: 1}},
+				},
+			}
+
+			var applied *quotav1alpha1.ResourceClaim
+			c := fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourcePatch: func(_ context.Context, _ client.Client, _ string, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						applied = obj.(*quotav1alpha1.ResourceClaim)
+						return nil
+					},
+				}).
+				Build()
+
+			r := &ResourceClaimController{PauseGranting: tt.pauseGranting}
+			if err := r.updateOverallClaimConditionFromAllocations(context.Background(), c, claim); err != nil {
+				t.Fatalf("updateOverallClaimConditionFromAllocations() error = %v", err)
+			}
+			if applied == nil {
+				t.Fatal("expected the Granted condition to be applied")
+			}
+
+			condition := apimeta.FindStatusCondition(applied.Status.Conditions, quotav1alpha1.ResourceClaimGranted)
+			if condition == nil {
+				t.Fatal("expected a Granted condition")
+			}
+			if condition.Status != metav1.ConditionFalse {
+				t.Errorf("expected Granted=False, got %s", condition.Status)
+			}
+			if condition.Reason != tt.expectedReason {
+				t.Errorf("expected reason %s, got %s", tt.expectedReason, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestAdmissionTraceContext(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	claimWith := func(annotations map[string]string, reason string) *quotav1alpha1.ResourceClaim {
+		claim := &quotav1alpha1.ResourceClaim{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+		if reason != "" {
+			claim.Status.Conditions = []metav1.Condition{{
+				Type:   quotav1alpha1.ResourceClaimGranted,
+				Status: metav1.ConditionFalse,
+				Reason: reason,
+			}}
+		}
+		return claim
+	}
+	withTraceParent := map[string]string{quotav1alpha1.ResourceClaimTraceParentAnnotation: traceParent}
+
+	tests := []struct {
+		name   string
+		claim  *quotav1alpha1.ResourceClaim
+		wantOK bool
+	}{
+		{name: "new claim", claim: claimWith(withTraceParent, ""), wantOK: true},
+		{name: "pending claim", claim: claimWith(withTraceParent, quotav1alpha1.ResourceClaimPendingReason), wantOK: true},
+		{name: "paused claim", claim: claimWith(withTraceParent, quotav1alpha1.ResourceClaimGrantingPausedReason), wantOK: true},
+		{name: "denied claim", claim: claimWith(withTraceParent, quotav1alpha1.ResourceClaimDeniedReason)},
+		{name: "no annotation", claim: claimWith(nil, "")},
+		{name: "malformed annotation", claim: claimWith(map[string]string{quotav1alpha1.ResourceClaimTraceParentAnnotation: "not-a-traceparent"}, "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ok := admissionTraceContext(context.Background(), tt.claim)
+			if ok != tt.wantOK {
+				t.Fatalf("admissionTraceContext() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			spanContext := trace.SpanContextFromContext(ctx)
+			if got := spanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+				t.Errorf("trace ID = %s, want the admission trace", got)
+			}
+			if !spanContext.IsRemote() {
+				t.Error("expected the admission span to be a remote parent")
+			}
+		})
+	}
+}
+
+func TestClaimObservedGenerationBackfill(t *testing.T) {
+	pendingCondition := metav1.Condition{
+		Type:    quotav1alpha1.ResourceClaimGranted,
+		Status:  metav1.ConditionFalse,
+		Reason:  quotav1alpha1.ResourceClaimPendingReason,
+		Message: "Awaiting capacity evaluation: 0 granted, 1 pending",
+	}
+
+	tests := []struct {
+		name               string
+		observedGeneration int64
+		wantApplied        bool
+	}{
+		{name: "legacy claim without observedGeneration", wantApplied: true},
+		{name: "claim behind its generation", observedGeneration: 1, wantApplied: true},
+		{name: "claim up to date", observedGeneration: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &quotav1alpha1.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "claim", Namespace: "default", Generation: 2},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					Requests: []quotav1alpha1.ResourceRequest{{ResourceType: "apps/Deployment", Amount: 1}},
+				},
+				Status: quotav1alpha1.ResourceClaimStatus{
+					ObservedGeneration: tt.observedGeneration,
+					Conditions:         []metav1.Condition{pendingCondition},
+				},
+			}
+
+			var applied *quotav1alpha1.ResourceClaim
+			c := fake.NewClientBuilder().
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourcePatch: func(_ context.Context, _ client.Client, _ string, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+						applied = obj.(*quotav1alpha1.ResourceClaim)
+						return nil
+					},
+				}).
+				Build()
+
+			r := &ResourceClaimController{}
+			if err := r.updateOverallClaimConditionFromAllocations(context.Background(), c, claim); err != nil {
+				t.Fatalf("updateOverallClaimConditionFromAllocations() error = %v", err)
+			}
+			if !tt.wantApplied {
+				if applied != nil {
+					t.Errorf("expected no status patch for an up-to-date claim, got %+v", applied.Status)
+				}
+				return
+			}
+			if applied == nil {
+				t.Fatal("expected a status patch")
+			}
+			if applied.Status.ObservedGeneration != 2 {
+				t.Errorf("observedGeneration = %d, want 2", applied.Status.ObservedGeneration)
+			}
+		})
+	}
+}
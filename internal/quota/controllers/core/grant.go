@@ -3,12 +3,14 @@
 //
 // The ResourceGrantController validates ResourceGrants against ResourceRegistrations
 // and manages their Active status condition. It ensures that all resource types
-// referenced in grants have valid registrations before marking grants as active.
+// referenced in grants have valid registrations before marking grants as active,
+// and deactivates grants once their expiry passes.
 package core
 
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -23,6 +25,7 @@ import (
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
+	"go.miloapis.com/milo/internal/quota/bucketutil"
 	"go.miloapis.com/milo/internal/quota/validation"
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
 )
@@ -62,22 +65,38 @@ func (r *ResourceGrantController) Reconcile(ctx context.Context, req mcreconcile
 		return ctrl.Result{}, fmt.Errorf("failed to get ResourceGrant: %w", err)
 	}
 
+	now := time.Now()
+	wasExpired := isGrantExpired(&grant)
+
 	// Update observed generation and conditions
-	if err := r.updateResourceGrantStatus(ctx, clusterClient, &grant); err != nil {
+	if err := r.updateResourceGrantStatus(ctx, clusterClient, &grant, now); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if !wasExpired && isGrantExpired(&grant) {
+		logger.Info("ResourceGrant expired", "expiresAt", grant.Spec.ExpiresAt)
+		if recorder := cluster.GetEventRecorderFor("resource-grant"); recorder != nil {
+			recorder.Event(&grant, "Normal", quotav1alpha1.ResourceGrantExpiredReason,
+				fmt.Sprintf("ResourceGrant expired at %s and no longer provides capacity", grant.Spec.ExpiresAt.UTC().Format(time.RFC3339)))
+		}
+	}
+
 	if apimeta.IsStatusConditionTrue(grant.Status.Conditions, quotav1alpha1.ResourceGrantActive) {
 		if err := r.ensureBucketsFromGrant(ctx, clusterClient, &grant); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to pre-create allowance buckets: %w", err)
 		}
 	}
 
+	// Check again once the grant expires so it is deactivated on time
+	if remaining, ok := grantExpiryRemaining(&grant, now); ok && remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
 // updateResourceGrantStatus updates the status of the ResourceGrant.
-func (r *ResourceGrantController) updateResourceGrantStatus(ctx context.Context, clusterClient client.Client, grant *quotav1alpha1.ResourceGrant) error {
+func (r *ResourceGrantController) updateResourceGrantStatus(ctx context.Context, clusterClient client.Client, grant *quotav1alpha1.ResourceGrant, now time.Time) error {
 	logger := log.FromContext(ctx)
 	originalStatus := grant.Status.DeepCopy()
 
@@ -98,6 +117,12 @@ func (r *ResourceGrantController) updateResourceGrantStatus(ctx context.Context,
 		return r.updateStatusIfChanged(ctx, clusterClient, grant, originalStatus)
 	}
 
+	// Expired grants stay valid but no longer provide capacity
+	if remaining, ok := grantExpiryRemaining(grant, now); ok && remaining <= 0 {
+		setExpiredCondition(grant)
+		return r.updateStatusIfChanged(ctx, clusterClient, grant, originalStatus)
+	}
+
 	// Set active condition
 	r.setActiveCondition(grant)
 
@@ -117,6 +142,32 @@ func (r *ResourceGrantController) setActiveCondition(grant *quotav1alpha1.Resour
 	apimeta.SetStatusCondition(&grant.Status.Conditions, condition)
 }
 
+// setExpiredCondition marks the grant inactive because its expiry has passed.
+func setExpiredCondition(grant *quotav1alpha1.ResourceGrant) {
+	apimeta.SetStatusCondition(&grant.Status.Conditions, metav1.Condition{
+		Type:               quotav1alpha1.ResourceGrantActive,
+		Status:             metav1.ConditionFalse,
+		Reason:             quotav1alpha1.ResourceGrantExpiredReason,
+		Message:            fmt.Sprintf("ResourceGrant expired at %s", grant.Spec.ExpiresAt.UTC().Format(time.RFC3339)),
+		ObservedGeneration: grant.Generation,
+	})
+}
+
+// isGrantExpired reports whether the grant's Active condition records its expiry.
+func isGrantExpired(grant *quotav1alpha1.ResourceGrant) bool {
+	condition := apimeta.FindStatusCondition(grant.Status.Conditions, quotav1alpha1.ResourceGrantActive)
+	return condition != nil && condition.Reason == quotav1alpha1.ResourceGrantExpiredReason
+}
+
+// grantExpiryRemaining returns how long until the grant expires, or a non-positive
+// duration once it has. The boolean is false for grants without spec.expiresAt.
+func grantExpiryRemaining(grant *quotav1alpha1.ResourceGrant, now time.Time) (time.Duration, bool) {
+	if grant.Spec.ExpiresAt == nil {
+		return 0, false
+	}
+	return grant.Spec.ExpiresAt.Sub(now), true
+}
+
 // updateStatusIfChanged updates the status only if it has actually changed.
 // This prevents unnecessary API server writes and audit log entries.
 func (r *ResourceGrantController) updateStatusIfChanged(ctx context.Context, clusterClient client.Client, grant *quotav1alpha1.ResourceGrant, originalStatus *quotav1alpha1.ResourceGrantStatus) error {
@@ -167,8 +218,8 @@ func (r *ResourceGrantController) ensureBucketsFromGrant(ctx context.Context, cl
 	// For each allowance in the grant, create a dimensionless bucket if it doesn't exist
 	for _, allowance := range grant.Spec.Allowances {
 		// Generate bucket name using helper functions from bucket controller
-		bucketName := generateAllowanceBucketName(allowance.ResourceType, grant.Spec.ConsumerRef)
-		bucketNamespace := getBucketNamespace(grant.Spec.ConsumerRef)
+		bucketName := bucketutil.Name(allowance.ResourceType, grant.Spec.ConsumerRef)
+		bucketNamespace := bucketutil.Namespace(grant.Spec.ConsumerRef)
 
 		logger.Info("Checking if bucket needs pre-creation",
 			"bucket", bucketName,
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// resolveBucketAmount returns the capacity an allowance bucket of the grant provides in
+// the resource type's BaseUnit. Percentage buckets are resolved against the current
+// allowance of their parent grant, and provide nothing while it is missing or inactive.
+func (r *AllowanceBucketController) resolveBucketAmount(ctx context.Context, clusterClient client.Client, grant *quotav1alpha1.ResourceGrant, resourceType string, bucket quotav1alpha1.Bucket) (int64, error) {
+	if bucket.Percentage == nil {
+		return absoluteBucketAmount(bucket), nil
+	}
+	if bucket.ParentGrantRef == nil {
+		return 0, nil
+	}
+
+	key := types.NamespacedName{Name: bucket.ParentGrantRef.Name, Namespace: bucket.ParentGrantRef.Namespace}
+	if key.Namespace == "" {
+		key.Namespace = grant.Namespace
+	}
+
+	var parent quotav1alpha1.ResourceGrant
+	if err := clusterClient.Get(ctx, key, &parent); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.FromContext(ctx).V(1).Info("Parent ResourceGrant not found; percentage bucket provides no capacity",
+				"grant", grant.Name, "parentGrant", key.String())
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get parent ResourceGrant %s: %w", key, err)
+	}
+	if !r.isResourceGrantActive(&parent) {
+		return 0, nil
+	}
+
+	var parentTotal int64
+	for _, allowance := range parent.Spec.Allowances {
+		if allowance.ResourceType != resourceType {
+			continue
+		}
+		for _, parentBucket := range allowance.Buckets {
+			// Percentages are not chained, which keeps resolution to a single lookup
+			if parentBucket.Percentage == nil {
+				parentTotal += absoluteBucketAmount(parentBucket)
+			}
+		}
+	}
+	return percentageOf(parentTotal, *bucket.Percentage), nil
+}
+
+// absoluteBucketAmount returns the capacity of an amount or quantity bucket.
+func absoluteBucketAmount(bucket quotav1alpha1.Bucket) int64 {
+	if bucket.Quantity != nil {
+		return quantityAmount(*bucket.Quantity)
+	}
+	return bucket.Amount
+}
+
+// quantityAmount converts a quantity to an integer, truncating any fractional part
+// toward zero so that a grant never provides more than it states.
+func quantityAmount(q resource.Quantity) int64 {
+	// Value rounds away from zero, so step back when that overshoots
+	value := q.Value()
+	if q.Cmp(*resource.NewQuantity(value, resource.DecimalSI)) < 0 && value > 0 {
+		value--
+	}
+	return max(0, value)
+}
+
+// percentageOf returns percent percent of total, truncated toward zero. It splits
+// total around 100 so the multiplication cannot overflow.
+func percentageOf(total int64, percent int32) int64 {
+	if total <= 0 || percent <= 0 {
+		return 0
+	}
+	p := int64(percent)
+	return total/100*p + total%100*p/100
+}
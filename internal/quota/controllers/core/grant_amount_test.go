@@ -0,0 +1,204 @@
+package core
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func newActiveTestGrant(name string, buckets ...quotav1alpha1.Bucket) *quotav1alpha1.ResourceGrant {
+	grant := newTestGrant(name, testResourceType, testConsumerRef())
+	grant.Spec.Allowances[0].Buckets = buckets
+	meta.SetStatusCondition(&grant.Status.Conditions, metav1.Condition{
+		Type:   quotav1alpha1.ResourceGrantActive,
+		Status: metav1.ConditionTrue,
+		Reason: quotav1alpha1.ResourceGrantActiveReason,
+	})
+	return grant
+}
+
+func TestQuantityAmount(t *testing.T) {
+	tests := []struct {
+		quantity string
+		expected int64
+	}{
+		{quantity: "10", expected: 10},
+		{quantity: "10Gi", expected: 10 * 1024 * 1024 * 1024},
+		{quantity: "2k", expected: 2000},
+		{quantity: "1500m", expected: 1},
+		{quantity: "999m", expected: 0},
+		{quantity: "2.5", expected: 2},
+		{quantity: "0", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.quantity, func(t *testing.T) {
+			if got := quantityAmount(resource.MustParse(tt.quantity)); got != tt.expected {
+				t.Errorf("quantityAmount(%s) = %d, want %d", tt.quantity, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPercentageOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int64
+		percent  int32
+		expected int64
+	}{
+		{name: "exact", total: 200, percent: 50, expected: 100},
+		{name: "truncated", total: 10, percent: 33, expected: 3},
+		{name: "below one", total: 1, percent: 50, expected: 0},
+		{name: "whole", total: 7, percent: 100, expected: 7},
+		{name: "no total", total: 0, percent: 50, expected: 0},
+		{name: "no overflow", total: math.MaxInt64, percent: 100, expected: math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentageOf(tt.total, tt.percent); got != tt.expected {
+				t.Errorf("percentageOf(%d, %d) = %d, want %d", tt.total, tt.percent, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveBucketAmount(t *testing.T) {
+	quantity := resource.MustParse("2k")
+	parent := newActiveTestGrant("parent",
+		quotav1alpha1.Bucket{Amount: 100},
+		quotav1alpha1.Bucket{Quantity: &quantity},
+		// Percentage buckets of the parent are not chained
+		quotav1alpha1.Bucket{Percentage: ptr.To[int32](50), ParentGrantRef: &quotav1alpha1.ParentGrantReference{Name: "grandparent"}},
+	)
+	inactiveParent := newTestGrant("inactive-parent", testResourceType, testConsumerRef())
+
+	child := newActiveTestGrant("child")
+
+	tests := []struct {
+		name     string
+		bucket   quotav1alpha1.Bucket
+		expected int64
+	}{
+		{
+			name:     "amount",
+			bucket:   quotav1alpha1.Bucket{Amount: 5},
+			expected: 5,
+		},
+		{
+			name:     "quantity",
+			bucket:   quotav1alpha1.Bucket{Quantity: &quantity},
+			expected: 2000,
+		},
+		{
+			name:     "percentage of parent",
+			bucket:   quotav1alpha1.Bucket{Percentage: ptr.To[int32](25), ParentGrantRef: &quotav1alpha1.ParentGrantReference{Name: "parent"}},
+			expected: 525,
+		},
+		{
+			name:     "percentage of parent in another namespace",
+			bucket:   quotav1alpha1.Bucket{Percentage: ptr.To[int32](25), ParentGrantRef: &quotav1alpha1.ParentGrantReference{Name: "parent", Namespace: "other"}},
+			expected: 0,
+		},
+		{
+			name:     "percentage of inactive parent",
+			bucket:   quotav1alpha1.Bucket{Percentage: ptr.To[int32](25), ParentGrantRef: &quotav1alpha1.ParentGrantReference{Name: "inactive-parent"}},
+			expected: 0,
+		},
+		{
+			name:     "percentage of missing parent",
+			bucket:   quotav1alpha1.Bucket{Percentage: ptr.To[int32](25), ParentGrantRef: &quotav1alpha1.ParentGrantReference{Name: "missing"}},
+			expected: 0,
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(parent, inactiveParent).Build()
+	r := &AllowanceBucketController{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.resolveBucketAmount(context.Background(), c, child, testResourceType, tt.bucket)
+			if err != nil {
+				t.Fatalf("resolveBucketAmount() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("resolveBucketAmount() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUpdateLimitsFromGrantsResolvesPercentages(t *testing.T) {
+	parent := newActiveTestGrant("parent", quotav1alpha1.Bucket{Amount: 10})
+	child := newActiveTestGrant("child",
+		quotav1alpha1.Bucket{Percentage: ptr.To[int32](50), ParentGrantRef: &quotav1alpha1.ParentGrantReference{Name: "parent"}},
+	)
+	objs := []client.Object{parent, child}
+
+	r := &AllowanceBucketController{}
+	bucket := newLedgerTestBucket()
+	if _, err := r.updateLimitsFromGrants(context.Background(), newFakeClientWithClaimIndex(objs...), bucket); err != nil {
+		t.Fatalf("updateLimitsFromGrants() error = %v", err)
+	}
+
+	if bucket.Status.Limit != 15 {
+		t.Errorf("Limit = %d, want 15", bucket.Status.Limit)
+	}
+	for _, ref := range bucket.Status.ContributingGrantRefs {
+		if ref.Name == "child" && ref.Amount != 5 {
+			t.Errorf("child contributing amount = %d, want 5", ref.Amount)
+		}
+	}
+
+	children, err := listChildGrants(context.Background(), newFakeClientWithClaimIndex(objs...), parent)
+	if err != nil {
+		t.Fatalf("listChildGrants() error = %v", err)
+	}
+	if len(children) != 1 || children[0].Name != "child" {
+		t.Errorf("listChildGrants() = %v, want [child]", children)
+	}
+}
+
+func TestUpdateLimitsFromGrantsProjectsPendingGrants(t *testing.T) {
+	withActiveCondition := func(grant *quotav1alpha1.ResourceGrant, status metav1.ConditionStatus, reason string) *quotav1alpha1.ResourceGrant {
+		meta.SetStatusCondition(&grant.Status.Conditions, metav1.Condition{
+			Type:   quotav1alpha1.ResourceGrantActive,
+			Status: status,
+			Reason: reason,
+		})
+		return grant
+	}
+	objs := []client.Object{
+		newActiveTestGrant("active", quotav1alpha1.Bucket{Amount: 10}),
+		withActiveCondition(newTestGrant("pending", testResourceType, testConsumerRef()), metav1.ConditionUnknown, quotav1alpha1.ResourceGrantPendingReason),
+		newTestGrant("unvalidated", testResourceType, testConsumerRef()),
+		withActiveCondition(newTestGrant("failed", testResourceType, testConsumerRef()), metav1.ConditionFalse, quotav1alpha1.ResourceGrantValidationFailedReason),
+		withActiveCondition(newTestGrant("expired", testResourceType, testConsumerRef()), metav1.ConditionFalse, quotav1alpha1.ResourceGrantExpiredReason),
+	}
+
+	r := &AllowanceBucketController{}
+	bucket := newLedgerTestBucket()
+	if _, err := r.updateLimitsFromGrants(context.Background(), newFakeClientWithClaimIndex(objs...), bucket); err != nil {
+		t.Fatalf("updateLimitsFromGrants() error = %v", err)
+	}
+
+	if bucket.Status.Limit != 10 {
+		t.Errorf("Limit = %d, want 10", bucket.Status.Limit)
+	}
+	if bucket.Status.ProjectedLimit != 30 {
+		t.Errorf("ProjectedLimit = %d, want 30", bucket.Status.ProjectedLimit)
+	}
+	if bucket.Status.GrantCount != 1 || len(bucket.Status.ContributingGrantRefs) != 1 {
+		t.Errorf("expected only the active grant to contribute, got %+v", bucket.Status.ContributingGrantRefs)
+	}
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// GrantSelectionStrategy decides the order in which the ResourceGrants contributing to
+// an AllowanceBucket are consumed. A bucket's allocation fills its grants in that order,
+// so the strategy determines which grant satisfies each claim and which claims are
+// affected when a grant is released or expires.
+type GrantSelectionStrategy string
+
+const (
+	// GrantSelectionOldestFirst consumes grants in the order they were created.
+	GrantSelectionOldestFirst GrantSelectionStrategy = "OldestFirst"
+	// GrantSelectionSoonestExpiry consumes the grants that expire soonest first, so
+	// capacity that is about to disappear is used before permanent capacity. Grants
+	// without spec.expiresAt are consumed last.
+	GrantSelectionSoonestExpiry GrantSelectionStrategy = "SoonestExpiry"
+	// GrantSelectionHighestPriority consumes grants with the highest spec.priority first.
+	GrantSelectionHighestPriority GrantSelectionStrategy = "HighestPriority"
+
+	// defaultGrantSelection is used when no strategy is configured.
+	defaultGrantSelection = GrantSelectionOldestFirst
+)
+
+// ParseGrantSelectionStrategy returns the strategy named s, or the default when s is empty.
+func ParseGrantSelectionStrategy(s string) (GrantSelectionStrategy, error) {
+	switch strategy := GrantSelectionStrategy(s); strategy {
+	case "":
+		return defaultGrantSelection, nil
+	case GrantSelectionOldestFirst, GrantSelectionSoonestExpiry, GrantSelectionHighestPriority:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown grant selection strategy %q: must be one of %s, %s, %s",
+			s, GrantSelectionOldestFirst, GrantSelectionSoonestExpiry, GrantSelectionHighestPriority)
+	}
+}
+
+// sortGrants orders grants in the sequence the strategy consumes them. Ties, and every
+// grant under the oldest-first strategy, are ordered by creation time, namespace and
+// name so the order is stable across reconciles.
+func sortGrants(grants []quotav1alpha1.ResourceGrant, strategy GrantSelectionStrategy) {
+	slices.SortStableFunc(grants, func(a, b quotav1alpha1.ResourceGrant) int {
+		switch strategy {
+		case GrantSelectionSoonestExpiry:
+			if c := compareExpiry(a, b); c != 0 {
+				return c
+			}
+		case GrantSelectionHighestPriority:
+			if c := cmp.Compare(b.Spec.Priority, a.Spec.Priority); c != 0 {
+				return c
+			}
+		}
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// compareExpiry orders grants by spec.expiresAt, placing grants that never expire last.
+func compareExpiry(a, b quotav1alpha1.ResourceGrant) int {
+	switch {
+	case a.Spec.ExpiresAt == nil && b.Spec.ExpiresAt == nil:
+		return 0
+	case a.Spec.ExpiresAt == nil:
+		return 1
+	case b.Spec.ExpiresAt == nil:
+		return -1
+	default:
+		return a.Spec.ExpiresAt.Compare(b.Spec.ExpiresAt.Time)
+	}
+}
+
+// selectGrant returns the contributing grant that the next allocation is drawn from,
+// given refs in consumption order and the amount already allocated from the bucket.
+// It is the first grant with capacity left once earlier grants are filled, or empty
+// when the bucket's grants are exhausted, as with borrowed or overridden capacity.
+func selectGrant(refs []quotav1alpha1.ContributingGrantRef, allocated int64) string {
+	var filled int64
+	for _, ref := range refs {
+		filled += ref.Amount
+		if filled > allocated {
+			return ref.Name
+		}
+	}
+	return ""
+}
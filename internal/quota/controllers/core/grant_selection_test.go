@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestGrantSelectionStrategies(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	oldest := newActiveTestGrant("a-oldest", quotav1alpha1.Bucket{Amount: 5})
+	oldest.CreationTimestamp = metav1.NewTime(created)
+
+	expiring := newActiveTestGrant("b-expiring", quotav1alpha1.Bucket{Amount: 5})
+	expiring.CreationTimestamp = metav1.NewTime(created.Add(time.Hour))
+	expiring.Spec.ExpiresAt = &metav1.Time{Time: created.Add(48 * time.Hour)}
+
+	prioritized := newActiveTestGrant("c-prioritized", quotav1alpha1.Bucket{Amount: 5})
+	prioritized.CreationTimestamp = metav1.NewTime(created.Add(2 * time.Hour))
+	prioritized.Spec.Priority = 10
+
+	tests := []struct {
+		strategy GrantSelectionStrategy
+		// wantOrder is the order in which the strategy consumes the grants
+		wantOrder []string
+	}{
+		{strategy: GrantSelectionOldestFirst, wantOrder: []string{"a-oldest", "b-expiring", "c-prioritized"}},
+		{strategy: GrantSelectionSoonestExpiry, wantOrder: []string{"b-expiring", "a-oldest", "c-prioritized"}},
+		{strategy: GrantSelectionHighestPriority, wantOrder: []string{"c-prioritized", "a-oldest", "b-expiring"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			r := &AllowanceBucketController{GrantSelection: tt.strategy}
+			bucket := newLedgerTestBucket()
+			c := newFakeClientWithClaimIndex(oldest.DeepCopy(), expiring.DeepCopy(), prioritized.DeepCopy())
+			if _, err := r.updateLimitsFromGrants(context.Background(), c, bucket); err != nil {
+				t.Fatalf("updateLimitsFromGrants() error = %v", err)
+			}
+
+			// Each grant provides 5, so allocations fill them five at a time
+			for i, want := range tt.wantOrder {
+				for _, allocated := range []int64{int64(i) * 5, int64(i)*5 + 4} {
+					if got := selectGrant(bucket.Status.ContributingGrantRefs, allocated); got != want {
+						t.Errorf("selectGrant(allocated=%d) = %q, want %q", allocated, got, want)
+					}
+				}
+			}
+			if got := selectGrant(bucket.Status.ContributingGrantRefs, 15); got != "" {
+				t.Errorf("selectGrant() with grants exhausted = %q, want none", got)
+			}
+		})
+	}
+}
+
+func TestParseGrantSelectionStrategy(t *testing.T) {
+	if got, err := ParseGrantSelectionStrategy(""); err != nil || got != GrantSelectionOldestFirst {
+		t.Errorf("ParseGrantSelectionStrategy(\"\") = %q, %v, want the default", got, err)
+	}
+	if got, err := ParseGrantSelectionStrategy("SoonestExpiry"); err != nil || got != GrantSelectionSoonestExpiry {
+		t.Errorf("ParseGrantSelectionStrategy(SoonestExpiry) = %q, %v", got, err)
+	}
+	if _, err := ParseGrantSelectionStrategy("Random"); err == nil {
+		t.Error("ParseGrantSelectionStrategy(Random) succeeded, want an error")
+	}
+}
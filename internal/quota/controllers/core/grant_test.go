@@ -0,0 +1,158 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"go.miloapis.com/milo/internal/quota/validation"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// registeredResourceTypes accepts every resource type as registered.
+type registeredResourceTypes struct{}
+
+func (registeredResourceTypes) ValidateResourceType(context.Context, string) error { return nil }
+func (registeredResourceTypes) IsClaimingResourceAllowed(context.Context, string, quotav1alpha1.ConsumerRef, string, string) (bool, []string, error) {
+	return true, nil, nil
+}
+func (registeredResourceTypes) IsResourceTypeRegistered(string) bool { return true }
+func (registeredResourceTypes) HasExactRegistration(string) bool     { return true }
+func (registeredResourceTypes) GetMeasurement(string) (string, int64, bool) {
+	return "", 0, false
+}
+func (registeredResourceTypes) GetMaxGrantAmount(string) (int64, bool) { return 0, false }
+func (registeredResourceTypes) GetDefaultClaim(string, string) (validation.DefaultClaim, bool) {
+	return validation.DefaultClaim{}, false
+}
+func (registeredResourceTypes) GetDenialMessageTemplate(string) (string, bool) { return "", false }
+func (registeredResourceTypes) HasSynced() bool                                { return true }
+
+func TestResourceGrantExpiry(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		expiresAt     *metav1.Time
+		wantStatus    metav1.ConditionStatus
+		wantReason    string
+		wantRemaining time.Duration
+		wantExpiry    bool
+	}{
+		{
+			name:       "no expiry",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: quotav1alpha1.ResourceGrantActiveReason,
+		},
+		{
+			name:          "expires in the future",
+			expiresAt:     &metav1.Time{Time: now.Add(time.Hour)},
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    quotav1alpha1.ResourceGrantActiveReason,
+			wantRemaining: time.Hour,
+			wantExpiry:    true,
+		},
+		{
+			name:          "expired",
+			expiresAt:     &metav1.Time{Time: now.Add(-time.Minute)},
+			wantStatus:    metav1.ConditionFalse,
+			wantReason:    quotav1alpha1.ResourceGrantExpiredReason,
+			wantRemaining: -time.Minute,
+			wantExpiry:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grant := &quotav1alpha1.ResourceGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "boost", Namespace: "default"},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: testConsumerRef(),
+					Allowances: []quotav1alpha1.Allowance{{
+						ResourceType: testResourceType,
+						Buckets:      []quotav1alpha1.Bucket{{Amount: 10}},
+					}},
+					ExpiresAt: tt.expiresAt,
+				},
+				Status: quotav1alpha1.ResourceGrantStatus{
+					Conditions: []metav1.Condition{{
+						Type:   quotav1alpha1.ResourceGrantActive,
+						Status: metav1.ConditionTrue,
+						Reason: quotav1alpha1.ResourceGrantActiveReason,
+					}},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(testScheme()).
+				WithObjects(grant).WithStatusSubresource(grant).Build()
+			r := &ResourceGrantController{
+				GrantValidator: validation.NewResourceGrantValidator(registeredResourceTypes{}),
+			}
+
+			if err := r.updateResourceGrantStatus(context.Background(), c, grant, now); err != nil {
+				t.Fatalf("updateResourceGrantStatus() error = %v", err)
+			}
+
+			var updated quotav1alpha1.ResourceGrant
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(grant), &updated); err != nil {
+				t.Fatalf("failed to get grant: %v", err)
+			}
+			condition := apimeta.FindStatusCondition(updated.Status.Conditions, quotav1alpha1.ResourceGrantActive)
+			if condition == nil || condition.Status != tt.wantStatus || condition.Reason != tt.wantReason {
+				t.Errorf("Active condition = %+v, want %s/%s", condition, tt.wantStatus, tt.wantReason)
+			}
+			if got := isGrantExpired(&updated); got != (tt.wantReason == quotav1alpha1.ResourceGrantExpiredReason) {
+				t.Errorf("isGrantExpired() = %v", got)
+			}
+
+			remaining, ok := grantExpiryRemaining(&updated, now)
+			if ok != tt.wantExpiry || remaining != tt.wantRemaining {
+				t.Errorf("grantExpiryRemaining() = %v, %v, want %v, %v", remaining, ok, tt.wantRemaining, tt.wantExpiry)
+			}
+		})
+	}
+}
+
+func TestResourceGrantObservedGenerationBackfill(t *testing.T) {
+	grant := &quotav1alpha1.ResourceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default", Generation: 3},
+		Spec: quotav1alpha1.ResourceGrantSpec{
+			ConsumerRef: testConsumerRef(),
+			Allowances: []quotav1alpha1.Allowance{{
+				ResourceType: testResourceType,
+				Buckets:      []quotav1alpha1.Bucket{{Amount: 10}},
+			}},
+		},
+		// Written before observedGeneration was recorded, but otherwise current
+		Status: quotav1alpha1.ResourceGrantStatus{
+			Conditions: []metav1.Condition{{
+				Type:               quotav1alpha1.ResourceGrantActive,
+				Status:             metav1.ConditionTrue,
+				Reason:             quotav1alpha1.ResourceGrantActiveReason,
+				Message:            "ResourceGrant is active",
+				ObservedGeneration: 3,
+			}},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(testScheme()).
+		WithObjects(grant).WithStatusSubresource(grant).Build()
+	r := &ResourceGrantController{
+		GrantValidator: validation.NewResourceGrantValidator(registeredResourceTypes{}),
+	}
+
+	if err := r.updateResourceGrantStatus(context.Background(), c, grant, time.Now()); err != nil {
+		t.Fatalf("updateResourceGrantStatus() error = %v", err)
+	}
+
+	var updated quotav1alpha1.ResourceGrant
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(grant), &updated); err != nil {
+		t.Fatalf("failed to get grant: %v", err)
+	}
+	if updated.Status.ObservedGeneration != 3 {
+		t.Errorf("observedGeneration = %d, want 3", updated.Status.ObservedGeneration)
+	}
+}
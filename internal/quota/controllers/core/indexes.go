@@ -0,0 +1,195 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+const (
+	// resourceClaimBucketIndex indexes ResourceClaims by each requested resource type
+	// combined with the claim's consumer, i.e. by the buckets the claim draws from.
+	resourceClaimBucketIndex = "spec.requests.resourceType+consumerRef"
+
+	// resourceGrantBucketIndex indexes ResourceGrants by each allowance resource type
+	// combined with the grant's consumer, i.e. by the buckets the grant contributes to.
+	resourceGrantBucketIndex = "spec.allowances.resourceType+consumerRef"
+
+	// resourceGrantParentIndex indexes ResourceGrants by the parent grants their
+	// percentage buckets are resolved against.
+	resourceGrantParentIndex = "spec.allowances.buckets.parentGrantRef"
+)
+
+// claimBucketKey is the resourceClaimBucketIndex value for a resource type and consumer.
+func claimBucketKey(resourceType string, ref quotav1alpha1.ConsumerRef) string {
+	return resourceType + "|" + consumerRefKey(ref)
+}
+
+// grantBucketKey is the resourceGrantBucketIndex value for a resource type and consumer.
+// Grants match buckets by consumer kind and name only.
+func grantBucketKey(resourceType string, ref quotav1alpha1.ConsumerRef) string {
+	return resourceType + "|" + ref.Kind + "/" + ref.Name
+}
+
+// claimBucketIndexValues emits one index value per requested resource type.
+func claimBucketIndexValues(obj client.Object) []string {
+	claim, ok := obj.(*quotav1alpha1.ResourceClaim)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(claim.Spec.Requests))
+	for _, request := range claim.Spec.Requests {
+		values = append(values, claimBucketKey(request.ResourceType, claim.Spec.ConsumerRef))
+	}
+	return values
+}
+
+// grantBucketIndexValues emits one index value per allowance resource type.
+func grantBucketIndexValues(obj client.Object) []string {
+	grant, ok := obj.(*quotav1alpha1.ResourceGrant)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(grant.Spec.Allowances))
+	for _, allowance := range grant.Spec.Allowances {
+		values = append(values, grantBucketKey(allowance.ResourceType, grant.Spec.ConsumerRef))
+	}
+	return values
+}
+
+// grantParentIndexValues emits the namespace/name of each distinct parent grant.
+func grantParentIndexValues(obj client.Object) []string {
+	grant, ok := obj.(*quotav1alpha1.ResourceGrant)
+	if !ok {
+		return nil
+	}
+	var values []string
+	for _, allowance := range grant.Spec.Allowances {
+		for _, bucket := range allowance.Buckets {
+			if bucket.ParentGrantRef == nil {
+				continue
+			}
+			namespace := bucket.ParentGrantRef.Namespace
+			if namespace == "" {
+				namespace = grant.Namespace
+			}
+			if value := namespace + "/" + bucket.ParentGrantRef.Name; !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// registerBucketIndexes registers the claim and grant bucket indexes on both the
+// multicluster manager and the local manager to support queries across all clusters.
+func registerBucketIndexes(mgr mcmanager.Manager) error {
+	indexers := []struct {
+		name    string
+		indexer client.FieldIndexer
+	}{
+		{name: "provider clusters", indexer: mgr.GetFieldIndexer()},
+		{name: "local cluster", indexer: mgr.GetLocalManager().GetFieldIndexer()},
+	}
+
+	for _, idx := range indexers {
+		if err := idx.indexer.IndexField(context.Background(), &quotav1alpha1.ResourceClaim{}, resourceClaimBucketIndex, claimBucketIndexValues); err != nil {
+			return fmt.Errorf("failed to set up field index %s on %s: %w", resourceClaimBucketIndex, idx.name, err)
+		}
+		if err := idx.indexer.IndexField(context.Background(), &quotav1alpha1.ResourceGrant{}, resourceGrantBucketIndex, grantBucketIndexValues); err != nil {
+			return fmt.Errorf("failed to set up field index %s on %s: %w", resourceGrantBucketIndex, idx.name, err)
+		}
+		if err := idx.indexer.IndexField(context.Background(), &quotav1alpha1.ResourceGrant{}, resourceGrantParentIndex, grantParentIndexValues); err != nil {
+			return fmt.Errorf("failed to set up field index %s on %s: %w", resourceGrantParentIndex, idx.name, err)
+		}
+	}
+	return nil
+}
+
+// listBucketClaims returns the ResourceClaims that request the bucket's resource type
+// for its consumer. If the index is unavailable it falls back to listing all claims
+// and filtering in memory. Additional list options, such as client.UnsafeDisableDeepCopy
+// for callers that only read the claims, apply to both lists.
+func listBucketClaims(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket, opts ...client.ListOption) ([]quotav1alpha1.ResourceClaim, error) {
+	key := claimBucketKey(bucket.Spec.ResourceType, bucket.Spec.ConsumerRef)
+
+	var claims quotav1alpha1.ResourceClaimList
+	err := clusterClient.List(ctx, &claims, append([]client.ListOption{client.MatchingFields{resourceClaimBucketIndex: key}}, opts...)...)
+	if err == nil {
+		return claims.Items, nil
+	}
+	log.FromContext(ctx).V(1).Info("ResourceClaim bucket index unavailable, scanning all claims", "error", err.Error())
+
+	if err := clusterClient.List(ctx, &claims, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list ResourceClaims: %w", err)
+	}
+	var matching []quotav1alpha1.ResourceClaim
+	for _, claim := range claims.Items {
+		for _, value := range claimBucketIndexValues(&claim) {
+			if value == key {
+				matching = append(matching, claim)
+				break
+			}
+		}
+	}
+	return matching, nil
+}
+
+// listBucketGrants returns the ResourceGrants with an allowance for the bucket's resource
+// type and consumer. If the index is unavailable it falls back to listing all grants
+// and filtering in memory.
+func listBucketGrants(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) ([]quotav1alpha1.ResourceGrant, error) {
+	key := grantBucketKey(bucket.Spec.ResourceType, bucket.Spec.ConsumerRef)
+
+	var grants quotav1alpha1.ResourceGrantList
+	err := clusterClient.List(ctx, &grants, client.MatchingFields{resourceGrantBucketIndex: key})
+	if err == nil {
+		return grants.Items, nil
+	}
+	log.FromContext(ctx).V(1).Info("ResourceGrant bucket index unavailable, scanning all grants", "error", err.Error())
+
+	if err := clusterClient.List(ctx, &grants); err != nil {
+		return nil, fmt.Errorf("failed to list ResourceGrants: %w", err)
+	}
+	var matching []quotav1alpha1.ResourceGrant
+	for _, grant := range grants.Items {
+		for _, value := range grantBucketIndexValues(&grant) {
+			if value == key {
+				matching = append(matching, grant)
+				break
+			}
+		}
+	}
+	return matching, nil
+}
+
+// listChildGrants returns the ResourceGrants with percentage buckets resolved against the
+// parent grant. If the index is unavailable it falls back to listing all grants and
+// filtering in memory.
+func listChildGrants(ctx context.Context, clusterClient client.Client, parent *quotav1alpha1.ResourceGrant) ([]quotav1alpha1.ResourceGrant, error) {
+	key := parent.Namespace + "/" + parent.Name
+
+	var grants quotav1alpha1.ResourceGrantList
+	err := clusterClient.List(ctx, &grants, client.MatchingFields{resourceGrantParentIndex: key})
+	if err == nil {
+		return grants.Items, nil
+	}
+	log.FromContext(ctx).V(1).Info("ResourceGrant parent index unavailable, scanning all grants", "error", err.Error())
+
+	if err := clusterClient.List(ctx, &grants); err != nil {
+		return nil, fmt.Errorf("failed to list ResourceGrants: %w", err)
+	}
+	var matching []quotav1alpha1.ResourceGrant
+	for _, grant := range grants.Items {
+		if slices.Contains(grantParentIndexValues(&grant), key) {
+			matching = append(matching, grant)
+		}
+	}
+	return matching, nil
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func newTestGrant(name, resourceType string, consumer quotav1alpha1.ConsumerRef) *quotav1alpha1.ResourceGrant {
+	return &quotav1alpha1.ResourceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: quotav1alpha1.ResourceGrantSpec{
+			ConsumerRef: consumer,
+			Allowances: []quotav1alpha1.Allowance{
+				{
+					ResourceType: resourceType,
+					Buckets:      []quotav1alpha1.Bucket{{Amount: 10}},
+				},
+			},
+		},
+	}
+}
+
+func TestBucketIndexMatchesScan(t *testing.T) {
+	otherConsumer := testConsumerRef()
+	otherConsumer.Name = "globex"
+	otherResourceType := "resourcemanager.miloapis.com/users"
+
+	matchingClaim := newGrantedClaim("matching", 1)
+	multiRequestClaim := newGrantedClaim("multi-request", 2)
+	multiRequestClaim.Spec.Requests = append([]quotav1alpha1.ResourceRequest{
+		{ResourceType: otherResourceType, Amount: 1},
+	}, multiRequestClaim.Spec.Requests...)
+	otherTypeClaim := newGrantedClaim("other-type", 3)
+	otherTypeClaim.Spec.Requests[0].ResourceType = otherResourceType
+	otherConsumerClaim := newGrantedClaim("other-consumer", 4)
+	otherConsumerClaim.Spec.ConsumerRef = otherConsumer
+
+	objs := []client.Object{
+		matchingClaim,
+		multiRequestClaim,
+		otherTypeClaim,
+		otherConsumerClaim,
+		newTestGrant("matching", testResourceType, testConsumerRef()),
+		newTestGrant("other-type", otherResourceType, testConsumerRef()),
+		newTestGrant("other-consumer", testResourceType, otherConsumer),
+	}
+
+	indexed := newFakeClientWithClaimIndex(objs...)
+	unindexed := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(objs...).Build()
+	bucket := newLedgerTestBucket()
+	ctx := context.Background()
+
+	claimNames := func(t *testing.T, c client.Client) []string {
+		t.Helper()
+		claims, err := listBucketClaims(ctx, c, bucket)
+		if err != nil {
+			t.Fatalf("listBucketClaims() error = %v", err)
+		}
+		var names []string
+		for _, claim := range claims {
+			names = append(names, claim.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+	grantNames := func(t *testing.T, c client.Client) []string {
+		t.Helper()
+		grants, err := listBucketGrants(ctx, c, bucket)
+		if err != nil {
+			t.Fatalf("listBucketGrants() error = %v", err)
+		}
+		var names []string
+		for _, grant := range grants {
+			names = append(names, grant.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	wantClaims := []string{"matching", "multi-request"}
+	if got := claimNames(t, indexed); !slices.Equal(got, wantClaims) {
+		t.Errorf("indexed claims = %v, want %v", got, wantClaims)
+	}
+	if got := claimNames(t, unindexed); !slices.Equal(got, wantClaims) {
+		t.Errorf("scanned claims = %v, want %v", got, wantClaims)
+	}
+
+	wantGrants := []string{"matching"}
+	if got := grantNames(t, indexed); !slices.Equal(got, wantGrants) {
+		t.Errorf("indexed grants = %v, want %v", got, wantGrants)
+	}
+	if got := grantNames(t, unindexed); !slices.Equal(got, wantGrants) {
+		t.Errorf("scanned grants = %v, want %v", got, wantGrants)
+	}
+}
+
+// TestClaimUsageOfLargeClaimSet checks that usage read from the cache without copying
+// matches a plain list of a large claim set.
+func TestClaimUsageOfLargeClaimSet(t *testing.T) {
+	const claimCount = 5000
+
+	objs := make([]client.Object, 0, claimCount)
+	for i := range claimCount {
+		claim := newGrantedClaim(fmt.Sprintf("claim-%d", i), int64(i%7+1))
+		if i%5 == 0 {
+			claim.Status.Allocations[0].Status = quotav1alpha1.ResourceClaimAllocationStatusPending
+		}
+		objs = append(objs, claim)
+	}
+
+	var uncopied int
+	base := newFakeClientWithClaimIndex(objs...)
+	c := interceptor.NewClient(base.(client.WithWatch), interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if (&client.ListOptions{}).ApplyOptions(opts).UnsafeDisableDeepCopy != nil {
+				uncopied++
+			}
+			return c.List(ctx, list, opts...)
+		},
+	})
+	bucket := newLedgerTestBucket()
+	ctx := context.Background()
+
+	// Baseline: a single plain list of every claim
+	var baseline quotav1alpha1.ResourceClaimList
+	if err := base.List(ctx, &baseline); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var wantAllocated int64
+	var wantClaims int
+	for _, claim := range baseline.Items {
+		if allocated, granted := grantedAllocation(&claim, testResourceType); granted {
+			wantAllocated += allocated
+			wantClaims++
+		}
+	}
+
+	r := &AllowanceBucketController{}
+	allocated, claimAllocations, err := r.listClaimUsage(ctx, c, bucket)
+	if err != nil {
+		t.Fatalf("listClaimUsage() error = %v", err)
+	}
+	if allocated != wantAllocated || len(claimAllocations) != wantClaims {
+		t.Errorf("listClaimUsage() = %d over %d claims, want %d over %d claims", allocated, len(claimAllocations), wantAllocated, wantClaims)
+	}
+
+	projectAllocated, projectAllocations, err := projectClaimUsage(ctx, c, "web", testResourceType)
+	if err != nil {
+		t.Fatalf("projectClaimUsage() error = %v", err)
+	}
+	if projectAllocated != wantAllocated || len(projectAllocations) != wantClaims {
+		t.Errorf("projectClaimUsage() = %d over %d claims, want %d over %d claims", projectAllocated, len(projectAllocations), wantAllocated, wantClaims)
+	}
+
+	if uncopied != 2 {
+		t.Errorf("expected both usage lists to skip copying claims, got %d", uncopied)
+	}
+}
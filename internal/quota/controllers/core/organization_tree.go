@@ -0,0 +1,210 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+)
+
+// organizationTreeResyncInterval is how often an OrganizationTree bucket recounts the
+// claims of its Projects, whose events cannot enqueue it directly.
+const organizationTreeResyncInterval = time.Minute
+
+// treeBucket is an OrganizationTree bucket in the core control plane that caps the
+// buckets of the Organization's Projects.
+//
+// Organization-tree aggregation lets a ResourceGrant to an Organization cap usage
+// across the Organization's Projects. The Organization's bucket lives in the core
+// control plane, while Project claims live in the Project control planes that the
+// multicluster manager engages as clusters named after each Project:
+//
+//   - The Organization bucket adds the granted claims of every child Project control
+//     plane to its own allocated usage.
+//   - Project buckets cap their available capacity at the Organization bucket's
+//     availability, and reserve capacity in it when granting.
+//
+// The Organization bucket recomputes its usage from claims on every reconcile, so
+// any reservation that is not followed by a granted claim is corrected.
+type treeBucket struct {
+	client client.Client
+	bucket *quotav1alpha1.AllowanceBucket
+}
+
+// available returns the capacity remaining in the Organization tree.
+func (t *treeBucket) available() int64 {
+	return bucketAvailable(t.bucket)
+}
+
+// reserve records a grant made by a Project bucket against the Organization tree.
+// Updates are guarded by the bucket's resourceVersion, so concurrent reservations
+// from different Project control planes conflict rather than overcommit.
+func (t *treeBucket) reserve(ctx context.Context, amount int64) error {
+	t.bucket.Status.Allocated += amount
+	t.bucket.Status.Available = t.available()
+	if err := t.client.Status().Update(ctx, t.bucket); err != nil {
+		return fmt.Errorf("failed to reserve capacity in organization bucket %s: %w", t.bucket.Name, err)
+	}
+	return nil
+}
+
+// organizationConsumerRef returns the consumer reference for an Organization.
+func organizationConsumerRef(name string) quotav1alpha1.ConsumerRef {
+	return quotav1alpha1.ConsumerRef{
+		APIGroup: resourcemanagerv1alpha1.GroupVersion.Group,
+		Kind:     "Organization",
+		Name:     name,
+	}
+}
+
+// organizationTreeBucket returns the OrganizationTree bucket that caps a bucket in a
+// Project control plane, or nil if the bucket is not in a Project control plane or
+// its Organization has no such bucket for the resource type.
+func (r *AllowanceBucketController) organizationTreeBucket(ctx context.Context, clusterName, resourceType string) (*treeBucket, error) {
+	if clusterName == "" || r.Manager == nil {
+		return nil, nil
+	}
+	return findOrganizationTreeBucket(ctx, r.Manager.GetLocalManager().GetClient(), clusterName, resourceType)
+}
+
+// findOrganizationTreeBucket looks up the Project in the core control plane and returns its
+// Organization's bucket for the resource type when that bucket aggregates the Organization tree.
+func findOrganizationTreeBucket(ctx context.Context, localClient client.Client, projectName, resourceType string) (*treeBucket, error) {
+	var project resourcemanagerv1alpha1.Project
+	if err := localClient.Get(ctx, types.NamespacedName{Name: projectName}, &project); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get Project %s: %w", projectName, err)
+	}
+
+	organization := project.Labels[resourcemanagerv1alpha1.OrganizationNameLabel]
+	if organization == "" {
+		return nil, nil
+	}
+
+	consumer := organizationConsumerRef(organization)
+	bucket := newAllowanceBucket(resourceType, consumer)
+	if err := localClient.Get(ctx, client.ObjectKeyFromObject(bucket), bucket); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get organization AllowanceBucket: %w", err)
+		}
+
+		// The Organization may have no claims of its own, so its tree grant is the only
+		// thing that creates the bucket capping its Projects
+		treeGrant, err := hasActiveTreeGrant(ctx, localClient, bucket)
+		if err != nil || !treeGrant {
+			return nil, err
+		}
+		if err := localClient.Create(ctx, bucket); err != nil && !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create organization AllowanceBucket: %w", err)
+		}
+		return nil, fmt.Errorf("organization AllowanceBucket %s for %s was just created and has no limit yet", bucket.Name, resourceType)
+	}
+
+	if bucket.Status.AggregationScope != quotav1alpha1.AggregationScopeOrganizationTree {
+		// A bucket that has not been reconciled since its tree grant became active does
+		// not report its scope yet, and must not let Project claims through uncapped
+		treeGrant, err := hasActiveTreeGrant(ctx, localClient, bucket)
+		if err != nil || !treeGrant {
+			return nil, err
+		}
+		return nil, fmt.Errorf("organization AllowanceBucket %s for %s does not aggregate its Projects yet", bucket.Name, resourceType)
+	}
+	return &treeBucket{client: localClient, bucket: bucket}, nil
+}
+
+// hasActiveTreeGrant reports whether an active OrganizationTree grant contributes to the
+// Organization bucket.
+func hasActiveTreeGrant(ctx context.Context, localClient client.Client, bucket *quotav1alpha1.AllowanceBucket) (bool, error) {
+	grants, err := listBucketGrants(ctx, localClient, bucket)
+	if err != nil {
+		return false, err
+	}
+	for _, grant := range grants {
+		if grant.Spec.AggregationScope == quotav1alpha1.AggregationScopeOrganizationTree &&
+			apimeta.IsStatusConditionTrue(grant.Status.Conditions, quotav1alpha1.ResourceGrantActive) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// addProjectUsage adds the granted claims made in the control planes of the
+// Organization's Projects to an OrganizationTree bucket's usage. Projects whose
+// control planes are not engaged yet are skipped until they are.
+func (r *AllowanceBucketController) addProjectUsage(ctx context.Context, localClient client.Client, bucket *quotav1alpha1.AllowanceBucket) error {
+	var projects resourcemanagerv1alpha1.ProjectList
+	if err := localClient.List(ctx, &projects, client.MatchingLabels{
+		resourcemanagerv1alpha1.OrganizationNameLabel: bucket.Spec.ConsumerRef.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list Projects: %w", err)
+	}
+
+	var total int64
+	var claimAllocations []quotav1alpha1.TopClaimRef
+	for _, project := range projects.Items {
+		cluster, err := r.Manager.GetCluster(ctx, project.Name)
+		if err != nil {
+			log.FromContext(ctx).V(1).Info("Project control plane not engaged; its usage is not counted yet",
+				"project", project.Name, "error", err.Error())
+			continue
+		}
+
+		allocated, refs, err := projectClaimUsage(ctx, cluster.GetClient(), project.Name, bucket.Spec.ResourceType)
+		if err != nil {
+			return err
+		}
+		total += allocated
+		claimAllocations = append(claimAllocations, refs...)
+	}
+
+	addClaimUsage(bucket, total, claimAllocations)
+	return nil
+}
+
+// projectClaimUsage returns the total granted allocation for the resource type across
+// all claims in a Project control plane, regardless of their consumer, along with each
+// contributing claim.
+func projectClaimUsage(ctx context.Context, projectClient client.Client, projectName, resourceType string) (int64, []quotav1alpha1.TopClaimRef, error) {
+	// Every claim in the project is listed, so they are read from the informer cache
+	// without being copied
+	var claims quotav1alpha1.ResourceClaimList
+	if err := projectClient.List(ctx, &claims, client.UnsafeDisableDeepCopy); err != nil {
+		return 0, nil, fmt.Errorf("failed to list ResourceClaims in project %s: %w", projectName, err)
+	}
+
+	var total int64
+	var claimAllocations []quotav1alpha1.TopClaimRef
+	for _, claim := range claims.Items {
+		allocated, granted := grantedAllocation(&claim, resourceType)
+		if !granted {
+			continue
+		}
+		total += allocated
+		claimAllocations = append(claimAllocations, quotav1alpha1.TopClaimRef{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			Project:   projectName,
+			Amount:    allocated,
+		})
+	}
+	return total, claimAllocations, nil
+}
+
+// addClaimUsage adds further contributing claims to the bucket's usage aggregates. The
+// existing top claims are the largest of the bucket's own claims, so ranking them with
+// the additional claims yields the overall top claims.
+func addClaimUsage(bucket *quotav1alpha1.AllowanceBucket, allocated int64, claimAllocations []quotav1alpha1.TopClaimRef) {
+	bucket.Status.Allocated += allocated
+	bucket.Status.ClaimCount += int32(len(claimAllocations))
+	bucket.Status.TopClaims = rankTopClaims(append(bucket.Status.TopClaims, claimAllocations...), maxTopClaims)
+}
@@ -0,0 +1,270 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"go.miloapis.com/milo/internal/quota/bucketutil"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+)
+
+func newTestProject(name, organization string) *resourcemanagerv1alpha1.Project {
+	project := &resourcemanagerv1alpha1.Project{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if organization != "" {
+		project.Labels = map[string]string{resourcemanagerv1alpha1.OrganizationNameLabel: organization}
+	}
+	return project
+}
+
+func newOrganizationTreeBucket(limit, allocated int64) *quotav1alpha1.AllowanceBucket {
+	bucket := newLedgerTestBucket()
+	bucket.Status.Limit = limit
+	bucket.Status.Allocated = allocated
+	bucket.Status.Available = limit - allocated
+	bucket.Status.AggregationScope = quotav1alpha1.AggregationScopeOrganizationTree
+	return bucket
+}
+
+func TestFindOrganizationTreeBucket(t *testing.T) {
+	consumerBucket := newOrganizationTreeBucket(10, 0)
+	consumerBucket.Status.AggregationScope = ""
+
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		project string
+		want    bool
+	}{
+		{
+			name:    "organization tree bucket",
+			objs:    []client.Object{newTestProject("web", "acme"), newOrganizationTreeBucket(10, 0)},
+			project: "web",
+			want:    true,
+		},
+		{
+			name:    "organization bucket scoped to consumer",
+			objs:    []client.Object{newTestProject("web", "acme"), consumerBucket},
+			project: "web",
+		},
+		{
+			name:    "project without organization",
+			objs:    []client.Object{newTestProject("web", ""), newOrganizationTreeBucket(10, 0)},
+			project: "web",
+		},
+		{
+			name:    "organization without bucket",
+			objs:    []client.Object{newTestProject("web", "acme")},
+			project: "web",
+		},
+		{
+			name:    "unknown project",
+			objs:    []client.Object{newOrganizationTreeBucket(10, 0)},
+			project: "web",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(tt.objs...).Build()
+			tree, err := findOrganizationTreeBucket(context.Background(), c, tt.project, testResourceType)
+			if err != nil {
+				t.Fatalf("findOrganizationTreeBucket() error = %v", err)
+			}
+			if got := tree != nil; got != tt.want {
+				t.Fatalf("findOrganizationTreeBucket() found = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindOrganizationTreeBucketWaitsForTreeGrant(t *testing.T) {
+	treeGrant := newActiveTestGrant("acme-tree", quotav1alpha1.Bucket{Amount: 3})
+	treeGrant.Spec.AggregationScope = quotav1alpha1.AggregationScopeOrganizationTree
+	unscopedBucket := newOrganizationTreeBucket(3, 0)
+	unscopedBucket.Status.AggregationScope = ""
+
+	tests := []struct {
+		name string
+		objs []client.Object
+	}{
+		{
+			name: "tree grant without organization bucket",
+			objs: []client.Object{newTestProject("web", "acme"), treeGrant},
+		},
+		{
+			name: "tree grant before organization bucket reconciles",
+			objs: []client.Object{newTestProject("web", "acme"), treeGrant, unscopedBucket},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := newFakeClientWithClaimIndex(tt.objs...)
+			tree, err := findOrganizationTreeBucket(ctx, c, "web", testResourceType)
+			if err == nil || tree != nil {
+				t.Fatalf("findOrganizationTreeBucket() = %v, %v, want an error so Project claims wait", tree, err)
+			}
+			var bucket quotav1alpha1.AllowanceBucket
+			if err := c.Get(ctx, client.ObjectKeyFromObject(newLedgerTestBucket()), &bucket); err != nil {
+				t.Fatalf("organization bucket was not created: %v", err)
+			}
+		})
+	}
+}
+
+func TestTreeGrantCapsProjectClaimsWithoutOrganizationClaim(t *testing.T) {
+	ctx := context.Background()
+	r := &AllowanceBucketController{}
+
+	treeGrant := newActiveTestGrant("acme-tree", quotav1alpha1.Bucket{Amount: 3})
+	treeGrant.Spec.AggregationScope = quotav1alpha1.AggregationScopeOrganizationTree
+	core := newClaimAllocationClient(newTestProject("web", "acme"), treeGrant)
+
+	// The Organization has no claims, so the first Project reconcile creates its bucket
+	if _, err := findOrganizationTreeBucket(ctx, core, "web", testResourceType); err == nil {
+		t.Fatal("expected Project claims to wait for the new organization bucket")
+	}
+
+	// The Organization bucket's own reconcile picks up the tree grant
+	var orgBucket quotav1alpha1.AllowanceBucket
+	if err := core.Get(ctx, client.ObjectKeyFromObject(newLedgerTestBucket()), &orgBucket); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if _, err := r.updateLimitsFromGrants(ctx, core, &orgBucket); err != nil {
+		t.Fatalf("updateLimitsFromGrants() error = %v", err)
+	}
+	orgBucket.Status.Available = bucketAvailable(&orgBucket)
+	if err := core.Status().Update(ctx, &orgBucket); err != nil {
+		t.Fatalf("Status().Update() error = %v", err)
+	}
+
+	tree, err := findOrganizationTreeBucket(ctx, core, "web", testResourceType)
+	if err != nil || tree == nil {
+		t.Fatalf("findOrganizationTreeBucket() = %v, %v, want the organization tree bucket", tree, err)
+	}
+
+	projectConsumer := quotav1alpha1.ConsumerRef{
+		APIGroup: resourcemanagerv1alpha1.GroupVersion.Group,
+		Kind:     "Project",
+		Name:     "web",
+	}
+	projectBucket := newAllowanceBucket(testResourceType, projectConsumer)
+	projectBucket.Status.Limit = 10
+	projectBucket.Status.Available = 10
+	var claims []client.Object
+	for _, name := range []string{"first", "second"} {
+		claim := newGrantedClaim(name, 2)
+		claim.Spec.ConsumerRef = projectConsumer
+		claim.Status.Allocations = nil
+		claims = append(claims, claim)
+	}
+	project := newClaimAllocationClient(append(claims, projectBucket)...)
+	if err := project.Get(ctx, client.ObjectKeyFromObject(projectBucket), projectBucket); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if err := r.processPendingClaims(ctx, project, projectBucket, tree, nil); err != nil {
+		t.Fatalf("processPendingClaims() error = %v", err)
+	}
+
+	// The Project bucket has room for both claims, but the tree grant only allows 3
+	want := map[string]string{
+		"first":  quotav1alpha1.ResourceClaimAllocationStatusGranted,
+		"second": quotav1alpha1.ResourceClaimAllocationStatusDenied,
+	}
+	for name, status := range want {
+		var claim quotav1alpha1.ResourceClaim
+		if err := project.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &claim); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if len(claim.Status.Allocations) != 1 || claim.Status.Allocations[0].Status != status {
+			t.Errorf("claim %s allocations = %+v, want %s", name, claim.Status.Allocations, status)
+		}
+	}
+	if err := core.Get(ctx, client.ObjectKeyFromObject(&orgBucket), &orgBucket); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if orgBucket.Status.Allocated != 2 {
+		t.Errorf("organization bucket allocated = %d, want 2", orgBucket.Status.Allocated)
+	}
+}
+
+func TestProjectClaimUsage(t *testing.T) {
+	otherConsumerClaim := newGrantedClaim("other-consumer", 3)
+	otherConsumerClaim.Spec.ConsumerRef.Kind = "Project"
+	otherConsumerClaim.Spec.ConsumerRef.Name = "web"
+	pendingClaim := newGrantedClaim("pending", 5)
+	pendingClaim.Status.Allocations = nil
+
+	c := fake.NewClientBuilder().WithScheme(testScheme()).
+		WithObjects(newGrantedClaim("granted", 2), otherConsumerClaim, pendingClaim).Build()
+
+	allocated, refs, err := projectClaimUsage(context.Background(), c, "web", testResourceType)
+	if err != nil {
+		t.Fatalf("projectClaimUsage() error = %v", err)
+	}
+	if allocated != 5 {
+		t.Errorf("allocated = %d, want 5", allocated)
+	}
+	if len(refs) != 2 {
+		t.Fatalf("len(refs) = %d, want 2", len(refs))
+	}
+	for _, ref := range refs {
+		if ref.Project != "web" {
+			t.Errorf("ref %s project = %q, want %q", ref.Name, ref.Project, "web")
+		}
+	}
+
+	bucket := newOrganizationTreeBucket(10, 1)
+	bucket.Status.ClaimCount = 1
+	bucket.Status.TopClaims = []quotav1alpha1.TopClaimRef{{Name: "local", Namespace: "default", Amount: 1}}
+	addClaimUsage(bucket, allocated, refs)
+
+	if bucket.Status.Allocated != 6 {
+		t.Errorf("Allocated = %d, want 6", bucket.Status.Allocated)
+	}
+	if bucket.Status.ClaimCount != 3 {
+		t.Errorf("ClaimCount = %d, want 3", bucket.Status.ClaimCount)
+	}
+	if len(bucket.Status.TopClaims) != 3 || bucket.Status.TopClaims[0].Name != "other-consumer" {
+		t.Errorf("TopClaims = %+v, want other-consumer ranked first of 3", bucket.Status.TopClaims)
+	}
+}
+
+func TestTreeBucketReserve(t *testing.T) {
+	bucket := newOrganizationTreeBucket(10, 4)
+	c := fake.NewClientBuilder().WithScheme(testScheme()).
+		WithObjects(bucket).WithStatusSubresource(bucket).Build()
+	ctx := context.Background()
+
+	tree := &treeBucket{client: c, bucket: bucket.DeepCopy()}
+	stale := &treeBucket{client: c, bucket: bucket.DeepCopy()}
+
+	if got := tree.available(); got != 6 {
+		t.Fatalf("available() = %d, want 6", got)
+	}
+	if err := tree.reserve(ctx, 3); err != nil {
+		t.Fatalf("reserve() error = %v", err)
+	}
+
+	var stored quotav1alpha1.AllowanceBucket
+	key := types.NamespacedName{Name: bucketutil.Name(testResourceType, testConsumerRef()), Namespace: bucketutil.Namespace(testConsumerRef())}
+	if err := c.Get(ctx, key, &stored); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if stored.Status.Allocated != 7 || stored.Status.Available != 3 {
+		t.Errorf("stored allocated/available = %d/%d, want 7/3", stored.Status.Allocated, stored.Status.Available)
+	}
+
+	// A reservation based on an outdated view must not overcommit the Organization
+	if err := stale.reserve(ctx, 6); err == nil {
+		t.Error("reserve() with stale bucket succeeded, want conflict")
+	}
+}
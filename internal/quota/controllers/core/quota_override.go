@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// quotaOverride reports whether the bucket's consumer carries a quota override for the
+// bucket's resource type. Consumers live in the core control plane and are read directly
+// from its API server, as overrides are only checked for pending requests.
+func (r *AllowanceBucketController) quotaOverride(ctx context.Context, clusterClient client.Client, bucket *quotav1alpha1.AllowanceBucket) (bool, error) {
+	var reader client.Reader = clusterClient
+	mapper := clusterClient.RESTMapper()
+	if r.Manager != nil {
+		reader = r.Manager.GetLocalManager().GetAPIReader()
+		mapper = r.Manager.GetLocalManager().GetRESTMapper()
+	}
+	return consumerHasQuotaOverride(ctx, reader, mapper, bucket.Spec.ConsumerRef, bucket.Spec.ResourceType)
+}
+
+// consumerHasQuotaOverride reports whether the consumer's QuotaOverrideAnnotation lists
+// the resource type. Consumers that cannot be found have no override.
+func consumerHasQuotaOverride(ctx context.Context, reader client.Reader, mapper meta.RESTMapper, consumerRef quotav1alpha1.ConsumerRef, resourceType string) (bool, error) {
+	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: consumerRef.APIGroup, Kind: consumerRef.Kind})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to resolve consumer kind %s: %w", consumerRef.Kind, err)
+	}
+
+	consumer := &metav1.PartialObjectMetadata{}
+	consumer.SetGroupVersionKind(mapping.GroupVersionKind)
+	key := types.NamespacedName{Name: consumerRef.Name, Namespace: consumerRef.Namespace}
+	if err := reader.Get(ctx, key, consumer); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get consumer %s %s: %w", consumerRef.Kind, consumerRef.Name, err)
+	}
+
+	for _, overridden := range strings.Split(consumer.GetAnnotations()[quotav1alpha1.QuotaOverrideAnnotation], ",") {
+		if strings.TrimSpace(overridden) == resourceType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// overrideRequest grants the full request without checking capacity and logs the grant,
+// so that every use of an override can be audited.
+func overrideRequest(ctx context.Context, claim *quotav1alpha1.ResourceClaim, request quotav1alpha1.ResourceRequest, available int64) (int64, string, string) {
+	log.FromContext(ctx).Info("Granting request under consumer quota override",
+		"claimName", claim.Name,
+		"claimNamespace", claim.Namespace,
+		"consumerKind", claim.Spec.ConsumerRef.Kind,
+		"consumerName", claim.Spec.ConsumerRef.Name,
+		"resourceType", request.ResourceType,
+		"requestAmount", request.Amount,
+		"available", available)
+
+	return request.Amount, quotav1alpha1.ResourceClaimOverrideReason,
+		fmt.Sprintf("Granted %d under the consumer's quota override (%s), available %d", request.Amount, quotav1alpha1.QuotaOverrideAnnotation, available)
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+)
+
+func newTestOrganization(name, override string) *resourcemanagerv1alpha1.Organization {
+	organization := &resourcemanagerv1alpha1.Organization{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if override != "" {
+		organization.Annotations = map[string]string{quotav1alpha1.QuotaOverrideAnnotation: override}
+	}
+	return organization
+}
+
+func TestConsumerHasQuotaOverride(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{resourcemanagerv1alpha1.GroupVersion})
+	mapper.Add(resourcemanagerv1alpha1.GroupVersion.WithKind("Organization"), meta.RESTScopeRoot)
+
+	unknownKind := testConsumerRef()
+	unknownKind.APIGroup = "example.com"
+	unknownKind.Kind = "Widget"
+
+	tests := []struct {
+		name     string
+		objs     []client.Object
+		consumer quotav1alpha1.ConsumerRef
+		expected bool
+	}{
+		{
+			name:     "resource type overridden",
+			objs:     []client.Object{newTestOrganization("acme", testResourceType)},
+			consumer: testConsumerRef(),
+			expected: true,
+		},
+		{
+			name:     "resource type listed with others",
+			objs:     []client.Object{newTestOrganization("acme", "compute.miloapis.com/instances, "+testResourceType)},
+			consumer: testConsumerRef(),
+			expected: true,
+		},
+		{
+			name:     "other resource type overridden",
+			objs:     []client.Object{newTestOrganization("acme", "compute.miloapis.com/instances")},
+			consumer: testConsumerRef(),
+		},
+		{
+			name:     "no override",
+			objs:     []client.Object{newTestOrganization("acme", "")},
+			consumer: testConsumerRef(),
+		},
+		{
+			name:     "consumer not found",
+			consumer: testConsumerRef(),
+		},
+		{
+			name:     "unknown consumer kind",
+			consumer: unknownKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(testScheme()).WithObjects(tt.objs...).Build()
+			got, err := consumerHasQuotaOverride(context.Background(), c, mapper, tt.consumer, testResourceType)
+			if err != nil {
+				t.Fatalf("consumerHasQuotaOverride() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("consumerHasQuotaOverride() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOverrideRequestGrantsAndLogs(t *testing.T) {
+	var logs []string
+	logger := funcr.New(func(prefix, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{})
+	ctx := log.IntoContext(context.Background(), logger)
+
+	claim := newGrantedClaim("over-limit", 50)
+	claim.Status.Allocations = nil
+
+	amount, reason, message := overrideRequest(ctx, claim, claim.Spec.Requests[0], 0)
+	if amount != 50 {
+		t.Errorf("amount = %d, want 50", amount)
+	}
+	if reason != quotav1alpha1.ResourceClaimOverrideReason {
+		t.Errorf("reason = %q, want %q", reason, quotav1alpha1.ResourceClaimOverrideReason)
+	}
+	if !strings.Contains(message, quotav1alpha1.QuotaOverrideAnnotation) {
+		t.Errorf("message = %q, want it to name the override annotation", message)
+	}
+
+	if len(logs) != 1 || !strings.Contains(logs[0], `"claimName"="over-limit"`) || !strings.Contains(logs[0], `"consumerName"="acme"`) {
+		t.Errorf("logs = %v, want one override entry naming the claim and consumer", logs)
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// QuotaSnapshotController captures a consumer's AllowanceBucket figures into a
+// QuotaSnapshot. Each snapshot is captured once; after status.capturedAt is set
+// the controller leaves it untouched, and the API rejects further changes.
+type QuotaSnapshotController struct {
+	Scheme  *runtime.Scheme
+	Manager mcmanager.Manager
+}
+
+// +kubebuilder:rbac:groups=quota.miloapis.com,resources=quotasnapshots,verbs=get;list;watch
+// +kubebuilder:rbac:groups=quota.miloapis.com,resources=quotasnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=quota.miloapis.com,resources=allowancebuckets,verbs=get;list;watch
+
+// Reconcile captures the allocations of a QuotaSnapshot that has not been captured yet.
+// This controller watches QuotaSnapshots across all control planes.
+func (r *QuotaSnapshotController) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	if req.ClusterName != "" {
+		logger = logger.WithValues("cluster", req.ClusterName)
+		ctx = log.IntoContext(ctx, logger)
+	}
+
+	cluster, err := r.Manager.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get cluster %q: %w", req.ClusterName, err)
+	}
+	clusterClient := cluster.GetClient()
+
+	var snapshot quotav1alpha1.QuotaSnapshot
+	if err := clusterClient.Get(ctx, req.NamespacedName, &snapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("QuotaSnapshot not found")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get QuotaSnapshot: %w", err)
+	}
+
+	if err := r.captureSnapshot(ctx, clusterClient, &snapshot, time.Now()); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// captureSnapshot records the current limit and allocation of every AllowanceBucket
+// tracking the snapshot's consumer. Snapshots that are already captured or being
+// deleted are left unchanged.
+func (r *QuotaSnapshotController) captureSnapshot(ctx context.Context, clusterClient client.Client, snapshot *quotav1alpha1.QuotaSnapshot, now time.Time) error {
+	if snapshot.Status.CapturedAt != nil || !snapshot.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	consumer := snapshot.Spec.ConsumerRef
+	var buckets quotav1alpha1.AllowanceBucketList
+	if err := clusterClient.List(ctx, &buckets, client.MatchingLabels{
+		"quota.miloapis.com/consumer-kind": consumer.Kind,
+		"quota.miloapis.com/consumer-name": consumer.Name,
+	}); err != nil {
+		return fmt.Errorf("failed to list AllowanceBuckets: %w", err)
+	}
+
+	allocations := make([]quotav1alpha1.QuotaSnapshotAllocation, 0, len(buckets.Items))
+	for _, bucket := range buckets.Items {
+		// Labels only carry kind and name; the full reference also distinguishes API group and namespace
+		if bucket.Spec.ConsumerRef != consumer {
+			continue
+		}
+		allocations = append(allocations, quotav1alpha1.QuotaSnapshotAllocation{
+			ResourceType:             bucket.Spec.ResourceType,
+			Limit:                    bucket.Status.Limit,
+			Allocated:                bucket.Status.Allocated,
+			ClaimCount:               bucket.Status.ClaimCount,
+			BucketLastReconciliation: bucket.Status.LastReconciliation,
+		})
+	}
+	slices.SortFunc(allocations, func(a, b quotav1alpha1.QuotaSnapshotAllocation) int {
+		return cmp.Compare(a.ResourceType, b.ResourceType)
+	})
+
+	capturedAt := metav1.NewTime(now)
+	snapshot.Status.CapturedAt = &capturedAt
+	snapshot.Status.Allocations = allocations
+	apimeta.SetStatusCondition(&snapshot.Status.Conditions, metav1.Condition{
+		Type:               quotav1alpha1.QuotaSnapshotCaptured,
+		Status:             metav1.ConditionTrue,
+		Reason:             quotav1alpha1.QuotaSnapshotCapturedReason,
+		Message:            fmt.Sprintf("Captured %d resource types for %s %q", len(allocations), consumer.Kind, consumer.Name),
+		LastTransitionTime: capturedAt,
+	})
+
+	if err := clusterClient.Status().Update(ctx, snapshot); err != nil {
+		return fmt.Errorf("failed to update QuotaSnapshot status: %w", err)
+	}
+
+	log.FromContext(ctx).Info("Captured QuotaSnapshot",
+		"snapshot", snapshot.Name, "consumerKind", consumer.Kind, "consumerName", consumer.Name, "resourceTypes", len(allocations))
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Watches QuotaSnapshots across all control planes (core and project control planes).
+func (r *QuotaSnapshotController) SetupWithManager(mgr mcmanager.Manager) error {
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&quotav1alpha1.QuotaSnapshot{},
+			mcbuilder.WithEngageWithLocalCluster(true),
+			mcbuilder.WithEngageWithProviderClusters(true)).
+		Named("quota-snapshot").
+		Complete(r)
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func newSnapshotBucket(name, resourceType string, consumer quotav1alpha1.ConsumerRef, limit, allocated int64) *quotav1alpha1.AllowanceBucket {
+	return &quotav1alpha1.AllowanceBucket{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "milo-system",
+			Labels: map[string]string{
+				"quota.miloapis.com/consumer-kind": consumer.Kind,
+				"quota.miloapis.com/consumer-name": consumer.Name,
+			},
+		},
+		Spec: quotav1alpha1.AllowanceBucketSpec{ConsumerRef: consumer, ResourceType: resourceType},
+		Status: quotav1alpha1.AllowanceBucketStatus{
+			Limit:      limit,
+			Allocated:  allocated,
+			Available:  limit - allocated,
+			ClaimCount: 1,
+		},
+	}
+}
+
+func TestCaptureSnapshot(t *testing.T) {
+	ctx := context.Background()
+	consumer := testConsumerRef()
+	otherGroup := consumer
+	otherGroup.APIGroup = "iam.miloapis.com"
+
+	snapshot := &quotav1alpha1.QuotaSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "acme-2026-09", Namespace: "milo-system"},
+		Spec:       quotav1alpha1.QuotaSnapshotSpec{ConsumerRef: consumer},
+	}
+	bucket := newSnapshotBucket("projects", testResourceType, consumer, 10, 4)
+	c := fake.NewClientBuilder().
+		WithScheme(testScheme()).
+		WithObjects(
+			snapshot,
+			bucket,
+			newSnapshotBucket("instances", "compute.miloapis.com/instances", consumer, 100, 30),
+			newSnapshotBucket("other-consumer", testResourceType, otherGroup, 5, 5),
+		).
+		WithStatusSubresource(&quotav1alpha1.QuotaSnapshot{}, &quotav1alpha1.AllowanceBucket{}).
+		Build()
+
+	r := &QuotaSnapshotController{}
+	capturedAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("captures the current allocation", func(t *testing.T) {
+		if err := r.captureSnapshot(ctx, c, snapshot, capturedAt); err != nil {
+			t.Fatalf("captureSnapshot() error = %v", err)
+		}
+
+		var got quotav1alpha1.QuotaSnapshot
+		if err := c.Get(ctx, client.ObjectKeyFromObject(snapshot), &got); err != nil {
+			t.Fatalf("failed to get QuotaSnapshot: %v", err)
+		}
+		if got.Status.CapturedAt == nil || !got.Status.CapturedAt.Time.Equal(capturedAt) {
+			t.Errorf("capturedAt = %v, want %v", got.Status.CapturedAt, capturedAt)
+		}
+		if !apimeta.IsStatusConditionTrue(got.Status.Conditions, quotav1alpha1.QuotaSnapshotCaptured) {
+			t.Errorf("expected Captured=True, got %+v", got.Status.Conditions)
+		}
+
+		want := []quotav1alpha1.QuotaSnapshotAllocation{
+			{ResourceType: "compute.miloapis.com/instances", Limit: 100, Allocated: 30, ClaimCount: 1},
+			{ResourceType: testResourceType, Limit: 10, Allocated: 4, ClaimCount: 1},
+		}
+		if len(got.Status.Allocations) != len(want) {
+			t.Fatalf("allocations = %+v, want %+v", got.Status.Allocations, want)
+		}
+		for i := range want {
+			if got.Status.Allocations[i] != want[i] {
+				t.Errorf("allocations[%d] = %+v, want %+v", i, got.Status.Allocations[i], want[i])
+			}
+		}
+	})
+
+	t.Run("is unchanged by later allocation", func(t *testing.T) {
+		bucket.Status.Allocated = 9
+		if err := c.Status().Update(ctx, bucket); err != nil {
+			t.Fatalf("failed to update AllowanceBucket status: %v", err)
+		}
+
+		var current quotav1alpha1.QuotaSnapshot
+		if err := c.Get(ctx, client.ObjectKeyFromObject(snapshot), &current); err != nil {
+			t.Fatalf("failed to get QuotaSnapshot: %v", err)
+		}
+		if err := r.captureSnapshot(ctx, c, &current, capturedAt.Add(time.Hour)); err != nil {
+			t.Fatalf("captureSnapshot() error = %v", err)
+		}
+
+		var got quotav1alpha1.QuotaSnapshot
+		if err := c.Get(ctx, client.ObjectKeyFromObject(snapshot), &got); err != nil {
+			t.Fatalf("failed to get QuotaSnapshot: %v", err)
+		}
+		if !got.Status.CapturedAt.Time.Equal(capturedAt) {
+			t.Errorf("capturedAt = %v, want it to stay %v", got.Status.CapturedAt, capturedAt)
+		}
+		if got.ResourceVersion != current.ResourceVersion {
+			t.Errorf("expected no write to a captured snapshot, resourceVersion %s -> %s", current.ResourceVersion, got.ResourceVersion)
+		}
+		for _, allocation := range got.Status.Allocations {
+			if allocation.ResourceType == testResourceType && allocation.Allocated != 4 {
+				t.Errorf("captured allocated = %d, want 4", allocation.Allocated)
+			}
+		}
+	})
+}
@@ -0,0 +1,292 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+
+	"go.miloapis.com/milo/internal/quota/bucketutil"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// defaultUsageResyncInterval is how long a bucket's incrementally maintained usage
+// is trusted before the controller rebuilds it from a full ResourceClaim list.
+const defaultUsageResyncInterval = 10 * time.Minute
+
+// usageLedger tracks the granted allocation each ResourceClaim contributes to each
+// AllowanceBucket, so bucket reconciles can read usage without listing every claim
+// for the consumer. Claim events adjust bucket totals by the difference between a
+// claim's previous and current contribution.
+//
+// Contributions are stored as absolute amounts rather than raw deltas, which makes
+// replaying an event harmless. Bucket entries expire after the resync interval and
+// are rebuilt from a full list to correct any drift from missed events. Claims are
+// listed outside the lock, so the claim events applied while a bucket is rebuilt are
+// recorded and replayed over the list when it replaces the bucket's usage.
+type usageLedger struct {
+	mu sync.Mutex
+
+	// buckets is keyed by cluster/namespace/name of the AllowanceBucket
+	buckets map[string]*bucketUsage
+
+	// claims maps a cluster/namespace/name claim key to its contribution per bucket key
+	claims map[string]map[string]int64
+
+	// rebuilds maps the key of each bucket being rebuilt to the latest state of every
+	// claim seen since the rebuild began, keyed by claim key
+	rebuilds map[string]map[string]claimEvent
+}
+
+// claimEvent is the state of a claim seen while a bucket is rebuilt: its contribution
+// per bucket key, which is empty once the claim no longer contributes or is deleted.
+type claimEvent struct {
+	name          string
+	namespace     string
+	contributions map[string]int64
+}
+
+// bucketUsage is the incrementally maintained usage of a single bucket.
+type bucketUsage struct {
+	allocated int64
+	claims    map[string]quotav1alpha1.TopClaimRef
+	syncedAt  time.Time
+}
+
+func newUsageLedger() *usageLedger {
+	return &usageLedger{
+		buckets:  make(map[string]*bucketUsage),
+		claims:   make(map[string]map[string]int64),
+		rebuilds: make(map[string]map[string]claimEvent),
+	}
+}
+
+func ledgerKey(cluster, namespace, name string) string {
+	return cluster + "/" + namespace + "/" + name
+}
+
+// claimContributions returns the granted amount a claim contributes to each bucket it references.
+func claimContributions(cluster string, claim *quotav1alpha1.ResourceClaim) map[string]int64 {
+	contributions := make(map[string]int64)
+	for _, request := range claim.Spec.Requests {
+		amount, granted := grantedAllocation(claim, request.ResourceType)
+		if !granted {
+			continue
+		}
+		key := ledgerKey(cluster, bucketutil.Namespace(claim.Spec.ConsumerRef), bucketutil.Name(request.ResourceType, claim.Spec.ConsumerRef))
+		contributions[key] = amount
+	}
+	return contributions
+}
+
+// setClaim records the claim's current contributions, adjusting every affected
+// bucket by the difference from what was previously recorded.
+func (l *usageLedger) setClaim(cluster string, claim *quotav1alpha1.ResourceClaim) {
+	claimKey := ledgerKey(cluster, claim.Namespace, claim.Name)
+	contributions := claimContributions(cluster, claim)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for bucketKey := range l.claims[claimKey] {
+		if _, ok := contributions[bucketKey]; !ok {
+			l.unsetLocked(bucketKey, claimKey)
+		}
+	}
+	for bucketKey, amount := range contributions {
+		l.setLocked(bucketKey, claimKey, quotav1alpha1.TopClaimRef{Name: claim.Name, Namespace: claim.Namespace, Amount: amount})
+	}
+
+	if len(contributions) == 0 {
+		delete(l.claims, claimKey)
+	} else {
+		l.claims[claimKey] = contributions
+	}
+	l.recordLocked(claimKey, claimEvent{name: claim.Name, namespace: claim.Namespace, contributions: contributions})
+}
+
+// removeClaim drops a deleted claim's contributions from every bucket.
+func (l *usageLedger) removeClaim(cluster, namespace, name string) {
+	claimKey := ledgerKey(cluster, namespace, name)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for bucketKey := range l.claims[claimKey] {
+		l.unsetLocked(bucketKey, claimKey)
+	}
+	delete(l.claims, claimKey)
+	l.recordLocked(claimKey, claimEvent{name: name, namespace: namespace})
+}
+
+// recordLocked records a claim event for every bucket being rebuilt. Only the latest
+// state of a claim is kept, since contributions are absolute.
+func (l *usageLedger) recordLocked(claimKey string, event claimEvent) {
+	for _, events := range l.rebuilds {
+		events[claimKey] = event
+	}
+}
+
+// setLocked sets a claim's contribution to a bucket, adjusting the bucket total by
+// the difference. Buckets that have not been synced yet are skipped; their first
+// reconcile builds them from a full list.
+func (l *usageLedger) setLocked(bucketKey, claimKey string, ref quotav1alpha1.TopClaimRef) {
+	usage, ok := l.buckets[bucketKey]
+	if !ok {
+		return
+	}
+	usage.allocated += ref.Amount - usage.claims[claimKey].Amount
+	usage.claims[claimKey] = ref
+}
+
+// unsetLocked removes a claim's contribution from a bucket.
+func (l *usageLedger) unsetLocked(bucketKey, claimKey string) {
+	usage, ok := l.buckets[bucketKey]
+	if !ok {
+		return
+	}
+	usage.allocated -= usage.claims[claimKey].Amount
+	delete(usage.claims, claimKey)
+}
+
+// beginRebuild starts recording claim events for a bucket whose claims are about to be
+// listed. It must be followed by replaceBucket, or by abortRebuild if the list fails.
+func (l *usageLedger) beginRebuild(bucketKey string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rebuilds[bucketKey] = make(map[string]claimEvent)
+}
+
+// abortRebuild stops recording claim events for a bucket that was not rebuilt.
+func (l *usageLedger) abortRebuild(bucketKey string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.rebuilds, bucketKey)
+}
+
+// replaceBucket resets a bucket's usage from a full list of contributing claims, then
+// replays the claim events recorded since beginRebuild, which the list may predate. It
+// returns the bucket's resulting allocated total and contributing claims.
+func (l *usageLedger) replaceBucket(cluster, bucketKey string, refs []quotav1alpha1.TopClaimRef, now time.Time) (int64, []quotav1alpha1.TopClaimRef) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if previous, ok := l.buckets[bucketKey]; ok {
+		for claimKey := range previous.claims {
+			delete(l.claims[claimKey], bucketKey)
+			if len(l.claims[claimKey]) == 0 {
+				delete(l.claims, claimKey)
+			}
+		}
+	}
+
+	usage := &bucketUsage{
+		claims:   make(map[string]quotav1alpha1.TopClaimRef, len(refs)),
+		syncedAt: now,
+	}
+	for _, ref := range refs {
+		claimKey := ledgerKey(cluster, ref.Namespace, ref.Name)
+		usage.claims[claimKey] = ref
+		usage.allocated += ref.Amount
+
+		if l.claims[claimKey] == nil {
+			l.claims[claimKey] = make(map[string]int64)
+		}
+		l.claims[claimKey][bucketKey] = ref.Amount
+	}
+	l.buckets[bucketKey] = usage
+
+	for claimKey, event := range l.rebuilds[bucketKey] {
+		if amount, ok := event.contributions[bucketKey]; ok {
+			l.setLocked(bucketKey, claimKey, quotav1alpha1.TopClaimRef{Name: event.name, Namespace: event.namespace, Amount: amount})
+			if l.claims[claimKey] == nil {
+				l.claims[claimKey] = make(map[string]int64)
+			}
+			l.claims[claimKey][bucketKey] = amount
+			continue
+		}
+
+		l.unsetLocked(bucketKey, claimKey)
+		delete(l.claims[claimKey], bucketKey)
+		if len(l.claims[claimKey]) == 0 {
+			delete(l.claims, claimKey)
+		}
+	}
+	delete(l.rebuilds, bucketKey)
+
+	claims := make([]quotav1alpha1.TopClaimRef, 0, len(usage.claims))
+	for _, ref := range usage.claims {
+		claims = append(claims, ref)
+	}
+	return usage.allocated, claims
+}
+
+// forgetBucket drops a deleted bucket from the ledger.
+func (l *usageLedger) forgetBucket(bucketKey string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if usage, ok := l.buckets[bucketKey]; ok {
+		for claimKey := range usage.claims {
+			delete(l.claims[claimKey], bucketKey)
+			if len(l.claims[claimKey]) == 0 {
+				delete(l.claims, claimKey)
+			}
+		}
+	}
+	delete(l.buckets, bucketKey)
+}
+
+// usage returns the bucket's allocated total and contributing claims, or false when
+// the bucket has never been synced or its last full sync is older than maxAge.
+func (l *usageLedger) usage(bucketKey string, maxAge time.Duration, now time.Time) (int64, []quotav1alpha1.TopClaimRef, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	usage, ok := l.buckets[bucketKey]
+	if !ok || now.Sub(usage.syncedAt) >= maxAge {
+		return 0, nil, false
+	}
+
+	refs := make([]quotav1alpha1.TopClaimRef, 0, len(usage.claims))
+	for _, ref := range usage.claims {
+		refs = append(refs, ref)
+	}
+	return usage.allocated, refs, true
+}
+
+// trackClaim applies a ResourceClaim watch event to the usage ledger. The event
+// object may be the final state of a deleted claim, so the claim is re-read from
+// the informer cache, which is updated before handlers run: a missing claim has
+// been deleted.
+func (r *AllowanceBucketController) trackClaim(ctx context.Context, claim *quotav1alpha1.ResourceClaim) {
+	if r.usageLedger == nil {
+		return
+	}
+
+	clusterName, _ := mccontext.ClusterFrom(ctx)
+	cluster, err := r.Manager.GetCluster(ctx, clusterName)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("Unable to track claim usage; bucket will be rebuilt on resync", "error", err.Error())
+		return
+	}
+
+	var current quotav1alpha1.ResourceClaim
+	if err := cluster.GetClient().Get(ctx, client.ObjectKeyFromObject(claim), &current); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.usageLedger.removeClaim(clusterName, claim.Namespace, claim.Name)
+		}
+		return
+	}
+	r.usageLedger.setClaim(clusterName, &current)
+}
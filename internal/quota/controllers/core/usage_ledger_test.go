@@ -0,0 +1,219 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"go.miloapis.com/milo/internal/quota/bucketutil"
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func newLedgerTestBucket() *quotav1alpha1.AllowanceBucket {
+	consumer := testConsumerRef()
+	return &quotav1alpha1.AllowanceBucket{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bucketutil.Name(testResourceType, consumer),
+			Namespace: bucketutil.Namespace(consumer),
+		},
+		Spec: quotav1alpha1.AllowanceBucketSpec{
+			ConsumerRef:  consumer,
+			ResourceType: testResourceType,
+		},
+	}
+}
+
+// countingClient wraps a fake client and counts ResourceClaim list calls.
+func countingClient(lists *int, objs ...client.Object) client.Client {
+	return interceptor.NewClient(newFakeClientWithClaimIndex(objs...).(client.WithWatch), interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			*lists++
+			return c.List(ctx, list, opts...)
+		},
+	})
+}
+
+func TestUsageLedgerTracksClaimChanges(t *testing.T) {
+	bucket := newLedgerTestBucket()
+	key := ledgerKey("", bucket.Namespace, bucket.Name)
+	now := time.Now()
+
+	ledger := newUsageLedger()
+	ledger.replaceBucket("", key, []quotav1alpha1.TopClaimRef{
+		{Name: "a", Namespace: "default", Amount: 2},
+		{Name: "b", Namespace: "default", Amount: 3},
+	}, now)
+
+	assertUsage := func(t *testing.T, wantAllocated int64, wantClaims int) {
+		t.Helper()
+		allocated, refs, ok := ledger.usage(key, time.Minute, now)
+		if !ok {
+			t.Fatal("expected bucket usage to be available")
+		}
+		if allocated != wantAllocated {
+			t.Errorf("expected allocated %d, got %d", wantAllocated, allocated)
+		}
+		if len(refs) != wantClaims {
+			t.Errorf("expected %d contributing claims, got %d", wantClaims, len(refs))
+		}
+	}
+
+	assertUsage(t, 5, 2)
+
+	// A new granted claim adds its amount
+	ledger.setClaim("", newGrantedClaim("c", 4))
+	assertUsage(t, 9, 3)
+
+	// Replaying the same event changes nothing
+	ledger.setClaim("", newGrantedClaim("c", 4))
+	assertUsage(t, 9, 3)
+
+	// An updated claim adjusts by the difference
+	ledger.setClaim("", newGrantedClaim("a", 7))
+	assertUsage(t, 14, 3)
+
+	// A claim losing its grant stops contributing
+	denied := newGrantedClaim("b", 3)
+	denied.Status.Allocations[0].Status = quotav1alpha1.ResourceClaimAllocationStatusDenied
+	ledger.setClaim("", denied)
+	assertUsage(t, 11, 2)
+
+	// A deleted claim is removed
+	ledger.removeClaim("", "default", "c")
+	assertUsage(t, 7, 1)
+
+	// Claims in another cluster do not affect this bucket
+	ledger.setClaim("project-a", newGrantedClaim("d", 100))
+	assertUsage(t, 7, 1)
+
+	if _, _, ok := ledger.usage(key, time.Minute, now.Add(time.Minute)); ok {
+		t.Error("expected usage older than the resync interval to be stale")
+	}
+
+	ledger.forgetBucket(key)
+	if _, _, ok := ledger.usage(key, time.Minute, now); ok {
+		t.Error("expected forgotten bucket to have no usage")
+	}
+}
+
+func TestRefreshUsageListsOnlyWhenStale(t *testing.T) {
+	var lists int
+	c := countingClient(&lists, newGrantedClaim("a", 2), newGrantedClaim("b", 3))
+
+	r := &AllowanceBucketController{usageLedger: newUsageLedger(), UsageResyncInterval: time.Hour}
+	bucket := newLedgerTestBucket()
+
+	if err := r.refreshUsage(context.Background(), "", c, bucket); err != nil {
+		t.Fatalf("refreshUsage() error = %v", err)
+	}
+	if lists != 1 || bucket.Status.Allocated != 5 || bucket.Status.ClaimCount != 2 {
+		t.Fatalf("expected initial full list with allocated 5 from 2 claims, got lists=%d allocated=%d claims=%d",
+			lists, bucket.Status.Allocated, bucket.Status.ClaimCount)
+	}
+
+	r.usageLedger.setClaim("", newGrantedClaim("c", 10))
+	if err := r.refreshUsage(context.Background(), "", c, bucket); err != nil {
+		t.Fatalf("refreshUsage() error = %v", err)
+	}
+	if lists != 1 {
+		t.Errorf("expected incremental refresh without listing claims, got %d lists", lists)
+	}
+	if bucket.Status.Allocated != 15 || bucket.Status.ClaimCount != 3 {
+		t.Errorf("expected allocated 15 from 3 claims, got allocated=%d claims=%d", bucket.Status.Allocated, bucket.Status.ClaimCount)
+	}
+	if len(bucket.Status.TopClaims) == 0 || bucket.Status.TopClaims[0].Name != "c" {
+		t.Errorf("expected claim c to rank first, got %v", bucket.Status.TopClaims)
+	}
+
+	// Once stale, the ledger is rebuilt from the API, correcting drift
+	r.UsageResyncInterval = time.Nanosecond
+	if err := r.refreshUsage(context.Background(), "", c, bucket); err != nil {
+		t.Fatalf("refreshUsage() error = %v", err)
+	}
+	if lists != 2 || bucket.Status.Allocated != 5 {
+		t.Errorf("expected resync to list claims and restore allocated 5, got lists=%d allocated=%d", lists, bucket.Status.Allocated)
+	}
+}
+
+// TestRefreshUsageKeepsEventsDuringRebuild verifies that claim events applied between
+// listing a bucket's claims and replacing its usage are not lost by the rebuild.
+func TestRefreshUsageKeepsEventsDuringRebuild(t *testing.T) {
+	r := &AllowanceBucketController{usageLedger: newUsageLedger(), UsageResyncInterval: time.Hour}
+	released := newGrantedClaim("a", 2)
+	released.Status.Allocations[0].Status = quotav1alpha1.ResourceClaimAllocationStatusDenied
+
+	c := interceptor.NewClient(newFakeClientWithClaimIndex(newGrantedClaim("a", 2), newGrantedClaim("b", 3)).(client.WithWatch), interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := c.List(ctx, list, opts...); err != nil {
+				return err
+			}
+			// Claim events handled after the list was read but before the ledger is replaced
+			r.usageLedger.setClaim("", newGrantedClaim("c", 10))
+			r.usageLedger.setClaim("", released)
+			return nil
+		},
+	})
+
+	bucket := newLedgerTestBucket()
+	if err := r.refreshUsage(context.Background(), "", c, bucket); err != nil {
+		t.Fatalf("refreshUsage() error = %v", err)
+	}
+	if bucket.Status.Allocated != 13 || bucket.Status.ClaimCount != 2 {
+		t.Errorf("expected allocated 13 from 2 claims, got allocated=%d claims=%d", bucket.Status.Allocated, bucket.Status.ClaimCount)
+	}
+
+	allocated, refs, ok := r.usageLedger.usage(ledgerKey("", bucket.Namespace, bucket.Name), time.Hour, time.Now())
+	if !ok || allocated != 13 || len(refs) != 2 {
+		t.Errorf("expected the ledger to hold allocated 13 from 2 claims, got allocated=%d claims=%d synced=%v", allocated, len(refs), ok)
+	}
+	if len(r.usageLedger.rebuilds) != 0 {
+		t.Errorf("expected no rebuild to remain in progress, got %d", len(r.usageLedger.rebuilds))
+	}
+}
+
+const benchmarkClaimCount = 10000
+
+func newBenchmarkClaims() []client.Object {
+	objs := make([]client.Object, 0, benchmarkClaimCount)
+	for i := 0; i < benchmarkClaimCount; i++ {
+		objs = append(objs, newGrantedClaim(fmt.Sprintf("claim-%05d", i), 1))
+	}
+	return objs
+}
+
+// BenchmarkBucketUsageFullList measures recomputing usage by listing every claim on each reconcile.
+func BenchmarkBucketUsageFullList(b *testing.B) {
+	c := newFakeClientWithClaimIndex(newBenchmarkClaims()...)
+	r := &AllowanceBucketController{}
+	bucket := newLedgerTestBucket()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := r.updateUsageFromClaims(context.Background(), c, bucket); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkBucketUsageIncremental measures applying a single claim event and reading usage from the ledger.
+func BenchmarkBucketUsageIncremental(b *testing.B) {
+	c := newFakeClientWithClaimIndex(newBenchmarkClaims()...)
+	r := &AllowanceBucketController{usageLedger: newUsageLedger(), UsageResyncInterval: time.Hour}
+	bucket := newLedgerTestBucket()
+	if err := r.refreshUsage(context.Background(), "", c, bucket); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.usageLedger.setClaim("", newGrantedClaim("claim-00000", int64(i%5+1)))
+		if err := r.refreshUsage(context.Background(), "", c, bucket); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
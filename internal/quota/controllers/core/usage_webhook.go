@@ -0,0 +1,359 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+// UsageWebhookConfig configures notifications sent to an external system when a
+// consumer's quota usage crosses a configured utilization threshold.
+type UsageWebhookConfig struct {
+	// URL receives a JSON POST for every threshold crossing. Empty disables notifications.
+	URL string
+
+	// Thresholds are utilization percentages (Allocated/Limit) that trigger a notification
+	// when crossed in either direction.
+	Thresholds []int
+
+	// Timeout bounds each delivery attempt. Defaults to 5 seconds when zero.
+	Timeout time.Duration
+
+	// MaxRetries is the number of retries after a failed delivery attempt.
+	MaxRetries int
+
+	// RetryDelay is the delay before the first retry; it doubles on each retry. Events
+	// still undelivered after MaxRetries are queued again with a backoff starting at
+	// RetryDelay.
+	RetryDelay time.Duration
+}
+
+// DefaultUsageWebhookConfig returns a disabled webhook configuration with default thresholds.
+func DefaultUsageWebhookConfig() UsageWebhookConfig {
+	return UsageWebhookConfig{
+		Thresholds: []int{80, 100},
+		Timeout:    5 * time.Second,
+		MaxRetries: 3,
+		RetryDelay: 500 * time.Millisecond,
+	}
+}
+
+// UsageEvent is the payload delivered to the usage webhook. Threshold is the
+// utilization percentage that was crossed, and Direction is "above" when usage
+// rose past it or "below" when usage fell under it. PreviousThreshold is the
+// threshold band the webhook was last notified of.
+type UsageEvent struct {
+	ConsumerRef           quotav1alpha1.ConsumerRef `json:"consumerRef"`
+	ResourceType          string                    `json:"resourceType"`
+	Bucket                string                    `json:"bucket"`
+	BucketNamespace       string                    `json:"bucketNamespace"`
+	Threshold             int                       `json:"threshold"`
+	Direction             string                    `json:"direction"`
+	Limit                 int64                     `json:"limit"`
+	Allocated             int64                     `json:"allocated"`
+	Available             int64                     `json:"available"`
+	PreviousThreshold     int                       `json:"previousThreshold"`
+	UtilizationPercentage int64                     `json:"utilizationPercentage"`
+}
+
+const (
+	// defaultUsageWebhookTimeout bounds a delivery attempt when no timeout is configured
+	defaultUsageWebhookTimeout = 5 * time.Second
+
+	// maxUsageWebhookBackoff caps the delay between deliveries of an event the
+	// webhook keeps rejecting
+	maxUsageWebhookBackoff = 5 * time.Minute
+)
+
+// UsageNotifier delivers UsageEvents to the configured webhook. Events are
+// delivered by a background worker so that a slow or failing webhook never
+// blocks bucket reconciliation; failed deliveries are retried with per-bucket
+// exponential backoff. A nil notifier or one without a URL does nothing.
+type UsageNotifier struct {
+	config UsageWebhookConfig
+	client *http.Client
+	queue  workqueue.TypedRateLimitingInterface[string]
+
+	mu sync.Mutex
+	// pending holds the latest event owed for each queued bucket
+	pending map[string]UsageEvent
+	// delivered holds the threshold band last delivered for each bucket until the
+	// controller has persisted it in the bucket's status
+	delivered map[string]int32
+}
+
+// NewUsageNotifier creates a notifier for the given configuration, or returns nil
+// when no webhook URL is configured. The notifier only delivers events once it
+// has been started.
+func NewUsageNotifier(config UsageWebhookConfig) *UsageNotifier {
+	if config.URL == "" {
+		return nil
+	}
+	thresholds := slices.Clone(config.Thresholds)
+	slices.Sort(thresholds)
+	config.Thresholds = slices.Compact(thresholds)
+	if config.Timeout <= 0 {
+		config.Timeout = defaultUsageWebhookTimeout
+	}
+	baseDelay := config.RetryDelay
+	if baseDelay <= 0 {
+		baseDelay = DefaultUsageWebhookConfig().RetryDelay
+	}
+	return &UsageNotifier{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[string](baseDelay, maxUsageWebhookBackoff),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "quota_usage_webhook"},
+		),
+		pending:   make(map[string]UsageEvent),
+		delivered: make(map[string]int32),
+	}
+}
+
+// thresholdBand returns the highest configured threshold at or below the given
+// utilization, or zero when utilization is below every threshold.
+func (n *UsageNotifier) thresholdBand(utilization int64) int32 {
+	var band int32
+	for _, threshold := range n.config.Thresholds {
+		if int64(threshold) <= utilization {
+			band = int32(threshold)
+		}
+	}
+	return band
+}
+
+// EventFor returns the notification owed for a bucket whose utilization is no
+// longer in the threshold band recorded in status.notifiedUsageThreshold, if any.
+// Only one threshold is reported per change: the highest crossed going up, or
+// the lowest crossed going down.
+func (n *UsageNotifier) EventFor(bucket *quotav1alpha1.AllowanceBucket) (UsageEvent, bool) {
+	if n == nil {
+		return UsageEvent{}, false
+	}
+
+	notified := bucket.Status.NotifiedUsageThreshold
+	cur := utilizationPercentage(bucket.Status.Allocated, bucket.Status.Limit)
+	band := n.thresholdBand(cur)
+	if band == notified {
+		return UsageEvent{}, false
+	}
+
+	// Going up, the band is the highest threshold crossed
+	crossed, direction := int(band), "above"
+	if band < notified {
+		// Going down, report the lowest threshold usage fell under
+		crossed, direction = int(notified), "below"
+		for _, threshold := range n.config.Thresholds {
+			if int64(threshold) > cur && int32(threshold) <= notified {
+				crossed = threshold
+				break
+			}
+		}
+	}
+
+	return UsageEvent{
+		ConsumerRef:           bucket.Spec.ConsumerRef,
+		ResourceType:          bucket.Spec.ResourceType,
+		Bucket:                bucket.Name,
+		BucketNamespace:       bucket.Namespace,
+		Threshold:             crossed,
+		Direction:             direction,
+		Limit:                 bucket.Status.Limit,
+		Allocated:             bucket.Status.Allocated,
+		Available:             bucket.Status.Available,
+		PreviousThreshold:     int(notified),
+		UtilizationPercentage: cur,
+	}, true
+}
+
+// Track records in the bucket's status the threshold band last delivered to the
+// webhook, and queues the notification still owed for the bucket, if any. It is
+// called by the bucket controller before it writes the bucket's status, so the
+// controller stays the only writer of buckets. The delivered band is kept until
+// Recorded confirms the status write, so a failed write does not resend it.
+//
+// A bucket whose notification is already queued is not queued again: its event
+// is replaced by the latest one and delivered on the existing schedule, so a
+// failing webhook is retried with backoff rather than on every reconciliation.
+func (n *UsageNotifier) Track(key string, bucket *quotav1alpha1.AllowanceBucket) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if band, ok := n.delivered[key]; ok {
+		bucket.Status.NotifiedUsageThreshold = band
+	}
+
+	event, ok := n.EventFor(bucket)
+	if !ok {
+		delete(n.pending, key)
+		return
+	}
+	_, queued := n.pending[key]
+	n.pending[key] = event
+	if !queued {
+		n.queue.Add(key)
+	}
+}
+
+// Recorded is called once the bucket's status has been written with the given
+// notified band. The delivered band is dropped if that write persisted it; a band
+// delivered since Track stays until a later write records it.
+func (n *UsageNotifier) Recorded(key string, band int32) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if delivered, ok := n.delivered[key]; ok && delivered == band {
+		delete(n.delivered, key)
+	}
+}
+
+// Forget drops the notification state of a deleted bucket.
+func (n *UsageNotifier) Forget(key string) {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.pending, key)
+	delete(n.delivered, key)
+}
+
+// Start delivers queued notifications until the context is cancelled. It
+// implements manager.Runnable so that the notifier runs alongside the
+// controllers on the elected leader.
+func (n *UsageNotifier) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		n.queue.ShutDown()
+	}()
+
+	for n.processNextEvent(ctx) {
+	}
+	return nil
+}
+
+// processNextEvent delivers the event owed for the next queued bucket. It returns
+// false once the queue has been shut down.
+func (n *UsageNotifier) processNextEvent(ctx context.Context) bool {
+	key, shutdown := n.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer n.queue.Done(key)
+
+	n.mu.Lock()
+	event, ok := n.pending[key]
+	n.mu.Unlock()
+	if !ok {
+		// Usage returned to the notified band before the event was delivered
+		n.queue.Forget(key)
+		return true
+	}
+
+	logger := log.FromContext(ctx).WithValues(
+		"bucket", event.Bucket,
+		"namespace", event.BucketNamespace,
+		"threshold", event.Threshold,
+		"direction", event.Direction)
+
+	if err := n.Notify(ctx, event); err != nil {
+		logger.Error(err, "failed to deliver usage webhook notification, will retry")
+		n.queue.AddRateLimited(key)
+		return true
+	}
+	n.queue.Forget(key)
+
+	logger.V(1).Info("Delivered usage webhook notification", "utilization", event.UtilizationPercentage)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.delivered[key] = n.thresholdBand(event.UtilizationPercentage)
+	if n.pending[key] == event {
+		delete(n.pending, key)
+	} else if _, ok := n.pending[key]; ok {
+		// Usage changed again while the event was being delivered
+		n.queue.Add(key)
+	}
+	return true
+}
+
+// Notify delivers the event, retrying failed attempts with exponential backoff.
+func (n *UsageNotifier) Notify(ctx context.Context, event UsageEvent) error {
+	if n == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage event: %w", err)
+	}
+
+	delay := n.config.RetryDelay
+	for attempt := 0; ; attempt++ {
+		err = n.deliver(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= n.config.MaxRetries {
+			return fmt.Errorf("usage webhook delivery failed after %d attempts: %w", attempt+1, err)
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("usage webhook delivery cancelled: %w", ctx.Err())
+		}
+		delay *= 2
+	}
+}
+
+// deliver performs a single webhook POST.
+func (n *UsageNotifier) deliver(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// utilizationPercentage returns allocated as a percentage of limit. A bucket
+// without a limit is fully utilized as soon as anything is allocated.
+func utilizationPercentage(allocated, limit int64) int64 {
+	if limit <= 0 {
+		if allocated > 0 {
+			return 100
+		}
+		return 0
+	}
+	return allocated * 100 / limit
+}
@@ -0,0 +1,280 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func newTestBucket(limit, allocated int64) *quotav1alpha1.AllowanceBucket {
+	return &quotav1alpha1.AllowanceBucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "bucket-test", Namespace: "organization-acme"},
+		Spec: quotav1alpha1.AllowanceBucketSpec{
+			ConsumerRef:  testConsumerRef(),
+			ResourceType: testResourceType,
+		},
+		Status: quotav1alpha1.AllowanceBucketStatus{
+			Limit:     limit,
+			Allocated: allocated,
+			Available: max(0, limit-allocated),
+		},
+	}
+}
+
+func TestUsageNotifierEventFor(t *testing.T) {
+	notifier := NewUsageNotifier(UsageWebhookConfig{
+		URL:        "http://example.invalid",
+		Thresholds: []int{100, 50, 80},
+	})
+
+	tests := []struct {
+		name              string
+		notified          int32
+		limit, allocated  int64
+		expectEvent       bool
+		expectedThreshold int
+		expectedDirection string
+	}{
+		{
+			name:        "no threshold crossed",
+			notified:    0,
+			limit:       10,
+			allocated:   4,
+			expectEvent: false,
+		},
+		{
+			name:              "crossing a single threshold upward",
+			notified:          0,
+			limit:             10,
+			allocated:         5,
+			expectEvent:       true,
+			expectedThreshold: 50,
+			expectedDirection: "above",
+		},
+		{
+			name:              "crossing several thresholds reports the highest",
+			notified:          0,
+			limit:             10,
+			allocated:         10,
+			expectEvent:       true,
+			expectedThreshold: 100,
+			expectedDirection: "above",
+		},
+		{
+			name:              "falling below thresholds reports the lowest",
+			notified:          100,
+			limit:             10,
+			allocated:         2,
+			expectEvent:       true,
+			expectedThreshold: 50,
+			expectedDirection: "below",
+		},
+		{
+			name:              "raising the limit drops utilization below a threshold",
+			notified:          80,
+			limit:             20,
+			allocated:         9,
+			expectEvent:       true,
+			expectedThreshold: 50,
+			expectedDirection: "below",
+		},
+		{
+			name:        "usage within the notified band",
+			notified:    80,
+			limit:       10,
+			allocated:   9,
+			expectEvent: false,
+		},
+		{
+			name:              "undelivered crossing is still owed",
+			notified:          50,
+			limit:             10,
+			allocated:         9,
+			expectEvent:       true,
+			expectedThreshold: 80,
+			expectedDirection: "above",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := newTestBucket(tt.limit, tt.allocated)
+			bucket.Status.NotifiedUsageThreshold = tt.notified
+			event, ok := notifier.EventFor(bucket)
+			if ok != tt.expectEvent {
+				t.Fatalf("expected event=%v, got %v (%+v)", tt.expectEvent, ok, event)
+			}
+			if !ok {
+				return
+			}
+			if event.Threshold != tt.expectedThreshold {
+				t.Errorf("expected threshold %d, got %d", tt.expectedThreshold, event.Threshold)
+			}
+			if event.Direction != tt.expectedDirection {
+				t.Errorf("expected direction %q, got %q", tt.expectedDirection, event.Direction)
+			}
+		})
+	}
+}
+
+func TestUsageNotifierDisabled(t *testing.T) {
+	if notifier := NewUsageNotifier(UsageWebhookConfig{}); notifier != nil {
+		t.Fatal("expected no notifier without a webhook URL")
+	}
+
+	var notifier *UsageNotifier
+	if _, ok := notifier.EventFor(newTestBucket(10, 10)); ok {
+		t.Error("expected nil notifier to produce no events")
+	}
+	bucket := newTestBucket(10, 10)
+	notifier.Track("bucket-test", bucket)
+	if bucket.Status.NotifiedUsageThreshold != 0 {
+		t.Errorf("expected nil notifier to leave the bucket untouched, got %d", bucket.Status.NotifiedUsageThreshold)
+	}
+	if err := notifier.Notify(context.Background(), UsageEvent{}); err != nil {
+		t.Errorf("expected nil notifier to ignore notifications, got %v", err)
+	}
+}
+
+// TestUsageNotifierTrack verifies that a crossing is delivered in the background,
+// retried with backoff while the webhook fails without being resent on every
+// reconciliation, and recorded in the bucket's status once delivered until a status
+// write has persisted it.
+func TestUsageNotifierTrack(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewUsageNotifier(UsageWebhookConfig{
+		URL:        server.URL,
+		Thresholds: []int{80},
+		Timeout:    time.Second,
+		RetryDelay: 20 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = notifier.Start(ctx)
+	}()
+
+	// Reconcile the bucket repeatedly until the delivered threshold is recorded
+	deadline := time.Now().Add(5 * time.Second)
+	var bucket *quotav1alpha1.AllowanceBucket
+	for {
+		bucket = newTestBucket(10, 8)
+		notifier.Track("bucket-test", bucket)
+		if bucket.Status.NotifiedUsageThreshold != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("notification was not delivered, %d attempts made", calls.Load())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if bucket.Status.NotifiedUsageThreshold != 80 {
+		t.Errorf("expected notified threshold 80, got %d", bucket.Status.NotifiedUsageThreshold)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 delivery attempts, got %d", got)
+	}
+
+	// A status write that fails leaves the delivered band to be recorded again
+	bucket = newTestBucket(10, 8)
+	notifier.Track("bucket-test", bucket)
+	if bucket.Status.NotifiedUsageThreshold != 80 {
+		t.Errorf("expected notified threshold 80 to be recorded again, got %d", bucket.Status.NotifiedUsageThreshold)
+	}
+
+	// Once recorded, the crossing is not delivered again
+	notifier.Recorded("bucket-test", bucket.Status.NotifiedUsageThreshold)
+	notifier.Track("bucket-test", bucket)
+	time.Sleep(50 * time.Millisecond)
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected no further delivery attempts, got %d", got)
+	}
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	if _, ok := notifier.delivered["bucket-test"]; ok {
+		t.Error("expected the delivered band to be dropped once recorded")
+	}
+}
+
+func TestUsageNotifierNotify(t *testing.T) {
+	t.Run("delivers event after transient failure", func(t *testing.T) {
+		var calls atomic.Int32
+		var received UsageEvent
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Errorf("failed to decode webhook payload: %v", err)
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		notifier := NewUsageNotifier(UsageWebhookConfig{
+			URL:        server.URL,
+			Thresholds: []int{80},
+			Timeout:    time.Second,
+			MaxRetries: 2,
+			RetryDelay: time.Millisecond,
+		})
+
+		event, ok := notifier.EventFor(newTestBucket(10, 8))
+		if !ok {
+			t.Fatal("expected threshold crossing event")
+		}
+		if err := notifier.Notify(context.Background(), event); err != nil {
+			t.Fatalf("Notify() error = %v", err)
+		}
+
+		if got := calls.Load(); got != 2 {
+			t.Errorf("expected 2 delivery attempts, got %d", got)
+		}
+		if received.Threshold != 80 || received.Allocated != 8 || received.ResourceType != testResourceType {
+			t.Errorf("unexpected payload: %+v", received)
+		}
+	})
+
+	t.Run("gives up after retries", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		notifier := NewUsageNotifier(UsageWebhookConfig{
+			URL:        server.URL,
+			Timeout:    time.Second,
+			MaxRetries: 2,
+			RetryDelay: time.Millisecond,
+		})
+
+		if err := notifier.Notify(context.Background(), UsageEvent{}); err == nil {
+			t.Fatal("expected delivery to fail")
+		}
+		if got := calls.Load(); got != 3 {
+			t.Errorf("expected 3 delivery attempts, got %d", got)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+const (
+	// ResourceClaimConsumerDeletedReason is the event reason recorded when a claim is
+	// deleted because its consumer no longer exists.
+	ResourceClaimConsumerDeletedReason = "ResourceClaimConsumerDeleted"
+
+	// defaultConsumerCheckInterval is how often a claim's consumer is checked when no
+	// interval is configured.
+	defaultConsumerCheckInterval = time.Minute
+)
+
+// ResourceClaimConsumerCascadeController deletes ResourceClaims whose consumer has been
+// deleted, so that removing a consumer cascades to its claims.
+//
+// Consumers live in the core control plane while claims may live in any project control
+// plane, so the link cannot be an owner reference. A second owner reference would also
+// keep claims alive after their triggering resource is deleted, since the garbage
+// collector only removes objects once every owner is gone. Claims therefore keep their
+// single owner reference to the triggering resource, and this controller checks the
+// consumer on an interval.
+type ResourceClaimConsumerCascadeController struct {
+	Scheme  *runtime.Scheme
+	Manager mcmanager.Manager
+
+	// CheckInterval is how often each claim's consumer is checked for deletion.
+	// Defaults to defaultConsumerCheckInterval when zero.
+	CheckInterval time.Duration
+}
+
+// +kubebuilder:rbac:groups=quota.miloapis.com,resources=resourceclaims,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=*,resources=*,verbs=get;list;watch
+
+// Reconcile deletes the claim if its consumer is gone, or requeues to check again later.
+// This controller runs across all control planes to reap claims wherever they exist.
+func (r *ResourceClaimConsumerCascadeController) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("claim", req.Name, "namespace", req.Namespace)
+	if req.ClusterName != "" {
+		logger = logger.WithValues("cluster", req.ClusterName)
+		ctx = log.IntoContext(ctx, logger)
+	}
+
+	cluster, err := r.Manager.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get cluster %q: %w", req.ClusterName, err)
+	}
+	clusterClient := cluster.GetClient()
+
+	var claim quotav1alpha1.ResourceClaim
+	if err := clusterClient.Get(ctx, req.NamespacedName, &claim); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !claim.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Consumers are read through the core control plane's cache, and a missing consumer
+	// is confirmed against the API server before its claims are deleted
+	localMgr := r.Manager.GetLocalManager()
+	deleted, err := deleteClaimIfConsumerGone(ctx, clusterClient, localMgr.GetClient(), localMgr.GetAPIReader(), localMgr.GetRESTMapper(), &claim)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !deleted {
+		return ctrl.Result{RequeueAfter: r.checkInterval()}, nil
+	}
+
+	if recorder := cluster.GetEventRecorderFor("resourceclaim-consumer-cascade"); recorder != nil {
+		recorder.Event(&claim, "Normal", ResourceClaimConsumerDeletedReason,
+			fmt.Sprintf("Consumer %s %q no longer exists; ResourceClaim has been deleted",
+				claim.Spec.ConsumerRef.Kind, claim.Spec.ConsumerRef.Name))
+	}
+	return ctrl.Result{}, nil
+}
+
+// checkInterval returns the configured check interval or the default.
+func (r *ResourceClaimConsumerCascadeController) checkInterval() time.Duration {
+	if r.CheckInterval > 0 {
+		return r.CheckInterval
+	}
+	return defaultConsumerCheckInterval
+}
+
+// deleteClaimIfConsumerGone deletes the claim when its consumer is missing or being
+// deleted, reporting whether it did. The consumer is looked up in consumerCache first;
+// a consumer the cache reports as gone is confirmed with the uncached apiReader, so a
+// cache that has not yet seen a new consumer does not delete its claims.
+func deleteClaimIfConsumerGone(ctx context.Context, clusterClient client.Client, consumerCache, apiReader client.Reader, mapper meta.RESTMapper, claim *quotav1alpha1.ResourceClaim) (bool, error) {
+	gone, err := consumerGone(ctx, consumerCache, mapper, claim.Spec.ConsumerRef)
+	if err != nil || !gone {
+		return false, err
+	}
+	if gone, err = consumerGone(ctx, apiReader, mapper, claim.Spec.ConsumerRef); err != nil || !gone {
+		return false, err
+	}
+
+	log.FromContext(ctx).Info("Deleting ResourceClaim whose consumer was deleted",
+		"consumerKind", claim.Spec.ConsumerRef.Kind,
+		"consumerName", claim.Spec.ConsumerRef.Name)
+	if err := clusterClient.Delete(ctx, claim); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return true, nil
+}
+
+// consumerGone reports whether the consumer no longer exists or is being deleted.
+// Consumers of an unknown kind are treated as present, as their absence cannot be told
+// apart from a kind that is not yet served.
+func consumerGone(ctx context.Context, reader client.Reader, mapper meta.RESTMapper, consumerRef quotav1alpha1.ConsumerRef) (bool, error) {
+	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: consumerRef.APIGroup, Kind: consumerRef.Kind})
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to resolve consumer kind %s: %w", consumerRef.Kind, err)
+	}
+
+	consumer := &metav1.PartialObjectMetadata{}
+	consumer.SetGroupVersionKind(mapping.GroupVersionKind)
+	key := types.NamespacedName{Name: consumerRef.Name, Namespace: consumerRef.Namespace}
+	if err := reader.Get(ctx, key, consumer); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get consumer %s %s: %w", consumerRef.Kind, consumerRef.Name, err)
+	}
+	return !consumer.GetDeletionTimestamp().IsZero(), nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResourceClaimConsumerCascadeController) SetupWithManager(mgr mcmanager.Manager) error {
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&quotav1alpha1.ResourceClaim{},
+			mcbuilder.WithEngageWithLocalCluster(true),
+			mcbuilder.WithEngageWithProviderClusters(true)).
+		Named("resource-claim-consumer-cascade").
+		Complete(r)
+}
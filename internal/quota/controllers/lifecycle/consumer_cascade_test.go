@@ -0,0 +1,99 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+)
+
+func TestDeleteClaimIfConsumerGone(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = quotav1alpha1.AddToScheme(scheme)
+	_ = resourcemanagerv1alpha1.AddToScheme(scheme)
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{resourcemanagerv1alpha1.GroupVersion})
+	mapper.Add(resourcemanagerv1alpha1.GroupVersion.WithKind("Organization"), meta.RESTScopeRoot)
+
+	deletingOrganization := &resourcemanagerv1alpha1.Organization{ObjectMeta: metav1.ObjectMeta{
+		Name:              "acme",
+		DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		Finalizers:        []string{"example.com/hold"},
+	}}
+
+	tests := []struct {
+		name      string
+		consumers []client.Object
+		// uncached holds consumers only the API server has seen. Nil means the cache is current.
+		uncached    []client.Object
+		consumerRef quotav1alpha1.ConsumerRef
+		wantDeleted bool
+	}{
+		{
+			name:        "consumer exists",
+			consumers:   []client.Object{&resourcemanagerv1alpha1.Organization{ObjectMeta: metav1.ObjectMeta{Name: "acme"}}},
+			consumerRef: quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Organization", Name: "acme"},
+		},
+		{
+			name:        "consumer deleted",
+			consumerRef: quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Organization", Name: "acme"},
+			wantDeleted: true,
+		},
+		{
+			name:        "consumer missing from a stale cache",
+			uncached:    []client.Object{&resourcemanagerv1alpha1.Organization{ObjectMeta: metav1.ObjectMeta{Name: "acme"}}},
+			consumerRef: quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Organization", Name: "acme"},
+		},
+		{
+			name:        "consumer being deleted",
+			consumers:   []client.Object{deletingOrganization},
+			consumerRef: quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Organization", Name: "acme"},
+			wantDeleted: true,
+		},
+		{
+			name:        "unknown consumer kind",
+			consumerRef: quotav1alpha1.ConsumerRef{APIGroup: "example.com", Kind: "Widget", Name: "acme"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &quotav1alpha1.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "claim", Namespace: "default"},
+				Spec:       quotav1alpha1.ResourceClaimSpec{ConsumerRef: tt.consumerRef},
+			}
+			claimClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(claim).Build()
+			consumerCache := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.consumers...).Build()
+			apiReader := consumerCache
+			if tt.uncached != nil {
+				apiReader = fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tt.consumers, tt.uncached...)...).Build()
+			}
+
+			deleted, err := deleteClaimIfConsumerGone(context.Background(), claimClient, consumerCache, apiReader, mapper, claim)
+			if err != nil {
+				t.Fatalf("deleteClaimIfConsumerGone() error = %v", err)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("deleteClaimIfConsumerGone() = %v, want %v", deleted, tt.wantDeleted)
+			}
+
+			err = claimClient.Get(context.Background(), client.ObjectKeyFromObject(claim), &quotav1alpha1.ResourceClaim{})
+			if tt.wantDeleted && !apierrors.IsNotFound(err) {
+				t.Errorf("expected claim to be deleted, got err = %v", err)
+			}
+			if !tt.wantDeleted && err != nil {
+				t.Errorf("expected claim to remain, got err = %v", err)
+			}
+		})
+	}
+}
@@ -105,50 +105,61 @@ func (r *ResourceClaimOwnershipController) Reconcile(ctx context.Context, req mc
 		return ctrl.Result{}, nil
 	}
 
-	claimAge := time.Since(claim.CreationTimestamp.Time)
-
-	// Fast path: attempt to resolve owner immediately and set ownerRef
 	ownerObj, _, _, err := r.resolveOwner(ctx, cluster, &claim)
-	if err == nil && ownerObj != nil {
-		if err := r.applyOwnerReferenceSSA(ctx, clusterClient, &claim, ownerObj); err != nil {
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	analysis := analyzeOrphan(&claim, ownerObj, time.Now(), r.getOwnershipGracePeriod(), r.getOrphanMaxAge())
+	switch analysis.Status {
+	case OrphanStatusCanBeRescued:
+		if err := r.rescueOrphanedClaim(ctx, clusterClient, &claim, analysis.ClaimingResource); err != nil {
 			logger.Error(err, "Failed to set ownerReference via SSA; requeue")
 			return ctrl.Result{RequeueAfter: 500 * time.Millisecond}, nil
 		}
 		return ctrl.Result{}, nil
-	}
 
-	// If owner not found, apply safety net logic based on age thresholds
-	if apierrors.IsNotFound(err) || err == nil {
-		// Owner missing; evaluate grace and max age
-		grace := r.getOwnershipGracePeriod()
-		if claimAge < grace {
-			// Short requeue to check again soon
-			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+	case OrphanStatusShouldBeDeleted:
+		// The triggering create failed after the claim was granted, e.g. in a later
+		// admission plugin or in storage, so the allocation is released
+		logger.Info("Deleting orphaned ResourceClaim", "claim", claim.Name, "reason", analysis.Reason)
+		if err := clusterClient.Delete(ctx, &claim); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
-
-		// Beyond grace: try once more to resolve owner and rescue
-		ownerObj2, _, _, err2 := r.resolveOwner(ctx, cluster, &claim)
-		if err2 == nil && ownerObj2 != nil {
-			if err := r.applyOwnerReferenceSSA(ctx, clusterClient, &claim, ownerObj2); err != nil {
-				logger.Error(err, "Failed to rescue owner reference via SSA; requeue")
-				return ctrl.Result{RequeueAfter: time.Second}, nil
-			}
-			return ctrl.Result{}, nil
+		if recorder := cluster.GetEventRecorderFor("resourceclaim-ownership"); recorder != nil {
+			recorder.Event(&claim, "Normal", ResourceClaimOrphanedReason, analysis.Reason)
 		}
+		return ctrl.Result{}, nil
+	}
 
-		// If max age exceeded without owner, delete the claim
-		maxAge := r.getOrphanMaxAge()
-		if claimAge > maxAge {
-			logger.Info("Deleting orphaned ResourceClaim after max age", "claim", claim.Name, "age", claimAge, "maxAge", maxAge)
-			return ctrl.Result{}, clusterClient.Delete(ctx, &claim)
-		}
+	// Short requeue while the create may still be in flight, then check less often
+	if time.Since(claim.CreationTimestamp.Time) < r.getOwnershipGracePeriod() {
+		return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+	}
+	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+}
 
-		// Not beyond max age; requeue to check later
-		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+// analyzeOrphan decides what to do with a granted claim that has no owner reference,
+// given the claiming resource if it exists. A claim whose resource is still missing
+// once both the grace period and the max orphan age have passed is orphaned.
+func analyzeOrphan(claim *quotav1alpha1.ResourceClaim, claimingResource *unstructured.Unstructured, now time.Time, grace, maxAge time.Duration) OrphanAnalysis {
+	if claimingResource != nil {
+		return OrphanAnalysis{
+			Status:           OrphanStatusCanBeRescued,
+			Reason:           "claiming resource exists",
+			ClaimingResource: claimingResource,
+		}
 	}
 
-	// Unexpected error when resolving owner
-	return ctrl.Result{}, err
+	claimAge := now.Sub(claim.CreationTimestamp.Time)
+	if claimAge < grace || claimAge <= maxAge {
+		return OrphanAnalysis{Status: OrphanStatusKeepWaiting, Reason: "claiming resource not found yet"}
+	}
+	return OrphanAnalysis{
+		Status: OrphanStatusShouldBeDeleted,
+		Reason: fmt.Sprintf("Claiming resource %s %q was not found %s after the ResourceClaim was granted; ResourceClaim has been deleted",
+			claim.Spec.ResourceRef.Kind, claim.Spec.ResourceRef.Name, claimAge.Round(time.Second)),
+	}
 }
 
 // rescueOrphanedClaim adds an owner reference via SSA
@@ -219,17 +230,23 @@ func (r *ResourceClaimOwnershipController) SetupWithManager(mgr mcmanager.Manage
 func (r *ResourceClaimOwnershipController) resolveOwner(ctx context.Context, cluster interface {
 	GetConfig() *rest.Config
 }, claim *quotav1alpha1.ResourceClaim) (*unstructured.Unstructured, schema.GroupVersionKind, string, error) {
-	if r.restMapper == nil {
+	return resolveClaimingResource(ctx, r.restMapper, cluster.GetConfig(), claim)
+}
+
+// resolveClaimingResource fetches the resource referenced by claim.spec.resourceRef from the
+// cluster, returning it along with its GVK and the namespace used for GET.
+func resolveClaimingResource(ctx context.Context, restMapper meta.RESTMapper, config *rest.Config, claim *quotav1alpha1.ResourceClaim) (*unstructured.Unstructured, schema.GroupVersionKind, string, error) {
+	if restMapper == nil {
 		return nil, schema.GroupVersionKind{}, "", fmt.Errorf("RESTMapper not initialized")
 	}
 
-	dynamicClient, err := dynamic.NewForConfig(cluster.GetConfig())
+	dynamicClient, err := dynamic.NewForConfig(config)
 	if err != nil {
 		return nil, schema.GroupVersionKind{}, "", fmt.Errorf("failed to create dynamic client for cluster: %w", err)
 	}
 
 	gk := schema.GroupKind{Group: claim.Spec.ResourceRef.APIGroup, Kind: claim.Spec.ResourceRef.Kind}
-	mapping, err := r.restMapper.RESTMapping(gk)
+	mapping, err := restMapper.RESTMapping(gk)
 	if err != nil {
 		return nil, schema.GroupVersionKind{}, "", err
 	}
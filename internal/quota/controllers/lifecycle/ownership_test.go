@@ -0,0 +1,68 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestAnalyzeOrphan(t *testing.T) {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	claim := &quotav1alpha1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment-web", CreationTimestamp: metav1.NewTime(created)},
+		Spec: quotav1alpha1.ResourceClaimSpec{
+			ResourceRef: quotav1alpha1.UnversionedObjectReference{APIGroup: "apps", Kind: "Deployment", Name: "web", Namespace: "default"},
+		},
+	}
+	deployment := &unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetName("web")
+
+	const grace, maxAge = 30 * time.Second, 2 * time.Minute
+
+	tests := []struct {
+		name             string
+		claimingResource *unstructured.Unstructured
+		now              time.Time
+		expected         OrphanStatus
+	}{
+		{
+			name:             "existing resource is retained",
+			claimingResource: deployment,
+			now:              created.Add(time.Hour),
+			expected:         OrphanStatusCanBeRescued,
+		},
+		{
+			name:     "missing resource within grace period",
+			now:      created.Add(10 * time.Second),
+			expected: OrphanStatusKeepWaiting,
+		},
+		{
+			name:     "missing resource before max age",
+			now:      created.Add(time.Minute),
+			expected: OrphanStatusKeepWaiting,
+		},
+		{
+			name:     "missing resource past max age is cleaned up",
+			now:      created.Add(3 * time.Minute),
+			expected: OrphanStatusShouldBeDeleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := analyzeOrphan(claim, tt.claimingResource, tt.now, grace, maxAge)
+			if analysis.Status != tt.expected {
+				t.Fatalf("analyzeOrphan() status = %v, want %v (%s)", analysis.Status, tt.expected, analysis.Reason)
+			}
+			if tt.expected == OrphanStatusCanBeRescued && analysis.ClaimingResource != deployment {
+				t.Errorf("expected the claiming resource to be returned for rescue")
+			}
+		})
+	}
+}
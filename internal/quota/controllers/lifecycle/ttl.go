@@ -0,0 +1,288 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+const (
+	// ResourceClaimExpiredReason is the event reason recorded when an ungranted claim outlives its TTL.
+	ResourceClaimExpiredReason = "ResourceClaimExpired"
+	// ResourceClaimOrphanedReason is the event reason recorded when a granted claim outlives its TTL
+	// and the resource that triggered it no longer exists.
+	ResourceClaimOrphanedReason = "ResourceClaimOrphaned"
+	// ResourceClaimReservationExpiredReason is the event reason recorded when a reserved claim is not
+	// confirmed within its reservation TTL.
+	ResourceClaimReservationExpiredReason = "ResourceClaimReservationExpired"
+	// ResourceClaimResourceFinishedReason is the event reason recorded when a claim is released
+	// its TTL after the resource that triggered it finished.
+	ResourceClaimResourceFinishedReason = "ResourceClaimResourceFinished"
+
+	// finishedResourcePollInterval is how often a claim released after its resource finishes
+	// checks whether the resource has finished
+	finishedResourcePollInterval = time.Minute
+)
+
+// ResourceClaimTTLController deletes ResourceClaims that have outlived
+// spec.ttlSecondsAfterCreation without being granted, so claims whose triggering
+// resource was never created stop distorting bucket recalculation.
+//
+// Granted claims are exempt unless the resource in spec.resourceRef is gone.
+// Reserved claims are also deleted, granted or not, once spec.reservation.ttlSeconds
+// elapses without the reservation being confirmed, releasing the quota they hold.
+// Claims with spec.ttlSecondsAfterFinished are deleted once that long has passed
+// since the resource in spec.resourceRef finished.
+type ResourceClaimTTLController struct {
+	Scheme  *runtime.Scheme
+	Manager mcmanager.Manager
+
+	// RESTMapper for resolving the claiming resource of granted claims
+	restMapper meta.RESTMapper
+}
+
+// +kubebuilder:rbac:groups=quota.miloapis.com,resources=resourceclaims,verbs=get;list;watch;delete
+
+// Reconcile deletes the claim once its TTL or unconfirmed reservation has elapsed, or
+// requeues until it does.
+// This controller runs across all control planes to reap claims wherever they exist.
+func (r *ResourceClaimTTLController) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("claim", req.Name, "namespace", req.Namespace)
+	if req.ClusterName != "" {
+		logger = logger.WithValues("cluster", req.ClusterName)
+	}
+	ctx = log.IntoContext(ctx, logger)
+
+	cluster, err := r.Manager.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get cluster %q: %w", req.ClusterName, err)
+	}
+	clusterClient := cluster.GetClient()
+
+	var claim quotav1alpha1.ResourceClaim
+	if err := clusterClient.Get(ctx, req.NamespacedName, &claim); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !claim.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	expiry := &claimExpiry{
+		client:   clusterClient,
+		recorder: cluster.GetEventRecorderFor("resourceclaim-ttl"),
+		resolve: func(ctx context.Context, claim *quotav1alpha1.ResourceClaim) (*unstructured.Unstructured, error) {
+			obj, _, _, err := resolveClaimingResource(ctx, r.restMapper, cluster.GetConfig(), claim)
+			return obj, err
+		},
+		now: time.Now(),
+	}
+	return expiry.reconcile(ctx, &claim)
+}
+
+// claimExpiry applies a claim's TTLs at a point in time.
+type claimExpiry struct {
+	client   client.Client
+	recorder record.EventRecorder
+	// resolve returns the resource in the claim's spec.resourceRef
+	resolve func(ctx context.Context, claim *quotav1alpha1.ResourceClaim) (*unstructured.Unstructured, error)
+	now     time.Time
+}
+
+// reconcile deletes the claim if any of its expiries has elapsed, or returns when the
+// soonest of them is due to be checked again.
+func (e *claimExpiry) reconcile(ctx context.Context, claim *quotav1alpha1.ResourceClaim) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var result ctrl.Result
+	if isReservationPending(claim) {
+		remaining := reservationRemaining(claim, e.now)
+		if remaining <= 0 {
+			return ctrl.Result{}, e.deleteExpiredClaim(ctx, claim, ResourceClaimReservationExpiredReason,
+				fmt.Sprintf("ResourceClaim reservation was not confirmed within %ds and has been released", claim.Spec.Reservation.TTLSeconds))
+		}
+		logger.V(2).Info("ResourceClaim reservation not yet expired", "remaining", remaining)
+		result.RequeueAfter = remaining
+	}
+
+	// An ungranted reservation may still expire earlier under its TTL
+	ttlResult, deleted, err := e.reconcileTTL(ctx, claim)
+	if err != nil || deleted {
+		return ctrl.Result{}, err
+	}
+	result = soonestResult(result, ttlResult)
+
+	finishedResult, err := e.reconcileTTLAfterFinished(ctx, claim)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return soonestResult(result, finishedResult), nil
+}
+
+// reconcileTTL deletes the claim once spec.ttlSecondsAfterCreation has elapsed, or
+// returns when to check again.
+func (e *claimExpiry) reconcileTTL(ctx context.Context, claim *quotav1alpha1.ResourceClaim) (ctrl.Result, bool, error) {
+	logger := log.FromContext(ctx)
+	if claim.Spec.TTLSecondsAfterCreation == nil {
+		return ctrl.Result{}, false, nil
+	}
+
+	if remaining := ttlRemaining(claim, e.now); remaining > 0 {
+		logger.V(2).Info("ResourceClaim TTL not yet elapsed", "remaining", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, false, nil
+	}
+
+	reason := ResourceClaimExpiredReason
+	message := fmt.Sprintf("ResourceClaim was not granted within %ds and has been deleted", *claim.Spec.TTLSecondsAfterCreation)
+
+	if isResourceClaimGranted(claim) {
+		if claim.Spec.ResourceRef.Name == "" {
+			return ctrl.Result{}, false, nil
+		}
+
+		_, err := e.resolve(ctx, claim)
+		switch {
+		case err == nil:
+			// Owner references hand cleanup to the garbage collector; otherwise check again later
+			if len(claim.OwnerReferences) > 0 {
+				return ctrl.Result{}, false, nil
+			}
+			return ctrl.Result{RequeueAfter: time.Duration(*claim.Spec.TTLSecondsAfterCreation) * time.Second}, false, nil
+		case !apierrors.IsNotFound(err):
+			return ctrl.Result{}, false, fmt.Errorf("failed to resolve claiming resource: %w", err)
+		}
+
+		reason = ResourceClaimOrphanedReason
+		message = fmt.Sprintf("Claiming resource %s %q no longer exists; ResourceClaim has been deleted",
+			claim.Spec.ResourceRef.Kind, claim.Spec.ResourceRef.Name)
+	}
+
+	return ctrl.Result{}, true, e.deleteExpiredClaim(ctx, claim, reason, message)
+}
+
+// reconcileTTLAfterFinished deletes the claim once spec.ttlSecondsAfterFinished has
+// passed since its resource finished. Claims whose resource has not finished, or has
+// not been created yet, are checked again later.
+func (e *claimExpiry) reconcileTTLAfterFinished(ctx context.Context, claim *quotav1alpha1.ResourceClaim) (ctrl.Result, error) {
+	if claim.Spec.TTLSecondsAfterFinished == nil || claim.Spec.ResourceRef.Name == "" {
+		return ctrl.Result{}, nil
+	}
+
+	obj, err := e.resolve(ctx, claim)
+	if apierrors.IsNotFound(err) {
+		// Either not created yet or already deleted, which ttlSecondsAfterCreation covers
+		return ctrl.Result{RequeueAfter: finishedResourcePollInterval}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to resolve claiming resource: %w", err)
+	}
+
+	finishedAt, finished := resourceFinishedAt(obj)
+	if !finished {
+		log.FromContext(ctx).V(2).Info("Claiming resource has not finished", "resource", obj.GetName())
+		return ctrl.Result{RequeueAfter: finishedResourcePollInterval}, nil
+	}
+
+	ttl := time.Duration(*claim.Spec.TTLSecondsAfterFinished) * time.Second
+	if remaining := finishedAt.Add(ttl).Sub(e.now); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	return ctrl.Result{}, e.deleteExpiredClaim(ctx, claim, ResourceClaimResourceFinishedReason,
+		fmt.Sprintf("Claiming resource %s %q finished more than %ds ago; ResourceClaim has been deleted",
+			claim.Spec.ResourceRef.Kind, claim.Spec.ResourceRef.Name, *claim.Spec.TTLSecondsAfterFinished))
+}
+
+// deleteExpiredClaim deletes the claim and records an event explaining why.
+func (e *claimExpiry) deleteExpiredClaim(ctx context.Context, claim *quotav1alpha1.ResourceClaim, reason, message string) error {
+	log.FromContext(ctx).Info("Deleting expired ResourceClaim", "reason", reason, "age", e.now.Sub(claim.CreationTimestamp.Time))
+	if err := e.client.Delete(ctx, claim); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if e.recorder != nil {
+		e.recorder.Event(claim, "Normal", reason, message)
+	}
+	return nil
+}
+
+// soonestResult returns whichever result requeues first; a zero result never requeues.
+func soonestResult(a, b ctrl.Result) ctrl.Result {
+	if a.RequeueAfter == 0 || (b.RequeueAfter > 0 && b.RequeueAfter < a.RequeueAfter) {
+		return b
+	}
+	return a
+}
+
+// resourceFinishedAt returns when a run-to-completion resource such as a Job finished:
+// the transition time of its Complete or Failed condition with status True.
+func resourceFinishedAt(obj *unstructured.Unstructured) (time.Time, bool) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if (condition["type"] != "Complete" && condition["type"] != "Failed") || condition["status"] != "True" {
+			continue
+		}
+		transition, _ := condition["lastTransitionTime"].(string)
+		finishedAt, err := time.Parse(time.RFC3339, transition)
+		if err != nil {
+			continue
+		}
+		return finishedAt, true
+	}
+	return time.Time{}, false
+}
+
+// ttlRemaining returns how long until the claim's TTL elapses, or a non-positive
+// duration once it has.
+func ttlRemaining(claim *quotav1alpha1.ResourceClaim, now time.Time) time.Duration {
+	ttl := time.Duration(*claim.Spec.TTLSecondsAfterCreation) * time.Second
+	return claim.CreationTimestamp.Add(ttl).Sub(now)
+}
+
+// isReservationPending reports whether the claim holds reserved quota that has not been confirmed.
+func isReservationPending(claim *quotav1alpha1.ResourceClaim) bool {
+	return claim.Spec.Reservation != nil && !claim.Spec.Reservation.Confirmed
+}
+
+// reservationRemaining returns how long until the claim's reservation expires, or a
+// non-positive duration once it has.
+func reservationRemaining(claim *quotav1alpha1.ResourceClaim, now time.Time) time.Duration {
+	ttl := time.Duration(claim.Spec.Reservation.TTLSeconds) * time.Second
+	return claim.CreationTimestamp.Add(ttl).Sub(now)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResourceClaimTTLController) SetupWithManager(mgr mcmanager.Manager) error {
+	r.restMapper = mgr.GetLocalManager().GetRESTMapper()
+
+	return mcbuilder.ControllerManagedBy(mgr).
+		For(&quotav1alpha1.ResourceClaim{},
+			mcbuilder.WithEngageWithLocalCluster(true),
+			mcbuilder.WithEngageWithProviderClusters(true)).
+		// Claims without a TTL or pending reservation never expire, so there is nothing to reconcile
+		WithEventFilter(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			claim, ok := obj.(*quotav1alpha1.ResourceClaim)
+			return ok && (claim.Spec.TTLSecondsAfterCreation != nil || claim.Spec.TTLSecondsAfterFinished != nil || isReservationPending(claim))
+		})).
+		Named("resource-claim-ttl").
+		Complete(r)
+}
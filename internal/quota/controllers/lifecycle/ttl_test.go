@@ -0,0 +1,281 @@
+package lifecycle
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestTTLRemaining(t *testing.T) {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ttl := int64(600)
+	claim := &quotav1alpha1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Spec:       quotav1alpha1.ResourceClaimSpec{TTLSecondsAfterCreation: &ttl},
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Duration
+	}{
+		{
+			name:     "just created",
+			now:      created,
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "partway through TTL",
+			now:      created.Add(4 * time.Minute),
+			expected: 6 * time.Minute,
+		},
+		{
+			name:     "exactly at expiry",
+			now:      created.Add(10 * time.Minute),
+			expected: 0,
+		},
+		{
+			name:     "past expiry",
+			now:      created.Add(15 * time.Minute),
+			expected: -5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ttlRemaining(claim, tt.now); got != tt.expected {
+				t.Errorf("ttlRemaining() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReservationExpiry(t *testing.T) {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newReservedClaim := func() *quotav1alpha1.ResourceClaim {
+		return &quotav1alpha1.ResourceClaim{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Spec: quotav1alpha1.ResourceClaimSpec{
+				Reservation: &quotav1alpha1.ResourceClaimReservation{TTLSeconds: 300},
+			},
+		}
+	}
+
+	t.Run("reserve then expire", func(t *testing.T) {
+		claim := newReservedClaim()
+		if !isReservationPending(claim) {
+			t.Fatal("expected an unconfirmed reservation to be pending")
+		}
+		if got := reservationRemaining(claim, created.Add(2*time.Minute)); got != 3*time.Minute {
+			t.Errorf("reservationRemaining() = %v, want %v", got, 3*time.Minute)
+		}
+		if got := reservationRemaining(claim, created.Add(6*time.Minute)); got > 0 {
+			t.Errorf("expected the reservation to have expired, %v remaining", got)
+		}
+	})
+
+	t.Run("reserve then confirm", func(t *testing.T) {
+		claim := newReservedClaim()
+		claim.Spec.Reservation.Confirmed = true
+		if isReservationPending(claim) {
+			t.Error("expected a confirmed reservation not to expire")
+		}
+	})
+
+	t.Run("not reserved", func(t *testing.T) {
+		if isReservationPending(&quotav1alpha1.ResourceClaim{}) {
+			t.Error("expected a claim without a reservation not to expire")
+		}
+	})
+}
+
+func TestReservedClaimReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = quotav1alpha1.AddToScheme(scheme)
+
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	job := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata":   map[string]interface{}{"name": "provision", "namespace": "default"},
+	}}
+
+	tests := []struct {
+		name        string
+		confirmed   bool
+		now         time.Time
+		wantDeleted bool
+		wantReason  string
+		wantRequeue time.Duration
+	}{
+		{
+			name:        "granted reservation survives its TTL",
+			now:         created.Add(2 * time.Minute),
+			wantRequeue: time.Minute,
+		},
+		{
+			name:        "unconfirmed reservation is released once it expires",
+			now:         created.Add(6 * time.Minute),
+			wantDeleted: true,
+			wantReason:  ResourceClaimReservationExpiredReason,
+		},
+		{
+			name:        "confirmed reservation is kept after it would have expired",
+			confirmed:   true,
+			now:         created.Add(6 * time.Minute),
+			wantRequeue: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &quotav1alpha1.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "provision-claim", Namespace: "default", CreationTimestamp: metav1.NewTime(created)},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ResourceRef:             quotav1alpha1.UnversionedObjectReference{APIGroup: "batch", Kind: "Job", Name: "provision", Namespace: "default"},
+					TTLSecondsAfterCreation: ptr.To(int64(60)),
+					Reservation:             &quotav1alpha1.ResourceClaimReservation{TTLSeconds: 300, Confirmed: tt.confirmed},
+				},
+				Status: quotav1alpha1.ResourceClaimStatus{
+					Conditions: []metav1.Condition{{Type: quotav1alpha1.ResourceClaimGranted, Status: metav1.ConditionTrue}},
+				},
+			}
+			claimClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(claim).Build()
+			recorder := record.NewFakeRecorder(1)
+			expiry := &claimExpiry{
+				client:   claimClient,
+				recorder: recorder,
+				resolve: func(context.Context, *quotav1alpha1.ResourceClaim) (*unstructured.Unstructured, error) {
+					return job, nil
+				},
+				now: tt.now,
+			}
+
+			result, err := expiry.reconcile(context.Background(), claim)
+			if err != nil {
+				t.Fatalf("reconcile() error = %v", err)
+			}
+			if result.RequeueAfter != tt.wantRequeue {
+				t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, tt.wantRequeue)
+			}
+
+			err = claimClient.Get(context.Background(), client.ObjectKeyFromObject(claim), &quotav1alpha1.ResourceClaim{})
+			if tt.wantDeleted != apierrors.IsNotFound(err) {
+				t.Errorf("claim deleted = %v, want %v (err = %v)", apierrors.IsNotFound(err), tt.wantDeleted, err)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if tt.wantReason == "" || !strings.Contains(event, tt.wantReason) {
+					t.Errorf("unexpected event %q", event)
+				}
+			default:
+				if tt.wantReason != "" {
+					t.Errorf("expected a %s event", tt.wantReason)
+				}
+			}
+		})
+	}
+}
+
+func TestTTLAfterFinished(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = quotav1alpha1.AddToScheme(scheme)
+
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newJob := func(conditions ...interface{}) *unstructured.Unstructured {
+		job := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "batch/v1",
+			"kind":       "Job",
+			"metadata":   map[string]interface{}{"name": "backup", "namespace": "default"},
+		}}
+		if len(conditions) > 0 {
+			_ = unstructured.SetNestedSlice(job.Object, conditions, "status", "conditions")
+		}
+		return job
+	}
+	complete := func(at time.Time) interface{} {
+		return map[string]interface{}{"type": "Complete", "status": "True", "lastTransitionTime": at.Format(time.RFC3339)}
+	}
+
+	tests := []struct {
+		name        string
+		job         *unstructured.Unstructured
+		now         time.Time
+		wantDeleted bool
+		wantRequeue time.Duration
+	}{
+		{
+			name:        "running job outlives the TTL counted from creation",
+			job:         newJob(map[string]interface{}{"type": "Suspended", "status": "True", "lastTransitionTime": created.Format(time.RFC3339)}),
+			now:         created.Add(time.Hour),
+			wantRequeue: finishedResourcePollInterval,
+		},
+		{
+			name:        "job not created yet",
+			now:         created.Add(time.Hour),
+			wantRequeue: finishedResourcePollInterval,
+		},
+		{
+			name:        "job finished within the TTL",
+			job:         newJob(complete(created.Add(time.Hour))),
+			now:         created.Add(time.Hour + 20*time.Second),
+			wantRequeue: 100 * time.Second,
+		},
+		{
+			name:        "job finished longer ago than the TTL",
+			job:         newJob(complete(created.Add(time.Hour))),
+			now:         created.Add(time.Hour + 3*time.Minute),
+			wantDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &quotav1alpha1.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup-claim", Namespace: "default", CreationTimestamp: metav1.NewTime(created)},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ResourceRef:             quotav1alpha1.UnversionedObjectReference{APIGroup: "batch", Kind: "Job", Name: "backup", Namespace: "default"},
+					TTLSecondsAfterFinished: ptr.To(int64(120)),
+				},
+			}
+			claimClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(claim).Build()
+			expiry := &claimExpiry{
+				client: claimClient,
+				resolve: func(context.Context, *quotav1alpha1.ResourceClaim) (*unstructured.Unstructured, error) {
+					if tt.job == nil {
+						return nil, apierrors.NewNotFound(schema.GroupResource{Group: "batch", Resource: "jobs"}, "backup")
+					}
+					return tt.job, nil
+				},
+				now: tt.now,
+			}
+
+			result, err := expiry.reconcile(context.Background(), claim)
+			if err != nil {
+				t.Fatalf("reconcile() error = %v", err)
+			}
+			if result.RequeueAfter != tt.wantRequeue {
+				t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, tt.wantRequeue)
+			}
+
+			err = claimClient.Get(context.Background(), client.ObjectKeyFromObject(claim), &quotav1alpha1.ResourceClaim{})
+			if tt.wantDeleted != apierrors.IsNotFound(err) {
+				t.Errorf("claim deleted = %v, want %v (err = %v)", apierrors.IsNotFound(err), tt.wantDeleted, err)
+			}
+		})
+	}
+}
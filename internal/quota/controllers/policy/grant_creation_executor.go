@@ -27,6 +27,17 @@ import (
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
 )
 
+const (
+	// GrantCleanupFinalizer holds a GrantCreationPolicy until the ResourceGrants it
+	// created have been deleted.
+	GrantCleanupFinalizer = "quota.miloapis.com/grant-cleanup"
+
+	// grantPolicyLabel identifies the policy that created a ResourceGrant.
+	grantPolicyLabel = "quota.miloapis.com/policy"
+	// grantAutoCreatedLabel marks ResourceGrants created by a GrantCreationPolicy.
+	grantAutoCreatedLabel = "quota.miloapis.com/auto-created"
+)
+
 // GrantCreationController watches trigger resources and creates grants based on active policies.
 type GrantCreationController struct {
 	Scheme                *runtime.Scheme
@@ -87,9 +98,7 @@ func NewGrantCreationController(
 
 // +kubebuilder:rbac:groups=*,resources=*,verbs=get;list;watch
 // +kubebuilder:rbac:groups=quota.miloapis.com,resources=resourcegrants,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=quota.miloapis.com,resources=grantcreationpolicies,verbs=get;list;watch
-
-// Reconcile processes GrantCreationPolicy changes.
+// +kubebuilder:rbac:groups=quota.miloapis.com,resources=grantcreationpolicies,verbs=get;list;watch;update;patch
 
 // processTriggerResource processes a trigger resource event.
 func (r *GrantCreationController) processTriggerResource(obj *unstructured.Unstructured, policyName, eventType string) {
@@ -161,6 +170,31 @@ func (r *GrantCreationController) Reconcile(ctx context.Context, req mcreconcile
 		return ctrl.Result{}, err
 	}
 
+	// When the policy is being deleted, remove the grants it created before the
+	// object is removed.
+	if !policy.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&policy, GrantCleanupFinalizer) {
+			if err := r.removeWatchForPolicy(ctx, req.Name); err != nil {
+				logger.Error(err, "Failed to remove watch for deleting policy")
+			}
+			if err := r.cleanupPolicyGrants(ctx, &policy); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to clean up grants for policy: %w", err)
+			}
+			controllerutil.RemoveFinalizer(&policy, GrantCleanupFinalizer)
+			if err := clusterClient.Update(ctx, &policy); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to remove grant cleanup finalizer: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(&policy, GrantCleanupFinalizer) {
+		controllerutil.AddFinalizer(&policy, GrantCleanupFinalizer)
+		if err := clusterClient.Update(ctx, &policy); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add grant cleanup finalizer: %w", err)
+		}
+	}
+
 	// Check if policy is Ready (has Ready=True condition)
 	isReady := r.isPolicyReady(&policy)
 	logger.V(1).Info("Policy reconciled", "ready", isReady)
@@ -277,7 +311,7 @@ func (r *GrantCreationController) processPolicy(
 	}
 
 	// Render the grant (namespace is rendered by template engine)
-	grant, err := r.TemplateEngine.RenderGrant(policy, triggerObj)
+	grant, err := r.TemplateEngine.RenderGrant(policy, &engine.EvaluationContext{Object: triggerObj})
 	if err != nil {
 		return fmt.Errorf("failed to render grant: %w", err)
 	}
@@ -338,6 +372,13 @@ func (r *GrantCreationController) createOrUpdateGrant(
 ) error {
 	logger := log.FromContext(ctx).WithValues("grantName", grant.Name, "grantNamespace", grant.Namespace)
 
+	// Label the grant with its policy so it can be found when the policy is deleted
+	if grant.Labels == nil {
+		grant.Labels = make(map[string]string)
+	}
+	grant.Labels[grantAutoCreatedLabel] = "true"
+	grant.Labels[grantPolicyLabel] = policy.Name
+
 	// Check if grant already exists
 	existingGrant := &quotav1alpha1.ResourceGrant{}
 	err := targetClient.Get(ctx, client.ObjectKey{
@@ -403,7 +444,7 @@ func (r *GrantCreationController) cleanupGrant(
 	}
 
 	// Render the grant to get its name and namespace
-	grant, err := r.TemplateEngine.RenderGrant(policy, triggerObj)
+	grant, err := r.TemplateEngine.RenderGrant(policy, &engine.EvaluationContext{Object: triggerObj})
 	if err != nil {
 		return fmt.Errorf("failed to render grant for cleanup: %w", err)
 	}
@@ -424,7 +465,7 @@ func (r *GrantCreationController) cleanupGrant(
 	}
 
 	// Check if this grant was created by our policy
-	if existingGrant.Labels["quota.miloapis.com/policy"] == policy.Name {
+	if existingGrant.Labels[grantPolicyLabel] == policy.Name {
 		logger.Info("Cleaning up grant due to unmet conditions", "grantName", existingGrant.Name)
 
 		if err := targetClient.Delete(ctx, existingGrant); err != nil {
@@ -438,6 +479,55 @@ func (r *GrantCreationController) cleanupGrant(
 	return nil
 }
 
+// cleanupPolicyGrants deletes the grants created by a policy that is being deleted.
+// Grants targeting a parent context are found by resolving the parent context of
+// each trigger resource, since they may live in any project control plane.
+func (r *GrantCreationController) cleanupPolicyGrants(ctx context.Context, policy *quotav1alpha1.GrantCreationPolicy) error {
+	localClient := r.Manager.GetLocalManager().GetClient()
+	if policy.Spec.Target.ParentContext == nil {
+		_, err := deletePolicyGrants(ctx, localClient, policy.Name)
+		return err
+	}
+
+	triggers := &unstructured.UnstructuredList{}
+	triggers.SetGroupVersionKind(policy.Spec.Trigger.Resource.GetGVK())
+	if err := localClient.List(ctx, triggers); err != nil {
+		return fmt.Errorf("failed to list trigger resources: %w", err)
+	}
+
+	for i := range triggers.Items {
+		targetClient, err := r.resolveTargetClient(ctx, policy, &triggers.Items[i])
+		if err != nil {
+			return fmt.Errorf("failed to resolve target client for %s: %w", triggers.Items[i].GetName(), err)
+		}
+		if _, err := deletePolicyGrants(ctx, targetClient, policy.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deletePolicyGrants deletes every ResourceGrant labeled as created by the named
+// policy, reporting how many were deleted. Grants without the label are left alone.
+func deletePolicyGrants(ctx context.Context, c client.Client, policyName string) (int, error) {
+	var grants quotav1alpha1.ResourceGrantList
+	if err := c.List(ctx, &grants, client.MatchingLabels{grantPolicyLabel: policyName}); err != nil {
+		return 0, fmt.Errorf("failed to list grants for policy %s: %w", policyName, err)
+	}
+
+	deleted := 0
+	for i := range grants.Items {
+		grant := &grants.Items[i]
+		if err := c.Delete(ctx, grant); client.IgnoreNotFound(err) != nil {
+			return deleted, fmt.Errorf("failed to delete grant %s/%s: %w", grant.Namespace, grant.Name, err)
+		}
+		log.FromContext(ctx).Info("Deleted ResourceGrant for deleted policy",
+			"policy", policyName, "grantName", grant.Name, "grantNamespace", grant.Namespace)
+		deleted++
+	}
+	return deleted, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *GrantCreationController) SetupWithManager(mgr mcmanager.Manager) error {
 	r.logger.Info("Setting up GrantCreationController")
@@ -0,0 +1,46 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestDeletePolicyGrants(t *testing.T) {
+	grant := func(name string, labels map[string]string) *quotav1alpha1.ResourceGrant {
+		return &quotav1alpha1.ResourceGrant{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    labels,
+		}}
+	}
+
+	policyGrant := grant("policy-grant", map[string]string{grantPolicyLabel: "test-policy", grantAutoCreatedLabel: "true"})
+	otherPolicyGrant := grant("other-policy-grant", map[string]string{grantPolicyLabel: "other-policy", grantAutoCreatedLabel: "true"})
+	manualGrant := grant("manual-grant", nil)
+
+	c := newFakeClient(testScheme(), policyGrant, otherPolicyGrant, manualGrant)
+
+	deleted, err := deletePolicyGrants(context.Background(), c, "test-policy")
+	if err != nil {
+		t.Fatalf("deletePolicyGrants() error = %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deletePolicyGrants() = %d, want 1", deleted)
+	}
+
+	err = c.Get(context.Background(), client.ObjectKeyFromObject(policyGrant), &quotav1alpha1.ResourceGrant{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected policy grant to be deleted, got err = %v", err)
+	}
+	for _, remaining := range []*quotav1alpha1.ResourceGrant{otherPolicyGrant, manualGrant} {
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(remaining), &quotav1alpha1.ResourceGrant{}); err != nil {
+			t.Errorf("expected grant %s to remain, got err = %v", remaining.Name, err)
+		}
+	}
+}
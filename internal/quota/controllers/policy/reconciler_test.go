@@ -139,7 +139,7 @@ func newClaimPolicy(name string, generation int64) *quotav1alpha1.ClaimCreationP
 		},
 		Spec: quotav1alpha1.ClaimCreationPolicySpec{
 			Trigger: quotav1alpha1.ClaimTriggerSpec{
-				Resource: quotav1alpha1.ClaimTriggerResource{
+				Resource: &quotav1alpha1.ClaimTriggerResource{
 					APIVersion: "v1",
 					Kind:       "Namespace",
 				},
@@ -220,7 +220,16 @@ func (v *noopResourceTypeValidator) IsClaimingResourceAllowed(context.Context, s
 	return true, nil, nil
 }
 func (v *noopResourceTypeValidator) IsResourceTypeRegistered(string) bool { return true }
-func (v *noopResourceTypeValidator) HasSynced() bool                      { return true }
+func (v *noopResourceTypeValidator) HasExactRegistration(string) bool     { return true }
+func (v *noopResourceTypeValidator) GetMeasurement(string) (string, int64, bool) {
+	return "", 0, false
+}
+func (v *noopResourceTypeValidator) GetMaxGrantAmount(string) (int64, bool) { return 0, false }
+func (v *noopResourceTypeValidator) GetDefaultClaim(string, string) (validation.DefaultClaim, bool) {
+	return validation.DefaultClaim{}, false
+}
+func (v *noopResourceTypeValidator) GetDenialMessageTemplate(string) (string, bool) { return "", false }
+func (v *noopResourceTypeValidator) HasSynced() bool                                { return true }
 
 func reconcileRequest(name string) mcreconcile.Request {
 	return mcreconcile.Request{
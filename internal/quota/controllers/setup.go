@@ -6,6 +6,7 @@ package controllers
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/dynamic"
@@ -19,18 +20,45 @@ import (
 	"go.miloapis.com/milo/internal/quota/validation"
 )
 
+// Options configures optional behavior of the quota controllers.
+type Options struct {
+	// UsageWebhook configures notifications sent when a consumer's quota usage
+	// crosses a utilization threshold. Disabled when UsageWebhook.URL is empty.
+	UsageWebhook core.UsageWebhookConfig
+
+	// BucketResyncPeriod is how often each AllowanceBucket is recomputed without a
+	// triggering event. The controller default applies when zero.
+	BucketResyncPeriod time.Duration
+
+	// PauseGranting holds pending ResourceClaims without granting or denying them,
+	// for quota-system maintenance. Admission keeps creating claims and reports the
+	// pause to clients as a retryable error.
+	PauseGranting bool
+
+	// CascadeConsumerDeletion deletes ResourceClaims whose consumer has been deleted,
+	// in addition to the cleanup driven by their triggering resource.
+	CascadeConsumerDeletion bool
+
+	// GrantSelection names the order in which a consumer's grants are consumed when
+	// several contribute to the same AllowanceBucket: OldestFirst (the default),
+	// SoonestExpiry or HighestPriority.
+	GrantSelection string
+}
+
 // SetupQuotaControllers registers all quota controllers with the provided multicluster manager.
 //
 // All quota controllers now use the multicluster runtime framework to enable cross-cluster
 // quota management. Controllers watch resources based on their engagement strategy:
 //   - Core cluster only: ResourceRegistration, ClaimCreationPolicy, GrantCreationPolicy, GrantCreation
-//   - All clusters: ResourceGrant, ResourceClaim, AllowanceBucket, Ownership, Cleanup
+//   - All clusters: ResourceGrant, ResourceClaim, AllowanceBucket, Ownership, Cleanup, TTL,
+//     ConsumerCascade (when enabled)
 //
 // Parameters:
 //   - mgr: Multicluster controller manager
 //   - dynamicClient: Dynamic client for resource type validation
 //   - logger: Logger for quota controller operations
-func SetupQuotaControllers(mgr mcmanager.Manager, dynamicClient dynamic.Interface, logger logr.Logger) error {
+//   - opts: Optional controller behavior such as usage webhooks
+func SetupQuotaControllers(mgr mcmanager.Manager, dynamicClient dynamic.Interface, logger logr.Logger, opts Options) error {
 	logger.Info("Setting up quota controllers with multicluster support")
 
 	// Get the local manager for accessing shared components like EventRecorder
@@ -41,6 +69,11 @@ func SetupQuotaControllers(mgr mcmanager.Manager, dynamicClient dynamic.Interfac
 	sharedResourceTypeValidator := validation.NewResourceTypeValidator(dynamicClient)
 	logger.Info("Shared ResourceTypeValidator created, will sync in background")
 
+	grantSelection, err := core.ParseGrantSelectionStrategy(opts.GrantSelection)
+	if err != nil {
+		return err
+	}
+
 	// Create shared CEL validator for policy validation
 	celValidator, err := validation.NewCELValidator()
 	if err != nil {
@@ -48,7 +81,7 @@ func SetupQuotaControllers(mgr mcmanager.Manager, dynamicClient dynamic.Interfac
 	}
 
 	// Create CEL engine for runtime evaluation (used by grant creation controller)
-	celEngine, err := engine.NewCELEngine()
+	celEngine, err := engine.NewCELEngineWithOrganizationTiers(engine.NewOrganizationTierLister(standardMgr.GetClient()))
 	if err != nil {
 		return fmt.Errorf("failed to create CEL engine: %w", err)
 	}
@@ -78,8 +111,9 @@ func SetupQuotaControllers(mgr mcmanager.Manager, dynamicClient dynamic.Interfac
 	// 3. ResourceClaim controller (all clusters)
 	logger.V(1).Info("Setting up ResourceClaim controller (all clusters)")
 	if err := (&core.ResourceClaimController{
-		Scheme:  standardMgr.GetScheme(),
-		Manager: mgr,
+		Scheme:        standardMgr.GetScheme(),
+		Manager:       mgr,
+		PauseGranting: opts.PauseGranting,
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("failed to setup ResourceClaimController: %w", err)
 	}
@@ -87,8 +121,12 @@ func SetupQuotaControllers(mgr mcmanager.Manager, dynamicClient dynamic.Interfac
 	// 4. AllowanceBucket controller (aggregates quota data - all clusters)
 	logger.V(1).Info("Setting up AllowanceBucket controller (all clusters)")
 	if err := (&core.AllowanceBucketController{
-		Scheme:  standardMgr.GetScheme(),
-		Manager: mgr,
+		Scheme:         standardMgr.GetScheme(),
+		Manager:        mgr,
+		UsageNotifier:  core.NewUsageNotifier(opts.UsageWebhook),
+		ResyncPeriod:   opts.BucketResyncPeriod,
+		PauseGranting:  opts.PauseGranting,
+		GrantSelection: grantSelection,
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("failed to setup AllowanceBucketController: %w", err)
 	}
@@ -96,6 +134,7 @@ func SetupQuotaControllers(mgr mcmanager.Manager, dynamicClient dynamic.Interfac
 	// 5. ClaimCreationPolicy controller (policy validation - core cluster only)
 	logger.V(1).Info("Setting up ClaimCreationPolicy controller (core cluster only)")
 	claimCreationPolicyValidator := validation.NewClaimCreationPolicyValidator(sharedResourceTypeValidator)
+	claimCreationPolicyValidator.ClaimRenderer = engine.NewSampleClaimRenderer(engine.NewTemplateEngine(celEngine, logger))
 	if err := (&policy.ClaimCreationPolicyReconciler{
 		Scheme:          standardMgr.GetScheme(),
 		Manager:         mgr,
@@ -111,6 +150,7 @@ func SetupQuotaControllers(mgr mcmanager.Manager, dynamicClient dynamic.Interfac
 		return fmt.Errorf("failed to create GrantTemplateValidator: %w", err)
 	}
 	grantCreationPolicyValidator := validation.NewGrantCreationPolicyValidator(celValidator, grantTemplateValidator)
+	grantCreationPolicyValidator.GrantRenderer = engine.NewSampleGrantRenderer(engine.NewTemplateEngine(celEngine, logger))
 	if err := (&policy.GrantCreationPolicyReconciler{
 		Scheme:          standardMgr.GetScheme(),
 		Manager:         mgr,
@@ -165,6 +205,35 @@ func SetupQuotaControllers(mgr mcmanager.Manager, dynamicClient dynamic.Interfac
 		return fmt.Errorf("failed to setup DeniedAutoClaimCleanupController: %w", err)
 	}
 
+	// 10. ResourceClaim TTL controller (lifecycle management - all clusters)
+	logger.V(1).Info("Setting up ResourceClaim TTL controller (all clusters)")
+	if err := (&lifecycle.ResourceClaimTTLController{
+		Scheme:  standardMgr.GetScheme(),
+		Manager: mgr,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup ResourceClaimTTLController: %w", err)
+	}
+
+	// 11. QuotaSnapshot controller (billing snapshots - all clusters)
+	logger.V(1).Info("Setting up QuotaSnapshot controller (all clusters)")
+	if err := (&core.QuotaSnapshotController{
+		Scheme:  standardMgr.GetScheme(),
+		Manager: mgr,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup QuotaSnapshotController: %w", err)
+	}
+
+	// 12. ResourceClaim consumer cascade controller (lifecycle management - all clusters)
+	if opts.CascadeConsumerDeletion {
+		logger.V(1).Info("Setting up ResourceClaim consumer cascade controller (all clusters)")
+		if err := (&lifecycle.ResourceClaimConsumerCascadeController{
+			Scheme:  standardMgr.GetScheme(),
+			Manager: mgr,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("failed to setup ResourceClaimConsumerCascadeController: %w", err)
+		}
+	}
+
 	logger.Info("All quota controllers set up successfully")
 	return nil
 }
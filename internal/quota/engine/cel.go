@@ -2,10 +2,14 @@ package engine
 
 import (
 	"fmt"
-	"sync"
+	"math"
 
 	"github.com/google/cel-go/cel"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/component-base/metrics"
+	legacyregistry "k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/lru"
 
 	quotacel "go.miloapis.com/milo/internal/quota/cel"
 	"go.miloapis.com/milo/internal/quota/validation"
@@ -22,8 +26,28 @@ const (
 	//
 	// Reference: https://github.com/kubernetes/apiserver/blob/v0.32.9/pkg/apis/cel/config.go#L26
 	runtimeCostLimit = 1000000
+
+	// DefaultProgramCacheSize is the number of compiled CEL programs kept by a CEL engine
+	// when no size is configured. Policies contribute a handful of expressions each, so this
+	// comfortably holds every expression in use.
+	DefaultProgramCacheSize = 1024
+)
+
+// celProgramCacheTotal counts compiled program cache lookups. Registered once at init.
+var celProgramCacheTotal = metrics.NewCounterVec(
+	&metrics.CounterOpts{
+		Subsystem:      "milo_quota",
+		Name:           "cel_program_cache_lookups_total",
+		Help:           "Compiled CEL program cache lookups, by result.",
+		StabilityLevel: metrics.ALPHA,
+	},
+	[]string{"result"}, // hit|miss
 )
 
+func init() {
+	legacyregistry.MustRegister(celProgramCacheTotal)
+}
+
 // CELEngine provides CEL expression evaluation capabilities for quota operations.
 // It combines compile-time validation with runtime evaluation and program caching.
 type CELEngine interface {
@@ -36,19 +60,58 @@ type CELEngine interface {
 	// EvaluateConditions evaluates all trigger conditions against a resource object.
 	EvaluateConditions(conditions []quotav1alpha1.ConditionExpression, obj *unstructured.Unstructured) (bool, error)
 
+	// EvaluateConditionsWithVariables evaluates all trigger conditions with context variables
+	// (trigger, user, requestInfo, parent).
+	EvaluateConditionsWithVariables(conditions []quotav1alpha1.ConditionExpression, variables map[string]interface{}) (bool, error)
+
 	// EvaluateTemplateExpression evaluates a template expression with context variables (trigger, user, requestInfo).
 	EvaluateTemplateExpression(expression string, variables map[string]interface{}) (string, error)
+
+	// EvaluateIntegerExpression evaluates a claim template expression, such as a request amount,
+	// with context variables and returns its non-negative integer result.
+	EvaluateIntegerExpression(expression string, variables map[string]interface{}) (int64, error)
 }
 
 // celEngine implements CELEngine with program caching for performance.
 type celEngine struct {
-	env          *cel.Env
-	validator    *validation.CELValidator
-	programCache sync.Map // map[string]cel.Program - keyed by expression
+	env       *cel.Env
+	validator *validation.CELValidator
+
+	// programCache holds compiled programs keyed by expression text. A program depends
+	// only on its expression, so entries are never invalidated, only evicted.
+	programCache *lru.Cache
 }
 
-// NewCELEngine creates a new CEL engine with validation and evaluation capabilities.
+// NewCELEngine creates a new CEL engine with validation and evaluation capabilities,
+// caching up to DefaultProgramCacheSize compiled programs.
 func NewCELEngine() (CELEngine, error) {
+	return NewCELEngineWithCacheSize(DefaultProgramCacheSize)
+}
+
+// NewCELEngineWithCacheSize creates a new CEL engine that keeps up to cacheSize compiled
+// programs, evicting the least recently used. A non-positive size uses DefaultProgramCacheSize.
+// Options configure the environment's functions, e.g. the lister read by orgTier().
+func NewCELEngineWithCacheSize(cacheSize int, opts ...quotacel.EnvironmentOption) (CELEngine, error) {
+	return newCELEngine(cacheSize, opts...)
+}
+
+// NewCELEngineWithClock creates a new CEL engine whose now() function reads the given
+// clock, so time-based policies can be evaluated at a fixed instant in tests.
+func NewCELEngineWithClock(clk clock.PassiveClock) (CELEngine, error) {
+	return newCELEngine(DefaultProgramCacheSize, quotacel.WithClock(clk))
+}
+
+// NewCELEngineWithOrganizationTiers creates a new CEL engine whose orgTier() function looks up
+// organization tiers with the given lister.
+func NewCELEngineWithOrganizationTiers(lister quotacel.OrganizationTierLister) (CELEngine, error) {
+	return newCELEngine(DefaultProgramCacheSize, quotacel.WithOrganizationTiers(lister))
+}
+
+func newCELEngine(cacheSize int, opts ...quotacel.EnvironmentOption) (CELEngine, error) {
+	if cacheSize <= 0 {
+		cacheSize = DefaultProgramCacheSize
+	}
+
 	// Create validator for compile-time checks
 	validator, err := validation.NewCELValidator()
 	if err != nil {
@@ -56,14 +119,15 @@ func NewCELEngine() (CELEngine, error) {
 	}
 
 	// Create CEL environment for runtime evaluation using the shared quota environment
-	env, err := quotacel.NewQuotaEnvironment()
+	env, err := quotacel.NewQuotaEnvironment(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
 	}
 
 	return &celEngine{
-		env:       env,
-		validator: validator,
+		env:          env,
+		validator:    validator,
+		programCache: lru.New(cacheSize),
 	}, nil
 }
 
@@ -84,10 +148,16 @@ func (e *celEngine) EvaluateConditions(conditions []quotav1alpha1.ConditionExpre
 		return true, nil // No conditions means always match
 	}
 
-	objData := obj.Object
+	return e.EvaluateConditionsWithVariables(conditions, map[string]interface{}{
+		"trigger": obj.Object,
+	})
+}
 
+// EvaluateConditionsWithVariables evaluates all trigger conditions with context variables.
+// Returns true if all conditions pass, false if any fail.
+func (e *celEngine) EvaluateConditionsWithVariables(conditions []quotav1alpha1.ConditionExpression, variables map[string]interface{}) (bool, error) {
 	for i, condition := range conditions {
-		result, err := e.evaluateCondition(condition.Expression, objData)
+		result, err := e.evaluateCondition(condition.Expression, variables)
 		if err != nil {
 			return false, fmt.Errorf("condition %d evaluation failed: %w", i, err)
 		}
@@ -128,20 +198,56 @@ func (e *celEngine) EvaluateTemplateExpression(expression string, variables map[
 	return "", fmt.Errorf("expression did not return a string value")
 }
 
-// evaluateCondition evaluates a single condition expression.
-func (e *celEngine) evaluateCondition(expression string, objData map[string]interface{}) (bool, error) {
+// EvaluateIntegerExpression evaluates an integer-valued claim template expression with context
+// variables. Returns the integer result, which must not be negative.
+func (e *celEngine) EvaluateIntegerExpression(expression string, variables map[string]interface{}) (int64, error) {
 	// Get or create cached program
 	program, err := e.getOrCompileProgram(expression)
 	if err != nil {
-		return false, err
+		return 0, err
 	}
 
-	// Evaluate with the resource object
-	vars := map[string]interface{}{
-		"trigger": objData,
+	// Evaluate with provided variables
+	result, details, err := program.Eval(variables)
+	if err != nil {
+		// Check if this was a cost limit error and include cost information in error
+		if details != nil && details.ActualCost() != nil {
+			actualCost := *details.ActualCost()
+			return 0, fmt.Errorf("evaluation failed (cost: %d, limit: %d): %w", actualCost, runtimeCostLimit, err)
+		}
+		return 0, fmt.Errorf("evaluation failed: %w", err)
+	}
+
+	// Convert result to integer
+	var value int64
+	switch v := result.Value().(type) {
+	case int64:
+		value = v
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("expression result %d overflows int64", v)
+		}
+		value = int64(v)
+	default:
+		return 0, fmt.Errorf("expression did not return an integer value, got %T", result.Value())
 	}
 
-	result, details, err := program.Eval(vars)
+	if value < 0 {
+		return 0, fmt.Errorf("expression returned negative value %d", value)
+	}
+
+	return value, nil
+}
+
+// evaluateCondition evaluates a single condition expression.
+func (e *celEngine) evaluateCondition(expression string, variables map[string]interface{}) (bool, error) {
+	// Get or create cached program
+	program, err := e.getOrCompileProgram(expression)
+	if err != nil {
+		return false, err
+	}
+
+	result, details, err := program.Eval(variables)
 	if err != nil {
 		// Check if this was a cost limit error and include cost information in error
 		if details != nil && details.ActualCost() != nil {
@@ -162,10 +268,25 @@ func (e *celEngine) evaluateCondition(expression string, objData map[string]inte
 // getOrCompileProgram retrieves a cached program or compiles and caches a new one.
 func (e *celEngine) getOrCompileProgram(expression string) (cel.Program, error) {
 	// Check cache first
-	if cached, ok := e.programCache.Load(expression); ok {
+	if cached, ok := e.programCache.Get(expression); ok {
+		celProgramCacheTotal.WithLabelValues("hit").Inc()
 		return cached.(cel.Program), nil
 	}
+	celProgramCacheTotal.WithLabelValues("miss").Inc()
 
+	program, err := e.compileProgram(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the program
+	e.programCache.Add(expression, program)
+
+	return program, nil
+}
+
+// compileProgram parses, type-checks, and plans an expression into a program.
+func (e *celEngine) compileProgram(expression string) (cel.Program, error) {
 	// Parse the expression
 	ast, issues := e.env.Parse(expression)
 	if issues != nil && issues.Err() != nil {
@@ -187,8 +308,5 @@ func (e *celEngine) getOrCompileProgram(expression string) (cel.Program, error)
 		return nil, fmt.Errorf("program creation failed: %w", err)
 	}
 
-	// Cache the program
-	e.programCache.Store(expression, program)
-
 	return program, nil
 }
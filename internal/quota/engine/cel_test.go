@@ -0,0 +1,289 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+var benchmarkConditions = []quotav1alpha1.ConditionExpression{
+	{Expression: `trigger.metadata.namespace == "default" && trigger.spec.replicas > 1`},
+}
+
+func benchmarkTrigger() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "namespace": "default"},
+		"spec":     map[string]interface{}{"replicas": int64(3)},
+	}}
+}
+
+func TestCELEngine_ProgramCacheEviction(t *testing.T) {
+	e, err := NewCELEngineWithCacheSize(1)
+	if err != nil {
+		t.Fatalf("NewCELEngineWithCacheSize() error = %v", err)
+	}
+	ce := e.(*celEngine)
+
+	for _, expr := range []string{`"a"`, `"b"`} {
+		if _, err := e.EvaluateTemplateExpression(expr, nil); err != nil {
+			t.Fatalf("EvaluateTemplateExpression(%s) error = %v", expr, err)
+		}
+	}
+
+	if got := ce.programCache.Len(); got != 1 {
+		t.Errorf("programCache.Len() = %d, want 1", got)
+	}
+	if _, ok := ce.programCache.Get(`"a"`); ok {
+		t.Errorf("expected least recently used program to be evicted")
+	}
+	if _, ok := ce.programCache.Get(`"b"`); !ok {
+		t.Errorf("expected most recent program to be cached")
+	}
+}
+
+func TestNewCELEngineWithCacheSize_NonPositiveUsesDefault(t *testing.T) {
+	e, err := NewCELEngineWithCacheSize(0)
+	if err != nil {
+		t.Fatalf("NewCELEngineWithCacheSize() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := e.EvaluateTemplateExpression(`"a"`, nil); err != nil {
+			t.Fatalf("EvaluateTemplateExpression() error = %v", err)
+		}
+	}
+	if got := e.(*celEngine).programCache.Len(); got != 1 {
+		t.Errorf("programCache.Len() = %d, want 1", got)
+	}
+}
+
+// newCELEngineAt returns a CEL engine whose now() function reports the given time.
+func newCELEngineAt(t *testing.T, now time.Time) CELEngine {
+	t.Helper()
+	e, err := NewCELEngineWithClock(clocktesting.NewFakePassiveClock(now))
+	if err != nil {
+		t.Fatalf("NewCELEngineWithClock() error = %v", err)
+	}
+	return e
+}
+
+func TestCELEngine_Now(t *testing.T) {
+	businessHours := []quotav1alpha1.ConditionExpression{
+		{Expression: `now().getHours() >= 9 && now().getHours() < 17`},
+	}
+	amount := `now().getHours() >= 9 && now().getHours() < 17 ? 10 : 100`
+	obj := benchmarkTrigger()
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantMet    bool
+		wantAmount int64
+	}{
+		{
+			name:       "during business hours",
+			now:        time.Date(2025, time.March, 3, 10, 30, 0, 0, time.UTC),
+			wantMet:    true,
+			wantAmount: 10,
+		},
+		{
+			name:       "outside business hours",
+			now:        time.Date(2025, time.March, 3, 20, 0, 0, 0, time.UTC),
+			wantAmount: 100,
+		},
+		{
+			name:       "non-UTC clock is read in UTC",
+			now:        time.Date(2025, time.March, 3, 8, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60)),
+			wantMet:    true,
+			wantAmount: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newCELEngineAt(t, tt.now)
+
+			met, err := e.EvaluateConditions(businessHours, obj)
+			if err != nil {
+				t.Fatalf("EvaluateConditions() error = %v", err)
+			}
+			if met != tt.wantMet {
+				t.Errorf("EvaluateConditions() = %v, want %v", met, tt.wantMet)
+			}
+
+			got, err := e.EvaluateIntegerExpression(amount, map[string]interface{}{"trigger": obj.Object})
+			if err != nil {
+				t.Fatalf("EvaluateIntegerExpression() error = %v", err)
+			}
+			if got != tt.wantAmount {
+				t.Errorf("EvaluateIntegerExpression() = %d, want %d", got, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestCELEngine_NowIsReadAtEvaluation(t *testing.T) {
+	clk := clocktesting.NewFakePassiveClock(time.Date(2025, time.March, 3, 10, 0, 0, 0, time.UTC))
+	e, err := NewCELEngineWithClock(clk)
+	if err != nil {
+		t.Fatalf("NewCELEngineWithClock() error = %v", err)
+	}
+	conditions := []quotav1alpha1.ConditionExpression{{Expression: `now().getHours() < 17`}}
+
+	for _, tc := range []struct {
+		hour int
+		want bool
+	}{{10, true}, {18, false}} {
+		clk.SetTime(time.Date(2025, time.March, 3, tc.hour, 0, 0, 0, time.UTC))
+		got, err := e.EvaluateConditions(conditions, benchmarkTrigger())
+		if err != nil {
+			t.Fatalf("EvaluateConditions() error = %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("at %02d:00 EvaluateConditions() = %v, want %v", tc.hour, got, tc.want)
+		}
+	}
+}
+
+// staticOrganizationTiers is an OrganizationTierLister backed by a map.
+type staticOrganizationTiers map[string]string
+
+func (s staticOrganizationTiers) OrganizationTier(name string) (string, error) {
+	tier, ok := s[name]
+	if !ok {
+		return "", fmt.Errorf("organization %s not found", name)
+	}
+	return tier, nil
+}
+
+func TestCELEngine_CustomFunctions(t *testing.T) {
+	e, err := NewCELEngineWithOrganizationTiers(staticOrganizationTiers{"acme": "Standard"})
+	if err != nil {
+		t.Fatalf("NewCELEngineWithOrganizationTiers() error = %v", err)
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":   "data",
+			"labels": map[string]interface{}{"tier": "premium"},
+		},
+		"spec": map[string]interface{}{
+			"storage": "10Gi",
+			"consumerRef": map[string]interface{}{
+				"apiGroup": "resourcemanager.miloapis.com",
+				"kind":     "Organization",
+				"name":     "acme",
+			},
+			"projectRef": map[string]interface{}{"kind": "Project", "name": "web"},
+		},
+	}}
+
+	tests := []struct {
+		expression string
+		want       bool
+		wantErr    bool
+	}{
+		{expression: `quantity(trigger.spec.storage) > 1000`, want: true},
+		{expression: `quantity(trigger.spec.storage) == 10737418240`, want: true},
+		{expression: `quantity("500m") == 1`, want: true},
+		{expression: `quantity("ten") > 0`, wantErr: true},
+		{expression: `hasLabel(trigger, "tier")`, want: true},
+		{expression: `hasLabel(trigger, "team")`, want: false},
+		{expression: `orgTier(trigger.spec.consumerRef) == "Standard"`, want: true},
+		{expression: `orgTier(trigger.spec.projectRef) == "Standard"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			conditions := []quotav1alpha1.ConditionExpression{{Expression: tt.expression}}
+			if err := e.ValidateConstraints(conditions); err != nil {
+				t.Fatalf("ValidateConstraints() error = %v", err)
+			}
+
+			got, err := e.EvaluateConditions(conditions, obj)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("EvaluateConditions() expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EvaluateConditions() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateConditions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCELEngine_CustomFunctionsAreTypeChecked(t *testing.T) {
+	e, err := NewCELEngine()
+	if err != nil {
+		t.Fatalf("NewCELEngine() error = %v", err)
+	}
+
+	for _, expression := range []string{
+		`quantity(1) > 0`,
+		`hasLabel(trigger) == true`,
+		`orgTier(trigger.spec.consumerRef, "extra") == "Standard"`,
+	} {
+		conditions := []quotav1alpha1.ConditionExpression{{Expression: expression}}
+		if err := e.ValidateConstraints(conditions); err == nil {
+			t.Errorf("ValidateConstraints(%s) expected error", expression)
+		}
+	}
+
+	// Without a lister, orgTier() type-checks but cannot be evaluated.
+	conditions := []quotav1alpha1.ConditionExpression{{Expression: `orgTier({"kind": "Organization", "name": "acme"}) == "Standard"`}}
+	if err := e.ValidateConstraints(conditions); err != nil {
+		t.Fatalf("ValidateConstraints() error = %v", err)
+	}
+	if _, err := e.EvaluateConditions(conditions, benchmarkTrigger()); err == nil {
+		t.Errorf("EvaluateConditions() expected error without an organization tier lister")
+	}
+}
+
+// BenchmarkEvaluateConditions_Cached measures a repeated trigger constraint served from
+// the program cache, as on the admission hot path.
+func BenchmarkEvaluateConditions_Cached(b *testing.B) {
+	e, err := NewCELEngine()
+	if err != nil {
+		b.Fatalf("NewCELEngine() error = %v", err)
+	}
+	obj := benchmarkTrigger()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := e.EvaluateConditions(benchmarkConditions, obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkEvaluateConditions_Uncached compiles the constraint on every call, the cost
+// each admission request paid before programs were cached.
+func BenchmarkEvaluateConditions_Uncached(b *testing.B) {
+	e, err := NewCELEngine()
+	if err != nil {
+		b.Fatalf("NewCELEngine() error = %v", err)
+	}
+	ce := e.(*celEngine)
+	vars := map[string]interface{}{"trigger": benchmarkTrigger().Object}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		program, err := ce.compileProgram(benchmarkConditions[0].Expression)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, _, err := program.Eval(vars); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	quotacel "go.miloapis.com/milo/internal/quota/cel"
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+)
+
+// organizationTierReader implements quotacel.OrganizationTierLister by reading Organizations
+// through a client.Reader. An organization's tier is its spec.type.
+type organizationTierReader struct {
+	reader client.Reader
+}
+
+// NewOrganizationTierLister returns a lister for the orgTier() CEL function that reads
+// Organizations through reader, typically a manager's informer-backed cache.
+func NewOrganizationTierLister(reader client.Reader) quotacel.OrganizationTierLister {
+	return &organizationTierReader{reader: reader}
+}
+
+// OrganizationTier returns the type of the named Organization.
+func (r *organizationTierReader) OrganizationTier(name string) (string, error) {
+	var organization resourcemanagerv1alpha1.Organization
+	if err := r.reader.Get(context.TODO(), client.ObjectKey{Name: name}, &organization); err != nil {
+		return "", fmt.Errorf("failed to get organization %s: %w", name, err)
+	}
+	return organization.Spec.Type, nil
+}
+
+// organizationsGVR identifies Organizations for dynamic client lookups.
+var organizationsGVR = schema.GroupVersionResource{
+	Group:    resourcemanagerv1alpha1.GroupVersion.Group,
+	Version:  resourcemanagerv1alpha1.GroupVersion.Version,
+	Resource: "organizations",
+}
+
+const (
+	// organizationResyncPeriod is how often the Organization informer of the dynamic
+	// lister resyncs its cache.
+	organizationResyncPeriod = 10 * time.Minute
+
+	// organizationLookupTimeout bounds the direct read of an Organization made before the
+	// informer cache has synced.
+	organizationLookupTimeout = 5 * time.Second
+)
+
+// dynamicOrganizationTierReader implements quotacel.OrganizationTierLister by reading
+// Organizations through a dynamic client, for callers without a manager cache such as
+// the quota admission plugin. Organizations are served from an informer started by the
+// first lookup, so only deployments with policies that call orgTier() watch them;
+// lookups made before the informer has synced read the Organization directly.
+type dynamicOrganizationTierReader struct {
+	client   dynamic.Interface
+	start    sync.Once
+	informer cache.SharedIndexInformer
+}
+
+// NewDynamicOrganizationTierLister returns a lister for the orgTier() CEL function that
+// reads Organizations through a dynamic client of the control plane that stores them.
+func NewDynamicOrganizationTierLister(client dynamic.Interface) quotacel.OrganizationTierLister {
+	return &dynamicOrganizationTierReader{client: client}
+}
+
+// OrganizationTier returns the type of the named Organization.
+func (r *dynamicOrganizationTierReader) OrganizationTier(name string) (string, error) {
+	r.start.Do(r.startInformer)
+
+	organization, err := r.getOrganization(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get organization %s: %w", name, err)
+	}
+	tier, _, err := unstructured.NestedString(organization.Object, "spec", "type")
+	if err != nil {
+		return "", fmt.Errorf("failed to read type of organization %s: %w", name, err)
+	}
+	return tier, nil
+}
+
+// startInformer starts watching Organizations for the lifetime of the process.
+func (r *dynamicOrganizationTierReader) startInformer() {
+	r.informer = cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return r.client.Resource(organizationsGVR).List(context.Background(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return r.client.Resource(organizationsGVR).Watch(context.Background(), options)
+			},
+		},
+		&unstructured.Unstructured{},
+		organizationResyncPeriod,
+		cache.Indexers{},
+	)
+	go r.informer.Run(wait.NeverStop)
+}
+
+// getOrganization returns the named Organization from the informer cache, or reads it
+// directly while the cache has not synced yet.
+func (r *dynamicOrganizationTierReader) getOrganization(name string) (*unstructured.Unstructured, error) {
+	if r.informer.HasSynced() {
+		obj, exists, err := r.informer.GetStore().GetByKey(name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, apierrors.NewNotFound(organizationsGVR.GroupResource(), name)
+		}
+		return obj.(*unstructured.Unstructured), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), organizationLookupTimeout)
+	defer cancel()
+	return r.client.Resource(organizationsGVR).Get(ctx, name, metav1.GetOptions{})
+}
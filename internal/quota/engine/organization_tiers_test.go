@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func newTestOrganization(name, tier string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+		"kind":       "Organization",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec":       map[string]interface{}{"type": tier},
+	}}
+}
+
+func TestDynamicOrganizationTierListerServesFromCache(t *testing.T) {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{organizationsGVR: "OrganizationList"},
+		newTestOrganization("acme", "Standard"))
+	lister := NewDynamicOrganizationTierLister(client).(*dynamicOrganizationTierReader)
+
+	// The first lookup starts the informer and may read the Organization directly
+	if tier, err := lister.OrganizationTier("acme"); err != nil || tier != "Standard" {
+		t.Fatalf("OrganizationTier() = %q, %v; want Standard", tier, err)
+	}
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true,
+		func(context.Context) (bool, error) { return lister.informer.HasSynced(), nil }); err != nil {
+		t.Fatalf("informer did not sync: %v", err)
+	}
+
+	if _, err := client.Resource(organizationsGVR).Update(context.Background(), newTestOrganization("acme", "Personal"), metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update organization: %v", err)
+	}
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true,
+		func(context.Context) (bool, error) {
+			tier, err := lister.OrganizationTier("acme")
+			return err == nil && tier == "Personal", nil
+		}); err != nil {
+		t.Fatalf("expected the updated tier to be observed: %v", err)
+	}
+
+	client.ClearActions()
+	if tier, err := lister.OrganizationTier("acme"); err != nil || tier != "Personal" {
+		t.Fatalf("OrganizationTier() = %q, %v; want Personal", tier, err)
+	}
+	if _, err := lister.OrganizationTier("missing"); err == nil {
+		t.Error("expected an error for a missing organization")
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "get" {
+			t.Errorf("expected lookups to be served from the informer cache, got a get of %s", action.GetResource().Resource)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -74,12 +75,47 @@ type PolicyEngine interface {
 	Close()
 }
 
+// PolicyChangeNotifier is implemented by policy engines that can report changes to
+// the set of active ClaimCreationPolicies, allowing callers to invalidate derived caches.
+type PolicyChangeNotifier interface {
+	// AddPolicyChangeHandler registers a handler that is invoked after the engine has
+	// applied a ClaimCreationPolicy add, update, or delete to its index.
+	AddPolicyChangeHandler(handler func())
+}
+
+// PolicyLister is implemented by policy engines that can enumerate the active
+// ClaimCreationPolicies in their index, for checks that span more than one GVK.
+type PolicyLister interface {
+	// ListPolicies returns each active, enabled policy once, ordered by name.
+	ListPolicies() []*quotav1alpha1.ClaimCreationPolicy
+}
+
+// UnreadyPolicyLookup is implemented by policy engines that remember enabled
+// ClaimCreationPolicies that are not Ready, so callers can explain why a resource the
+// policy triggers on was not charged.
+type UnreadyPolicyLookup interface {
+	// GetUnreadyPolicyForGVK returns an enabled policy that triggers on gvk but is not
+	// Ready, or nil if there is none.
+	GetUnreadyPolicyForGVK(gvk schema.GroupVersionKind) *quotav1alpha1.ClaimCreationPolicy
+}
+
+// PolicyEngineOptions configures optional policy engine behavior.
+type PolicyEngineOptions struct {
+	// MatchAnyVersion lets a policy that triggers on one version of a group and kind
+	// apply to the others, so a resource served at several API versions is charged
+	// against the same quota whichever version it is created through. A policy that
+	// triggers on the requested version exactly always takes precedence.
+	MatchAnyVersion bool
+}
+
 // policyEngine implements PolicyEngine with shared informer support.
 type policyEngine struct {
 	dynamicClient dynamic.Interface
 	logger        logr.Logger
+	options       PolicyEngineOptions
 	mu            sync.RWMutex
 	gvkIndex      sync.Map // map[string]*quotav1alpha1.ClaimCreationPolicy
+	unreadyIndex  sync.Map // map[string]*quotav1alpha1.ClaimCreationPolicy, enabled policies that are not Ready
 	initialized   bool
 
 	// Shared informer management
@@ -88,14 +124,30 @@ type policyEngine struct {
 	workqueue    workqueue.TypedRateLimitingInterface[types.NamespacedName]
 	startOnce    sync.Once
 	resyncPeriod time.Duration
+
+	// Handlers notified after the policy index changes
+	changeHandlers []func()
 }
 
+var (
+	_ PolicyChangeNotifier = &policyEngine{}
+	_ PolicyLister         = &policyEngine{}
+	_ UnreadyPolicyLookup  = &policyEngine{}
+)
+
 // NewPolicyEngine creates a policy engine that uses shared informer for policy access.
 // Call Start() to begin loading and watching policies.
 func NewPolicyEngine(dynamicClient dynamic.Interface, logger logr.Logger) PolicyEngine {
+	return NewPolicyEngineWithOptions(dynamicClient, logger, PolicyEngineOptions{})
+}
+
+// NewPolicyEngineWithOptions creates a policy engine with the given options.
+// Call Start() to begin loading and watching policies.
+func NewPolicyEngineWithOptions(dynamicClient dynamic.Interface, logger logr.Logger, options PolicyEngineOptions) PolicyEngine {
 	return &policyEngine{
 		dynamicClient: dynamicClient,
 		logger:        logger.WithName("policy-engine"),
+		options:       options,
 		gvkIndex:      sync.Map{},
 		initialized:   false,
 		stopCh:        make(chan struct{}),
@@ -185,21 +237,90 @@ func (e *policyEngine) Start(ctx context.Context) error {
 func (e *policyEngine) GetPolicyForGVK(gvk schema.GroupVersionKind) (*quotav1alpha1.ClaimCreationPolicy, error) {
 	e.logger.V(1).Info("Looking up policy for GVK", "gvk", gvk.String())
 
-	if value, ok := e.gvkIndex.Load(gvk.String()); ok {
-		if policy, ok := value.(*quotav1alpha1.ClaimCreationPolicy); ok {
-			// Skip disabled policies
-			if policy.Spec.Disabled != nil && *policy.Spec.Disabled {
-				return nil, nil // Policy exists but is disabled
-			}
-			e.logger.V(1).Info("Found policy for GVK", "gvk", gvk.String(), "policy", policy.Name)
-			return policy, nil
+	if policy := e.lookup(&e.gvkIndex, gvk); policy != nil {
+		// Skip disabled policies
+		if policy.Spec.Disabled != nil && *policy.Spec.Disabled {
+			return nil, nil // Policy exists but is disabled
 		}
+		e.logger.V(1).Info("Found policy for GVK", "gvk", gvk.String(), "policy", policy.Name)
+		return policy, nil
 	}
 
 	e.logger.V(3).Info("No policy found for GVK", "gvk", gvk.String())
 	return nil, nil // No policy found for this GVK
 }
 
+// ListPolicies returns each active, enabled policy in the index once, ordered by name.
+func (e *policyEngine) ListPolicies() []*quotav1alpha1.ClaimCreationPolicy {
+	seen := make(map[string]bool)
+	var policies []*quotav1alpha1.ClaimCreationPolicy
+	e.gvkIndex.Range(func(_, value interface{}) bool {
+		policy := value.(*quotav1alpha1.ClaimCreationPolicy)
+		if seen[policy.Name] || (policy.Spec.Disabled != nil && *policy.Spec.Disabled) {
+			return true
+		}
+		seen[policy.Name] = true
+		policies = append(policies, policy)
+		return true
+	})
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+	return policies
+}
+
+// GetUnreadyPolicyForGVK returns an enabled policy that triggers on gvk but is not Ready.
+func (e *policyEngine) GetUnreadyPolicyForGVK(gvk schema.GroupVersionKind) *quotav1alpha1.ClaimCreationPolicy {
+	return e.lookup(&e.unreadyIndex, gvk)
+}
+
+// lookup returns the policy indexed under gvk. With MatchAnyVersion, a miss falls back
+// to a policy indexed under another version of the same group and kind, choosing the
+// one that sorts first by name when several do so the choice is stable.
+func (e *policyEngine) lookup(index *sync.Map, gvk schema.GroupVersionKind) *quotav1alpha1.ClaimCreationPolicy {
+	if value, ok := index.Load(gvk.String()); ok {
+		return value.(*quotav1alpha1.ClaimCreationPolicy)
+	}
+	if !e.options.MatchAnyVersion {
+		return nil
+	}
+
+	var match *quotav1alpha1.ClaimCreationPolicy
+	index.Range(func(_, value interface{}) bool {
+		policy := value.(*quotav1alpha1.ClaimCreationPolicy)
+		if match != nil && match.Name <= policy.Name {
+			return true
+		}
+		for _, trigger := range policy.Spec.Trigger.GetGVKs() {
+			if trigger.GroupKind() == gvk.GroupKind() {
+				match = policy
+				break
+			}
+		}
+		return true
+	})
+	if match != nil {
+		e.logger.V(1).Info("Matched policy triggering on another version", "gvk", gvk.String(), "policy", match.Name)
+	}
+	return match
+}
+
+// AddPolicyChangeHandler registers a handler invoked after each processed policy event.
+func (e *policyEngine) AddPolicyChangeHandler(handler func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.changeHandlers = append(e.changeHandlers, handler)
+}
+
+// notifyPolicyChange invokes all registered policy change handlers.
+func (e *policyEngine) notifyPolicyChange() {
+	e.mu.RLock()
+	handlers := e.changeHandlers
+	e.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler()
+	}
+}
+
 // handlePolicyEvent handles ClaimCreationPolicy events from the shared informer
 func (e *policyEngine) handlePolicyEvent(obj interface{}) {
 	unstructuredObj, ok := obj.(*unstructured.Unstructured)
@@ -249,6 +370,8 @@ func (e *policyEngine) processPolicyEvent(ctx context.Context, key types.Namespa
 	if policy == nil {
 		// Policy was deleted - remove from our cache
 		e.removePolicy(key.Name)
+		e.setUnreadyPolicy(key.Name, nil)
+		e.notifyPolicyChange()
 		e.logger.V(1).Info("Policy deleted, removed from cache", "policy", key.Name)
 		return nil
 	}
@@ -263,6 +386,7 @@ func (e *policyEngine) processPolicyEvent(ctx context.Context, key types.Namespa
 	if err := e.updatePolicy(&claimPolicy); err != nil {
 		return fmt.Errorf("failed to update policy: %w", err)
 	}
+	e.notifyPolicyChange()
 
 	return nil
 }
@@ -295,44 +419,58 @@ func (e *policyEngine) updatePolicy(policy *quotav1alpha1.ClaimCreationPolicy) e
 
 	policyLoadTotal.Inc()
 
+	disabled := policy.Spec.Disabled != nil && *policy.Spec.Disabled
+
 	// Only process policies with Ready=True status
 	if !e.isPolicyReady(policy) {
 		e.logger.V(1).Info("Policy not ready, skipping update", "policy", policy.Name)
 		e.removePolicy(policy.Name)
+		if disabled {
+			e.setUnreadyPolicy(policy.Name, nil)
+		} else {
+			e.setUnreadyPolicy(policy.Name, policy)
+		}
 		return nil
 	}
+	e.setUnreadyPolicy(policy.Name, nil)
 
-	gvk := policy.Spec.Trigger.Resource.GetGVK()
-	gvkKey := gvk.String()
+	gvks := policy.Spec.Trigger.GetGVKs()
 
 	// Check if policy is disabled
-	if policy.Spec.Disabled != nil && *policy.Spec.Disabled {
+	if disabled {
 		// Remove disabled policy from cache
 		e.removePolicy(policy.Name)
-		e.logger.V(1).Info("Policy disabled, removed from cache", "policy", policy.Name, "gvk", gvk)
+		e.logger.V(1).Info("Policy disabled, removed from cache", "policy", policy.Name, "gvks", gvks)
 		return nil
 	}
 
-	// Check for conflicts - only one policy per GVK is allowed
-	if existing, exists := e.gvkIndex.Load(gvkKey); exists {
-		existingPolicy := existing.(*quotav1alpha1.ClaimCreationPolicy)
-		if existingPolicy.Name != policy.Name {
-			e.logger.Error(nil, "Multiple policies found for same GVK, replacing existing",
-				"gvk", gvk,
-				"existing", existingPolicy.Name,
-				"new", policy.Name)
+	// Index the policy under every trigger GVK before dropping keys it no longer
+	// triggers on, so lookups never miss a policy that is being updated
+	stored := policy.DeepCopy()
+	keys := make(map[string]bool, len(gvks))
+	for _, gvk := range gvks {
+		gvkKey := gvk.String()
+		keys[gvkKey] = true
+
+		// Check for conflicts - only one policy per GVK is allowed
+		if existing, exists := e.gvkIndex.Load(gvkKey); exists {
+			existingPolicy := existing.(*quotav1alpha1.ClaimCreationPolicy)
+			if existingPolicy.Name != policy.Name {
+				e.logger.Error(nil, "Multiple policies found for same GVK, replacing existing",
+					"gvk", gvk,
+					"existing", existingPolicy.Name,
+					"new", policy.Name)
+			}
 		}
-	} else {
-		// New policy being added
-		policyActiveGauge.Inc()
-	}
 
-	// Store the policy in the cache
-	e.gvkIndex.Store(gvkKey, policy.DeepCopy())
+		// Store the policy in the cache
+		e.gvkIndex.Store(gvkKey, stored)
+	}
+	e.removePolicyKeys(policy.Name, keys)
 
 	e.logger.V(1).Info("Policy updated in cache",
 		"policy", policy.Name,
-		"gvk", gvk,
+		"gvks", gvks,
 		"ready", true,
 		"disabled", policy.Spec.Disabled != nil && *policy.Spec.Disabled)
 
@@ -341,26 +479,55 @@ func (e *policyEngine) updatePolicy(policy *quotav1alpha1.ClaimCreationPolicy) e
 
 // removePolicy removes a policy from the cache by name.
 func (e *policyEngine) removePolicy(policyName string) {
+	e.removePolicyKeys(policyName, nil)
+}
+
+// removePolicyKeys removes the named policy from every GVK key except those in keep,
+// then refreshes the active policy gauge.
+func (e *policyEngine) removePolicyKeys(policyName string, keep map[string]bool) {
 	// Since we need to find the policy by name but our index is by GVK,
-	// we need to iterate through the cache to find the policy with the matching name
-	var gvkKeyToRemove *string
+	// we need to iterate through the cache to find the keys with the matching name
+	var gvkKeysToRemove []string
+	active := make(map[string]bool)
 
 	e.gvkIndex.Range(func(key, value interface{}) bool {
 		gvkKey := key.(string)
 		policy := value.(*quotav1alpha1.ClaimCreationPolicy)
 
-		if policy.Name == policyName {
-			gvkKeyToRemove = &gvkKey
-			return false // Stop iteration
+		if policy.Name == policyName && !keep[gvkKey] {
+			gvkKeysToRemove = append(gvkKeysToRemove, gvkKey)
+		} else {
+			active[policy.Name] = true
 		}
 		return true // Continue iteration
 	})
 
-	if gvkKeyToRemove != nil {
-		e.gvkIndex.Delete(*gvkKeyToRemove)
-		policyActiveGauge.Dec()
-		e.logger.V(1).Info("Policy removed from cache", "policy", policyName, "gvkKey", *gvkKeyToRemove)
+	for _, gvkKey := range gvkKeysToRemove {
+		e.gvkIndex.Delete(gvkKey)
+		e.logger.V(1).Info("Policy removed from cache", "policy", policyName, "gvkKey", gvkKey)
 	}
+	policyActiveGauge.Set(float64(len(active)))
+}
+
+// setUnreadyPolicy indexes policy under its trigger GVKs as enabled but not Ready,
+// dropping any GVKs the named policy no longer triggers on. A nil policy forgets the
+// named policy entirely.
+func (e *policyEngine) setUnreadyPolicy(policyName string, policy *quotav1alpha1.ClaimCreationPolicy) {
+	keys := make(map[string]bool)
+	if policy != nil {
+		stored := policy.DeepCopy()
+		for _, gvk := range policy.Spec.Trigger.GetGVKs() {
+			keys[gvk.String()] = true
+			e.unreadyIndex.Store(gvk.String(), stored)
+		}
+	}
+
+	e.unreadyIndex.Range(func(key, value interface{}) bool {
+		if value.(*quotav1alpha1.ClaimCreationPolicy).Name == policyName && !keys[key.(string)] {
+			e.unreadyIndex.Delete(key)
+		}
+		return true
+	})
 }
 
 // isPolicyReady checks if a ClaimCreationPolicy has Ready=True status condition
@@ -0,0 +1,224 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func newReadyPolicy(name string, resources ...quotav1alpha1.ClaimTriggerResource) *quotav1alpha1.ClaimCreationPolicy {
+	policy := &quotav1alpha1.ClaimCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: quotav1alpha1.ClaimCreationPolicyStatus{
+			Conditions: []metav1.Condition{{
+				Type:   quotav1alpha1.ClaimCreationPolicyReady,
+				Status: metav1.ConditionTrue,
+			}},
+		},
+	}
+	if len(resources) == 1 {
+		policy.Spec.Trigger.Resource = &resources[0]
+	} else {
+		policy.Spec.Trigger.Resources = resources
+	}
+	return policy
+}
+
+func TestUpdatePolicyIndexesEveryTriggerKind(t *testing.T) {
+	deployment := quotav1alpha1.ClaimTriggerResource{APIVersion: "apps/v1", Kind: "Deployment"}
+	statefulSet := quotav1alpha1.ClaimTriggerResource{APIVersion: "apps/v1", Kind: "StatefulSet"}
+	daemonSet := quotav1alpha1.ClaimTriggerResource{APIVersion: "apps/v1", Kind: "DaemonSet"}
+
+	lookup := func(t *testing.T, e *policyEngine, kind string) string {
+		t.Helper()
+		policy, err := e.GetPolicyForGVK(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: kind})
+		if err != nil {
+			t.Fatalf("GetPolicyForGVK(%s) returned error: %v", kind, err)
+		}
+		if policy == nil {
+			return ""
+		}
+		return policy.Name
+	}
+
+	t.Run("indexes each kind and drops kinds removed by an update", func(t *testing.T) {
+		e := &policyEngine{logger: logr.Discard()}
+
+		if err := e.updatePolicy(newReadyPolicy("workloads", deployment, statefulSet)); err != nil {
+			t.Fatalf("updatePolicy returned error: %v", err)
+		}
+		if got := lookup(t, e, "Deployment"); got != "workloads" {
+			t.Errorf("Deployment policy = %q, want workloads", got)
+		}
+		if got := lookup(t, e, "StatefulSet"); got != "workloads" {
+			t.Errorf("StatefulSet policy = %q, want workloads", got)
+		}
+
+		if err := e.updatePolicy(newReadyPolicy("workloads", deployment, daemonSet)); err != nil {
+			t.Fatalf("updatePolicy returned error: %v", err)
+		}
+		if got := lookup(t, e, "StatefulSet"); got != "" {
+			t.Errorf("StatefulSet policy = %q, want none after the kind was removed", got)
+		}
+		if got := lookup(t, e, "DaemonSet"); got != "workloads" {
+			t.Errorf("DaemonSet policy = %q, want workloads", got)
+		}
+	})
+
+	t.Run("removing the policy clears every kind", func(t *testing.T) {
+		e := &policyEngine{logger: logr.Discard()}
+
+		if err := e.updatePolicy(newReadyPolicy("workloads", deployment, statefulSet)); err != nil {
+			t.Fatalf("updatePolicy returned error: %v", err)
+		}
+		if err := e.updatePolicy(newReadyPolicy("deployments", deployment)); err != nil {
+			t.Fatalf("updatePolicy returned error: %v", err)
+		}
+		e.removePolicy("workloads")
+
+		if got := lookup(t, e, "StatefulSet"); got != "" {
+			t.Errorf("StatefulSet policy = %q, want none", got)
+		}
+		if got := lookup(t, e, "Deployment"); got != "deployments" {
+			t.Errorf("Deployment policy = %q, want deployments", got)
+		}
+	})
+}
+
+func TestListPoliciesReturnsEachPolicyOnce(t *testing.T) {
+	deployment := quotav1alpha1.ClaimTriggerResource{APIVersion: "apps/v1", Kind: "Deployment"}
+	statefulSet := quotav1alpha1.ClaimTriggerResource{APIVersion: "apps/v1", Kind: "StatefulSet"}
+	daemonSet := quotav1alpha1.ClaimTriggerResource{APIVersion: "apps/v1", Kind: "DaemonSet"}
+
+	e := &policyEngine{logger: logr.Discard()}
+	if err := e.updatePolicy(newReadyPolicy("workloads", deployment, statefulSet)); err != nil {
+		t.Fatalf("updatePolicy returned error: %v", err)
+	}
+	if err := e.updatePolicy(newReadyPolicy("daemons", daemonSet)); err != nil {
+		t.Fatalf("updatePolicy returned error: %v", err)
+	}
+
+	policies := e.ListPolicies()
+	if len(policies) != 2 || policies[0].Name != "daemons" || policies[1].Name != "workloads" {
+		names := make([]string, 0, len(policies))
+		for _, policy := range policies {
+			names = append(names, policy.Name)
+		}
+		t.Errorf("ListPolicies() = %v, want [daemons workloads]", names)
+	}
+}
+
+func TestUnreadyPoliciesAreTrackedSeparately(t *testing.T) {
+	deployment := quotav1alpha1.ClaimTriggerResource{APIVersion: "apps/v1", Kind: "Deployment"}
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	unreadyName := func(e *policyEngine) string {
+		if policy := e.GetUnreadyPolicyForGVK(gvk); policy != nil {
+			return policy.Name
+		}
+		return ""
+	}
+
+	e := &policyEngine{logger: logr.Discard()}
+	policy := newReadyPolicy("deployments", deployment)
+	policy.Status.Conditions[0].Status = metav1.ConditionFalse
+
+	if err := e.updatePolicy(policy); err != nil {
+		t.Fatalf("updatePolicy returned error: %v", err)
+	}
+	if active, _ := e.GetPolicyForGVK(gvk); active != nil {
+		t.Errorf("GetPolicyForGVK() = %q, want no active policy while not Ready", active.Name)
+	}
+	if got := unreadyName(e); got != "deployments" {
+		t.Errorf("unready policy = %q, want deployments", got)
+	}
+
+	policy.Status.Conditions[0].Status = metav1.ConditionTrue
+	if err := e.updatePolicy(policy); err != nil {
+		t.Fatalf("updatePolicy returned error: %v", err)
+	}
+	if got := unreadyName(e); got != "" {
+		t.Errorf("unready policy = %q, want none once Ready", got)
+	}
+
+	disabled := true
+	policy.Status.Conditions[0].Status = metav1.ConditionFalse
+	policy.Spec.Disabled = &disabled
+	if err := e.updatePolicy(policy); err != nil {
+		t.Fatalf("updatePolicy returned error: %v", err)
+	}
+	if got := unreadyName(e); got != "" {
+		t.Errorf("unready policy = %q, want none while disabled", got)
+	}
+}
+
+func TestMatchAnyVersion(t *testing.T) {
+	alpha := quotav1alpha1.ClaimTriggerResource{APIVersion: "networking.miloapis.com/v1alpha1", Kind: "Gateway"}
+	beta := quotav1alpha1.ClaimTriggerResource{APIVersion: "networking.miloapis.com/v1beta1", Kind: "Gateway"}
+	betaGVK := schema.GroupVersionKind{Group: "networking.miloapis.com", Version: "v1beta1", Kind: "Gateway"}
+	otherKindGVK := schema.GroupVersionKind{Group: "networking.miloapis.com", Version: "v1beta1", Kind: "Route"}
+
+	lookup := func(t *testing.T, e *policyEngine, gvk schema.GroupVersionKind) string {
+		t.Helper()
+		policy, err := e.GetPolicyForGVK(gvk)
+		if err != nil {
+			t.Fatalf("GetPolicyForGVK(%s) returned error: %v", gvk, err)
+		}
+		if policy == nil {
+			return ""
+		}
+		return policy.Name
+	}
+
+	t.Run("exact version required by default", func(t *testing.T) {
+		e := &policyEngine{logger: logr.Discard()}
+		if err := e.updatePolicy(newReadyPolicy("gateways", alpha)); err != nil {
+			t.Fatalf("updatePolicy returned error: %v", err)
+		}
+		if got := lookup(t, e, betaGVK); got != "" {
+			t.Errorf("v1beta1 policy = %q, want none", got)
+		}
+	})
+
+	t.Run("other versions of the same kind match", func(t *testing.T) {
+		e := &policyEngine{logger: logr.Discard(), options: PolicyEngineOptions{MatchAnyVersion: true}}
+		if err := e.updatePolicy(newReadyPolicy("gateways", alpha)); err != nil {
+			t.Fatalf("updatePolicy returned error: %v", err)
+		}
+		if got := lookup(t, e, betaGVK); got != "gateways" {
+			t.Errorf("v1beta1 policy = %q, want gateways", got)
+		}
+		if got := lookup(t, e, otherKindGVK); got != "" {
+			t.Errorf("Route policy = %q, want none", got)
+		}
+	})
+
+	t.Run("exact version takes precedence", func(t *testing.T) {
+		e := &policyEngine{logger: logr.Discard(), options: PolicyEngineOptions{MatchAnyVersion: true}}
+		if err := e.updatePolicy(newReadyPolicy("a-gateways", alpha)); err != nil {
+			t.Fatalf("updatePolicy returned error: %v", err)
+		}
+		if err := e.updatePolicy(newReadyPolicy("z-gateways", beta)); err != nil {
+			t.Fatalf("updatePolicy returned error: %v", err)
+		}
+		if got := lookup(t, e, betaGVK); got != "z-gateways" {
+			t.Errorf("v1beta1 policy = %q, want z-gateways", got)
+		}
+	})
+
+	t.Run("unready policies match other versions", func(t *testing.T) {
+		e := &policyEngine{logger: logr.Discard(), options: PolicyEngineOptions{MatchAnyVersion: true}}
+		policy := newReadyPolicy("gateways", alpha)
+		policy.Status.Conditions[0].Status = metav1.ConditionFalse
+		if err := e.updatePolicy(policy); err != nil {
+			t.Fatalf("updatePolicy returned error: %v", err)
+		}
+		if unready := e.GetUnreadyPolicyForGVK(betaGVK); unready == nil || unready.Name != "gateways" {
+			t.Errorf("GetUnreadyPolicyForGVK(v1beta1) = %v, want gateways", unready)
+		}
+	})
+}
@@ -7,9 +7,12 @@ import (
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apiserver/pkg/endpoints/request"
 
 	"go.miloapis.com/milo/internal/quota/templateutil"
+	"go.miloapis.com/milo/internal/quota/validation"
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
 )
 
@@ -18,35 +21,34 @@ type TemplateEngine interface {
 	// RenderClaim renders a complete ResourceClaim from a ClaimCreationPolicy.
 	RenderClaim(policy *quotav1alpha1.ClaimCreationPolicy, evalContext *EvaluationContext) (*quotav1alpha1.ResourceClaim, error)
 
-	// RenderGrant renders a complete ResourceGrant from a GrantCreationPolicy.
-	RenderGrant(policy *quotav1alpha1.GrantCreationPolicy, triggerObj *unstructured.Unstructured) (*quotav1alpha1.ResourceGrant, error)
+	// RenderGrant renders a complete ResourceGrant from a GrantCreationPolicy. Grant templates
+	// only see the trigger object of the evaluation context.
+	RenderGrant(policy *quotav1alpha1.GrantCreationPolicy, evalContext *EvaluationContext) (*quotav1alpha1.ResourceGrant, error)
 
 	// EvaluateConditions evaluates trigger conditions against a resource object.
 	EvaluateConditions(conditions []quotav1alpha1.ConditionExpression, obj *unstructured.Unstructured) (bool, error)
+
+	// EvaluateClaimConditions evaluates ClaimCreationPolicy trigger conditions with the same
+	// variables as claim templates, so constraints can also read the user, request, and parent.
+	EvaluateClaimConditions(conditions []quotav1alpha1.ConditionExpression, evalContext *EvaluationContext) (bool, error)
 }
 
-// EvaluationContext provides context for template evaluation in admission scenarios.
+// EvaluationContext provides context for template evaluation. Admission fills in every field
+// when rendering claims; grant creation only sets the trigger Object.
 type EvaluationContext struct {
 	Object      *unstructured.Unstructured
 	User        UserContext
 	RequestInfo *request.RequestInfo
-	Namespace   string
-	GVK         struct {
+	// Parent is the Organization that owns the project the request was made in, if resolved.
+	Parent    *unstructured.Unstructured
+	Namespace string
+	GVK       struct {
 		Group   string
 		Version string
 		Kind    string
 	}
 }
 
-// GrantEvaluationContext provides context for template evaluation in grant creation scenarios.
-type GrantEvaluationContext struct {
-	Object         *unstructured.Unstructured
-	ParentContext  map[string]interface{}
-	ResourceType   string
-	ConsumerRef    quotav1alpha1.ConsumerRef
-	RequestContext map[string]interface{}
-}
-
 // UserContext provides user information for template evaluation.
 type UserContext struct {
 	Name   string
@@ -83,8 +85,19 @@ func (e *templateEngine) renderResourceClaimSpec(template quotav1alpha1.Resource
 			return nil, fmt.Errorf("failed to render ResourceType: %w", err)
 		}
 
-		// Use the amount directly from the template
+		// Use the amount from the template unless it is computed from the trigger object
 		amount := requestTemplate.Amount
+		if requestTemplate.AmountExpression != "" {
+			amount, err = e.celEngine.EvaluateIntegerExpression(requestTemplate.AmountExpression, variables)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate amount expression for %s: %w", resourceType, err)
+			}
+			// Nothing to claim, e.g. a Deployment scaled to zero replicas; claims only
+			// accept positive amounts
+			if amount == 0 {
+				continue
+			}
+		}
 
 		resourceRequests = append(resourceRequests, quotav1alpha1.ResourceRequest{
 			ResourceType: resourceType,
@@ -92,7 +105,7 @@ func (e *templateEngine) renderResourceClaimSpec(template quotav1alpha1.Resource
 		})
 	}
 
-	// Render ConsumerRef using CEL
+	// Render ConsumerRef using CEL, so the consumer can be derived from the trigger
 	consumerRef := template.Spec.ConsumerRef
 	if template.Spec.ConsumerRef.Name != "" {
 		renderedName, err := e.renderCELTemplate(template.Spec.ConsumerRef.Name, variables)
@@ -101,11 +114,37 @@ func (e *templateEngine) renderResourceClaimSpec(template quotav1alpha1.Resource
 		}
 		consumerRef.Name = renderedName
 	}
+	if template.Spec.ConsumerRef.Kind != "" {
+		renderedKind, err := e.renderCELTemplate(template.Spec.ConsumerRef.Kind, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render ConsumerRef.Kind: %w", err)
+		}
+		consumerRef.Kind = renderedKind
+	}
 
-	return &quotav1alpha1.ResourceClaimSpec{
-		Requests:    resourceRequests,
-		ConsumerRef: consumerRef,
-	}, nil
+	spec := &quotav1alpha1.ResourceClaimSpec{
+		Requests:     resourceRequests,
+		ConsumerRef:  consumerRef,
+		AllowPartial: template.Spec.AllowPartial,
+		Priority:     template.Spec.Priority,
+	}
+	if template.Spec.TTLSecondsAfterCreation != nil {
+		ttl := *template.Spec.TTLSecondsAfterCreation
+		spec.TTLSecondsAfterCreation = &ttl
+	}
+
+	// Release the claim some time after the trigger finishes, e.g. with a Job's own TTL
+	if template.Spec.TTLSecondsAfterFinishedExpression != "" {
+		ttl, err := e.celEngine.EvaluateIntegerExpression(template.Spec.TTLSecondsAfterFinishedExpression, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate TTL after finished expression: %w", err)
+		}
+		spec.TTLSecondsAfterFinished = &ttl
+	} else if template.Spec.TTLSecondsAfterFinished != nil {
+		ttl := *template.Spec.TTLSecondsAfterFinished
+		spec.TTLSecondsAfterFinished = &ttl
+	}
+	return spec, nil
 }
 
 // renderClaimMetadata renders name/generateName/namespace and annotations for claim metadata.
@@ -163,7 +202,7 @@ func (e *templateEngine) renderClaimMetadata(metadata quotav1alpha1.ObjectMetaTe
 }
 
 // renderResourceGrantSpec renders a ResourceGrantTemplate into a ResourceGrantSpec.
-func (e *templateEngine) renderResourceGrantSpec(template quotav1alpha1.ResourceGrantTemplate, evalContext *GrantEvaluationContext) (*quotav1alpha1.ResourceGrantSpec, error) {
+func (e *templateEngine) renderResourceGrantSpec(template quotav1alpha1.ResourceGrantTemplate, evalContext *EvaluationContext) (*quotav1alpha1.ResourceGrantSpec, error) {
 	// Build context variables for GrantCreationPolicy templates (only trigger)
 	variables := e.buildGrantTemplateContext(evalContext)
 
@@ -177,18 +216,36 @@ func (e *templateEngine) renderResourceGrantSpec(template quotav1alpha1.Resource
 		consumerRef.Name = renderedName
 	}
 
-	// Process Allowances (currently just copy them as-is, but could add templating in future)
+	// Process Allowances, computing bucket amounts from the trigger object where templated.
+	// Buckets are copied so the policy's template is never modified.
 	allowances := make([]quotav1alpha1.Allowance, len(template.Spec.Allowances))
-	copy(allowances, template.Spec.Allowances)
+	for i, allowanceTemplate := range template.Spec.Allowances {
+		allowance := allowanceTemplate
+		allowance.Buckets = make([]quotav1alpha1.Bucket, len(allowanceTemplate.Buckets))
+		for j, bucket := range allowanceTemplate.Buckets {
+			if bucket.AmountExpression != "" {
+				amount, err := e.celEngine.EvaluateIntegerExpression(bucket.AmountExpression, variables)
+				if err != nil {
+					return nil, fmt.Errorf("failed to evaluate amount expression for %s: %w", allowance.ResourceType, err)
+				}
+				bucket.Amount = amount
+				bucket.AmountExpression = ""
+			}
+			allowance.Buckets[j] = bucket
+		}
+		allowances[i] = allowance
+	}
 
 	return &quotav1alpha1.ResourceGrantSpec{
-		ConsumerRef: consumerRef,
-		Allowances:  allowances,
+		ConsumerRef:      consumerRef,
+		Allowances:       allowances,
+		AggregationScope: template.Spec.AggregationScope,
+		ExpiresAt:        template.Spec.ExpiresAt.DeepCopy(),
 	}, nil
 }
 
-// renderGrantMetadata renders name/generateName/namespace and annotations for grant metadata.
-func (e *templateEngine) renderGrantMetadata(metadata quotav1alpha1.ObjectMetaTemplate, evalContext *GrantEvaluationContext) (string, string, string, map[string]string, map[string]string, error) {
+// renderGrantMetadata renders name/generateName/namespace, labels and annotations for grant metadata.
+func (e *templateEngine) renderGrantMetadata(metadata quotav1alpha1.ObjectMetaTemplate, evalContext *EvaluationContext) (string, string, string, map[string]string, map[string]string, error) {
 	// Build context variables for GrantCreationPolicy templates (only trigger)
 	variables := e.buildGrantTemplateContext(evalContext)
 
@@ -222,10 +279,17 @@ func (e *templateEngine) renderGrantMetadata(metadata quotav1alpha1.ObjectMetaTe
 		namespace = rendered
 	}
 
-	// Render labels (literal values, not templates)
+	// Render labels (support templates, rendered values must be valid label values)
 	labels := make(map[string]string)
 	for key, value := range metadata.Labels {
-		labels[key] = value
+		rendered, err := e.renderCELTemplate(value, variables)
+		if err != nil {
+			return "", "", "", nil, nil, fmt.Errorf("failed to render label %q: %w", key, err)
+		}
+		if errs := utilvalidation.IsValidLabelValue(rendered); len(errs) > 0 {
+			return "", "", "", nil, nil, fmt.Errorf("label %q rendered to invalid value %q: %s", key, rendered, strings.Join(errs, "; "))
+		}
+		labels[key] = rendered
 	}
 
 	// Render annotations (support templates)
@@ -284,7 +348,7 @@ func (e *templateEngine) renderCELTemplate(templateStr string, variables map[str
 }
 
 // buildClaimTemplateContext creates CEL evaluation context for ClaimCreationPolicy templates.
-// Includes trigger, user, and requestInfo variables.
+// Includes trigger, user, requestInfo, and parent variables.
 func (e *templateEngine) buildClaimTemplateContext(evalContext *EvaluationContext) map[string]interface{} {
 	variables := map[string]interface{}{}
 
@@ -319,12 +383,17 @@ func (e *templateEngine) buildClaimTemplateContext(evalContext *EvaluationContex
 		}
 	}
 
+	// Include the parent organization if it was resolved
+	if evalContext.Parent != nil {
+		variables["parent"] = evalContext.Parent.Object
+	}
+
 	return variables
 }
 
 // buildGrantTemplateContext creates CEL evaluation context for GrantCreationPolicy templates.
 // Includes only the trigger variable.
-func (e *templateEngine) buildGrantTemplateContext(evalContext *GrantEvaluationContext) map[string]interface{} {
+func (e *templateEngine) buildGrantTemplateContext(evalContext *EvaluationContext) map[string]interface{} {
 	variables := map[string]interface{}{}
 
 	// Always include trigger object
@@ -340,12 +409,18 @@ func (e *templateEngine) EvaluateConditions(conditions []quotav1alpha1.Condition
 	return e.celEngine.EvaluateConditions(conditions, obj)
 }
 
+// EvaluateClaimConditions evaluates trigger conditions with the claim template variables.
+func (e *templateEngine) EvaluateClaimConditions(conditions []quotav1alpha1.ConditionExpression, evalContext *EvaluationContext) (bool, error) {
+	if len(conditions) == 0 {
+		return true, nil
+	}
+	return e.celEngine.EvaluateConditionsWithVariables(conditions, e.buildClaimTemplateContext(evalContext))
+}
+
 // RenderGrant renders a complete ResourceGrant from a GrantCreationPolicy.
-func (e *templateEngine) RenderGrant(policy *quotav1alpha1.GrantCreationPolicy, triggerObj *unstructured.Unstructured) (*quotav1alpha1.ResourceGrant, error) {
-	// Create evaluation context for grant rendering
-	evalContext := &GrantEvaluationContext{
-		Object:       triggerObj,
-		ResourceType: triggerObj.GetKind(),
+func (e *templateEngine) RenderGrant(policy *quotav1alpha1.GrantCreationPolicy, evalContext *EvaluationContext) (*quotav1alpha1.ResourceGrant, error) {
+	if evalContext == nil || evalContext.Object == nil {
+		return nil, fmt.Errorf("evaluation context has no trigger object")
 	}
 
 	// Render the grant spec
@@ -362,7 +437,7 @@ func (e *templateEngine) RenderGrant(policy *quotav1alpha1.GrantCreationPolicy,
 
 	// If no name was specified in template, generate a default one
 	if name == "" {
-		name = fmt.Sprintf("%s-%s-grant", policy.Name, triggerObj.GetName())
+		name = fmt.Sprintf("%s-%s-grant", policy.Name, evalContext.Object.GetName())
 	}
 
 	// Create the ResourceGrant object
@@ -422,3 +497,75 @@ func (e *templateEngine) RenderClaim(policy *quotav1alpha1.ClaimCreationPolicy,
 
 	return claim, nil
 }
+
+// sampleClaimRenderer renders claim templates against a synthetic trigger object so
+// ClaimCreationPolicies can be checked before any real resource triggers them.
+type sampleClaimRenderer struct {
+	templateEngine TemplateEngine
+}
+
+// NewSampleClaimRenderer returns a validation.ClaimRenderer backed by the template engine.
+func NewSampleClaimRenderer(templateEngine TemplateEngine) validation.ClaimRenderer {
+	return &sampleClaimRenderer{templateEngine: templateEngine}
+}
+
+// RenderSampleClaim renders the policy's claim template for a minimal object of the
+// given trigger kind, created by a placeholder user.
+func (r *sampleClaimRenderer) RenderSampleClaim(policy *quotav1alpha1.ClaimCreationPolicy, trigger quotav1alpha1.ClaimTriggerResource) (*quotav1alpha1.ResourceClaim, error) {
+	gv, err := schema.ParseGroupVersion(trigger.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trigger apiVersion %q: %w", trigger.APIVersion, err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(trigger.APIVersion)
+	obj.SetKind(trigger.Kind)
+	obj.SetName("sample")
+	obj.SetNamespace("default")
+	obj.SetUID("00000000-0000-0000-0000-000000000000")
+
+	evalContext := &EvaluationContext{
+		Object: obj,
+		User: UserContext{
+			Name: "system:sample-user",
+			UID:  "sample-user",
+		},
+		RequestInfo: &request.RequestInfo{
+			IsResourceRequest: true,
+			Verb:              "create",
+			APIGroup:          gv.Group,
+			APIVersion:        gv.Version,
+			Namespace:         "default",
+			Name:              "sample",
+		},
+		Namespace: "default",
+	}
+	evalContext.GVK.Group = gv.Group
+	evalContext.GVK.Version = gv.Version
+	evalContext.GVK.Kind = trigger.Kind
+
+	return r.templateEngine.RenderClaim(policy, evalContext)
+}
+
+// sampleGrantRenderer renders grant templates against a synthetic trigger object so
+// GrantCreationPolicies can be checked before any real resource triggers them.
+type sampleGrantRenderer struct {
+	templateEngine TemplateEngine
+}
+
+// NewSampleGrantRenderer returns a validation.GrantRenderer backed by the template engine.
+func NewSampleGrantRenderer(templateEngine TemplateEngine) validation.GrantRenderer {
+	return &sampleGrantRenderer{templateEngine: templateEngine}
+}
+
+// RenderSampleGrant renders the policy's grant template for a minimal object of the
+// policy's trigger kind.
+func (r *sampleGrantRenderer) RenderSampleGrant(policy *quotav1alpha1.GrantCreationPolicy) (*quotav1alpha1.ResourceGrant, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(policy.Spec.Trigger.Resource.APIVersion)
+	obj.SetKind(policy.Spec.Trigger.Resource.Kind)
+	obj.SetName("sample")
+	obj.SetUID("00000000-0000-0000-0000-000000000000")
+
+	return r.templateEngine.RenderGrant(policy, &EvaluationContext{Object: obj})
+}
@@ -1,6 +1,9 @@
 package engine
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -8,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apiserver/pkg/endpoints/request"
 
+	"go.miloapis.com/milo/internal/quota/validation"
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
 )
 
@@ -26,6 +30,10 @@ func (m *mockCELEngine) EvaluateConditions(conditions []quotav1alpha1.ConditionE
 	return true, nil
 }
 
+func (m *mockCELEngine) EvaluateConditionsWithVariables(conditions []quotav1alpha1.ConditionExpression, variables map[string]interface{}) (bool, error) {
+	return true, nil
+}
+
 func (m *mockCELEngine) EvaluateTemplateExpression(expression string, variables map[string]interface{}) (string, error) {
 	// Simple mock that evaluates expressions based on variables
 	switch expression {
@@ -66,6 +74,10 @@ func (m *mockCELEngine) EvaluateTemplateExpression(expression string, variables
 	}
 }
 
+func (m *mockCELEngine) EvaluateIntegerExpression(expression string, variables map[string]interface{}) (int64, error) {
+	return 1, nil
+}
+
 func TestCELTemplateRendering(t *testing.T) {
 	engine := NewTemplateEngine(&mockCELEngine{}, logr.Discard())
 
@@ -309,3 +321,651 @@ func TestRenderClaimWithUserContext(t *testing.T) {
 		t.Errorf("ConsumerRef.Name = %v, want %v", result.Spec.ConsumerRef.Name, "test-user-consumer")
 	}
 }
+
+func TestClaimCreationPolicyValidatorRendersSampleClaim(t *testing.T) {
+	celEngine, err := NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+
+	validator := validation.NewClaimCreationPolicyValidator(nil)
+	validator.ClaimRenderer = NewSampleClaimRenderer(NewTemplateEngine(celEngine, logr.Discard()))
+
+	newPolicy := func(metadata quotav1alpha1.ObjectMetaTemplate, requests []quotav1alpha1.ResourceRequest) *quotav1alpha1.ClaimCreationPolicy {
+		return &quotav1alpha1.ClaimCreationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-policy"},
+			Spec: quotav1alpha1.ClaimCreationPolicySpec{
+				Trigger: quotav1alpha1.ClaimTriggerSpec{
+					Resource: &quotav1alpha1.ClaimTriggerResource{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+					},
+				},
+				Target: quotav1alpha1.ClaimTargetSpec{
+					ResourceClaimTemplate: quotav1alpha1.ResourceClaimTemplate{
+						Metadata: metadata,
+						Spec: quotav1alpha1.ResourceClaimSpec{
+							ConsumerRef: quotav1alpha1.ConsumerRef{
+								APIGroup: "resourcemanager.miloapis.com",
+								Kind:     "Organization",
+								Name:     "test-org",
+							},
+							Requests: requests,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	validRequests := []quotav1alpha1.ResourceRequest{
+		{ResourceType: "apps/Deployment", Amount: 1},
+	}
+
+	tests := []struct {
+		name        string
+		policy      *quotav1alpha1.ClaimCreationPolicy
+		expectError bool
+	}{
+		{
+			name: "template renders a valid claim",
+			policy: newPolicy(quotav1alpha1.ObjectMetaTemplate{
+				Name:      "{{trigger.metadata.name + '-claim'}}",
+				Namespace: "{{trigger.metadata.namespace}}",
+			}, validRequests),
+		},
+		{
+			name: "template renders an invalid claim name",
+			policy: newPolicy(quotav1alpha1.ObjectMetaTemplate{
+				Name: "{{trigger.metadata.name + '_Claim'}}",
+			}, validRequests),
+			expectError: true,
+		},
+		{
+			name:        "template renders a claim without requests",
+			policy:      newPolicy(quotav1alpha1.ObjectMetaTemplate{}, nil),
+			expectError: true,
+		},
+		{
+			name: "template referencing fields missing from the sample object is not rejected",
+			policy: newPolicy(quotav1alpha1.ObjectMetaTemplate{
+				Name: "{{trigger.spec.template.metadata.name}}",
+			}, validRequests),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.Validate(context.Background(), tt.policy, validation.AdmissionValidationOptions())
+			if tt.expectError && len(errs) == 0 {
+				t.Error("Expected validation errors but got none")
+			}
+			if !tt.expectError && len(errs) > 0 {
+				t.Errorf("Unexpected validation errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestRenderClaimWithAmountExpression(t *testing.T) {
+	celEngine, err := NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+	engine := NewTemplateEngine(celEngine, logr.Discard())
+
+	policy := &quotav1alpha1.ClaimCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment-replicas"},
+		Spec: quotav1alpha1.ClaimCreationPolicySpec{
+			Target: quotav1alpha1.ClaimTargetSpec{
+				ResourceClaimTemplate: quotav1alpha1.ResourceClaimTemplate{
+					Spec: quotav1alpha1.ResourceClaimSpec{
+						ConsumerRef: quotav1alpha1.ConsumerRef{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Organization",
+							Name:     "test-org",
+						},
+						Requests: []quotav1alpha1.ResourceRequest{
+							{
+								ResourceType:     "apps/Deployment.replicas",
+								AmountExpression: "trigger.spec.replicas",
+							},
+							{
+								ResourceType: "apps/Deployment",
+								Amount:       1,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	newDeployment := func(spec map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":      "web",
+					"namespace": "default",
+				},
+				"spec": spec,
+			},
+		}
+	}
+
+	t.Run("amount derived from replicas", func(t *testing.T) {
+		claim, err := engine.RenderClaim(policy, &EvaluationContext{
+			Object: newDeployment(map[string]interface{}{"replicas": int64(3)}),
+		})
+		if err != nil {
+			t.Fatalf("RenderClaim failed: %v", err)
+		}
+
+		if got := claim.Spec.Requests[0].Amount; got != 3 {
+			t.Errorf("Expected replicas-driven amount 3, got %d", got)
+		}
+		if got := claim.Spec.Requests[0].AmountExpression; got != "" {
+			t.Errorf("Expected rendered claim to omit amountExpression, got %q", got)
+		}
+		if got := claim.Spec.Requests[1].Amount; got != 1 {
+			t.Errorf("Expected static amount 1, got %d", got)
+		}
+	})
+
+	t.Run("zero result omits the request", func(t *testing.T) {
+		claim, err := engine.RenderClaim(policy, &EvaluationContext{
+			Object: newDeployment(map[string]interface{}{"replicas": int64(0)}),
+		})
+		if err != nil {
+			t.Fatalf("RenderClaim failed: %v", err)
+		}
+
+		if len(claim.Spec.Requests) != 1 {
+			t.Fatalf("Expected only the static request, got %+v", claim.Spec.Requests)
+		}
+		if got := claim.Spec.Requests[0].ResourceType; got != "apps/Deployment" {
+			t.Errorf("Expected static request for apps/Deployment, got %q", got)
+		}
+	})
+
+	t.Run("non-integer result fails", func(t *testing.T) {
+		_, err := engine.RenderClaim(policy, &EvaluationContext{
+			Object: newDeployment(map[string]interface{}{"replicas": "three"}),
+		})
+		if err == nil {
+			t.Fatal("Expected error for non-integer amount expression result")
+		}
+	})
+
+	t.Run("negative result fails", func(t *testing.T) {
+		_, err := engine.RenderClaim(policy, &EvaluationContext{
+			Object: newDeployment(map[string]interface{}{"replicas": int64(-1)}),
+		})
+		if err == nil {
+			t.Fatal("Expected error for negative amount expression result")
+		}
+	})
+}
+
+func TestRenderClaimWithTTLExpression(t *testing.T) {
+	celEngine, err := NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+	engine := NewTemplateEngine(celEngine, logr.Discard())
+
+	staticTTL := int64(3600)
+	policy := &quotav1alpha1.ClaimCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-ttl"},
+		Spec: quotav1alpha1.ClaimCreationPolicySpec{
+			Target: quotav1alpha1.ClaimTargetSpec{
+				ResourceClaimTemplate: quotav1alpha1.ResourceClaimTemplate{
+					Spec: quotav1alpha1.ResourceClaimSpec{
+						ConsumerRef: quotav1alpha1.ConsumerRef{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Organization",
+							Name:     "test-org",
+						},
+						Requests: []quotav1alpha1.ResourceRequest{
+							{ResourceType: "batch/Job", Amount: 1},
+						},
+						TTLSecondsAfterCreation:           &staticTTL,
+						TTLSecondsAfterFinishedExpression: "has(trigger.spec.ttlSecondsAfterFinished) ? trigger.spec.ttlSecondsAfterFinished : 86400",
+						Priority:                          50,
+					},
+				},
+			},
+		},
+	}
+
+	newJob := func(spec map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata": map[string]interface{}{
+					"name":      "backup",
+					"namespace": "default",
+				},
+				"spec": spec,
+			},
+		}
+	}
+
+	t.Run("release derived from ttlSecondsAfterFinished", func(t *testing.T) {
+		claim, err := engine.RenderClaim(policy, &EvaluationContext{
+			Object: newJob(map[string]interface{}{"ttlSecondsAfterFinished": int64(120)}),
+		})
+		if err != nil {
+			t.Fatalf("RenderClaim failed: %v", err)
+		}
+
+		if claim.Spec.TTLSecondsAfterFinished == nil || *claim.Spec.TTLSecondsAfterFinished != 120 {
+			t.Errorf("Expected release 120 seconds after the trigger finishes, got %v", claim.Spec.TTLSecondsAfterFinished)
+		}
+		if got := claim.Spec.TTLSecondsAfterFinishedExpression; got != "" {
+			t.Errorf("Expected rendered claim to omit ttlSecondsAfterFinishedExpression, got %q", got)
+		}
+		if claim.Spec.Priority != 50 {
+			t.Errorf("Expected the template's priority of 50, got %d", claim.Spec.Priority)
+		}
+	})
+
+	t.Run("ungranted TTL is not derived from the trigger", func(t *testing.T) {
+		claim, err := engine.RenderClaim(policy, &EvaluationContext{
+			Object: newJob(map[string]interface{}{"ttlSecondsAfterFinished": int64(5)}),
+		})
+		if err != nil {
+			t.Fatalf("RenderClaim failed: %v", err)
+		}
+
+		if claim.Spec.TTLSecondsAfterCreation == nil || *claim.Spec.TTLSecondsAfterCreation != staticTTL {
+			t.Errorf("Expected static TTL of %d seconds, got %v", staticTTL, claim.Spec.TTLSecondsAfterCreation)
+		}
+	})
+
+	t.Run("expression default when trigger has no TTL", func(t *testing.T) {
+		claim, err := engine.RenderClaim(policy, &EvaluationContext{
+			Object: newJob(map[string]interface{}{}),
+		})
+		if err != nil {
+			t.Fatalf("RenderClaim failed: %v", err)
+		}
+
+		if claim.Spec.TTLSecondsAfterFinished == nil || *claim.Spec.TTLSecondsAfterFinished != 86400 {
+			t.Errorf("Expected the expression's default of 86400 seconds, got %v", claim.Spec.TTLSecondsAfterFinished)
+		}
+	})
+
+	t.Run("non-integer result fails", func(t *testing.T) {
+		_, err := engine.RenderClaim(policy, &EvaluationContext{
+			Object: newJob(map[string]interface{}{"ttlSecondsAfterFinished": "2m"}),
+		})
+		if err == nil {
+			t.Fatal("Expected error for non-integer TTL expression result")
+		}
+	})
+}
+
+func TestRenderClaimWithStringHelpers(t *testing.T) {
+	celEngine, err := NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+	engine := NewTemplateEngine(celEngine, logr.Discard())
+
+	nameTemplate := "{{trunc(lower(trigger.metadata.name), 12)}}-{{trunc(sha256sum(trigger.metadata.namespace + '/' + trigger.metadata.name), 10)}}"
+	if err := celEngine.ValidateTemplateExpression("trunc(sha256sum(trigger.metadata.name), 10)"); err != nil {
+		t.Fatalf("Expected helper functions to pass validation: %v", err)
+	}
+
+	policy := &quotav1alpha1.ClaimCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment-policy"},
+		Spec: quotav1alpha1.ClaimCreationPolicySpec{
+			Target: quotav1alpha1.ClaimTargetSpec{
+				ResourceClaimTemplate: quotav1alpha1.ResourceClaimTemplate{
+					Metadata: quotav1alpha1.ObjectMetaTemplate{
+						Name: nameTemplate,
+						Annotations: map[string]string{
+							"tier":    `{{default("standard", trigger.spec.tier)}}`,
+							"service": `{{trimSuffix(trigger.metadata.name, "-Deployment-Controller")}}`,
+						},
+					},
+					Spec: quotav1alpha1.ResourceClaimSpec{
+						ConsumerRef: quotav1alpha1.ConsumerRef{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Organization",
+							Name:     "test-org",
+						},
+						Requests: []quotav1alpha1.ResourceRequest{{ResourceType: "apps/Deployment", Amount: 1}},
+					},
+				},
+			},
+		},
+	}
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "Checkout-Deployment-Controller",
+				"namespace": "default",
+			},
+			"spec": map[string]interface{}{"tier": ""},
+		},
+	}
+
+	claim, err := engine.RenderClaim(policy, &EvaluationContext{Object: obj})
+	if err != nil {
+		t.Fatalf("RenderClaim failed: %v", err)
+	}
+
+	sum := sha256.Sum256([]byte("default/Checkout-Deployment-Controller"))
+	if want := "checkout-dep-" + hex.EncodeToString(sum[:])[:10]; claim.Name != want {
+		t.Errorf("Expected name %q, got %q", want, claim.Name)
+	}
+	if got := claim.Annotations["tier"]; got != "standard" {
+		t.Errorf("Expected default tier annotation, got %q", got)
+	}
+	if got := claim.Annotations["service"]; got != "Checkout" {
+		t.Errorf("Expected trimmed service annotation, got %q", got)
+	}
+
+	again, err := engine.RenderClaim(policy, &EvaluationContext{Object: obj})
+	if err != nil {
+		t.Fatalf("RenderClaim failed: %v", err)
+	}
+	if again.Name != claim.Name {
+		t.Errorf("Expected deterministic name, got %q then %q", claim.Name, again.Name)
+	}
+}
+
+func TestRenderClaimWithConsumerFromTrigger(t *testing.T) {
+	celEngine, err := NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+	engine := NewTemplateEngine(celEngine, logr.Discard())
+
+	policy := &quotav1alpha1.ClaimCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "project-policy"},
+		Spec: quotav1alpha1.ClaimCreationPolicySpec{
+			Target: quotav1alpha1.ClaimTargetSpec{
+				ResourceClaimTemplate: quotav1alpha1.ResourceClaimTemplate{
+					Metadata: quotav1alpha1.ObjectMetaTemplate{Name: "{{trigger.metadata.name}}-claim"},
+					Spec: quotav1alpha1.ResourceClaimSpec{
+						ConsumerRef: quotav1alpha1.ConsumerRef{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     `{{default("Organization", trigger.metadata.labels["resourcemanager.miloapis.com/consumer-kind"])}}`,
+							Name:     `{{trigger.metadata.labels["resourcemanager.miloapis.com/organization"]}}`,
+						},
+						Requests: []quotav1alpha1.ResourceRequest{{ResourceType: "resourcemanager.miloapis.com/projects", Amount: 1}},
+					},
+				},
+			},
+		},
+	}
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+			"kind":       "Project",
+			"metadata": map[string]interface{}{
+				"name": "web-app",
+				"labels": map[string]interface{}{
+					"resourcemanager.miloapis.com/organization":  "acme-corp",
+					"resourcemanager.miloapis.com/consumer-kind": "",
+				},
+			},
+		},
+	}
+
+	claim, err := engine.RenderClaim(policy, &EvaluationContext{Object: obj})
+	if err != nil {
+		t.Fatalf("RenderClaim failed: %v", err)
+	}
+	want := quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Organization", Name: "acme-corp"}
+	if claim.Spec.ConsumerRef != want {
+		t.Errorf("ConsumerRef = %+v, want %+v", claim.Spec.ConsumerRef, want)
+	}
+	if got := policy.Spec.Target.ResourceClaimTemplate.Spec.ConsumerRef.Name; got == "acme-corp" {
+		t.Error("RenderClaim modified the policy's template")
+	}
+
+	// A trigger without the label cannot be attributed to a consumer
+	unlabeled := obj.DeepCopy()
+	unlabeled.SetLabels(nil)
+	if _, err := engine.RenderClaim(policy, &EvaluationContext{Object: unlabeled}); err == nil {
+		t.Error("Expected RenderClaim to fail for a trigger without the organization label")
+	}
+}
+
+func TestRenderGrantWithAmountExpression(t *testing.T) {
+	celEngine, err := NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+	engine := NewTemplateEngine(celEngine, logr.Discard())
+
+	policy := &quotav1alpha1.GrantCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "organization-tier"},
+		Spec: quotav1alpha1.GrantCreationPolicySpec{
+			Target: quotav1alpha1.GrantTargetSpec{
+				ResourceGrantTemplate: quotav1alpha1.ResourceGrantTemplate{
+					Metadata: quotav1alpha1.ObjectMetaTemplate{
+						Name:      "{{ trigger.metadata.name }}-projects",
+						Namespace: "organization-{{ trigger.metadata.name }}",
+					},
+					Spec: quotav1alpha1.ResourceGrantSpec{
+						ConsumerRef: quotav1alpha1.ConsumerRef{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Organization",
+							Name:     "{{ trigger.metadata.name }}",
+						},
+						Allowances: []quotav1alpha1.Allowance{
+							{
+								ResourceType: "resourcemanager.miloapis.com/projects",
+								Buckets: []quotav1alpha1.Bucket{
+									{AmountExpression: "trigger.spec.tier == 'gold' ? 500 : 5"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	newOrganization := func(tier interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+				"kind":       "Organization",
+				"metadata": map[string]interface{}{
+					"name": "acme",
+				},
+				"spec": map[string]interface{}{
+					"tier": tier,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		tier     string
+		expected int64
+	}{
+		{name: "gold tier", tier: "gold", expected: 500},
+		{name: "free tier", tier: "free", expected: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grant, err := engine.RenderGrant(policy, &EvaluationContext{Object: newOrganization(tt.tier)})
+			if err != nil {
+				t.Fatalf("RenderGrant failed: %v", err)
+			}
+
+			bucket := grant.Spec.Allowances[0].Buckets[0]
+			if bucket.Amount != tt.expected {
+				t.Errorf("Expected %d projects, got %d", tt.expected, bucket.Amount)
+			}
+			if bucket.AmountExpression != "" {
+				t.Errorf("Expected rendered grant to omit amountExpression, got %q", bucket.AmountExpression)
+			}
+		})
+	}
+
+	if got := policy.Spec.Target.ResourceGrantTemplate.Spec.Allowances[0].Buckets[0]; got.Amount != 0 || got.AmountExpression == "" {
+		t.Errorf("Expected policy template to be left unchanged, got %+v", got)
+	}
+}
+
+func TestRenderGrantMetadata(t *testing.T) {
+	celEngine, err := NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+	engine := NewTemplateEngine(celEngine, logr.Discard())
+
+	policy := &quotav1alpha1.GrantCreationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "organization-tier"},
+		Spec: quotav1alpha1.GrantCreationPolicySpec{
+			Target: quotav1alpha1.GrantTargetSpec{
+				ResourceGrantTemplate: quotav1alpha1.ResourceGrantTemplate{
+					Metadata: quotav1alpha1.ObjectMetaTemplate{
+						Namespace: "organization-{{ trigger.metadata.name }}",
+						Labels: map[string]string{
+							"quota.miloapis.com/policy": "organization-tier",
+							"quota.miloapis.com/tier":   "{{ trigger.spec.tier }}",
+						},
+						Annotations: map[string]string{
+							"quota.miloapis.com/tier": "{{ trigger.spec.tier }}",
+						},
+					},
+					Spec: quotav1alpha1.ResourceGrantSpec{
+						ConsumerRef: quotav1alpha1.ConsumerRef{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Organization",
+							Name:     "{{ trigger.metadata.name }}",
+						},
+						Allowances: []quotav1alpha1.Allowance{
+							{
+								ResourceType: "resourcemanager.miloapis.com/projects",
+								Buckets: []quotav1alpha1.Bucket{
+									{AmountExpression: "trigger.spec.tier == 'gold' ? 500 : 5"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+			"kind":       "Organization",
+			"metadata": map[string]interface{}{
+				"name": "acme",
+			},
+			"spec": map[string]interface{}{
+				"tier": "gold",
+			},
+		},
+	}
+
+	grant, err := engine.RenderGrant(policy, &EvaluationContext{Object: obj})
+	if err != nil {
+		t.Fatalf("RenderGrant failed: %v", err)
+	}
+
+	if grant.Name != "organization-tier-acme-grant" {
+		t.Errorf("Expected default grant name, got %q", grant.Name)
+	}
+	if grant.Namespace != "organization-acme" {
+		t.Errorf("Expected namespace organization-acme, got %q", grant.Namespace)
+	}
+	if got := grant.Labels["quota.miloapis.com/policy"]; got != "organization-tier" {
+		t.Errorf("Expected policy label to be copied, got %q", got)
+	}
+	if got := grant.Labels["quota.miloapis.com/tier"]; got != "gold" {
+		t.Errorf("Expected tier label to be rendered, got %q", got)
+	}
+	if got := grant.Annotations["quota.miloapis.com/tier"]; got != "gold" {
+		t.Errorf("Expected tier annotation to be rendered, got %q", got)
+	}
+	if grant.Spec.ConsumerRef.Name != "acme" {
+		t.Errorf("Expected consumer acme, got %q", grant.Spec.ConsumerRef.Name)
+	}
+	if got := grant.Spec.Allowances[0].Buckets[0].Amount; got != 500 {
+		t.Errorf("Expected 500 projects, got %d", got)
+	}
+
+	if _, err := engine.RenderGrant(policy, &EvaluationContext{}); err == nil {
+		t.Error("Expected RenderGrant to fail without a trigger object")
+	}
+}
+
+func TestEvaluateClaimConditionsWithParent(t *testing.T) {
+	celEngine, err := NewCELEngine()
+	if err != nil {
+		t.Fatalf("Failed to create CEL engine: %v", err)
+	}
+	engine := NewTemplateEngine(celEngine, logr.Discard())
+
+	constraints := []quotav1alpha1.ConditionExpression{
+		{Expression: `parent.spec.type == "Standard"`},
+	}
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name": "test-config",
+			},
+		},
+	}
+	parentWithType := func(orgType string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+				"kind":       "Organization",
+				"metadata": map[string]interface{}{
+					"name": "acme",
+				},
+				"spec": map[string]interface{}{
+					"type": orgType,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		parent  *unstructured.Unstructured
+		want    bool
+		wantErr bool
+	}{
+		{name: "matching type", parent: parentWithType("Standard"), want: true},
+		{name: "other type", parent: parentWithType("Personal"), want: false},
+		{name: "no parent", parent: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := engine.EvaluateClaimConditions(constraints, &EvaluationContext{Object: obj, Parent: tt.parent})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EvaluateClaimConditions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EvaluateClaimConditions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
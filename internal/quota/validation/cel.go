@@ -43,6 +43,37 @@ func (v *CELValidator) ValidateTemplateExpression(expression string) error {
 	return v.validateTemplateExpression(expression)
 }
 
+// ValidateIntegerExpression validates a CEL expression used to compute an integer claim or grant field,
+// such as a request amount, TTL, or bucket amount.
+// The expression must return an integer, or a dynamic value that is checked to be an integer at runtime.
+func (v *CELValidator) ValidateIntegerExpression(expression string) error {
+	if strings.TrimSpace(expression) == "" {
+		return fmt.Errorf("expression cannot be empty")
+	}
+
+	ast, issues := v.env.Parse(expression)
+	if issues != nil && issues.Err() != nil {
+		return fmt.Errorf("parse error: %w", issues.Err())
+	}
+
+	checked, issues := v.env.Check(ast)
+	if issues != nil && issues.Err() != nil {
+		return fmt.Errorf("type check error: %w", issues.Err())
+	}
+
+	// Fields read from the trigger object are dynamic, so dyn is accepted and checked at evaluation time
+	outputType := checked.OutputType()
+	if !outputType.IsEquivalentType(cel.IntType) && !outputType.IsEquivalentType(cel.UintType) && !outputType.IsEquivalentType(cel.DynType) {
+		return fmt.Errorf("expression must return int or dynamic type, got %s", outputType)
+	}
+
+	if err := v.validateSecurity(expression); err != nil {
+		return fmt.Errorf("security validation failed: %w", err)
+	}
+
+	return nil
+}
+
 // validateTemplateExpression validates template expressions allowing both string and dynamic types.
 // Template expressions are ultimately converted to strings during rendering, so we accept both.
 func (v *CELValidator) validateTemplateExpression(expression string) error {
@@ -2,15 +2,34 @@ package validation
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authentication/user"
 )
 
+// ClaimRenderer renders the ResourceClaim a policy would produce for a synthetic
+// object of one of its trigger kinds, so templates can be checked before the policy
+// becomes active.
+type ClaimRenderer interface {
+	RenderSampleClaim(policy *quotav1alpha1.ClaimCreationPolicy, trigger quotav1alpha1.ClaimTriggerResource) (*quotav1alpha1.ResourceClaim, error)
+}
+
 // ClaimCreationPolicyValidator validates ClaimCreationPolicy resources including
 // claim template structure/syntax and resource type registration.
 type ClaimCreationPolicyValidator struct {
 	ResourceTypeValidator ResourceTypeValidator
+
+	// ClaimRenderer, when set, is used to render the claim template against a
+	// sample trigger object and validate the structure of the result.
+	ClaimRenderer ClaimRenderer
 }
 
 // NewClaimCreationPolicyValidator creates a new ClaimCreationPolicyValidator.
@@ -24,6 +43,10 @@ func NewClaimCreationPolicyValidator(resourceTypeValidator ResourceTypeValidator
 func (v *ClaimCreationPolicyValidator) Validate(ctx context.Context, policy *quotav1alpha1.ClaimCreationPolicy, opts ValidationOptions) field.ErrorList {
 	var allErrs field.ErrorList
 
+	allErrs = append(allErrs, validateTriggerResources(policy.Spec.Trigger)...)
+	allErrs = append(allErrs, validateTriggerSelectors(policy.Spec.Trigger)...)
+	allErrs = append(allErrs, validateExemptSubjects(policy.Spec.ExemptSubjects)...)
+
 	templatePath := field.NewPath("spec", "target", "resourceClaimTemplate")
 	if errs := validateClaimTemplate(policy.Spec.Target.ResourceClaimTemplate); len(errs) > 0 {
 		for _, err := range errs {
@@ -36,6 +59,12 @@ func (v *ClaimCreationPolicyValidator) Validate(ctx context.Context, policy *quo
 		}
 	}
 
+	// Only render when the template itself is well-formed; otherwise the render
+	// would just repeat the errors above.
+	if len(allErrs) == 0 && v.ClaimRenderer != nil {
+		allErrs = append(allErrs, v.validateRenderedClaim(policy)...)
+	}
+
 	// Skip resource type validation when configured because it queries API server state
 	if !opts.SkipAPIStateValidation {
 		if errs := v.validateResourceTypes(ctx, policy); len(errs) > 0 {
@@ -46,6 +75,38 @@ func (v *ClaimCreationPolicyValidator) Validate(ctx context.Context, policy *quo
 	return allErrs
 }
 
+// tenantControlledGroups are system groups that every tenant belongs to, or that
+// tenants can place their own service accounts in.
+var tenantControlledGroups = []string{
+	user.AllAuthenticated,
+	user.AllUnauthenticated,
+	serviceaccount.AllServiceAccountsGroup,
+}
+
+// IsTenantControlledGroup reports whether tenants belong to or control membership of
+// group, so that it cannot identify platform operators.
+func IsTenantControlledGroup(group string) bool {
+	return slices.Contains(tenantControlledGroups, group) ||
+		strings.HasPrefix(group, serviceaccount.ServiceAccountGroupPrefix)
+}
+
+// validateExemptSubjects rejects exempt groups that tenants belong to or control, which
+// would exempt every tenant from the policy.
+func validateExemptSubjects(exempt *quotav1alpha1.ClaimExemptSubjects) field.ErrorList {
+	var allErrs field.ErrorList
+	if exempt == nil {
+		return allErrs
+	}
+	groupsPath := field.NewPath("spec", "exemptSubjects", "groups")
+	for i, group := range exempt.Groups {
+		if IsTenantControlledGroup(group) {
+			allErrs = append(allErrs, field.Forbidden(groupsPath.Index(i),
+				fmt.Sprintf("group %q is held or controlled by tenants and cannot be exempt", group)))
+		}
+	}
+	return allErrs
+}
+
 // validateResourceTypes validates that all resource types correspond to active ResourceRegistrations.
 // Deduplicates resource types to avoid redundant validation calls.
 func (v *ClaimCreationPolicyValidator) validateResourceTypes(ctx context.Context, policy *quotav1alpha1.ClaimCreationPolicy) field.ErrorList {
@@ -68,3 +129,99 @@ func (v *ClaimCreationPolicyValidator) validateResourceTypes(ctx context.Context
 	}
 	return allErrs
 }
+
+// validateTriggerResources checks that a trigger does not list the same kind twice.
+func validateTriggerResources(trigger quotav1alpha1.ClaimTriggerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	resourcesPath := field.NewPath("spec", "trigger", "resources")
+	seen := make(map[schema.GroupVersionKind]int)
+
+	for i, gvk := range trigger.GetGVKs() {
+		if firstIndex, exists := seen[gvk]; exists {
+			allErrs = append(allErrs, field.Duplicate(
+				resourcesPath.Index(i),
+				fmt.Sprintf("duplicate trigger resource '%s' (first occurrence at index %d)", gvk, firstIndex),
+			))
+			continue
+		}
+		seen[gvk] = i
+	}
+	return allErrs
+}
+
+// validateTriggerSelectors checks that the trigger's label selectors are well-formed.
+func validateTriggerSelectors(trigger quotav1alpha1.ClaimTriggerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	triggerPath := field.NewPath("spec", "trigger")
+	opts := metav1validation.LabelSelectorValidationOptions{}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(trigger.NamespaceSelector, opts, triggerPath.Child("namespaceSelector"))...)
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(trigger.ObjectSelector, opts, triggerPath.Child("objectSelector"))...)
+	return allErrs
+}
+
+// validateRenderedClaim renders the claim template against a sample object of each
+// trigger kind and verifies the results are structurally valid ResourceClaims. Errors
+// are reported for the first kind that renders an invalid claim.
+func (v *ClaimCreationPolicyValidator) validateRenderedClaim(policy *quotav1alpha1.ClaimCreationPolicy) field.ErrorList {
+	for _, trigger := range policy.Spec.Trigger.GetResources() {
+		claim, err := v.ClaimRenderer.RenderSampleClaim(policy, trigger)
+		if err != nil {
+			// Templates may reference trigger fields the sample object does not have, so a
+			// render failure is not conclusive. Syntax errors are caught by validateClaimTemplate.
+			continue
+		}
+		if errs := validateSampleClaim(claim, policy.Spec.Target.ResourceClaimTemplate); len(errs) > 0 {
+			return errs
+		}
+	}
+	return nil
+}
+
+// validateSampleClaim verifies a rendered sample claim is a structurally valid ResourceClaim.
+func validateSampleClaim(claim *quotav1alpha1.ResourceClaim, template quotav1alpha1.ResourceClaimTemplate) field.ErrorList {
+	var allErrs field.ErrorList
+	templatePath := field.NewPath("spec", "target", "resourceClaimTemplate")
+	metadataPath := templatePath.Child("metadata")
+
+	if claim.Name != "" {
+		for _, msg := range apivalidation.NameIsDNSSubdomain(claim.Name, false) {
+			allErrs = append(allErrs, field.Invalid(metadataPath.Child("name"), claim.Name, "rendered claim name is invalid: "+msg))
+		}
+	} else if claim.GenerateName != "" {
+		for _, msg := range apivalidation.NameIsDNSSubdomain(claim.GenerateName, true) {
+			allErrs = append(allErrs, field.Invalid(metadataPath.Child("generateName"), claim.GenerateName, "rendered claim generateName is invalid: "+msg))
+		}
+	}
+
+	if claim.Namespace != "" {
+		for _, msg := range apivalidation.ValidateNamespaceName(claim.Namespace, false) {
+			allErrs = append(allErrs, field.Invalid(metadataPath.Child("namespace"), claim.Namespace, "rendered claim namespace is invalid: "+msg))
+		}
+	}
+
+	requestsPath := templatePath.Child("spec", "requests")
+	// Requests whose amount expression evaluates to zero are left out, so the sample
+	// object may legitimately claim nothing
+	if len(claim.Spec.Requests) == 0 && !hasAmountExpression(template) {
+		allErrs = append(allErrs, field.Required(requestsPath, "claim template must render at least one resource request"))
+	}
+	for i, request := range claim.Spec.Requests {
+		if strings.TrimSpace(request.ResourceType) == "" {
+			allErrs = append(allErrs, field.Invalid(requestsPath.Index(i).Child("resourceType"), request.ResourceType, "rendered resource type must not be empty"))
+		}
+	}
+
+	return allErrs
+}
+
+// hasAmountExpression reports whether any request of the template computes its amount
+// with a CEL expression.
+func hasAmountExpression(template quotav1alpha1.ResourceClaimTemplate) bool {
+	for _, request := range template.Spec.Requests {
+		if request.AmountExpression != "" {
+			return true
+		}
+	}
+	return false
+}
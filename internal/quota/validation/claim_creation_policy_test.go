@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestClaimCreationPolicyValidatorExemptSubjects(t *testing.T) {
+	tests := []struct {
+		name           string
+		groups         []string
+		expectedFields []string
+	}{
+		{
+			name:   "platform groups are allowed",
+			groups: []string{"system:masters", "system:incident-response", "operators"},
+		},
+		{
+			name:           "groups every tenant belongs to are rejected",
+			groups:         []string{"system:authenticated", "system:unauthenticated"},
+			expectedFields: []string{"spec.exemptSubjects.groups[0]", "spec.exemptSubjects.groups[1]"},
+		},
+		{
+			name:           "service account groups are rejected",
+			groups:         []string{"operators", "system:serviceaccounts", "system:serviceaccounts:tenant-namespace"},
+			expectedFields: []string{"spec.exemptSubjects.groups[1]", "spec.exemptSubjects.groups[2]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &quotav1alpha1.ClaimCreationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "instances"},
+				Spec: quotav1alpha1.ClaimCreationPolicySpec{
+					Trigger: quotav1alpha1.ClaimTriggerSpec{
+						Resource: &quotav1alpha1.ClaimTriggerResource{APIVersion: "compute.miloapis.com/v1alpha1", Kind: "Instance"},
+					},
+					Target: quotav1alpha1.ClaimTargetSpec{
+						ResourceClaimTemplate: quotav1alpha1.ResourceClaimTemplate{
+							Spec: quotav1alpha1.ResourceClaimSpec{
+								ConsumerRef: quotav1alpha1.ConsumerRef{
+									APIGroup: "resourcemanager.miloapis.com",
+									Kind:     "Project",
+									Name:     "test-project",
+								},
+								Requests: []quotav1alpha1.ResourceRequest{{ResourceType: "compute.miloapis.com/instances", Amount: 1}},
+							},
+						},
+					},
+					ExemptSubjects: &quotav1alpha1.ClaimExemptSubjects{Groups: tt.groups},
+				},
+			}
+
+			errs := NewClaimCreationPolicyValidator(nil).Validate(context.Background(), policy, ValidationOptions{SkipAPIStateValidation: true})
+
+			var forbidden []string
+			for _, err := range errs {
+				if err.Type == field.ErrorTypeForbidden {
+					forbidden = append(forbidden, err.Field)
+				}
+			}
+			if len(forbidden) != len(tt.expectedFields) {
+				t.Fatalf("expected forbidden fields %v, got %v (all errors: %v)", tt.expectedFields, forbidden, errs)
+			}
+			for i, fieldPath := range tt.expectedFields {
+				if forbidden[i] != fieldPath {
+					t.Errorf("expected forbidden field %s, got %s", fieldPath, forbidden[i])
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"fmt"
 	"strings"
 
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
@@ -8,7 +9,7 @@ import (
 )
 
 // claimTemplateAllowedVariables defines the allowed template variables for ClaimCreationPolicy
-var claimTemplateAllowedVariables = []string{"trigger", "user", "requestInfo"}
+var claimTemplateAllowedVariables = []string{"trigger", "user", "requestInfo", "parent"}
 
 // validateClaimTemplate validates a ResourceClaimTemplate including name/generateName
 // mutual exclusivity and CEL expressions in template fields.
@@ -46,5 +47,60 @@ func validateClaimTemplate(t quotav1alpha1.ResourceClaimTemplate) field.ErrorLis
 		}
 	}
 
+	// The consumer may be derived from the trigger, for example from a label naming its
+	// Organization, so its name and kind are templates like the metadata fields. Both
+	// may be omitted, in which case admission resolves the consumer from the project.
+	consumerPath := field.NewPath("spec", "consumerRef")
+	if errs := validateTemplateOrKubernetesName(t.Spec.ConsumerRef.Name, claimTemplateAllowedVariables, true, consumerPath.Child("name")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := validateTemplateOrLiteral(t.Spec.ConsumerRef.Kind, claimTemplateAllowedVariables, true, consumerPath.Child("kind")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	// Resource types are rendered like the metadata fields, so an expression that cannot
+	// be evaluated would otherwise only surface at admission, where the resource is
+	// admitted without a claim
+	requestsPath := field.NewPath("spec", "requests")
+	for i, request := range t.Spec.Requests {
+		if errs := validateTemplateOrLiteral(request.ResourceType, claimTemplateAllowedVariables, true, requestsPath.Index(i).Child("resourceType")); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		}
+		if request.AmountExpression == "" {
+			continue
+		}
+		if errs := validateIntegerExpression(request.AmountExpression, requestsPath.Index(i).Child("amountExpression")); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		}
+	}
+
+	if t.Spec.TTLSecondsAfterFinishedExpression != "" {
+		if errs := validateIntegerExpression(t.Spec.TTLSecondsAfterFinishedExpression, field.NewPath("spec", "ttlSecondsAfterFinishedExpression")); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		}
+	}
+
+	// Reservations are confirmed by whoever created the claim, which admission cannot do
+	if t.Spec.Reservation != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "reservation"), "reservations are only supported on ResourceClaims created directly"))
+	}
+
+	return allErrs
+}
+
+// validateIntegerExpression validates a CEL expression that computes an integer claim or grant field.
+func validateIntegerExpression(expression string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	celValidator, err := NewCELValidator()
+	if err != nil {
+		allErrs = append(allErrs, field.InternalError(fldPath, fmt.Errorf("failed to create CEL validator: %w", err)))
+		return allErrs
+	}
+
+	if err := celValidator.ValidateIntegerExpression(expression); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, expression, fmt.Sprintf("CEL integer expression validation failed: %v", err)))
+	}
+
 	return allErrs
 }
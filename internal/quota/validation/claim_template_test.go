@@ -279,6 +279,304 @@ func TestValidateClaimTemplate(t *testing.T) {
 			expectError: false,
 			description: "Template with mixed literal and CEL expressions should pass",
 		},
+		{
+			name: "amount expression reading trigger replicas",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-claim",
+				},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType:     "test.example.com/replicas",
+							AmountExpression: "trigger.spec.replicas",
+						},
+					},
+				},
+			},
+			expectError: false,
+			description: "Amount expression returning a dynamic trigger field should pass",
+		},
+		{
+			name: "amount expression returning a string",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-claim",
+				},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType:     "test.example.com/replicas",
+							AmountExpression: "'three'",
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Amount expression that returns a string should fail",
+		},
+		{
+			name: "amount expression with syntax error",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-claim",
+				},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType:     "test.example.com/replicas",
+							AmountExpression: "trigger.spec.replicas +",
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Amount expression with a syntax error should fail",
+		},
+		{
+			name: "TTL expression reading job ttlSecondsAfterFinished",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-claim",
+				},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "batch/jobs",
+							Amount:       1,
+						},
+					},
+					TTLSecondsAfterFinishedExpression: "has(trigger.spec.ttlSecondsAfterFinished) ? trigger.spec.ttlSecondsAfterFinished : 86400",
+				},
+			},
+			expectError: false,
+			description: "TTL expression deriving expiry from a trigger field should pass",
+		},
+		{
+			name: "TTL expression returning a string",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-claim",
+				},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "batch/jobs",
+							Amount:       1,
+						},
+					},
+					TTLSecondsAfterFinishedExpression: `'1h'`,
+				},
+			},
+			expectError: true,
+			description: "TTL expression that returns a string should fail",
+		},
+		{
+			name: "consumer derived from a trigger label",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{Name: "{{trigger.metadata.name}}-claim"},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     `{{trigger.metadata.labels["resourcemanager.miloapis.com/organization"]}}`,
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "resourcemanager.miloapis.com/projects",
+							Amount:       1,
+						},
+					},
+				},
+			},
+			expectError: false,
+			description: "Consumer name templated from the trigger's labels should pass",
+		},
+		{
+			name: "consumer kind templated",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{Name: "{{trigger.metadata.name}}-claim"},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     `{{trigger.spec.consumerKind}}`,
+						Name:     "acme-corp",
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "resourcemanager.miloapis.com/projects",
+							Amount:       1,
+						},
+					},
+				},
+			},
+			expectError: false,
+			description: "Consumer kind templated from the trigger should pass",
+		},
+		{
+			name: "consumer name with unknown variable",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{Name: "{{trigger.metadata.name}}-claim"},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     `{{object.metadata.labels["resourcemanager.miloapis.com/organization"]}}`,
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "resourcemanager.miloapis.com/projects",
+							Amount:       1,
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Consumer name template using a variable that is not available should fail",
+		},
+		{
+			name: "consumer name with invalid expression",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{Name: "{{trigger.metadata.name}}-claim"},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "{{trigger.metadata.labels[}}",
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "resourcemanager.miloapis.com/projects",
+							Amount:       1,
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Consumer name template with a syntax error should fail",
+		},
+		{
+			name: "invalid literal consumer name",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{Name: "{{trigger.metadata.name}}-claim"},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "Acme_Corp",
+					},
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "resourcemanager.miloapis.com/projects",
+							Amount:       1,
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Literal consumer name that is not a Kubernetes name should fail",
+		},
+		{
+			name: "resource type derived from the trigger",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					GenerateName: "typed-",
+				},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "compute.miloapis.com/{{trigger.metadata.labels['tier']}}-instances",
+							Amount:       1,
+						},
+					},
+				},
+			},
+			expectError: false,
+			description: "Resource types may be rendered from the trigger",
+		},
+		{
+			name: "resource type with undefined variable",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					GenerateName: "typed-",
+				},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "{{foo.bar}}",
+							Amount:       1,
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Resource type referencing an undefined variable should fail instead of failing open at admission",
+		},
+		{
+			name: "annotation with undefined variable",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					GenerateName: "annotated-",
+					Annotations: map[string]string{
+						"owner": "{{foo.bar}}",
+					},
+				},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "resourcemanager.miloapis.com/projects",
+							Amount:       1,
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Annotation referencing an undefined variable should fail",
+		},
+		{
+			name: "reservation in template",
+			template: quotav1alpha1.ResourceClaimTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					GenerateName: "reserved-",
+				},
+				Spec: quotav1alpha1.ResourceClaimSpec{
+					Requests: []quotav1alpha1.ResourceRequest{
+						{
+							ResourceType: "resourcemanager.miloapis.com/projects",
+							Amount:       1,
+						},
+					},
+					Reservation: &quotav1alpha1.ResourceClaimReservation{TTLSeconds: 300},
+				},
+			},
+			expectError: true,
+			description: "Reservations cannot be confirmed for claims created by a policy",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,30 @@
+package validation
+
+import (
+	"strings"
+	"text/template"
+)
+
+// DenialMessageData is the data a ResourceRegistration's denialMessageTemplate is
+// rendered with when a request for its resource type is denied.
+type DenialMessageData struct {
+	ResourceType string
+	Requested    int64
+	Limit        int64
+	Used         int64
+	Available    int64
+}
+
+// RenderDenialMessage renders a denialMessageTemplate with data.
+func RenderDenialMessage(text string, data DenialMessageData) (string, error) {
+	tmpl, err := template.New("denialMessage").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var message strings.Builder
+	if err := tmpl.Execute(&message, data); err != nil {
+		return "", err
+	}
+	return message.String(), nil
+}
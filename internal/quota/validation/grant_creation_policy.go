@@ -2,15 +2,28 @@ package validation
 
 import (
 	"context"
+	"fmt"
 
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// GrantRenderer renders the ResourceGrant a policy would produce for a synthetic
+// object of its trigger kind, so the grant can be checked before the policy becomes
+// active.
+type GrantRenderer interface {
+	RenderSampleGrant(policy *quotav1alpha1.GrantCreationPolicy) (*quotav1alpha1.ResourceGrant, error)
+}
+
 // GrantCreationPolicyValidator validates GrantCreationPolicy resources.
 type GrantCreationPolicyValidator struct {
 	CELValidator      *CELValidator
 	TemplateValidator *GrantTemplateValidator
+
+	// GrantRenderer, when set, is used to render the grant template against a sample
+	// trigger object and check the rendered amounts against registration caps.
+	GrantRenderer GrantRenderer
 }
 
 // NewGrantCreationPolicyValidator creates a new GrantCreationPolicyValidator.
@@ -59,5 +72,68 @@ func (v *GrantCreationPolicyValidator) Validate(ctx context.Context, policy *quo
 		}
 	}
 
+	// Only render when the template itself is valid; otherwise the render would just
+	// repeat the errors above. Caps are read from registrations, which is API server state.
+	if len(allErrs) == 0 && v.GrantRenderer != nil && !opts.SkipAPIStateValidation {
+		allErrs = append(allErrs, v.validateRenderedGrantAmounts(policy)...)
+	}
+
+	return allErrs
+}
+
+// validateRenderedGrantAmounts renders the grant template against a sample trigger
+// object and verifies the grant does not exceed any registration's maxGrantAmount.
+func (v *GrantCreationPolicyValidator) validateRenderedGrantAmounts(policy *quotav1alpha1.GrantCreationPolicy) field.ErrorList {
+	grant, err := v.GrantRenderer.RenderSampleGrant(policy)
+	if err != nil {
+		// Amount expressions may reference trigger fields the sample object does not
+		// have, so a render failure is not conclusive.
+		return nil
+	}
+	return validateGrantAmountCaps(grant.Spec, v.TemplateValidator.resourceTypeValidator,
+		field.NewPath("spec", "target", "resourceGrantTemplate", "spec"))
+}
+
+// validateGrantAmountCaps verifies that the total a grant allocates for each resource
+// type is within the maxGrantAmount of the type's registration. Errors are reported on
+// the first allowance for the type. Percentage buckets are not counted, as they are
+// resolved against a parent grant that is itself held to the cap.
+func validateGrantAmountCaps(spec quotav1alpha1.ResourceGrantSpec, resourceTypeValidator ResourceTypeValidator, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	totals := make(map[string]*resource.Quantity)
+	firstIndex := make(map[string]int)
+	var resourceTypes []string
+
+	for i, allowance := range spec.Allowances {
+		total, ok := totals[allowance.ResourceType]
+		if !ok {
+			total = resource.NewQuantity(0, resource.DecimalSI)
+			totals[allowance.ResourceType] = total
+			firstIndex[allowance.ResourceType] = i
+			resourceTypes = append(resourceTypes, allowance.ResourceType)
+		}
+		for _, bucket := range allowance.Buckets {
+			switch {
+			case bucket.Percentage != nil:
+			case bucket.Quantity != nil:
+				total.Add(*bucket.Quantity)
+			default:
+				total.Add(*resource.NewQuantity(bucket.Amount, resource.DecimalSI))
+			}
+		}
+	}
+
+	for _, resourceType := range resourceTypes {
+		maxAmount, ok := resourceTypeValidator.GetMaxGrantAmount(resourceType)
+		if !ok || totals[resourceType].Cmp(*resource.NewQuantity(maxAmount, resource.DecimalSI)) <= 0 {
+			continue
+		}
+		allErrs = append(allErrs, field.Invalid(
+			fldPath.Child("allowances").Index(firstIndex[resourceType]),
+			totals[resourceType].String(),
+			fmt.Sprintf("grant allocates %s of resource type %s, exceeding the registration's maxGrantAmount of %d",
+				totals[resourceType].String(), resourceType, maxAmount),
+		))
+	}
 	return allErrs
 }
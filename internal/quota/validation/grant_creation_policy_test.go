@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+// templateGrantRenderer renders a policy's grant template as-is, which is enough for
+// templates whose amounts are literal.
+type templateGrantRenderer struct{}
+
+func (templateGrantRenderer) RenderSampleGrant(policy *quotav1alpha1.GrantCreationPolicy) (*quotav1alpha1.ResourceGrant, error) {
+	return &quotav1alpha1.ResourceGrant{Spec: policy.Spec.Target.ResourceGrantTemplate.Spec}, nil
+}
+
+func TestGrantCreationPolicyValidator_MaxGrantAmount(t *testing.T) {
+	const projects = "resourcemanager.miloapis.com/projects"
+
+	celValidator, err := NewCELValidator()
+	if err != nil {
+		t.Fatalf("NewCELValidator() error = %v", err)
+	}
+	templateValidator, err := NewGrantTemplateValidator(&MockResourceTypeValidator{
+		validResourceTypes: map[string]bool{projects: true},
+		maxGrantAmounts:    map[string]int64{projects: 10},
+	})
+	if err != nil {
+		t.Fatalf("NewGrantTemplateValidator() error = %v", err)
+	}
+	validator := NewGrantCreationPolicyValidator(celValidator, templateValidator)
+	validator.GrantRenderer = templateGrantRenderer{}
+
+	policyWithBuckets := func(buckets ...quotav1alpha1.Bucket) *quotav1alpha1.GrantCreationPolicy {
+		return &quotav1alpha1.GrantCreationPolicy{
+			Spec: quotav1alpha1.GrantCreationPolicySpec{
+				Trigger: quotav1alpha1.GrantTriggerSpec{
+					Resource: quotav1alpha1.GrantTriggerResource{APIVersion: "resourcemanager.miloapis.com/v1alpha1", Kind: "Organization"},
+				},
+				Target: quotav1alpha1.GrantTargetSpec{
+					ResourceGrantTemplate: quotav1alpha1.ResourceGrantTemplate{
+						Metadata: quotav1alpha1.ObjectMetaTemplate{Name: "acme-projects"},
+						Spec: quotav1alpha1.ResourceGrantSpec{
+							ConsumerRef: quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Organization", Name: "acme"},
+							Allowances:  []quotav1alpha1.Allowance{{ResourceType: projects, Buckets: buckets}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		policy    *quotav1alpha1.GrantCreationPolicy
+		opts      ValidationOptions
+		wantError bool
+	}{
+		{
+			name:   "amount within cap",
+			policy: policyWithBuckets(quotav1alpha1.Bucket{Amount: 10}),
+		},
+		{
+			name:      "amount over cap",
+			policy:    policyWithBuckets(quotav1alpha1.Bucket{Amount: 11}),
+			wantError: true,
+		},
+		{
+			name:      "buckets summed over cap",
+			policy:    policyWithBuckets(quotav1alpha1.Bucket{Amount: 6}, quotav1alpha1.Bucket{Amount: 5}),
+			wantError: true,
+		},
+		{
+			name:      "quantity over cap",
+			policy:    policyWithBuckets(quotav1alpha1.Bucket{Quantity: ptr.To(resource.MustParse("10500m"))}),
+			wantError: true,
+		},
+		{
+			name:   "cap not checked without API state",
+			policy: policyWithBuckets(quotav1alpha1.Bucket{Amount: 11}),
+			opts:   ValidationOptions{SkipAPIStateValidation: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.Validate(context.Background(), tt.policy, tt.opts)
+			if tt.wantError {
+				if len(errs) != 1 || !strings.Contains(errs[0].Detail, "maxGrantAmount") {
+					t.Fatalf("Validate() = %v, want a single maxGrantAmount error", errs)
+				}
+				if want := "spec.target.resourceGrantTemplate.spec.allowances[0]"; errs[0].Field != want {
+					t.Errorf("error field = %q, want %q", errs[0].Field, want)
+				}
+				return
+			}
+			if len(errs) != 0 {
+				t.Errorf("Validate() = %v, want no errors", errs)
+			}
+		})
+	}
+}
@@ -57,12 +57,12 @@ func (v *GrantTemplateValidator) ValidateMetadataTemplate(metadata quotav1alpha1
 		}
 	}
 
-	// No template variables allowed in keys or values for labels
+	// Keys must be valid, values are either valid label values or templates
 	for key, value := range metadata.Labels {
 		if errs := v.validateLabelKey(key, fldPath.Child("labels").Key(key)); len(errs) > 0 {
 			allErrs = append(allErrs, errs...)
 		}
-		if errs := v.validateLabelValue(value, fldPath.Child("labels").Key(key)); len(errs) > 0 {
+		if errs := v.validateLabelValueTemplate(value, fldPath.Child("labels").Key(key)); len(errs) > 0 {
 			allErrs = append(allErrs, errs...)
 		}
 	}
@@ -87,6 +87,22 @@ func (v *GrantTemplateValidator) ValidateSpecTemplate(ctx context.Context, spec
 		allErrs = append(allErrs, errs...)
 	}
 
+	allErrs = append(allErrs, validateAggregationScope(spec, fldPath)...)
+	allErrs = append(allErrs, validateAllowanceBuckets(spec, fldPath)...)
+	allErrs = append(allErrs, validateBorrowPolicies(spec, fldPath)...)
+
+	for i, allowance := range spec.Allowances {
+		for j, bucket := range allowance.Buckets {
+			if bucket.AmountExpression == "" {
+				continue
+			}
+			bucketPath := fldPath.Child("allowances").Index(i).Child("buckets").Index(j)
+			if errs := validateIntegerExpression(bucket.AmountExpression, bucketPath.Child("amountExpression")); len(errs) > 0 {
+				allErrs = append(allErrs, errs...)
+			}
+		}
+	}
+
 	// Skip resource type validation when configured because it queries API server state
 	if !opts.SkipAPIStateValidation {
 		for i, allowance := range spec.Allowances {
@@ -184,6 +200,20 @@ func (v *GrantTemplateValidator) validateLabelValue(value string, fldPath *field
 	return allErrs
 }
 
+// validateLabelValueTemplate validates a label value that may contain template
+// variables. Literal values must be valid label values; templates are checked again
+// once rendered.
+func (v *GrantTemplateValidator) validateLabelValueTemplate(value string, fldPath *field.Path) field.ErrorList {
+	hasExpression, err := templateutil.ContainsExpression(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	if hasExpression {
+		return validateCELTemplate(value, grantTemplateAllowedVariables, fldPath)
+	}
+	return v.validateLabelValue(value, fldPath)
+}
+
 // validateAnnotationKey validates a Kubernetes annotation key.
 func (v *GrantTemplateValidator) validateAnnotationKey(key string, fldPath *field.Path) field.ErrorList {
 	// Similar to label key validation but more permissive
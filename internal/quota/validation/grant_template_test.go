@@ -6,12 +6,15 @@ import (
 	"testing"
 
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 )
 
 // MockResourceTypeValidator for testing
 type MockResourceTypeValidator struct {
 	validResourceTypes map[string]bool
+	maxGrantAmounts    map[string]int64
 }
 
 func (m *MockResourceTypeValidator) ValidateResourceType(ctx context.Context, resourceType string) error {
@@ -33,6 +36,27 @@ func (m *MockResourceTypeValidator) IsResourceTypeRegistered(resourceType string
 	return false
 }
 
+func (m *MockResourceTypeValidator) HasExactRegistration(resourceType string) bool {
+	return false
+}
+
+func (m *MockResourceTypeValidator) GetMeasurement(resourceType string) (string, int64, bool) {
+	return "", 0, false
+}
+
+func (m *MockResourceTypeValidator) GetMaxGrantAmount(resourceType string) (int64, bool) {
+	amount, ok := m.maxGrantAmounts[resourceType]
+	return amount, ok
+}
+
+func (m *MockResourceTypeValidator) GetDefaultClaim(claimingAPIGroup, claimingKind string) (DefaultClaim, bool) {
+	return DefaultClaim{}, false
+}
+
+func (m *MockResourceTypeValidator) GetDenialMessageTemplate(resourceType string) (string, bool) {
+	return "", false
+}
+
 func (m *MockResourceTypeValidator) HasSynced() bool { return true }
 
 func TestValidateLabelKey(t *testing.T) {
@@ -354,6 +378,30 @@ func TestValidateMetadataTemplateLabels(t *testing.T) {
 			expectError: false,
 			description: "Mixed literal and CEL content should be valid",
 		},
+		{
+			name: "templated label value",
+			metadata: quotav1alpha1.ObjectMetaTemplate{
+				Name:      "test-grant",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"quota.miloapis.com/tier": "{{trigger.spec.type}}",
+				},
+			},
+			expectError: false,
+			description: "Label values with CEL template expressions should be valid",
+		},
+		{
+			name: "templated label value with invalid variable",
+			metadata: quotav1alpha1.ObjectMetaTemplate{
+				Name:      "test-grant",
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					"quota.miloapis.com/tier": "{{user.name}}",
+				},
+			},
+			expectError: true,
+			description: "Label value templates may only use the trigger variable",
+		},
 	}
 
 	for _, tt := range tests {
@@ -446,6 +494,161 @@ func TestGrantTemplateValidator_ValidateGrantTemplate(t *testing.T) {
 			expectError: false,
 			description: "Template with mixed literal and CEL expressions should pass",
 		},
+		{
+			name: "organization tree scope with project consumer",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Project",
+						Name:     "test-project",
+					},
+					AggregationScope: quotav1alpha1.AggregationScopeOrganizationTree,
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets: []quotav1alpha1.Bucket{
+								{Amount: 5},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Organization tree aggregation requires an Organization consumer",
+		},
+		{
+			name: "percentage bucket with parent grant",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets: []quotav1alpha1.Bucket{
+								{Percentage: ptr.To[int32](50), ParentGrantRef: &quotav1alpha1.ParentGrantReference{Name: "parent-grant"}},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+			description: "Percentage buckets resolved against a parent grant should pass",
+		},
+		{
+			name: "percentage bucket without parent grant",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets: []quotav1alpha1.Bucket{
+								{Percentage: ptr.To[int32](50)},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Percentage buckets must reference a parent grant",
+		},
+		{
+			name: "quantity bucket with amount",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets: []quotav1alpha1.Bucket{
+								{Amount: 5, Quantity: ptr.To(resource.MustParse("10Gi"))},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Quantity buckets must not also set an amount",
+		},
+		{
+			name: "borrow policy from another resource type",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets:      []quotav1alpha1.Bucket{{Amount: 10}},
+							BorrowPolicy: &quotav1alpha1.BorrowPolicy{
+								From:                []string{"test.example.com/workspaces"},
+								MaxBorrowPercentage: 25,
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+			description: "Allowances may borrow from the consumer's other resource types",
+		},
+		{
+			name: "borrow policy from own resource type",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "test-org",
+					},
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets:      []quotav1alpha1.Bucket{{Amount: 10}},
+							BorrowPolicy: &quotav1alpha1.BorrowPolicy{
+								From:                []string{"test.example.com/projects"},
+								MaxBorrowPercentage: 25,
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Allowances cannot borrow from their own resource type",
+		},
 		{
 			name: "template with invalid CEL expression",
 			template: quotav1alpha1.ResourceGrantTemplate{
@@ -496,6 +699,106 @@ func TestGrantTemplateValidator_ValidateGrantTemplate(t *testing.T) {
 			expectError: true,
 			description: "Template with invalid variable (user not allowed in grant templates) should fail",
 		},
+		{
+			name: "amount expression reading the organization tier",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "{{trigger.metadata.name}}",
+					},
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets: []quotav1alpha1.Bucket{
+								{AmountExpression: "trigger.spec.tier == 'gold' ? 500 : 5"},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+			description: "Amount expression returning an integer from the watched resource should pass",
+		},
+		{
+			name: "amount expression with syntax error",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "{{trigger.metadata.name}}",
+					},
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets: []quotav1alpha1.Bucket{
+								{AmountExpression: "trigger.spec.tier =="},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Amount expression with a syntax error should fail",
+		},
+		{
+			name: "amount expression returning a string",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "{{trigger.metadata.name}}",
+					},
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets: []quotav1alpha1.Bucket{
+								{AmountExpression: "'five'"},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Amount expression that returns a string should fail",
+		},
+		{
+			name: "amount expression combined with quantity",
+			template: quotav1alpha1.ResourceGrantTemplate{
+				Metadata: quotav1alpha1.ObjectMetaTemplate{
+					Name: "{{trigger.metadata.name}}-grant",
+				},
+				Spec: quotav1alpha1.ResourceGrantSpec{
+					ConsumerRef: quotav1alpha1.ConsumerRef{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+						Name:     "{{trigger.metadata.name}}",
+					},
+					Allowances: []quotav1alpha1.Allowance{
+						{
+							ResourceType: "test.example.com/projects",
+							Buckets: []quotav1alpha1.Bucket{
+								{AmountExpression: "5", Quantity: ptr.To(resource.MustParse("5"))},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+			description: "Amount expression cannot be combined with a quantity",
+		},
 	}
 
 	for _, tt := range tests {
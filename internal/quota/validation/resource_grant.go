@@ -2,6 +2,8 @@ package validation
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -20,13 +22,27 @@ func NewResourceGrantValidator(resourceTypeValidator ResourceTypeValidator) *Res
 }
 
 // Validate validates that all resource types in the grant's allowances correspond
-// to active ResourceRegistrations. This method deduplicates resource types to avoid
-// redundant validation calls.
+// to active ResourceRegistrations, and that the grant allocates no more of each type
+// than its registration's maxGrantAmount. This method deduplicates resource types to
+// avoid redundant validation calls.
 func (v *ResourceGrantValidator) Validate(ctx context.Context, grant *quotav1alpha1.ResourceGrant, opts ValidationOptions) field.ErrorList {
 	var allErrs field.ErrorList
 	allowancesPath := field.NewPath("spec", "allowances")
 	seen := make(map[string]bool)
 
+	allErrs = append(allErrs, validateAggregationScope(grant.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateAllowanceBuckets(grant.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateBorrowPolicies(grant.Spec, field.NewPath("spec"))...)
+
+	for i, allowance := range grant.Spec.Allowances {
+		for j, bucket := range allowance.Buckets {
+			if bucket.AmountExpression != "" {
+				allErrs = append(allErrs, field.Forbidden(allowancesPath.Index(i).Child("buckets").Index(j).Child("amountExpression"),
+					"amountExpression is only supported in GrantCreationPolicy grant templates"))
+			}
+		}
+	}
+
 	// Skip resource type validation when configured because it queries API server state
 	if !opts.SkipAPIStateValidation {
 		for i, allowance := range grant.Spec.Allowances {
@@ -42,7 +58,124 @@ func (v *ResourceGrantValidator) Validate(ctx context.Context, grant *quotav1alp
 				}
 			}
 		}
+		allErrs = append(allErrs, validateGrantAmountCaps(grant.Spec, v.ResourceTypeValidator, field.NewPath("spec"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateCreate validates a ResourceGrant being created. In addition to Validate, it
+// requires spec.expiresAt to be in the future, since a grant that is already expired
+// would never provide capacity. Existing grants are not held to this, as their expiry
+// is expected to pass.
+func (v *ResourceGrantValidator) ValidateCreate(ctx context.Context, grant *quotav1alpha1.ResourceGrant, opts ValidationOptions) field.ErrorList {
+	allErrs := v.Validate(ctx, grant, opts)
+	allErrs = append(allErrs, validateExpiresAt(grant.Spec, time.Now(), field.NewPath("spec"))...)
+	return allErrs
+}
+
+// validateExpiresAt validates that an expiry, when set, is after now.
+func validateExpiresAt(spec quotav1alpha1.ResourceGrantSpec, now time.Time, fldPath *field.Path) field.ErrorList {
+	if spec.ExpiresAt == nil || spec.ExpiresAt.After(now) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(fldPath.Child("expiresAt"), spec.ExpiresAt.UTC().Format(time.RFC3339),
+		"expiresAt must be in the future")}
+}
+
+// validateAggregationScope validates that an OrganizationTree scope is only used by
+// grants to Organizations, whose Projects' claims it aggregates.
+func validateAggregationScope(spec quotav1alpha1.ResourceGrantSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	switch spec.AggregationScope {
+	case "", quotav1alpha1.AggregationScopeConsumer:
+	case quotav1alpha1.AggregationScopeOrganizationTree:
+		if spec.ConsumerRef.Kind != "Organization" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("aggregationScope"), spec.AggregationScope,
+				fmt.Sprintf("aggregation scope %s requires an Organization consumer, got %q", spec.AggregationScope, spec.ConsumerRef.Kind)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("aggregationScope"), spec.AggregationScope,
+			[]string{string(quotav1alpha1.AggregationScopeConsumer), string(quotav1alpha1.AggregationScopeOrganizationTree)}))
+	}
+	return allErrs
+}
+
+// validateAllowanceBuckets validates that each bucket gives its capacity in exactly one
+// way, and that percentages name the parent grant they are resolved against.
+func validateAllowanceBuckets(spec quotav1alpha1.ResourceGrantSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, allowance := range spec.Allowances {
+		for j, bucket := range allowance.Buckets {
+			bucketPath := fldPath.Child("allowances").Index(i).Child("buckets").Index(j)
+
+			if bucket.Quantity != nil && bucket.Percentage != nil {
+				allErrs = append(allErrs, field.Invalid(bucketPath.Child("percentage"), *bucket.Percentage,
+					"quantity and percentage are mutually exclusive"))
+			}
+			if (bucket.Quantity != nil || bucket.Percentage != nil) && bucket.Amount != 0 {
+				allErrs = append(allErrs, field.Invalid(bucketPath.Child("amount"), bucket.Amount,
+					"amount must be omitted or 0 when quantity or percentage is set"))
+			}
+			if bucket.AmountExpression != "" && (bucket.Quantity != nil || bucket.Percentage != nil) {
+				allErrs = append(allErrs, field.Invalid(bucketPath.Child("amountExpression"), bucket.AmountExpression,
+					"amountExpression cannot be combined with quantity or percentage"))
+			}
+			if bucket.Quantity != nil && bucket.Quantity.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(bucketPath.Child("quantity"), bucket.Quantity.String(),
+					"quantity must be non-negative"))
+			}
+			if bucket.Percentage != nil {
+				if *bucket.Percentage < 1 || *bucket.Percentage > 100 {
+					allErrs = append(allErrs, field.Invalid(bucketPath.Child("percentage"), *bucket.Percentage,
+						"percentage must be between 1 and 100"))
+				}
+				if bucket.ParentGrantRef == nil || bucket.ParentGrantRef.Name == "" {
+					allErrs = append(allErrs, field.Required(bucketPath.Child("parentGrantRef"),
+						"percentage requires a parent grant to be resolved against"))
+				}
+			} else if bucket.ParentGrantRef != nil {
+				allErrs = append(allErrs, field.Invalid(bucketPath.Child("parentGrantRef"), bucket.ParentGrantRef.Name,
+					"parentGrantRef is only used with percentage"))
+			}
+		}
 	}
+	return allErrs
+}
+
+// validateBorrowPolicies validates that allowances only borrow from other resource types,
+// each named once, and that the lent share is a valid percentage.
+func validateBorrowPolicies(spec quotav1alpha1.ResourceGrantSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, allowance := range spec.Allowances {
+		policy := allowance.BorrowPolicy
+		if policy == nil {
+			continue
+		}
+		policyPath := fldPath.Child("allowances").Index(i).Child("borrowPolicy")
 
+		if len(policy.From) == 0 {
+			allErrs = append(allErrs, field.Required(policyPath.Child("from"),
+				"at least one resource type to borrow from is required"))
+		}
+		seen := make(map[string]bool)
+		for j, resourceType := range policy.From {
+			fromPath := policyPath.Child("from").Index(j)
+			switch {
+			case resourceType == "":
+				allErrs = append(allErrs, field.Required(fromPath, "resource type is required"))
+			case resourceType == allowance.ResourceType:
+				allErrs = append(allErrs, field.Invalid(fromPath, resourceType,
+					"an allowance cannot borrow from its own resource type"))
+			case seen[resourceType]:
+				allErrs = append(allErrs, field.Duplicate(fromPath, resourceType))
+			}
+			seen[resourceType] = true
+		}
+		if policy.MaxBorrowPercentage < 1 || policy.MaxBorrowPercentage > 100 {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("maxBorrowPercentage"), policy.MaxBorrowPercentage,
+				"maxBorrowPercentage must be between 1 and 100"))
+		}
+	}
 	return allErrs
 }
@@ -0,0 +1,120 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestValidateExpiresAt(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		expiresAt *metav1.Time
+		wantErr   bool
+	}{
+		{name: "not set"},
+		{name: "in the future", expiresAt: &metav1.Time{Time: now.Add(time.Hour)}},
+		{name: "now", expiresAt: &metav1.Time{Time: now}, wantErr: true},
+		{name: "in the past", expiresAt: &metav1.Time{Time: now.Add(-time.Hour)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := quotav1alpha1.ResourceGrantSpec{ExpiresAt: tt.expiresAt}
+			errs := validateExpiresAt(spec, now, field.NewPath("spec"))
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateExpiresAt() = %v, wantErr %v", errs, tt.wantErr)
+			}
+			if tt.wantErr && errs[0].Field != "spec.expiresAt" {
+				t.Errorf("error field = %s, want spec.expiresAt", errs[0].Field)
+			}
+		})
+	}
+}
+
+func TestResourceGrantValidator_MaxGrantAmount(t *testing.T) {
+	const (
+		projects  = "resourcemanager.miloapis.com/projects"
+		instances = "compute.miloapis.com/instances"
+	)
+
+	validator := NewResourceGrantValidator(&MockResourceTypeValidator{
+		validResourceTypes: map[string]bool{projects: true, instances: true},
+		maxGrantAmounts:    map[string]int64{projects: 10},
+	})
+
+	grantWithAllowances := func(allowances ...quotav1alpha1.Allowance) *quotav1alpha1.ResourceGrant {
+		return &quotav1alpha1.ResourceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "acme-projects", Namespace: "milo-system"},
+			Spec: quotav1alpha1.ResourceGrantSpec{
+				ConsumerRef: quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Organization", Name: "acme"},
+				Allowances:  allowances,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		grant     *quotav1alpha1.ResourceGrant
+		opts      ValidationOptions
+		wantField string
+	}{
+		{
+			name:  "within cap",
+			grant: grantWithAllowances(quotav1alpha1.Allowance{ResourceType: projects, Buckets: []quotav1alpha1.Bucket{{Amount: 5}}}),
+		},
+		{
+			name:  "at cap",
+			grant: grantWithAllowances(quotav1alpha1.Allowance{ResourceType: projects, Buckets: []quotav1alpha1.Bucket{{Amount: 10}}}),
+		},
+		{
+			name:      "over cap",
+			grant:     grantWithAllowances(quotav1alpha1.Allowance{ResourceType: projects, Buckets: []quotav1alpha1.Bucket{{Amount: 11}}}),
+			wantField: "spec.allowances[0]",
+		},
+		{
+			name: "allowances for the type summed over cap",
+			grant: grantWithAllowances(
+				quotav1alpha1.Allowance{ResourceType: instances, Buckets: []quotav1alpha1.Bucket{{Amount: 100}}},
+				quotav1alpha1.Allowance{ResourceType: projects, Buckets: []quotav1alpha1.Bucket{{Amount: 6}}},
+				quotav1alpha1.Allowance{ResourceType: projects, Buckets: []quotav1alpha1.Bucket{{Amount: 5}}},
+			),
+			wantField: "spec.allowances[1]",
+		},
+		{
+			name:  "no cap registered",
+			grant: grantWithAllowances(quotav1alpha1.Allowance{ResourceType: instances, Buckets: []quotav1alpha1.Bucket{{Amount: 1000}}}),
+		},
+		{
+			name:  "cap not checked without API state",
+			grant: grantWithAllowances(quotav1alpha1.Allowance{ResourceType: projects, Buckets: []quotav1alpha1.Bucket{{Amount: 11}}}),
+			opts:  ValidationOptions{SkipAPIStateValidation: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.Validate(context.Background(), tt.grant, tt.opts)
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Errorf("Validate() = %v, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Detail, "maxGrantAmount") {
+				t.Fatalf("Validate() = %v, want a single maxGrantAmount error", errs)
+			}
+			if errs[0].Field != tt.wantField {
+				t.Errorf("error field = %q, want %q", errs[0].Field, tt.wantField)
+			}
+		})
+	}
+}
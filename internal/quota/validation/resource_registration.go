@@ -20,7 +20,7 @@ func NewResourceRegistrationValidator(resourceTypeValidator ResourceTypeValidato
 }
 
 // Validate performs complete validation of a ResourceRegistration.
-// This includes both self-contained validation (duplicate claimingResources)
+// This includes both self-contained validation (duplicate claimingResources, measurementKind)
 // and cluster-wide validation (resourceType uniqueness).
 func (v *ResourceRegistrationValidator) Validate(registration *quotav1alpha1.ResourceRegistration) field.ErrorList {
 	var allErrs field.ErrorList
@@ -33,6 +33,18 @@ func (v *ResourceRegistrationValidator) Validate(registration *quotav1alpha1.Res
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := v.validateMeasurementKind(registration); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := v.validateDefaultClaim(registration); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := v.validateDenialMessageTemplate(registration); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	return allErrs
 }
 
@@ -63,10 +75,12 @@ func (v *ResourceRegistrationValidator) validateClaimingResourcesDuplicates(regi
 }
 
 // validateResourceTypeUniqueness checks that the resourceType is not already registered.
+// A resource type covered by a wildcard registration of its group may still be registered
+// by itself, overriding the wildcard.
 func (v *ResourceRegistrationValidator) validateResourceTypeUniqueness(registration *quotav1alpha1.ResourceRegistration) field.ErrorList {
 	var allErrs field.ErrorList
 
-	if v.resourceTypeValidator.IsResourceTypeRegistered(registration.Spec.ResourceType) {
+	if v.resourceTypeValidator.HasExactRegistration(registration.Spec.ResourceType) {
 		allErrs = append(allErrs, field.Duplicate(
 			field.NewPath("spec", "resourceType"),
 			fmt.Sprintf("resource type '%s' is already registered", registration.Spec.ResourceType),
@@ -75,3 +89,77 @@ func (v *ResourceRegistrationValidator) validateResourceTypeUniqueness(registrat
 
 	return allErrs
 }
+
+// validateMeasurementKind checks that measurementKind agrees with the registration type:
+// counted resources are entities and measured resources are allocations.
+func (v *ResourceRegistrationValidator) validateMeasurementKind(registration *quotav1alpha1.ResourceRegistration) field.ErrorList {
+	var allErrs field.ErrorList
+
+	kindPath := field.NewPath("spec", "measurementKind")
+	switch registration.Spec.MeasurementKind {
+	case "":
+	case quotav1alpha1.MeasurementKindCount:
+		if registration.Spec.Type != "Entity" {
+			allErrs = append(allErrs, field.Invalid(kindPath, registration.Spec.MeasurementKind,
+				fmt.Sprintf("measurementKind %s requires type Entity, got %s", quotav1alpha1.MeasurementKindCount, registration.Spec.Type)))
+		}
+	case quotav1alpha1.MeasurementKindQuantity:
+		if registration.Spec.Type != "Allocation" {
+			allErrs = append(allErrs, field.Invalid(kindPath, registration.Spec.MeasurementKind,
+				fmt.Sprintf("measurementKind %s requires type Allocation, got %s", quotav1alpha1.MeasurementKindQuantity, registration.Spec.Type)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(kindPath, registration.Spec.MeasurementKind,
+			[]string{quotav1alpha1.MeasurementKindCount, quotav1alpha1.MeasurementKindQuantity}))
+	}
+
+	return allErrs
+}
+
+// validateDefaultClaim checks that a default claim counts an entity and names a consumer
+// that can be resolved when a claiming resource is created.
+func (v *ResourceRegistrationValidator) validateDefaultClaim(registration *quotav1alpha1.ResourceRegistration) field.ErrorList {
+	var allErrs field.ErrorList
+
+	defaultClaim := registration.Spec.DefaultClaim
+	if defaultClaim == nil {
+		return nil
+	}
+
+	defaultClaimPath := field.NewPath("spec", "defaultClaim")
+	if registration.Spec.Type != "Entity" {
+		allErrs = append(allErrs, field.Invalid(defaultClaimPath, defaultClaim,
+			fmt.Sprintf("defaultClaim requires type Entity, got %s", registration.Spec.Type)))
+	}
+
+	consumerNamePath := defaultClaimPath.Child("consumerName")
+	if defaultClaim.ConsumerName == "" {
+		consumerType := registration.Spec.ConsumerType
+		if consumerType.APIGroup != "resourcemanager.miloapis.com" || consumerType.Kind != "Project" {
+			allErrs = append(allErrs, field.Required(consumerNamePath,
+				fmt.Sprintf("consumerName is required unless consumerType is resourcemanager.miloapis.com/Project, got %s/%s", consumerType.APIGroup, consumerType.Kind)))
+		}
+		return allErrs
+	}
+
+	if errs := validateTemplateOrKubernetesName(defaultClaim.ConsumerName, claimTemplateAllowedVariables, false, consumerNamePath); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	return allErrs
+}
+
+// validateDenialMessageTemplate checks that the denial message template parses and only
+// refers to the fields it is rendered with.
+func (v *ResourceRegistrationValidator) validateDenialMessageTemplate(registration *quotav1alpha1.ResourceRegistration) field.ErrorList {
+	if registration.Spec.DenialMessageTemplate == "" {
+		return nil
+	}
+
+	if _, err := RenderDenialMessage(registration.Spec.DenialMessageTemplate, DenialMessageData{}); err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "denialMessageTemplate"),
+			registration.Spec.DenialMessageTemplate, fmt.Sprintf("invalid template: %v", err))}
+	}
+
+	return nil
+}
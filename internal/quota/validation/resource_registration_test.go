@@ -25,6 +25,27 @@ func (m *mockResourceTypeValidator) IsResourceTypeRegistered(resourceType string
 	return exists
 }
 
+func (m *mockResourceTypeValidator) HasExactRegistration(resourceType string) bool {
+	_, exists := m.registrations[resourceType]
+	return exists
+}
+
+func (m *mockResourceTypeValidator) GetMeasurement(resourceType string) (string, int64, bool) {
+	return "", 0, false
+}
+
+func (m *mockResourceTypeValidator) GetMaxGrantAmount(resourceType string) (int64, bool) {
+	return 0, false
+}
+
+func (m *mockResourceTypeValidator) GetDefaultClaim(claimingAPIGroup, claimingKind string) (DefaultClaim, bool) {
+	return DefaultClaim{}, false
+}
+
+func (m *mockResourceTypeValidator) GetDenialMessageTemplate(resourceType string) (string, bool) {
+	return "", false
+}
+
 func (m *mockResourceTypeValidator) HasSynced() bool { return true }
 
 func TestResourceRegistrationValidator_Validate(t *testing.T) {
@@ -163,6 +184,305 @@ func TestResourceRegistrationValidator_Validate(t *testing.T) {
 			wantErrs:    true,
 			errContains: "already registered",
 		},
+		{
+			name: "valid count registration for entity type",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType:    "test-resources",
+					Type:            "Entity",
+					MeasurementKind: quotav1alpha1.MeasurementKindCount,
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+				},
+			},
+			wantErrs: false,
+		},
+		{
+			name: "valid quantity registration for allocation type",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType:    "test-resources",
+					Type:            "Allocation",
+					MeasurementKind: quotav1alpha1.MeasurementKindQuantity,
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+				},
+			},
+			wantErrs: false,
+		},
+		{
+			name: "invalid count registration for allocation type",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType:    "test-resources",
+					Type:            "Allocation",
+					MeasurementKind: quotav1alpha1.MeasurementKindCount,
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+				},
+			},
+			wantErrs:    true,
+			errContains: "requires type Entity",
+		},
+		{
+			name: "invalid quantity registration for entity type",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType:    "test-resources",
+					Type:            "Entity",
+					MeasurementKind: quotav1alpha1.MeasurementKindQuantity,
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+				},
+			},
+			wantErrs:    true,
+			errContains: "requires type Allocation",
+		},
+		{
+			name: "valid default claim with templated consumer name",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType: "test-resources",
+					Type:         "Entity",
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+					DefaultClaim: &quotav1alpha1.DefaultClaimTemplate{
+						ConsumerName: "{{trigger.spec.ownerRef.name}}",
+					},
+				},
+			},
+			wantErrs: false,
+		},
+		{
+			name: "valid default claim charging the request's project",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType: "test-resources",
+					Type:         "Entity",
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Project",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "discovery.k8s.io",
+							Kind:     "EndpointSlice",
+						},
+					},
+					DefaultClaim: &quotav1alpha1.DefaultClaimTemplate{},
+				},
+			},
+			wantErrs: false,
+		},
+		{
+			name: "invalid default claim for allocation type",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType: "test-resources",
+					Type:         "Allocation",
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Project",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "discovery.k8s.io",
+							Kind:     "EndpointSlice",
+						},
+					},
+					DefaultClaim: &quotav1alpha1.DefaultClaimTemplate{},
+				},
+			},
+			wantErrs:    true,
+			errContains: "defaultClaim requires type Entity",
+		},
+		{
+			name: "invalid default claim without consumer name for non-project consumer",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType: "test-resources",
+					Type:         "Entity",
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+					DefaultClaim: &quotav1alpha1.DefaultClaimTemplate{},
+				},
+			},
+			wantErrs:    true,
+			errContains: "consumerName is required",
+		},
+		{
+			name: "invalid default claim with disallowed template variable",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType: "test-resources",
+					Type:         "Entity",
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+					DefaultClaim: &quotav1alpha1.DefaultClaimTemplate{
+						ConsumerName: "{{grant.metadata.name}}",
+					},
+				},
+			},
+			wantErrs:    true,
+			errContains: "spec.defaultClaim.consumerName",
+		},
+		{
+			name: "valid denial message template",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType: "test-resources",
+					Type:         "Entity",
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+					DenialMessageTemplate: "Your plan allows {{ .Limit }} projects and {{ .Available }} remain.",
+				},
+			},
+			wantErrs: false,
+		},
+		{
+			name: "invalid denial message template syntax",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType: "test-resources",
+					Type:         "Entity",
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+					DenialMessageTemplate: "{{ .Limit ",
+				},
+			},
+			wantErrs:    true,
+			errContains: "spec.denialMessageTemplate",
+		},
+		{
+			name: "invalid denial message template field",
+			registration: &quotav1alpha1.ResourceRegistration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-registration",
+				},
+				Spec: quotav1alpha1.ResourceRegistrationSpec{
+					ResourceType: "test-resources",
+					Type:         "Entity",
+					ConsumerType: quotav1alpha1.ConsumerType{
+						APIGroup: "resourcemanager.miloapis.com",
+						Kind:     "Organization",
+					},
+					ClaimingResources: []quotav1alpha1.ClaimingResource{
+						{
+							APIGroup: "resourcemanager.miloapis.com",
+							Kind:     "Project",
+						},
+					},
+					DenialMessageTemplate: "{{ .Remaining }} projects remain.",
+				},
+			},
+			wantErrs:    true,
+			errContains: "spec.denialMessageTemplate",
+		},
 	}
 
 	// Create mock with one existing registration
@@ -2,9 +2,15 @@ package validation
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/dynamic"
 
@@ -42,6 +48,12 @@ func (v *resourceClaimValidator) Validate(ctx context.Context, claim *quotav1alp
 		errs = append(errs, requestErrs...)
 	}
 
+	if claim.Spec.TTLSecondsAfterFinishedExpression != "" {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "ttlSecondsAfterFinishedExpression"), "ttlSecondsAfterFinishedExpression is only supported in ClaimCreationPolicy claim templates"))
+	}
+
+	errs = append(errs, v.validateConsumerRef(ctx, claim.Spec.ConsumerRef)...)
+
 	if claim.Spec.ResourceRef.Kind == "" {
 		errs = append(errs, field.Required(resourceRefPath.Child("kind"), "resourceRef.kind is required"))
 	}
@@ -52,6 +64,50 @@ func (v *resourceClaimValidator) Validate(ctx context.Context, claim *quotav1alp
 	return errs
 }
 
+// consumerResources maps the consumer kinds of the resource manager API group to their resources.
+var consumerResources = map[string]schema.GroupVersionResource{
+	"Organization": {Group: resourceManagerGroup, Version: "v1alpha1", Resource: "organizations"},
+	"Project":      {Group: resourceManagerGroup, Version: "v1alpha1", Resource: "projects"},
+}
+
+const resourceManagerGroup = "resourcemanager.miloapis.com"
+
+// validateConsumerRef checks that the Organization or Project a claim is made for exists, as
+// claims for missing consumers never aggregate into a bucket. Consumers of other API groups are
+// not checked, and lookups that fail for other reasons than the consumer being missing, e.g.
+// because the plane does not serve resource manager resources, are tolerated.
+func (v *resourceClaimValidator) validateConsumerRef(ctx context.Context, consumerRef quotav1alpha1.ConsumerRef) field.ErrorList {
+	consumerRefPath := field.NewPath("spec", "consumerRef")
+	if consumerRef.APIGroup != resourceManagerGroup || consumerRef.Kind == "" || consumerRef.Name == "" {
+		return nil
+	}
+
+	gvr, ok := consumerResources[consumerRef.Kind]
+	if !ok {
+		return field.ErrorList{field.NotSupported(consumerRefPath.Child("kind"), consumerRef.Kind, slices.Sorted(maps.Keys(consumerResources)))}
+	}
+	if v.dynamicClient == nil {
+		return nil
+	}
+
+	_, err := v.dynamicClient.Resource(gvr).Get(ctx, consumerRef.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) && isObjectNotFound(err, consumerRef.Name) {
+		return field.ErrorList{field.Invalid(consumerRefPath.Child("name"), consumerRef.Name,
+			fmt.Sprintf("%s %s does not exist", consumerRef.Kind, consumerRef.Name))}
+	}
+	return nil
+}
+
+// isObjectNotFound distinguishes a missing object from a resource that is not served at all,
+// which the API server also reports as not found.
+func isObjectNotFound(err error, name string) bool {
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil {
+		return false
+	}
+	return statusErr.ErrStatus.Details.Name == name
+}
+
 // validateResourceRequests validates all resource requests including field validation,
 // duplicates, resource type registration, and claiming rules (when resourceRef is complete).
 func (v *resourceClaimValidator) validateResourceRequests(ctx context.Context, claim *quotav1alpha1.ResourceClaim) field.ErrorList {
@@ -87,6 +143,12 @@ func (v *resourceClaimValidator) validateResourceRequests(ctx context.Context, c
 
 		if request.Amount <= 0 {
 			errs = append(errs, field.Invalid(requestPath.Child("amount"), request.Amount, "amount must be greater than 0"))
+		} else if amountErr := v.validateAmountForMeasurement(request, requestPath); amountErr != nil {
+			errs = append(errs, amountErr)
+		}
+
+		if request.AmountExpression != "" {
+			errs = append(errs, field.Forbidden(requestPath.Child("amountExpression"), "amountExpression is only supported in ClaimCreationPolicy claim templates"))
 		}
 
 		if resourceRefComplete {
@@ -99,6 +161,22 @@ func (v *resourceClaimValidator) validateResourceRequests(ctx context.Context, c
 	return errs
 }
 
+// validateAmountForMeasurement checks a request amount against the measurement kind of its
+// registration. Count resources must be claimed in whole display units; Quantity resources
+// accept any positive base-unit amount.
+func (v *resourceClaimValidator) validateAmountForMeasurement(request quotav1alpha1.ResourceRequest, requestPath *field.Path) *field.Error {
+	kind, factor, ok := v.resourceTypeValidator.GetMeasurement(request.ResourceType)
+	if !ok || kind != quotav1alpha1.MeasurementKindCount || factor <= 1 {
+		return nil
+	}
+
+	if request.Amount%factor != 0 {
+		return field.Invalid(requestPath.Child("amount"), request.Amount,
+			fmt.Sprintf("resource type %s is counted in whole units; amount must be a multiple of %d", request.ResourceType, factor))
+	}
+	return nil
+}
+
 // validateClaimingRulesForRequest validates that the claim's resourceRef satisfies
 // the claiming rules defined in the ResourceRegistration for the requested resource type.
 func (v *resourceClaimValidator) validateClaimingRulesForRequest(
@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/dynamic/fake"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func TestValidateConsumerRef(t *testing.T) {
+	organization := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "resourcemanager.miloapis.com/v1alpha1",
+		"kind":       "Organization",
+		"metadata":   map[string]interface{}{"name": "acme"},
+	}}
+	v := &resourceClaimValidator{dynamicClient: fake.NewSimpleDynamicClient(runtime.NewScheme(), organization)}
+
+	tests := []struct {
+		name        string
+		consumerRef quotav1alpha1.ConsumerRef
+		wantErrType field.ErrorType
+	}{
+		{
+			name:        "existing organization",
+			consumerRef: quotav1alpha1.ConsumerRef{APIGroup: resourceManagerGroup, Kind: "Organization", Name: "acme"},
+		},
+		{
+			name:        "missing project",
+			consumerRef: quotav1alpha1.ConsumerRef{APIGroup: resourceManagerGroup, Kind: "Project", Name: "missing"},
+			wantErrType: field.ErrorTypeInvalid,
+		},
+		{
+			name:        "unsupported kind",
+			consumerRef: quotav1alpha1.ConsumerRef{APIGroup: resourceManagerGroup, Kind: "OrganizationMembership", Name: "acme"},
+			wantErrType: field.ErrorTypeNotSupported,
+		},
+		{
+			name:        "other API group",
+			consumerRef: quotav1alpha1.ConsumerRef{APIGroup: "iam.miloapis.com", Kind: "User", Name: "missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := v.validateConsumerRef(context.Background(), tt.consumerRef)
+			if tt.wantErrType == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Type != tt.wantErrType {
+				t.Fatalf("expected a single %s error, got %v", tt.wantErrType, errs)
+			}
+		})
+	}
+}
+
+// measuredResourceTypeValidator reports a fixed measurement for every resource type.
+type measuredResourceTypeValidator struct {
+	mockResourceTypeValidator
+	kind   string
+	factor int64
+}
+
+func (m *measuredResourceTypeValidator) GetMeasurement(resourceType string) (string, int64, bool) {
+	return m.kind, m.factor, true
+}
+
+func TestValidateAmountForMeasurement(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		factor  int64
+		amount  int64
+		wantErr bool
+	}{
+		{name: "count in whole units", kind: quotav1alpha1.MeasurementKindCount, factor: 1000, amount: 3000},
+		{name: "count in partial units", kind: quotav1alpha1.MeasurementKindCount, factor: 1000, amount: 1500, wantErr: true},
+		{name: "count without conversion", kind: quotav1alpha1.MeasurementKindCount, factor: 1, amount: 7},
+		{name: "quantity in partial units", kind: quotav1alpha1.MeasurementKindQuantity, factor: 1024, amount: 1500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &resourceClaimValidator{resourceTypeValidator: &measuredResourceTypeValidator{kind: tt.kind, factor: tt.factor}}
+			request := quotav1alpha1.ResourceRequest{ResourceType: "example.com/widgets", Amount: tt.amount}
+
+			err := v.validateAmountForMeasurement(request, field.NewPath("spec", "requests").Index(0))
+			if tt.wantErr {
+				if err == nil || err.Type != field.ErrorTypeInvalid || err.Field != "spec.requests[0].amount" {
+					t.Fatalf("expected an invalid amount error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
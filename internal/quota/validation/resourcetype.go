@@ -27,6 +27,23 @@ type claimingRules struct {
 	consumerType      quotav1alpha1.ConsumerType
 	claimingResources []quotav1alpha1.ClaimingResource
 	registrationName  string // For error messages
+
+	measurementKind       string
+	unitConversionFactor  int64
+	maxGrantAmount        *int64
+	defaultClaim          *quotav1alpha1.DefaultClaimTemplate
+	denialMessageTemplate string
+}
+
+// DefaultClaim is the claim an active ResourceRegistration declares for its claiming
+// resources in spec.defaultClaim.
+type DefaultClaim struct {
+	RegistrationName string
+	ResourceType     string
+	ConsumerType     quotav1alpha1.ConsumerType
+	ConsumerName     string
+	// Amount is one display unit of the resource type, in base units.
+	Amount int64
 }
 
 // ResourceTypeValidator provides an interface for validating resource types against ResourceRegistrations.
@@ -38,9 +55,31 @@ type ResourceTypeValidator interface {
 	// Returns allowed status and detailed error message information for user-friendly feedback.
 	IsClaimingResourceAllowed(ctx context.Context, resourceType string, consumerRef quotav1alpha1.ConsumerRef, claimingAPIGroup, claimingKind string) (bool, []string, error)
 
-	// IsResourceTypeRegistered checks if a resourceType is already registered.
+	// IsResourceTypeRegistered checks if a resourceType is already registered, either by itself
+	// or through a wildcard registration of its group such as "networking.datumapis.com/*".
 	IsResourceTypeRegistered(resourceType string) bool
 
+	// HasExactRegistration checks if a registration exists for resourceType itself, without
+	// matching group wildcards.
+	HasExactRegistration(resourceType string) bool
+
+	// GetMeasurement returns the measurement kind and unit conversion factor of the active
+	// registration for resourceType. The boolean is false when no active registration exists.
+	GetMeasurement(resourceType string) (string, int64, bool)
+
+	// GetMaxGrantAmount returns the most a single ResourceGrant may allocate for resourceType.
+	// The boolean is false when no active registration exists or it sets no cap.
+	GetMaxGrantAmount(resourceType string) (int64, bool)
+
+	// GetDefaultClaim returns the default claim declared by an active registration that
+	// lists the given kind as a claiming resource. When several registrations declare one,
+	// the registration whose name sorts first wins. The boolean is false when none does.
+	GetDefaultClaim(claimingAPIGroup, claimingKind string) (DefaultClaim, bool)
+
+	// GetDenialMessageTemplate returns the denial message template of the active registration
+	// for resourceType. The boolean is false when no active registration exists or it sets none.
+	GetDenialMessageTemplate(resourceType string) (string, bool)
+
 	// HasSynced returns true if the validator's cache has been synced with the API server.
 	// This can be used for readiness checks to ensure the validator is ready before serving traffic.
 	HasSynced() bool
@@ -205,13 +244,42 @@ func (v *resourceTypeValidator) tryInitializeInformer() error {
 	return nil
 }
 
+// resourceTypeWildcard is the resource type suffix of a registration that covers every
+// resource type of its group, e.g. "networking.datumapis.com/*".
+const resourceTypeWildcard = "/*"
+
+// GroupWildcard returns the wildcard resource type of the group resourceType belongs to.
+// The boolean is false when resourceType has no group or is itself a wildcard.
+func GroupWildcard(resourceType string) (string, bool) {
+	group, _, found := strings.Cut(resourceType, "/")
+	if !found || group == "" || strings.HasSuffix(resourceType, resourceTypeWildcard) {
+		return "", false
+	}
+	return group + resourceTypeWildcard, true
+}
+
+// rulesFor returns the cached rules for resourceType. A registration of the resource type
+// itself takes precedence over a wildcard registration of its group.
+// Callers must hold cacheMutex.
+func (v *resourceTypeValidator) rulesFor(resourceType string) (*claimingRules, bool) {
+	if rules, exists := v.cache[resourceType]; exists {
+		return rules, true
+	}
+	wildcard, ok := GroupWildcard(resourceType)
+	if !ok {
+		return nil, false
+	}
+	rules, exists := v.cache[wildcard]
+	return rules, exists
+}
+
 // ValidateResourceType validates using the cached data.
 // The cache only contains active ResourceRegistrations, so this is a simple lookup.
 func (v *resourceTypeValidator) ValidateResourceType(ctx context.Context, resourceType string) error {
 	v.cacheMutex.RLock()
 	defer v.cacheMutex.RUnlock()
 
-	_, exists := v.cache[resourceType]
+	_, exists := v.rulesFor(resourceType)
 	if !exists {
 		//lint:ignore ST1005 "Error message intentionally capitalized for user-facing display"
 		return fmt.Errorf("Resource type '%s' is not available for quota management. Enable quota tracking for this resource type by registering it with the quota system", resourceType)
@@ -224,16 +292,97 @@ func (v *resourceTypeValidator) IsResourceTypeRegistered(resourceType string) bo
 	v.cacheMutex.RLock()
 	defer v.cacheMutex.RUnlock()
 
+	_, exists := v.rulesFor(resourceType)
+	return exists
+}
+
+func (v *resourceTypeValidator) HasExactRegistration(resourceType string) bool {
+	v.cacheMutex.RLock()
+	defer v.cacheMutex.RUnlock()
+
 	_, exists := v.cache[resourceType]
 	return exists
 }
 
+// GetMeasurement returns the cached measurement kind and unit conversion factor for a resource type.
+func (v *resourceTypeValidator) GetMeasurement(resourceType string) (string, int64, bool) {
+	v.cacheMutex.RLock()
+	defer v.cacheMutex.RUnlock()
+
+	rules, exists := v.rulesFor(resourceType)
+	if !exists {
+		return "", 0, false
+	}
+	return rules.measurementKind, rules.unitConversionFactor, true
+}
+
+// GetMaxGrantAmount returns the cached per-grant cap for a resource type.
+func (v *resourceTypeValidator) GetMaxGrantAmount(resourceType string) (int64, bool) {
+	v.cacheMutex.RLock()
+	defer v.cacheMutex.RUnlock()
+
+	rules, exists := v.rulesFor(resourceType)
+	if !exists || rules.maxGrantAmount == nil {
+		return 0, false
+	}
+	return *rules.maxGrantAmount, true
+}
+
+// GetDenialMessageTemplate returns the cached denial message template for a resource type.
+func (v *resourceTypeValidator) GetDenialMessageTemplate(resourceType string) (string, bool) {
+	v.cacheMutex.RLock()
+	defer v.cacheMutex.RUnlock()
+
+	rules, exists := v.rulesFor(resourceType)
+	if !exists || rules.denialMessageTemplate == "" {
+		return "", false
+	}
+	return rules.denialMessageTemplate, true
+}
+
+// GetDefaultClaim scans the cached registrations for a default claim covering a claiming kind.
+func (v *resourceTypeValidator) GetDefaultClaim(claimingAPIGroup, claimingKind string) (DefaultClaim, bool) {
+	v.cacheMutex.RLock()
+	defer v.cacheMutex.RUnlock()
+
+	var found *claimingRules
+	for _, rules := range v.cache {
+		if rules.defaultClaim == nil || !rules.allowsClaimingResource(claimingAPIGroup, claimingKind) {
+			continue
+		}
+		if found == nil || rules.registrationName < found.registrationName {
+			found = rules
+		}
+	}
+	if found == nil {
+		return DefaultClaim{}, false
+	}
+	return DefaultClaim{
+		RegistrationName: found.registrationName,
+		ResourceType:     found.resourceType,
+		ConsumerType:     found.consumerType,
+		ConsumerName:     found.defaultClaim.ConsumerName,
+		Amount:           found.unitConversionFactor,
+	}, true
+}
+
+// allowsClaimingResource reports whether the kind is listed in the registration's claiming resources.
+func (r *claimingRules) allowsClaimingResource(apiGroup, kind string) bool {
+	for _, allowedResource := range r.claimingResources {
+		if allowedResource.APIGroup == apiGroup && strings.EqualFold(allowedResource.Kind, kind) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsClaimingResourceAllowed checks if the given resource type is allowed to claim quota for the specified resource type.
+// Resource types without a registration of their own use the rules of their group's wildcard registration.
 func (v *resourceTypeValidator) IsClaimingResourceAllowed(ctx context.Context, resourceType string, consumerRef quotav1alpha1.ConsumerRef, claimingAPIGroup, claimingKind string) (bool, []string, error) {
 	v.cacheMutex.RLock()
 	defer v.cacheMutex.RUnlock()
 
-	rules, exists := v.cache[resourceType]
+	rules, exists := v.rulesFor(resourceType)
 	if !exists {
 		return false, nil, fmt.Errorf("no ResourceRegistration found for resource type %s", resourceType)
 	}
@@ -348,6 +497,12 @@ func (v *resourceTypeValidator) updateCacheForRegistration(reg *quotav1alpha1.Re
 			consumerType:      reg.Spec.ConsumerType,
 			claimingResources: make([]quotav1alpha1.ClaimingResource, len(reg.Spec.ClaimingResources)),
 			registrationName:  reg.Name,
+
+			measurementKind:       reg.Spec.MeasurementKind,
+			unitConversionFactor:  reg.Spec.UnitConversionFactor,
+			maxGrantAmount:        reg.Spec.MaxGrantAmount,
+			defaultClaim:          reg.Spec.DefaultClaim,
+			denialMessageTemplate: reg.Spec.DenialMessageTemplate,
 		}
 		copy(rules.claimingResources, reg.Spec.ClaimingResources)
 
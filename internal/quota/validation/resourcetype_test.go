@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	quotav1alpha1 "go.miloapis.com/milo/pkg/apis/quota/v1alpha1"
+)
+
+func newActiveRegistration(name, resourceType string, maxGrantAmount int64, claimingKinds ...string) *quotav1alpha1.ResourceRegistration {
+	reg := &quotav1alpha1.ResourceRegistration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: quotav1alpha1.ResourceRegistrationSpec{
+			ResourceType:   resourceType,
+			ConsumerType:   quotav1alpha1.ConsumerType{APIGroup: "resourcemanager.miloapis.com", Kind: "Project"},
+			MaxGrantAmount: &maxGrantAmount,
+		},
+		Status: quotav1alpha1.ResourceRegistrationStatus{
+			Conditions: []metav1.Condition{{Type: quotav1alpha1.ResourceRegistrationActive, Status: metav1.ConditionTrue}},
+		},
+	}
+	for _, kind := range claimingKinds {
+		reg.Spec.ClaimingResources = append(reg.Spec.ClaimingResources, quotav1alpha1.ClaimingResource{
+			APIGroup: "networking.datumapis.com",
+			Kind:     kind,
+		})
+	}
+	return reg
+}
+
+func TestResourceTypeValidator_GroupWildcard(t *testing.T) {
+	v := &resourceTypeValidator{logger: logr.Discard(), cache: make(map[string]*claimingRules)}
+	v.updateCacheForRegistration(newActiveRegistration("networking", "networking.datumapis.com/*", 10, "Network"))
+	v.updateCacheForRegistration(newActiveRegistration("gateways", "networking.datumapis.com/gateways", 2, "Gateway"))
+
+	consumerRef := quotav1alpha1.ConsumerRef{APIGroup: "resourcemanager.miloapis.com", Kind: "Project", Name: "p"}
+
+	tests := []struct {
+		name           string
+		resourceType   string
+		wantRegistered bool
+		wantExact      bool
+		wantMaxGrant   int64
+		allowedKind    string
+	}{
+		{
+			name:           "wildcard match",
+			resourceType:   "networking.datumapis.com/networks",
+			wantRegistered: true,
+			wantMaxGrant:   10,
+			allowedKind:    "Network",
+		},
+		{
+			name:           "specific registration overrides wildcard",
+			resourceType:   "networking.datumapis.com/gateways",
+			wantRegistered: true,
+			wantExact:      true,
+			wantMaxGrant:   2,
+			allowedKind:    "Gateway",
+		},
+		{
+			name:         "non-matching group",
+			resourceType: "compute.datumapis.com/instances",
+		},
+		{
+			name:         "type without group",
+			resourceType: "networks",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.IsResourceTypeRegistered(tt.resourceType); got != tt.wantRegistered {
+				t.Errorf("IsResourceTypeRegistered() = %v, want %v", got, tt.wantRegistered)
+			}
+			if got := v.HasExactRegistration(tt.resourceType); got != tt.wantExact {
+				t.Errorf("HasExactRegistration() = %v, want %v", got, tt.wantExact)
+			}
+			if err := v.ValidateResourceType(context.Background(), tt.resourceType); (err == nil) != tt.wantRegistered {
+				t.Errorf("ValidateResourceType() error = %v, want registered %v", err, tt.wantRegistered)
+			}
+			if !tt.wantRegistered {
+				if _, _, err := v.IsClaimingResourceAllowed(context.Background(), tt.resourceType, consumerRef, "networking.datumapis.com", "Network"); err == nil {
+					t.Errorf("IsClaimingResourceAllowed() expected an error for an unregistered resource type")
+				}
+				return
+			}
+
+			if got, _ := v.GetMaxGrantAmount(tt.resourceType); got != tt.wantMaxGrant {
+				t.Errorf("GetMaxGrantAmount() = %d, want %d", got, tt.wantMaxGrant)
+			}
+			allowed, _, err := v.IsClaimingResourceAllowed(context.Background(), tt.resourceType, consumerRef, "networking.datumapis.com", tt.allowedKind)
+			if err != nil || !allowed {
+				t.Errorf("IsClaimingResourceAllowed(%s) = %v, %v, want allowed", tt.allowedKind, allowed, err)
+			}
+		})
+	}
+
+	// The wildcard registration is itself an exact registration
+	if !v.HasExactRegistration("networking.datumapis.com/*") {
+		t.Errorf("expected the wildcard registration to be registered exactly")
+	}
+}
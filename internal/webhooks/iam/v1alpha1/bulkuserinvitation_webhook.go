@@ -0,0 +1,132 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	iamv1alpha1 "go.miloapis.com/milo/pkg/apis/iam/v1alpha1"
+)
+
+var bulkuserinvitationlog = logf.Log.WithName("bulkuserinvitation-resource")
+
+// SetupBulkUserInvitationWebhooksWithManager sets up the webhooks for BulkUserInvitation resources.
+func SetupBulkUserInvitationWebhooksWithManager(mgr ctrl.Manager) error {
+	bulkuserinvitationlog.Info("Setting up iam.miloapis.com bulkuserinvitation webhooks")
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&iamv1alpha1.BulkUserInvitation{}).
+		WithDefaulter(&BulkUserInvitationMutator{client: mgr.GetClient()}).
+		WithValidator(&BulkUserInvitationValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-iam-miloapis-com-v1alpha1-bulkuserinvitation,mutating=true,failurePolicy=fail,sideEffects=None,groups=iam.miloapis.com,resources=bulkuserinvitations,verbs=create,versions=v1alpha1,name=mbulkuserinvitation.iam.miloapis.com,admissionReviewVersions={v1,v1beta1},serviceName=milo-controller-manager,servicePort=9443,serviceNamespace=milo-system
+
+// BulkUserInvitationMutator sets default values for BulkUserInvitation resources.
+type BulkUserInvitationMutator struct {
+	client client.Client
+}
+
+// Default sets the InvitedBy field to the requesting user.
+func (m *BulkUserInvitationMutator) Default(ctx context.Context, obj runtime.Object) error {
+	bui, ok := obj.(*iamv1alpha1.BulkUserInvitation)
+	if !ok {
+		return fmt.Errorf("failed to cast object to BulkUserInvitation")
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		bulkuserinvitationlog.Error(err, "failed to get admission request from context", "name", bui.GetName())
+		return fmt.Errorf("failed to get request from context: %w", err)
+	}
+
+	inviterUser := &iamv1alpha1.User{}
+	if err := m.client.Get(ctx, client.ObjectKey{Name: string(req.UserInfo.UID)}, inviterUser); err != nil {
+		bulkuserinvitationlog.Error(err, "failed to get user from iam.miloapis.com API", "user", string(req.UserInfo.UID))
+		return errors.NewInternalError(fmt.Errorf("failed to get user '%s' from iam.miloapis.com API: %w", string(req.UserInfo.UID), err))
+	}
+
+	bui.Spec.InvitedBy = iamv1alpha1.UserReference{
+		Name: inviterUser.Name,
+	}
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-iam-miloapis-com-v1alpha1-bulkuserinvitation,mutating=false,failurePolicy=fail,sideEffects=None,groups=iam.miloapis.com,resources=bulkuserinvitations,verbs=create,versions=v1alpha1,name=vbulkuserinvitation.iam.miloapis.com,admissionReviewVersions={v1,v1beta1},serviceName=milo-controller-manager,servicePort=9443,serviceNamespace=milo-system
+
+// BulkUserInvitationValidator validates BulkUserInvitation resources. Each UserInvitation created from a
+// BulkUserInvitation is still validated on its own, so per-invitation checks such as existing memberships
+// and role references are reported in the BulkUserInvitation status instead.
+type BulkUserInvitationValidator struct{}
+
+// ValidateCreate ensures the emails are valid and unique and the expiration date, if provided, is in the future.
+func (v *BulkUserInvitationValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	bui, ok := obj.(*iamv1alpha1.BulkUserInvitation)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast object to BulkUserInvitation")
+	}
+	bulkuserinvitationlog.Info("Validating BulkUserInvitation", "name", bui.Name)
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		bulkuserinvitationlog.Error(err, "failed to get admission request from context", "name", bui.GetName())
+		return nil, fmt.Errorf("failed to get request from context: %w", err)
+	}
+
+	var errs field.ErrorList
+
+	// Ensure the expiration date is in the future
+	if bui.Spec.ExpirationDate != nil {
+		now := metav1.NewTime(time.Now().UTC())
+		if bui.Spec.ExpirationDate.Before(&now) {
+			errs = append(errs, field.Invalid(field.NewPath("spec").Child("expirationDate"), bui.Spec.ExpirationDate.String(), "expirationDate must be in the future"))
+		}
+	}
+
+	// Ensure the OrganizationRef is in the organization's namespace
+	if fmt.Sprintf("organization-%s", bui.Spec.OrganizationRef.Name) != req.Namespace {
+		errs = append(errs, field.Invalid(field.NewPath("spec").Child("organizationRef"), bui.Spec.OrganizationRef.Name, "organizationRef must be the same as the requesting user's organization"))
+	}
+
+	// Ensure every email is valid and appears only once, as each email gets a single UserInvitation
+	seen := map[string]bool{}
+	for i, invitation := range bui.Spec.Invitations {
+		emailPath := field.NewPath("spec").Child("invitations").Index(i).Child("email")
+		if _, err := mail.ParseAddress(invitation.Email); err != nil {
+			errs = append(errs, field.Invalid(emailPath, invitation.Email, fmt.Sprintf("invalid email address: %v", err)))
+			continue
+		}
+		email := strings.ToLower(invitation.Email)
+		if seen[email] {
+			errs = append(errs, field.Duplicate(emailPath, invitation.Email))
+		}
+		seen[email] = true
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.NewInvalid(iamv1alpha1.SchemeGroupVersion.WithKind("BulkUserInvitation").GroupKind(), bui.Name, errs)
+	}
+
+	return nil, nil
+}
+
+func (v *BulkUserInvitationValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *BulkUserInvitationValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
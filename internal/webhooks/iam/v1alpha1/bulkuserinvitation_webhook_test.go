@@ -0,0 +1,86 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	iamv1alpha1 "go.miloapis.com/milo/pkg/apis/iam/v1alpha1"
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestBulkUserInvitationValidator_ValidateCreate(t *testing.T) {
+	entry := func(email string) iamv1alpha1.BulkUserInvitationEntry {
+		return iamv1alpha1.BulkUserInvitationEntry{
+			Email: email,
+			Roles: []iamv1alpha1.RoleReference{{Name: "org-viewer", Namespace: "milo-system"}},
+		}
+	}
+
+	tests := map[string]struct {
+		invitations    []iamv1alpha1.BulkUserInvitationEntry
+		organization   string
+		expectError    bool
+		errorSubstring string
+	}{
+		"valid with unique emails": {
+			invitations:  []iamv1alpha1.BulkUserInvitationEntry{entry("first@example.com"), entry("second@example.com")},
+			organization: "testorg",
+		},
+		"error when an email is repeated": {
+			invitations:    []iamv1alpha1.BulkUserInvitationEntry{entry("first@example.com"), entry("second@example.com"), entry("First@Example.com")},
+			organization:   "testorg",
+			expectError:    true,
+			errorSubstring: "spec.invitations[2].email: Duplicate value",
+		},
+		"error when an email is invalid": {
+			invitations:    []iamv1alpha1.BulkUserInvitationEntry{entry("not-an-email")},
+			organization:   "testorg",
+			expectError:    true,
+			errorSubstring: "invalid email address",
+		},
+		"error when organizationRef is not in the same namespace": {
+			invitations:    []iamv1alpha1.BulkUserInvitationEntry{entry("first@example.com")},
+			organization:   "testorg-1",
+			expectError:    true,
+			errorSubstring: "organizationRef must be the same as the requesting user's organization",
+		},
+	}
+
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Namespace: "organization-testorg",
+			UserInfo:  authenticationv1.UserInfo{Username: "tester"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			bui := &iamv1alpha1.BulkUserInvitation{
+				ObjectMeta: metav1.ObjectMeta{Name: "team"},
+				Spec: iamv1alpha1.BulkUserInvitationSpec{
+					OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: tc.organization},
+					Invitations:     tc.invitations,
+				},
+			}
+
+			validator := &BulkUserInvitationValidator{}
+			ctx := admission.NewContextWithRequest(context.Background(), req)
+
+			warnings, err := validator.ValidateCreate(ctx, bui)
+			if tc.expectError {
+				assert.Error(t, err)
+				if tc.errorSubstring != "" {
+					assert.Contains(t, err.Error(), tc.errorSubstring)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Empty(t, warnings)
+		})
+	}
+}
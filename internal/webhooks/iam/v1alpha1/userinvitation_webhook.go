@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -37,7 +38,8 @@ func buildOrganizationMembershipCompositeKey(organizationName, userName string)
 }
 
 // SetupUserInvitationWebhooksWithManager sets up the webhooks for UserInvitation resources.
-func SetupUserInvitationWebhooksWithManager(mgr ctrl.Manager, systemNamespace, assignableRolesNamespace string) error {
+// trustedInviters are the usernames of controllers that create invitations on behalf of a user.
+func SetupUserInvitationWebhooksWithManager(mgr ctrl.Manager, systemNamespace, assignableRolesNamespace string, trustedInviters []string) error {
 	userinvitationlog.Info("Setting up iam.miloapis.com userinvitation webhooks")
 
 	// Index UserInvitation by composite key (lowercased email + organization name) for efficient duplicate checks
@@ -67,7 +69,8 @@ func SetupUserInvitationWebhooksWithManager(mgr ctrl.Manager, systemNamespace, a
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&iamv1alpha1.UserInvitation{}).
 		WithDefaulter(&UserInvitationMutator{
-			client: mgr.GetClient(),
+			client:          mgr.GetClient(),
+			trustedInviters: trustedInviters,
 		}).
 		WithValidator(&UserInvitationValidator{
 			client:                   mgr.GetClient(),
@@ -82,9 +85,13 @@ func SetupUserInvitationWebhooksWithManager(mgr ctrl.Manager, systemNamespace, a
 // UserInvitationMutator sets default values for UserInvitation resources.
 type UserInvitationMutator struct {
 	client client.Client
+	// trustedInviters are the usernames allowed to set InvitedBy to another user.
+	trustedInviters []string
 }
 
-// Default sets the InvitedBy field to the requesting user if not already set.
+// Default sets the InvitedBy field to the requesting user.
+// Trusted controllers, such as the one that fans out BulkUserInvitations, have no User and
+// create invitations on behalf of the user set in InvitedBy, which is kept.
 func (m *UserInvitationMutator) Default(ctx context.Context, obj runtime.Object) error {
 	ui, ok := obj.(*iamv1alpha1.UserInvitation)
 	if !ok {
@@ -97,6 +104,10 @@ func (m *UserInvitationMutator) Default(ctx context.Context, obj runtime.Object)
 		return fmt.Errorf("failed to get request from context: %w", err)
 	}
 
+	if slices.Contains(m.trustedInviters, req.UserInfo.Username) && ui.Spec.InvitedBy.Name != "" {
+		return nil
+	}
+
 	inviterUser := &iamv1alpha1.User{}
 	if err := m.client.Get(ctx, client.ObjectKey{Name: string(req.UserInfo.UID)}, inviterUser); err != nil {
 		userinvitationlog.Error(err, "failed to get user '%s' from iam.miloapis.com API", string(req.UserInfo.UID))
@@ -148,14 +159,15 @@ func (v *UserInvitationValidator) ValidateCreate(ctx context.Context, obj runtim
 		errs = append(errs, field.Invalid(field.NewPath("spec").Child("organizationRef"), ui.Spec.OrganizationRef.Name, "organizationRef must be the same as the requesting user's organization"))
 	}
 
-	// Ensure there is no existing UserInvitation for the same email and organization
+	// Ensure there is no active UserInvitation for the same email and organization. Declined and
+	// expired invitations do not block inviting the user again.
 	var existing iamv1alpha1.UserInvitationList
 	if err := v.client.List(ctx, &existing,
 		client.MatchingFields{userInvitationCompositeKey: buildUserInvitationCompositeKey(*ui)}); err != nil {
 		userinvitationlog.Error(err, "failed to list existing UserInvitations by email", "email", ui.Spec.Email)
 		return nil, errors.NewInternalError(fmt.Errorf("failed to list existing UserInvitations: %w", err))
 	}
-	if len(existing.Items) > 0 {
+	if slices.ContainsFunc(existing.Items, isUserInvitationActive) {
 		errs = append(errs, field.Duplicate(
 			field.NewPath("spec").Child("organizationRef"),
 			ui.Spec.OrganizationRef.Name,
@@ -200,6 +212,18 @@ func (v *UserInvitationValidator) ValidateCreate(ctx context.Context, obj runtim
 	return nil, nil
 }
 
+// isUserInvitationActive reports whether the UserInvitation has neither been declined nor expired.
+func isUserInvitationActive(ui iamv1alpha1.UserInvitation) bool {
+	if ui.Spec.State == iamv1alpha1.UserInvitationStateDeclined {
+		return false
+	}
+	if meta.IsStatusConditionTrue(ui.Status.Conditions, string(iamv1alpha1.UserInvitationExpiredCondition)) {
+		return false
+	}
+	now := metav1.NewTime(time.Now().UTC())
+	return ui.Spec.ExpirationDate == nil || !ui.Spec.ExpirationDate.Before(&now)
+}
+
 func (v *UserInvitationValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	return nil, nil
 }
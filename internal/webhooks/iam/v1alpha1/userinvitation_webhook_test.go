@@ -141,6 +141,72 @@ func TestUserInvitationValidator_ValidateCreate(t *testing.T) {
 			expectError:    true,
 			errorSubstring: "organizationRef",
 		},
+		"valid when the existing invitation was declined": {
+			invitation: &iamv1alpha1.UserInvitation{
+				ObjectMeta: metav1.ObjectMeta{Name: "reinvitation"},
+				Spec: iamv1alpha1.UserInvitationSpec{
+					Email:           "declined@example.com",
+					State:           "Pending",
+					OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "testorg"},
+				},
+			},
+			existing: []client.Object{
+				&iamv1alpha1.UserInvitation{
+					ObjectMeta: metav1.ObjectMeta{Name: "declined-invitation"},
+					Spec: iamv1alpha1.UserInvitationSpec{
+						Email:           "Declined@example.com",
+						State:           "Declined",
+						OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "testorg"},
+					},
+				},
+			},
+			expectError: false,
+		},
+		"valid when the existing invitation expired": {
+			invitation: &iamv1alpha1.UserInvitation{
+				ObjectMeta: metav1.ObjectMeta{Name: "reinvitation"},
+				Spec: iamv1alpha1.UserInvitationSpec{
+					Email:           "expired@example.com",
+					State:           "Pending",
+					OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "testorg"},
+				},
+			},
+			existing: []client.Object{
+				&iamv1alpha1.UserInvitation{
+					ObjectMeta: metav1.ObjectMeta{Name: "expired-invitation"},
+					Spec: iamv1alpha1.UserInvitationSpec{
+						Email:           "expired@example.com",
+						State:           "Pending",
+						ExpirationDate:  &past,
+						OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "testorg"},
+					},
+				},
+			},
+			expectError: false,
+		},
+		"error when a differently cased duplicate invitation exists": {
+			invitation: &iamv1alpha1.UserInvitation{
+				ObjectMeta: metav1.ObjectMeta{Name: "duplicate-invitation"},
+				Spec: iamv1alpha1.UserInvitationSpec{
+					Email:           "Duplicate@Example.com",
+					State:           "Pending",
+					OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "testorg"},
+				},
+			},
+			existing: []client.Object{
+				&iamv1alpha1.UserInvitation{
+					ObjectMeta: metav1.ObjectMeta{Name: "existing-invitation"},
+					Spec: iamv1alpha1.UserInvitationSpec{
+						Email:           "duplicate@example.com",
+						State:           "Pending",
+						ExpirationDate:  &future,
+						OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "testorg"},
+					},
+				},
+			},
+			expectError:    true,
+			errorSubstring: "organizationRef",
+		},
 		"error when user is already a member of organization": {
 			invitation: &iamv1alpha1.UserInvitation{
 				ObjectMeta: metav1.ObjectMeta{Name: "already-member"},
@@ -256,3 +322,62 @@ func TestUserInvitationValidator_ValidateCreate(t *testing.T) {
 		})
 	}
 }
+
+// TestUserInvitationMutator_Default_SystemRequester verifies that invitations created by system components,
+// such as those fanned out from a BulkUserInvitation, keep the inviter they were created for.
+func TestUserInvitationMutator_Default_SystemRequester(t *testing.T) {
+	ui := &iamv1alpha1.UserInvitation{
+		ObjectMeta: metav1.ObjectMeta{Name: "invite-user"},
+		Spec: iamv1alpha1.UserInvitationSpec{
+			Email:     "invitee@example.com",
+			State:     "Pending",
+			InvitedBy: iamv1alpha1.UserReference{Name: "inviter"},
+		},
+	}
+
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: "system:serviceaccount:milo-system:milo-controller-manager"},
+		},
+	}
+	ctx := admission.NewContextWithRequest(context.Background(), req)
+
+	// The requester has no User, so the lookup would fail if it were attempted
+	fakeClient := fake.NewClientBuilder().WithScheme(runtimeScheme).Build()
+
+	mutator := &UserInvitationMutator{
+		client:          fakeClient,
+		trustedInviters: []string{"system:serviceaccount:milo-system:milo-controller-manager"},
+	}
+	assert.NoError(t, mutator.Default(ctx, ui))
+	assert.Equal(t, "inviter", ui.Spec.InvitedBy.Name, "invitedBy should be kept for trusted controllers")
+}
+
+func TestUserInvitationMutator_DefaultReplacesInvitedByFromUntrustedRequester(t *testing.T) {
+	ui := &iamv1alpha1.UserInvitation{
+		ObjectMeta: metav1.ObjectMeta{Name: "invite-user"},
+		Spec: iamv1alpha1.UserInvitationSpec{
+			Email:     "invitee@example.com",
+			State:     "Pending",
+			InvitedBy: iamv1alpha1.UserReference{Name: "inviter"},
+		},
+	}
+
+	// A service account outside the trusted list cannot attribute the invitation to another user
+	req := admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: "system:serviceaccount:tenant:robot", UID: "robot"},
+		},
+	}
+	ctx := admission.NewContextWithRequest(context.Background(), req)
+
+	robot := &iamv1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "robot", UID: "robot"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(robot).Build()
+
+	mutator := &UserInvitationMutator{
+		client:          fakeClient,
+		trustedInviters: []string{"system:serviceaccount:milo-system:milo-controller-manager"},
+	}
+	assert.NoError(t, mutator.Default(ctx, ui))
+	assert.Equal(t, "robot", ui.Spec.InvitedBy.Name, "invitedBy should be set to the requester")
+}
@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	iamv1alpha1 "go.miloapis.com/milo/pkg/apis/iam/v1alpha1"
+	"go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOrganizationValidator_createOrganizationMembership(t *testing.T) {
+	org := &v1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-org",
+			UID:  types.UID("org-123"),
+		},
+	}
+	user := &iamv1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-user",
+			UID:  types.UID("user-123"),
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(runtimeScheme).Build()
+	validator := &OrganizationValidator{
+		client:             fakeClient,
+		ownerRoleName:      "organization-owner",
+		ownerRoleNamespace: "milo-system",
+	}
+
+	require.NoError(t, validator.createOrganizationMembership(context.Background(), org, user))
+
+	membership := &v1alpha1.OrganizationMembership{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{
+		Name:      "member-test-user",
+		Namespace: "organization-test-org",
+	}, membership))
+
+	require.Len(t, membership.OwnerReferences, 1)
+	ownerRef := membership.OwnerReferences[0]
+
+	// The owner reference must carry a full group/version for the garbage collector to resolve the owner
+	gv, err := schema.ParseGroupVersion(ownerRef.APIVersion)
+	require.NoError(t, err)
+	assert.Equal(t, iamv1alpha1.SchemeGroupVersion, gv)
+	assert.Equal(t, "User", ownerRef.Kind)
+	assert.Equal(t, user.Name, ownerRef.Name)
+	assert.Equal(t, user.UID, ownerRef.UID)
+}
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -20,19 +21,29 @@ import (
 
 var organizationmembershiplog = logf.Log.WithName("organizationmembership-resource")
 
+// previewLaunchStage is the launch stage of roles that can be bound but may still change.
+const previewLaunchStage = "Preview"
+
+// DefaultDisallowedRoleLaunchStages are the role launch stages that memberships may not bind by default.
+var DefaultDisallowedRoleLaunchStages = []string{"Deprecated", "Disabled"}
+
 // +kubebuilder:webhook:path=/validate-resourcemanager-miloapis-com-v1alpha1-organizationmembership,mutating=false,failurePolicy=fail,sideEffects=None,groups=resourcemanager.miloapis.com,resources=organizationmemberships,verbs=create;update;delete,versions=v1alpha1,name=vorganizationmembership.datum.net,admissionReviewVersions={v1,v1beta1},serviceName=milo-controller-manager,servicePort=9443,serviceNamespace=milo-system
 
-// SetupOrganizationMembershipWebhooksWithManager sets up OrganizationMembership webhooks
-func SetupOrganizationMembershipWebhooksWithManager(mgr ctrl.Manager, organizationOwnerRoleName string, organizationOwnerRoleNamespace string) error {
+// SetupOrganizationMembershipWebhooksWithManager sets up OrganizationMembership webhooks. Organizations
+// must keep at least minOwners owners, or one when minOwners is below one, and memberships may not bind
+// roles whose launch stage is in disallowedRoleLaunchStages.
+func SetupOrganizationMembershipWebhooksWithManager(mgr ctrl.Manager, organizationOwnerRoleName string, organizationOwnerRoleNamespace string, minOwners int, disallowedRoleLaunchStages []string) error {
 	organizationmembershiplog.Info("Setting up resourcemanager.miloapis.com organizationmembership webhooks")
 
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&resourcemanagerv1alpha1.OrganizationMembership{}).
 		WithValidator(&OrganizationMembershipValidator{
-			client:             mgr.GetClient(),
-			apiReader:          mgr.GetAPIReader(),
-			ownerRoleName:      organizationOwnerRoleName,
-			ownerRoleNamespace: organizationOwnerRoleNamespace,
+			client:                 mgr.GetClient(),
+			apiReader:              mgr.GetAPIReader(),
+			ownerRoleName:          organizationOwnerRoleName,
+			ownerRoleNamespace:     organizationOwnerRoleNamespace,
+			minOwners:              minOwners,
+			disallowedLaunchStages: disallowedRoleLaunchStages,
 		}).
 		Complete()
 }
@@ -44,6 +55,10 @@ type OrganizationMembershipValidator struct {
 	decoder            admission.Decoder
 	ownerRoleName      string
 	ownerRoleNamespace string
+	// minOwners is the number of owners an organization must keep; values below one mean one.
+	minOwners int
+	// disallowedLaunchStages are the launch stages of roles that memberships may not bind.
+	disallowedLaunchStages []string
 }
 
 func (v *OrganizationMembershipValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -52,9 +67,7 @@ func (v *OrganizationMembershipValidator) ValidateCreate(ctx context.Context, ob
 
 	// Validate roles if specified
 	if len(membership.Spec.Roles) > 0 {
-		if err := v.validateRoles(ctx, membership); err != nil {
-			return nil, err
-		}
+		return v.validateRoles(ctx, membership, nil)
 	}
 
 	return nil, nil
@@ -66,17 +79,19 @@ func (v *OrganizationMembershipValidator) ValidateUpdate(ctx context.Context, ol
 	organizationmembershiplog.Info("Validating OrganizationMembership update", "name", newMembership.Name, "namespace", newMembership.Namespace)
 
 	// Validate roles if specified
+	var warnings admission.Warnings
 	if len(newMembership.Spec.Roles) > 0 {
-		if err := v.validateRoles(ctx, newMembership); err != nil {
-			return nil, err
+		var err error
+		if warnings, err = v.validateRoles(ctx, newMembership, oldMembership); err != nil {
+			return warnings, err
 		}
 	}
 
 	if err := v.ensureOwnerRoleNotRemovedFromLastOwner(ctx, oldMembership, newMembership); err != nil {
-		return nil, err
+		return warnings, err
 	}
 
-	return nil, nil
+	return warnings, nil
 }
 
 func (v *OrganizationMembershipValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -98,27 +113,11 @@ func (v *OrganizationMembershipValidator) ValidateDelete(ctx context.Context, ob
 		return nil, nil
 	}
 
-	var membershipList resourcemanagerv1alpha1.OrganizationMembershipList
-	if err := v.client.List(ctx, &membershipList, client.InNamespace(membership.Namespace)); err != nil {
-		return nil, fmt.Errorf("failed to list organization memberships: %w", err)
-	}
-
-	hasOtherOwner := false
-	for i := range membershipList.Items {
-		other := &membershipList.Items[i]
-		if other.Name == membership.Name {
-			continue
-		}
-		if other.Spec.OrganizationRef.Name != membership.Spec.OrganizationRef.Name {
-			continue
-		}
-		if v.isOwnerMembership(other) {
-			hasOtherOwner = true
-			break
-		}
+	otherOwners, err := v.countOtherOwners(ctx, membership)
+	if err != nil {
+		return nil, err
 	}
-
-	if hasOtherOwner {
+	if otherOwners >= v.minimumOwners() {
 		return nil, nil
 	}
 
@@ -192,21 +191,29 @@ func (v *OrganizationMembershipValidator) isNamespaceTerminating(ctx context.Con
 	return ns.DeletionTimestamp != nil
 }
 
-// validateRoles validates the role references in the membership
-func (v *OrganizationMembershipValidator) validateRoles(ctx context.Context, membership *resourcemanagerv1alpha1.OrganizationMembership) error {
+// validateRoles validates the role references in the membership, returning warnings for roles that
+// can be bound but are not yet stable. Roles the previous membership already bound are exempt from
+// the launch stage check, so a membership can still be edited after one of its roles is deprecated.
+func (v *OrganizationMembershipValidator) validateRoles(ctx context.Context, membership, previous *resourcemanagerv1alpha1.OrganizationMembership) (admission.Warnings, error) {
 	// Check for duplicate roles
 	if err := v.checkDuplicateRoles(membership); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Validate each role reference
+	var warnings admission.Warnings
 	for _, roleRef := range membership.Spec.Roles {
-		if err := v.validateRoleReference(ctx, membership, roleRef); err != nil {
-			return err
+		alreadyBound := previous != nil && hasRole(previous, roleNamespaceOf(membership, roleRef), roleRef.Name)
+		warning, err := v.validateRoleReference(ctx, membership, roleRef, alreadyBound)
+		if err != nil {
+			return nil, err
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
 		}
 	}
 
-	return nil
+	return warnings, nil
 }
 
 // isOwnerMembership returns true when the membership includes the configured owner role.
@@ -215,20 +222,27 @@ func (v *OrganizationMembershipValidator) isOwnerMembership(membership *resource
 		return false
 	}
 
-	for _, roleRef := range membership.Spec.Roles {
-		roleNamespace := roleRef.Namespace
-		if roleNamespace == "" {
-			roleNamespace = membership.Namespace
-		}
+	return hasRole(membership, v.ownerRoleNamespace, v.ownerRoleName)
+}
 
-		if roleRef.Name == v.ownerRoleName && roleNamespace == v.ownerRoleNamespace {
+// hasRole returns true when the membership binds the named role.
+func hasRole(membership *resourcemanagerv1alpha1.OrganizationMembership, namespace, name string) bool {
+	for _, roleRef := range membership.Spec.Roles {
+		if roleRef.Name == name && roleNamespaceOf(membership, roleRef) == namespace {
 			return true
 		}
 	}
-
 	return false
 }
 
+// roleNamespaceOf returns the namespace of a role reference, which defaults to the membership's.
+func roleNamespaceOf(membership *resourcemanagerv1alpha1.OrganizationMembership, roleRef resourcemanagerv1alpha1.RoleReference) string {
+	if roleRef.Namespace != "" {
+		return roleRef.Namespace
+	}
+	return membership.Namespace
+}
+
 func (v *OrganizationMembershipValidator) ensureOwnerRoleNotRemovedFromLastOwner(ctx context.Context, oldMembership, newMembership *resourcemanagerv1alpha1.OrganizationMembership) error {
 	var current resourcemanagerv1alpha1.OrganizationMembership
 	if err := v.client.Get(ctx, client.ObjectKey{Namespace: oldMembership.Namespace, Name: oldMembership.Name}, &current); err != nil {
@@ -246,37 +260,55 @@ func (v *OrganizationMembershipValidator) ensureOwnerRoleNotRemovedFromLastOwner
 		return nil
 	}
 
+	otherOwners, err := v.countOtherOwners(ctx, &current)
+	if err != nil {
+		return err
+	}
+	if otherOwners >= v.minimumOwners() {
+		return nil
+	}
+
+	return v.lastOwnerForbiddenError(&current, "update")
+}
+
+// minimumOwners returns the number of owners an organization must keep.
+func (v *OrganizationMembershipValidator) minimumOwners() int {
+	return max(v.minOwners, 1)
+}
+
+// countOtherOwners returns the number of owner memberships of the membership's organization,
+// excluding the membership itself.
+func (v *OrganizationMembershipValidator) countOtherOwners(ctx context.Context, membership *resourcemanagerv1alpha1.OrganizationMembership) (int, error) {
 	var membershipList resourcemanagerv1alpha1.OrganizationMembershipList
-	if err := v.client.List(ctx, &membershipList, client.InNamespace(current.Namespace)); err != nil {
-		return fmt.Errorf("failed to list organization memberships: %w", err)
+	if err := v.client.List(ctx, &membershipList, client.InNamespace(membership.Namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list organization memberships: %w", err)
 	}
 
-	hasOtherOwner := false
+	owners := 0
 	for i := range membershipList.Items {
 		other := &membershipList.Items[i]
-		if other.Name == current.Name {
+		if other.Name == membership.Name {
 			continue
 		}
-		if other.Spec.OrganizationRef.Name != current.Spec.OrganizationRef.Name {
+		if other.Spec.OrganizationRef.Name != membership.Spec.OrganizationRef.Name {
 			continue
 		}
 		if v.isOwnerMembership(other) {
-			hasOtherOwner = true
-			break
+			owners++
 		}
 	}
 
-	if hasOtherOwner {
-		return nil
-	}
-
-	return v.lastOwnerForbiddenError(&current, "update")
+	return owners, nil
 }
 
 func (v *OrganizationMembershipValidator) lastOwnerForbiddenError(membership *resourcemanagerv1alpha1.OrganizationMembership, action string) error {
+	required := "one owner"
+	if minOwners := v.minimumOwners(); minOwners > 1 {
+		required = fmt.Sprintf("%d owners", minOwners)
+	}
 	message := fmt.Sprintf(
-		"organization '%s' must have at least one owner. Assign the owner role to another member before removing this membership, or delete the organization instead if you intend to remove all owners.",
-		membership.Spec.OrganizationRef.Name,
+		"organization '%s' must have at least %s. Assign the owner role to another member before removing this membership, or delete the organization instead if you intend to remove all owners.",
+		membership.Spec.OrganizationRef.Name, required,
 	)
 
 	return apierrors.NewForbidden(
@@ -307,20 +339,19 @@ func (v *OrganizationMembershipValidator) checkDuplicateRoles(membership *resour
 	return nil
 }
 
-// validateRoleReference validates a single role reference
-func (v *OrganizationMembershipValidator) validateRoleReference(ctx context.Context, membership *resourcemanagerv1alpha1.OrganizationMembership, roleRef resourcemanagerv1alpha1.RoleReference) error {
+// validateRoleReference validates a single role reference, returning a warning when the role is in
+// the Preview launch stage
+func (v *OrganizationMembershipValidator) validateRoleReference(ctx context.Context, membership *resourcemanagerv1alpha1.OrganizationMembership, roleRef resourcemanagerv1alpha1.RoleReference, alreadyBound bool) (string, error) {
 	// Validate role name is not empty
 	if roleRef.Name == "" {
-		return fmt.Errorf("role name cannot be empty")
+		return "", fmt.Errorf("role name cannot be empty")
 	}
 
 	// Determine the namespace to check
-	roleNamespace := roleRef.Namespace
-	if roleNamespace == "" {
-		roleNamespace = membership.Namespace
-	}
+	roleNamespace := roleNamespaceOf(membership, roleRef)
 
-	// Verify the role exists
+	// Verify the role exists. v.client is the manager's informer-backed client, so this is served from
+	// the Role cache rather than the API server and tracks role edits and deletes as they are observed.
 	var role iamv1alpha1.Role
 	roleKey := client.ObjectKey{
 		Name:      roleRef.Name,
@@ -329,9 +360,14 @@ func (v *OrganizationMembershipValidator) validateRoleReference(ctx context.Cont
 
 	if err := v.client.Get(ctx, roleKey, &role); err != nil {
 		if client.IgnoreNotFound(err) == nil {
-			return fmt.Errorf("role '%s' not found in namespace '%s'", roleRef.Name, roleNamespace)
+			return "", fmt.Errorf("role '%s' not found in namespace '%s'", roleRef.Name, roleNamespace)
 		}
-		return fmt.Errorf("failed to verify role '%s' in namespace '%s': %w", roleRef.Name, roleNamespace, err)
+		return "", fmt.Errorf("failed to verify role '%s' in namespace '%s': %w", roleRef.Name, roleNamespace, err)
+	}
+
+	// Ensure a newly bound role is in a launch stage that members may be bound to
+	if !alreadyBound && slices.Contains(v.disallowedLaunchStages, role.Spec.LaunchStage) {
+		return "", fmt.Errorf("role '%s' in namespace '%s' cannot be bound because its launch stage is '%s'", roleRef.Name, roleNamespace, role.Spec.LaunchStage)
 	}
 
 	// Additional validation: ensure role is ready (if it has a status condition)
@@ -354,5 +390,9 @@ func (v *OrganizationMembershipValidator) validateRoleReference(ctx context.Cont
 		}
 	}
 
-	return nil
+	if role.Spec.LaunchStage == previewLaunchStage {
+		return fmt.Sprintf("role '%s' in namespace '%s' is in the %s launch stage and may change", roleRef.Name, roleNamespace, previewLaunchStage), nil
+	}
+
+	return "", nil
 }
@@ -13,6 +13,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -429,6 +430,96 @@ func TestOrganizationMembershipValidator_ValidateDelete_BlocksLastOwner(t *testi
 	}
 }
 
+func TestOrganizationMembershipValidator_ValidateDelete_EnforcesMinOwners(t *testing.T) {
+	ctx := context.TODO()
+	scheme := getWebhookTestScheme()
+
+	ownerMembership := func(user string) *resourcemanagerv1alpha1.OrganizationMembership {
+		return &resourcemanagerv1alpha1.OrganizationMembership{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "member-" + user,
+				Namespace: "organization-test",
+			},
+			Spec: resourcemanagerv1alpha1.OrganizationMembershipSpec{
+				OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{
+					Name: "test-org",
+				},
+				UserRef: resourcemanagerv1alpha1.MemberReference{
+					Name: user,
+				},
+				Roles: []resourcemanagerv1alpha1.RoleReference{
+					{
+						Name:      "resourcemanager.miloapis.com-organizationowner",
+						Namespace: "milo-system",
+					},
+				},
+			},
+		}
+	}
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "organization-test",
+			Finalizers: []string{"kubernetes"},
+		},
+	}
+	organization := &resourcemanagerv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-org",
+		},
+	}
+	alice := &iamv1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "alice",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		owners      []string
+		expectBlock bool
+	}{
+		{name: "blocks deleting down to a single owner", owners: []string{"alice", "bob"}, expectBlock: true},
+		{name: "allows deleting when two owners remain", owners: []string{"alice", "bob", "carol"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []runtime.Object{namespace.DeepCopy(), organization.DeepCopy(), alice.DeepCopy()}
+			for _, owner := range tt.owners {
+				objects = append(objects, ownerMembership(owner))
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(objects...).
+				Build()
+
+			validator := &OrganizationMembershipValidator{
+				client:             c,
+				apiReader:          c,
+				ownerRoleName:      "resourcemanager.miloapis.com-organizationowner",
+				ownerRoleNamespace: "milo-system",
+				minOwners:          2,
+			}
+
+			_, err := validator.ValidateDelete(ctx, ownerMembership("alice"))
+			if !tt.expectBlock {
+				if err != nil {
+					t.Fatalf("expected delete to succeed, got error: %v", err)
+				}
+				return
+			}
+			if !apierrors.IsForbidden(err) {
+				t.Fatalf("expected forbidden error, got: %v", err)
+			}
+			expectedSnippet := "must have at least 2 owners"
+			if !containsErrorMessage(err, expectedSnippet) {
+				t.Fatalf("expected error message to contain %q, got: %v", expectedSnippet, err)
+			}
+		})
+	}
+}
+
 func TestOrganizationMembershipValidator_ValidateDelete_AllowsWhenNamespaceTerminating(t *testing.T) {
 	ctx := context.TODO()
 	scheme := getWebhookTestScheme()
@@ -938,6 +1029,142 @@ func TestOrganizationMembershipValidator_ValidateCreate_MultipleRoles(t *testing
 	}
 }
 
+func TestOrganizationMembershipValidator_ValidateCreate_RoleLaunchStage(t *testing.T) {
+	ctx := context.TODO()
+	scheme := getWebhookTestScheme()
+
+	tests := []struct {
+		launchStage   string
+		expectError   bool
+		expectWarning bool
+	}{
+		{launchStage: "Early Access"},
+		{launchStage: "Alpha"},
+		{launchStage: "Beta"},
+		{launchStage: "Preview", expectWarning: true},
+		{launchStage: "Stable"},
+		{launchStage: "Deprecated", expectError: true},
+		{launchStage: "Disabled", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.launchStage, func(t *testing.T) {
+			role := &iamv1alpha1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-role",
+					Namespace: "organization-test",
+				},
+				Spec: iamv1alpha1.RoleSpec{
+					LaunchStage: tt.launchStage,
+				},
+			}
+
+			membership := &resourcemanagerv1alpha1.OrganizationMembership{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-membership",
+					Namespace: "organization-test",
+				},
+				Spec: resourcemanagerv1alpha1.OrganizationMembershipSpec{
+					OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{
+						Name: "test",
+					},
+					UserRef: resourcemanagerv1alpha1.MemberReference{
+						Name: "test-user",
+					},
+					Roles: []resourcemanagerv1alpha1.RoleReference{
+						{Name: "test-role"},
+					},
+				},
+			}
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(role).
+				Build()
+
+			validator := &OrganizationMembershipValidator{
+				client:                 c,
+				disallowedLaunchStages: DefaultDisallowedRoleLaunchStages,
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, membership)
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), "launch stage is '"+tt.launchStage+"'") {
+					t.Fatalf("expected launch stage error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateCreate failed: %v", err)
+			}
+			if tt.expectWarning != (len(warnings) > 0) {
+				t.Errorf("expected warning: %v, got warnings: %v", tt.expectWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestOrganizationMembershipValidator_ValidateUpdate_RoleLaunchStage(t *testing.T) {
+	ctx := context.TODO()
+	scheme := getWebhookTestScheme()
+
+	roles := []client.Object{
+		&iamv1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "deprecated-role", Namespace: "organization-test"},
+			Spec:       iamv1alpha1.RoleSpec{LaunchStage: "Deprecated"},
+		},
+		&iamv1alpha1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "stable-role", Namespace: "organization-test"},
+			Spec:       iamv1alpha1.RoleSpec{LaunchStage: "Stable"},
+		},
+	}
+
+	membershipWithRoles := func(names ...string) *resourcemanagerv1alpha1.OrganizationMembership {
+		membership := &resourcemanagerv1alpha1.OrganizationMembership{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-membership", Namespace: "organization-test"},
+			Spec: resourcemanagerv1alpha1.OrganizationMembershipSpec{
+				OrganizationRef: resourcemanagerv1alpha1.OrganizationReference{Name: "test"},
+				UserRef:         resourcemanagerv1alpha1.MemberReference{Name: "test-user"},
+			},
+		}
+		for _, name := range names {
+			membership.Spec.Roles = append(membership.Spec.Roles, resourcemanagerv1alpha1.RoleReference{Name: name})
+		}
+		return membership
+	}
+
+	tests := []struct {
+		name        string
+		oldRoles    []string
+		newRoles    []string
+		expectError bool
+	}{
+		{name: "keeps a role deprecated after it was bound", oldRoles: []string{"deprecated-role"}, newRoles: []string{"deprecated-role", "stable-role"}},
+		{name: "removes a deprecated role", oldRoles: []string{"deprecated-role", "stable-role"}, newRoles: []string{"stable-role"}},
+		{name: "adds a deprecated role", oldRoles: []string{"stable-role"}, newRoles: []string{"stable-role", "deprecated-role"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &OrganizationMembershipValidator{
+				client:                 fake.NewClientBuilder().WithScheme(scheme).WithObjects(roles...).Build(),
+				disallowedLaunchStages: DefaultDisallowedRoleLaunchStages,
+			}
+
+			_, err := validator.ValidateUpdate(ctx, membershipWithRoles(tt.oldRoles...), membershipWithRoles(tt.newRoles...))
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), "launch stage is 'Deprecated'") {
+					t.Fatalf("expected launch stage error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateUpdate failed: %v", err)
+			}
+		})
+	}
+}
+
 func TestOrganizationMembershipValidator_ValidateCreate_CrossNamespaceRole(t *testing.T) {
 	ctx := context.TODO()
 	scheme := getWebhookTestScheme()
@@ -0,0 +1,134 @@
+package v1alpha1
+
+import (
+	resourcemanagerv1alpha1 "go.miloapis.com/milo/pkg/apis/resourcemanager/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BulkUserInvitationReadyCondition is the condition Type that tracks whether every UserInvitation of a
+	// BulkUserInvitation was created.
+	BulkUserInvitationReadyCondition = "Ready"
+	// BulkUserInvitationCreatedReason is used when every UserInvitation was created.
+	BulkUserInvitationCreatedReason = "InvitationsCreated"
+	// BulkUserInvitationFailedReason is used when at least one UserInvitation could not be created.
+	BulkUserInvitationFailedReason = "InvitationsFailed"
+)
+
+// BulkUserInvitationResultState is the outcome of creating the UserInvitation for one email.
+type BulkUserInvitationResultState string
+
+const (
+	BulkUserInvitationResultCreated BulkUserInvitationResultState = "Created"
+	BulkUserInvitationResultFailed  BulkUserInvitationResultState = "Failed"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// BulkUserInvitation is the Schema for the bulkuserinvitations API
+// It invites several users to an Organization at once by creating a UserInvitation for each of them.
+// The UserInvitations are owned by the BulkUserInvitation and are deleted with it.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Organization",type=string,JSONPath=".spec.organizationRef.name"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=bulkuserinvitations,scope=Namespaced
+// +kubebuilder:metadata:annotations="discovery.miloapis.com/parent-contexts=Organization"
+type BulkUserInvitation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BulkUserInvitationSpec   `json:"spec,omitempty"`
+	Status BulkUserInvitationStatus `json:"status,omitempty"`
+}
+
+// BulkUserInvitationSpec defines the desired state of BulkUserInvitation
+type BulkUserInvitationSpec struct {
+	// OrganizationRef is a reference to the Organization that the users are invited to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="type(oldSelf) == null_type || self == oldSelf",message="organizationRef type is immutable"
+	OrganizationRef resourcemanagerv1alpha1.OrganizationReference `json:"organizationRef"`
+
+	// Invitations lists the users to invite. Each email may only appear once.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:XValidation:rule="type(oldSelf) == null_type || self == oldSelf",message="invitations type is immutable"
+	Invitations []BulkUserInvitationEntry `json:"invitations"`
+
+	// ExpirationDate is the date and time when the UserInvitations will expire.
+	// If not specified, the UserInvitations will never expire.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="type(oldSelf) == null_type || self == oldSelf",message="expirationDate type is immutable"
+	ExpirationDate *metav1.Time `json:"expirationDate,omitempty"`
+
+	// InvitedBy is the user who invited the users. A mutation webhook will default this field to the user who made the request.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="type(oldSelf) == null_type || self == oldSelf",message="invitedBy type is immutable"
+	InvitedBy UserReference `json:"invitedBy,omitempty"`
+}
+
+// BulkUserInvitationEntry describes one user to invite.
+type BulkUserInvitationEntry struct {
+	// The email of the user being invited.
+	// +kubebuilder:validation:Required
+	Email string `json:"email"`
+
+	// The first name of the user being invited.
+	// +kubebuilder:validation:Optional
+	GivenName string `json:"givenName,omitempty"`
+
+	// The last name of the user being invited.
+	// +kubebuilder:validation:Optional
+	FamilyName string `json:"familyName,omitempty"`
+
+	// The roles that will be assigned to the user when they accept the invitation.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Roles []RoleReference `json:"roles"`
+}
+
+// BulkUserInvitationStatus defines the observed state of BulkUserInvitation
+type BulkUserInvitationStatus struct {
+	// Conditions provide conditions that represent the current status of the BulkUserInvitation.
+	// +kubebuilder:default={{type: "Ready", status: "Unknown", reason: "ReconcilePending", message: "Bulk user invitation reconciliation is pending", lastTransitionTime: "1970-01-01T00:00:00Z"}}
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Invitations reports the outcome of creating the UserInvitation for each email, in the order of
+	// spec.invitations.
+	// +kubebuilder:validation:Optional
+	Invitations []BulkUserInvitationResult `json:"invitations,omitempty"`
+}
+
+// BulkUserInvitationResult is the outcome of creating the UserInvitation for one email.
+type BulkUserInvitationResult struct {
+	// Email is the email of the invited user.
+	// +kubebuilder:validation:Required
+	Email string `json:"email"`
+
+	// UserInvitationName is the name of the UserInvitation created for the email.
+	// +kubebuilder:validation:Optional
+	UserInvitationName string `json:"userInvitationName,omitempty"`
+
+	// State is whether the UserInvitation was created.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Created;Failed
+	State BulkUserInvitationResultState `json:"state"`
+
+	// Message explains why the UserInvitation could not be created.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// BulkUserInvitationList contains a list of BulkUserInvitation
+type BulkUserInvitationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BulkUserInvitation `json:"items"`
+}
@@ -47,6 +47,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PlatformAccessApprovalList{},
 		&PlatformAccessRejection{},
 		&PlatformAccessRejectionList{},
+		&BulkUserInvitation{},
+		&BulkUserInvitationList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
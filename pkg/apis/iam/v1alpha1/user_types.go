@@ -67,6 +67,10 @@ type UserSpec struct {
 	// The last name of the user.
 	// +kubebuilder:validation:Optional
 	FamilyName string `json:"familyName,omitempty"`
+	// The preferred locale of the user, such as "de" or "pt-BR". Emails sent to the user are
+	// localized for it when a template is configured for the locale.
+	// +kubebuilder:validation:Optional
+	Locale string `json:"locale,omitempty"`
 }
 
 // UserStatus defines the observed state of User
@@ -28,6 +28,16 @@ const (
 	UserInvitationPendingCondition UserInvitationConditionType = "Pending"
 )
 
+// UserInvitationResendEmailAnnotation requests that the invitation email is sent again. Its value is
+// a counter: each time it is set to a number greater than status.lastEmailResend, one more email is sent.
+const UserInvitationResendEmailAnnotation = "iam.miloapis.com/resend-email"
+
+// UserInvitationLocaleAnnotation sets the invitee's preferred locale, such as "de" or "pt-BR", and takes
+// precedence over the locale of an existing invitee User. The invitation email uses the template
+// configured for the locale, or for its language when there is no template for the region, falling
+// back to the default invitation email template.
+const UserInvitationLocaleAnnotation = "iam.miloapis.com/locale"
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 
@@ -115,6 +125,11 @@ type UserInvitationStatus struct {
 	// This value may be nil if the invitee user has not been created yet.
 	// +kubebuilder:validation:Optional
 	InviteeUser *UserInvitationInviteeUserStatus `json:"inviteeUser,omitempty"`
+
+	// LastEmailResend is the value of the iam.miloapis.com/resend-email annotation that was last
+	// processed, so each resend request sends a single email.
+	// +kubebuilder:validation:Optional
+	LastEmailResend int32 `json:"lastEmailResend,omitempty"`
 }
 
 // UserInvitationOrganizationStatus contains information about the organization in the invitation.
@@ -9,6 +9,155 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkUserInvitation) DeepCopyInto(out *BulkUserInvitation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkUserInvitation.
+func (in *BulkUserInvitation) DeepCopy() *BulkUserInvitation {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkUserInvitation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BulkUserInvitation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkUserInvitationEntry) DeepCopyInto(out *BulkUserInvitationEntry) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]RoleReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkUserInvitationEntry.
+func (in *BulkUserInvitationEntry) DeepCopy() *BulkUserInvitationEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkUserInvitationEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkUserInvitationList) DeepCopyInto(out *BulkUserInvitationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BulkUserInvitation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkUserInvitationList.
+func (in *BulkUserInvitationList) DeepCopy() *BulkUserInvitationList {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkUserInvitationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BulkUserInvitationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkUserInvitationResult) DeepCopyInto(out *BulkUserInvitationResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkUserInvitationResult.
+func (in *BulkUserInvitationResult) DeepCopy() *BulkUserInvitationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkUserInvitationResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkUserInvitationSpec) DeepCopyInto(out *BulkUserInvitationSpec) {
+	*out = *in
+	out.OrganizationRef = in.OrganizationRef
+	if in.Invitations != nil {
+		in, out := &in.Invitations, &out.Invitations
+		*out = make([]BulkUserInvitationEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpirationDate != nil {
+		in, out := &in.ExpirationDate, &out.ExpirationDate
+		*out = (*in).DeepCopy()
+	}
+	out.InvitedBy = in.InvitedBy
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkUserInvitationSpec.
+func (in *BulkUserInvitationSpec) DeepCopy() *BulkUserInvitationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkUserInvitationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkUserInvitationStatus) DeepCopyInto(out *BulkUserInvitationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Invitations != nil {
+		in, out := &in.Invitations, &out.Invitations
+		*out = make([]BulkUserInvitationResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkUserInvitationStatus.
+func (in *BulkUserInvitationStatus) DeepCopy() *BulkUserInvitationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkUserInvitationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Group) DeepCopyInto(out *Group) {
 	*out = *in
@@ -14,6 +14,12 @@ type ContributingGrantRef struct {
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 
+	// Namespace of the ResourceGrant. Grants of the same consumer may share a name
+	// across namespaces, so the name alone does not identify the grant.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
 	// LastObservedGeneration records the ResourceGrant's generation when the bucket
 	// quota system last processed it. Used to detect when grants have been updated
 	// and the bucket needs to recalculate its aggregated limit.
@@ -30,6 +36,48 @@ type ContributingGrantRef struct {
 	Amount int64 `json:"amount"`
 }
 
+// BorrowedCapacity records capacity borrowed from the consumer's bucket for another resource type.
+type BorrowedCapacity struct {
+	// ResourceType of the lender bucket.
+	//
+	// +kubebuilder:validation:Required
+	ResourceType string `json:"resourceType"`
+
+	// Amount borrowed, in the BaseUnit.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	Amount int64 `json:"amount"`
+}
+
+// TopClaimRef identifies a granted ResourceClaim that consumes a large share of a bucket's
+// allocated quota. The quota system reports the largest consumers to support capacity planning.
+type TopClaimRef struct {
+	// Name identifies the ResourceClaim consuming quota from this bucket.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace identifies the namespace of the ResourceClaim.
+	//
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Project identifies the Project control plane the ResourceClaim was made in,
+	// when it is counted by an OrganizationTree bucket. Empty for claims in the
+	// bucket's own control plane.
+	//
+	// +kubebuilder:validation:Optional
+	Project string `json:"project,omitempty"`
+
+	// Amount specifies how much quota the claim has been allocated from this bucket.
+	// Measured in BaseUnit.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	Amount int64 `json:"amount"`
+}
+
 // AllowanceBucketSpec defines the desired state of AllowanceBucket.
 // The system automatically creates buckets for each unique (consumer, resourceType) combination
 // found in active ResourceGrants.
@@ -89,6 +137,16 @@ type AllowanceBucketStatus struct {
 	// +kubebuilder:validation:Required
 	Limit int64 `json:"limit"`
 
+	// ProjectedLimit is the limit this bucket would have once its pending ResourceGrants
+	// activate: the Limit plus the bucket amounts of matching grants that have not been
+	// validated yet. Grants that failed validation or expired are excluded.
+	//
+	// It is informational, for capacity planning; claims are only evaluated against Limit.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ProjectedLimit int64 `json:"projectedLimit,omitempty"`
+
 	// Allocated represents the total quota currently consumed by granted ResourceClaims.
 	// Calculated by summing all allocation amounts from ResourceClaims with status.conditions[type=Granted]=True
 	// that match the bucket's spec.consumerRef and have requests for spec.resourceType.
@@ -103,7 +161,8 @@ type AllowanceBucketStatus struct {
 	Allocated int64 `json:"allocated"`
 
 	// Available represents the quota capacity remaining for new ResourceClaims.
-	// Always calculated as: Available = Limit - Allocated (never negative).
+	// Always calculated as: Available = Limit + borrowed - Allocated - Lent (never negative),
+	// where borrowed is the sum of Borrowed amounts.
 	// The system uses this value to determine whether new ResourceClaims can be granted.
 	//
 	// Decision logic:
@@ -149,6 +208,61 @@ type AllowanceBucketStatus struct {
 	// +kubebuilder:validation:Optional
 	ContributingGrantRefs []ContributingGrantRef `json:"contributingGrantRefs,omitempty"`
 
+	// AggregationScope reports which ResourceClaims count against this bucket. It is
+	// "OrganizationTree" when any contributing grant uses that scope, in which case
+	// allocated usage includes claims made in the control planes of the Organization's
+	// Projects, and their Project buckets are capped by this bucket's availability.
+	// Otherwise it is empty and only the consumer's claims count.
+	//
+	// +kubebuilder:validation:Optional
+	AggregationScope AggregationScope `json:"aggregationScope,omitempty"`
+
+	// Borrowed lists the capacity this bucket has borrowed from the consumer's buckets
+	// for other resource types under its grants' borrow policy. Borrowed capacity is
+	// returned as this bucket's own claims are released.
+	//
+	// +kubebuilder:validation:Optional
+	Borrowed []BorrowedCapacity `json:"borrowed,omitempty"`
+
+	// Lent is the capacity the consumer's buckets for other resource types have
+	// borrowed from this bucket. It is unavailable to this bucket's own claims.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Lent int64 `json:"lent,omitempty"`
+
+	// TopClaims lists the granted ResourceClaims with the largest allocations from this bucket,
+	// ordered from largest to smallest. The list is bounded so that buckets with many claims
+	// stay small; use it to identify what is consuming the most quota.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	TopClaims []TopClaimRef `json:"topClaims,omitempty"`
+
+	// NotifiedUsageThreshold is the highest usage webhook threshold, in percent of Limit,
+	// that utilization was at or above when the webhook last received a notification for
+	// this bucket. Zero means utilization was below every threshold. A notification is
+	// owed while current utilization falls in a different threshold band, so crossings
+	// whose delivery failed are retried rather than lost.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	NotifiedUsageThreshold int32 `json:"notifiedUsageThreshold,omitempty"`
+
+	// Conditions represents the latest available observations of the bucket's state.
+	//
+	// Standard condition types:
+	// - "Overcommitted": Indicates whether more quota is allocated than the bucket's limit
+	//   provides, e.g. after a contributing grant was revoked. When True, the message reports
+	//   the deficit and no new claims are granted until usage drops below the limit.
+	//
+	// Standard condition reasons for "Overcommitted":
+	// - "AllocationExceedsLimit": Allocated and lent capacity exceed the limit and borrowed capacity
+	// - "WithinLimit": Allocated and lent capacity fit within the limit and borrowed capacity
+	//
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
 	// LastReconciliation records when the quota system last recalculated this status.
 	// Used for monitoring quota system health and understanding how fresh the aggregated data is.
 	//
@@ -159,6 +273,20 @@ type AllowanceBucketStatus struct {
 	LastReconciliation *metav1.Time `json:"lastReconciliation,omitempty"`
 }
 
+// Condition type constants for AllowanceBucket
+const (
+	// Indicates whether more quota is allocated than the bucket's limit provides
+	AllowanceBucketOvercommitted = "Overcommitted"
+)
+
+// Condition reason constants for AllowanceBucket status updates
+const (
+	// Allocated and lent capacity exceed the limit and borrowed capacity
+	AllowanceBucketAllocationExceedsLimitReason = "AllocationExceedsLimit"
+	// Allocated and lent capacity fit within the limit and borrowed capacity
+	AllowanceBucketWithinLimitReason = "WithinLimit"
+)
+
 // **AllowanceBucket** aggregates quota limits and usage for a single (consumer, resourceType) combination.
 // The system automatically creates buckets to provide real-time quota availability information
 // for **ResourceClaim** evaluation during admission.
@@ -183,7 +311,8 @@ type AllowanceBucketStatus struct {
 // requests, creating a running total of consumed quota capacity.
 //
 // The available quota emerges from this simple relationship: Available = Limit - Allocated. The
-// system ensures this value never goes negative, treating any calculated negative as zero. This
+// system ensures this value never goes negative, treating any calculated negative as zero, and
+// reports buckets whose allocation exceeds their limit through the Overcommitted condition. This
 // available amount represents the quota capacity remaining for new **ResourceClaims** and drives
 // real-time admission decisions throughout the cluster.
 //
@@ -209,7 +338,7 @@ type AllowanceBucketStatus struct {
 //
 // **Scale Optimization:**
 // - Stores aggregates (limit, allocated, available) rather than individual entries
-// - ContributingGrantRefs tracks grants (few) but not claims (many)
+// - ContributingGrantRefs tracks grants (few); claims (many) are only summarized via TopClaims
 // - Single bucket per (consumer, resourceType) regardless of claim count
 //
 // ### Status Information
@@ -219,6 +348,7 @@ type AllowanceBucketStatus struct {
 // - **ClaimCount**: Number of granted claims consuming from this bucket
 // - **GrantCount**: Number of active grants contributing to this bucket
 // - **ContributingGrantRefs**: Detailed information about contributing grants
+// - **TopClaims**: The largest granted claims consuming from this bucket
 //
 // ### Monitoring and Troubleshooting
 // **Quota Monitoring:**
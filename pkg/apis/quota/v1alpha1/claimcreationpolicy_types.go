@@ -21,6 +21,57 @@ type ClaimCreationPolicySpec struct {
 	// +kubebuilder:default=false
 	// +optional
 	Disabled *bool `json:"disabled,omitempty"`
+	// ExemptSubjects lists users and groups whose requests are admitted without
+	// creating **ResourceClaims**, so platform operators can create resources for
+	// incident response while a consumer is out of quota.
+	//
+	// In project control planes only groups prefixed with "system:" are honored,
+	// as those are assigned by the platform rather than by tenants.
+	//
+	// +optional
+	ExemptSubjects *ClaimExemptSubjects `json:"exemptSubjects,omitempty"`
+	// EnforcementMode controls whether denied **ResourceClaims** block the
+	// triggering request.
+	//
+	// - "Enforce" (default): requests whose claim is denied are rejected
+	// - "Audit": claims are still created and evaluated, but a denial is only
+	//   reported as a warning and in metrics, so a policy can be rolled out
+	//   before it starts rejecting requests
+	//
+	// +kubebuilder:validation:Enum=Enforce;Audit
+	// +kubebuilder:default=Enforce
+	// +optional
+	EnforcementMode ClaimEnforcementMode `json:"enforcementMode,omitempty"`
+}
+
+// ClaimEnforcementMode identifies how a ClaimCreationPolicy acts on denied claims.
+type ClaimEnforcementMode string
+
+const (
+	// ClaimEnforcementModeEnforce rejects requests whose claim is denied.
+	ClaimEnforcementModeEnforce ClaimEnforcementMode = "Enforce"
+	// ClaimEnforcementModeAudit admits requests whose claim is denied, reporting
+	// the denial without acting on it.
+	ClaimEnforcementModeAudit ClaimEnforcementMode = "Audit"
+)
+
+// ClaimExemptSubjects identifies the requesters a ClaimCreationPolicy does not apply to.
+type ClaimExemptSubjects struct {
+	// Users lists exempt user names. Only honored in the root control plane.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:items:MinLength=1
+	Users []string `json:"users,omitempty"`
+	// Groups lists exempt groups. In project control planes only groups
+	// prefixed with "system:" are honored. Groups that tenants belong to or
+	// control, such as system:authenticated and the system:serviceaccounts
+	// groups, are rejected.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:items:MinLength=1
+	Groups []string `json:"groups,omitempty"`
 }
 
 // ClaimTriggerResource identifies the resource type that triggers this policy.
@@ -38,12 +89,47 @@ type ClaimTriggerResource struct {
 	Kind string `json:"kind"`
 }
 
-// ClaimTriggerSpec defines the resource type and optional conditions for triggering claim creation.
+// ClaimTriggerSpec defines the resource types and optional conditions for triggering claim creation.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.resource) != has(self.resources)",message="exactly one of resource or resources must be set"
 type ClaimTriggerSpec struct {
-	// Resource specifies which resource type triggers this policy.
+	// Resource specifies a single resource type that triggers this policy.
+	// Mutually exclusive with resources.
 	//
-	// +kubebuilder:validation:Required
-	Resource ClaimTriggerResource `json:"resource"`
+	// +optional
+	Resource *ClaimTriggerResource `json:"resource,omitempty"`
+	// Resources specifies several resource types that trigger this policy with
+	// the same constraints and claim template. The template is rendered against
+	// whichever kind triggered the policy. Mutually exclusive with resource.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	Resources []ClaimTriggerResource `json:"resources,omitempty"`
+	// Subresource limits the policy to scale-ups through the `scale` subresource of the
+	// trigger resource. The trigger resource must be the kind the API server reports
+	// for the subresource, `Scale` in `autoscaling/v1`. Each added replica is charged
+	// by its own ResourceClaim rendered from the template, which is released when the
+	// resource scales back down or is deleted. Omit to only match creates of the
+	// resource itself.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=scale
+	Subresource string `json:"subresource,omitempty"`
+	// NamespaceSelector limits the policy to objects in namespaces whose labels
+	// match the selector. Cluster-scoped objects are matched regardless of the
+	// selector, except Namespaces, which are matched against their own labels.
+	// Omit to match objects in every namespace.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ObjectSelector limits the policy to objects whose labels match the selector.
+	// Omit to match every object.
+	//
+	// Example: `matchLabels: {tier: premium}` only enforces quota for premium objects.
+	//
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
 	// Constraints are CEL expressions that must evaluate to true for claim creation to occur.
 	// These are pure CEL expressions WITHOUT {{ }} delimiters (unlike template fields).
 	// Evaluated in the admission context.
@@ -126,7 +212,7 @@ type ObjectMetaTemplate struct {
 	Namespace string `json:"namespace,omitempty"`
 
 	// Labels specifies static labels to apply to the created ResourceClaim.
-	// Values are literal strings (no template processing).
+	// Values are literal strings for ResourceClaims. ResourceGrant label values support CEL expressions wrapped in {{ }} delimiters.
 	// The system automatically adds standard labels for policy tracking.
 	//
 	// Useful for:
@@ -201,13 +287,32 @@ func (t *ClaimTriggerResource) GetGVK() schema.GroupVersionKind {
 	}
 }
 
+// GetResources returns every resource type that triggers the policy, whether it
+// is named by resource or listed in resources.
+func (t *ClaimTriggerSpec) GetResources() []ClaimTriggerResource {
+	if t.Resource != nil {
+		return append([]ClaimTriggerResource{*t.Resource}, t.Resources...)
+	}
+	return t.Resources
+}
+
+// GetGVKs returns the GVK of every resource type that triggers the policy.
+func (t *ClaimTriggerSpec) GetGVKs() []schema.GroupVersionKind {
+	resources := t.GetResources()
+	gvks := make([]schema.GroupVersionKind, 0, len(resources))
+	for i := range resources {
+		gvks = append(gvks, resources[i].GetGVK())
+	}
+	return gvks
+}
+
 // ClaimCreationPolicy automatically creates ResourceClaims during admission to enforce quota in real-time.
 // Policies intercept resource creation requests, evaluate trigger conditions, and generate
 // quota claims that prevent resource creation when quota limits are exceeded.
 //
 // ### How It Works
-// 1. **Trigger Matching**: Admission webhook matches incoming resource creates against spec.trigger.resource
-// 2. **Constraint Evaluation**: All CEL expressions in spec.trigger.constraints must evaluate to true
+// 1. **Trigger Matching**: Admission webhook matches incoming resource creates against spec.trigger.resource or spec.trigger.resources, or scale-ups through spec.trigger.subresource when it is set
+// 2. **Constraint Evaluation**: The object must match spec.trigger.namespaceSelector and spec.trigger.objectSelector, and all CEL expressions in spec.trigger.constraints must evaluate to true
 // 3. **Template Rendering**: Policy renders spec.target.resourceClaimTemplate using available template variables
 // 4. **Claim Creation**: System creates the rendered ResourceClaim in the specified namespace
 // 5. **Quota Evaluation**: Claim is immediately evaluated against AllowanceBucket capacity
@@ -244,6 +349,11 @@ func (t *ClaimTriggerResource) GetGVK() schema.GroupVersionKind {
 // - `{{trigger.metadata.labels["tier"] + "-tier"}}` - Label-based naming (spec)
 // - `fixed-claim-name` - Literal string only (no evaluation)
 //
+// **Template Functions:** `lower(s)`, `trunc(s, n)`, `sha256sum(s)`, `default(fallback, value)`, and
+// `trimSuffix(s, suffix)` behave like their sprig counterparts. They are side-effect free, so a
+// name built from them is the same on every evaluation:
+// - `{{trunc(lower(trigger.metadata.name), 40)}}-{{trunc(sha256sum(trigger.metadata.namespace + '/' + trigger.metadata.name), 8)}}` - Bounded, stable claim name
+//
 // **Use Template Expressions For:** ResourceClaimTemplate fields (metadata and spec)
 //
 // ### Constraint Expressions
@@ -260,6 +370,7 @@ func (t *ClaimTriggerResource) GetGVK() schema.GroupVersionKind {
 // - `trigger.metadata.labels["environment"] == "prod"` - Label-based filtering
 // - `user.groups.exists(g, g == "admin")` - User authorization check
 // - `has(trigger.spec.quotaProfile)` - Field existence check
+// - `now().getHours() >= 9 && now().getHours() < 17` - Business-hours window; `now()` returns the current UTC time
 //
 // **Use Constraint Expressions For:** spec.trigger.constraints fields
 //
@@ -275,6 +386,9 @@ func (t *ClaimTriggerResource) GetGVK() schema.GroupVersionKind {
 // **requestInfo**: Operational context including the API verb being performed and resource type
 // being manipulated. Useful for distinguishing between create, update, and delete operations.
 //
+// **parent**: The Organization that owns the project the request was made in, such as
+// `parent.spec.type`. Only set for requests made in a project control plane.
+//
 // **CEL Functions**: Standard CEL functions available for data manipulation including conditional
 // expressions (`condition ? value1 : value2`), string methods (`lowerAscii()`, `upperAscii()`, `trim()`),
 // and collection operations (`exists()`, `all()`, `filter()`).
@@ -323,7 +437,7 @@ func (t *ClaimTriggerResource) GetGVK() schema.GroupVersionKind {
 // - Template annotation values support CEL expressions
 //
 // ### Selectors and Filtering
-// - **Field selectors**: spec.trigger.resource.kind, spec.trigger.resource.apiVersion, spec.disabled
+// - **Field selectors**: spec.trigger.resource.kind, spec.trigger.resource.apiVersion (single-kind triggers only), spec.disabled
 // - **Recommended labels** (add manually):
 //   - quota.miloapis.com/target-kind: Project
 //   - quota.miloapis.com/environment: production
@@ -269,6 +269,7 @@ func (t *GrantTriggerResource) GetGVK() schema.GroupVersionKind {
 // - `trigger.metadata.labels["environment"] == "prod"` - Label-based filtering
 // - `trigger.status.phase == "Active"` - Status condition check
 // - `has(trigger.spec.quotaProfile)` - Field existence check
+// - `now().getHours() >= 9 && now().getHours() < 17` - Business-hours window; `now()` returns the current UTC time
 //
 // **Use Constraint Expressions For:** spec.trigger.constraints fields
 //
@@ -311,6 +312,7 @@ func (t *GrantTriggerResource) GetGVK() schema.GroupVersionKind {
 // ### Notes
 // - If `ParentContextReady=False`, verify `nameExpression` and referenced attributes.
 // - Disabled policies (`spec.disabled=true`) do not create grants.
+// - Deleting a policy deletes the grants it created (labeled `quota.miloapis.com/policy=<policy-name>`); grants created by hand are left alone.
 //
 // ### See Also
 // - [ResourceGrant](#resourcegrant): The object created by this policy.
@@ -0,0 +1,132 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QuotaSnapshotSpec defines which consumer a QuotaSnapshot captures.
+//
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type QuotaSnapshotSpec struct {
+	// ConsumerRef identifies the quota consumer whose allocations are captured.
+	// Every AllowanceBucket tracking this consumer contributes one entry to the snapshot.
+	//
+	// +kubebuilder:validation:Required
+	ConsumerRef ConsumerRef `json:"consumerRef"`
+}
+
+// QuotaSnapshotAllocation records a consumer's quota for one resource type at capture time.
+type QuotaSnapshotAllocation struct {
+	// ResourceType is the resource type of the captured AllowanceBucket.
+	//
+	// +kubebuilder:validation:Required
+	ResourceType string `json:"resourceType"`
+
+	// Limit is the bucket's total quota capacity at capture time, in BaseUnit.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	Limit int64 `json:"limit"`
+
+	// Allocated is the quota consumed by granted ResourceClaims at capture time, in BaseUnit.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	Allocated int64 `json:"allocated"`
+
+	// ClaimCount is the number of granted ResourceClaims counted in Allocated.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	ClaimCount int32 `json:"claimCount"`
+
+	// BucketLastReconciliation is when the quota system last recalculated the bucket
+	// before it was captured, indicating how fresh the captured figures are.
+	//
+	// +kubebuilder:validation:Optional
+	BucketLastReconciliation *metav1.Time `json:"bucketLastReconciliation,omitempty"`
+}
+
+// QuotaSnapshotStatus holds the captured allocations. It is written once and cannot
+// change afterwards.
+//
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.capturedAt) || self == oldSelf",message="snapshot is immutable once captured"
+type QuotaSnapshotStatus struct {
+	// CapturedAt is when the allocations were captured. Set once by the quota system.
+	//
+	// +kubebuilder:validation:Optional
+	CapturedAt *metav1.Time `json:"capturedAt,omitempty"`
+
+	// Allocations lists the consumer's quota per resource type, ordered by resource type.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=resourceType
+	Allocations []QuotaSnapshotAllocation `json:"allocations,omitempty"`
+
+	// Conditions represents the latest available observations of the snapshot's state.
+	//
+	// Known condition types: "Captured"
+	//
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// Condition type constants for QuotaSnapshot
+const (
+	// Indicates whether the snapshot's allocations have been captured
+	QuotaSnapshotCaptured = "Captured"
+)
+
+// Condition reason constants for QuotaSnapshot status updates
+const (
+	// The consumer's allocations were captured
+	QuotaSnapshotCapturedReason = "AllocationsCaptured"
+)
+
+// **QuotaSnapshot** records a consumer's quota limits and allocations at a point in time,
+// giving billing a consistent end-of-period view that later quota changes cannot alter.
+//
+// ### How It Works
+// 1. **Request**: Create a **QuotaSnapshot** naming the consumer in spec.consumerRef
+// 2. **Capture**: Quota system copies limit, allocated, and claim count from every **AllowanceBucket** tracking the consumer
+// 3. **Seal**: status.capturedAt is set and the snapshot can no longer change
+//
+// ### Immutability
+// The spec cannot change after creation, and the status cannot change once status.capturedAt
+// is set. To capture newer figures, create another snapshot.
+//
+// ### Consistency
+// Each entry is copied from a bucket's status, so it is as fresh as that bucket's last
+// recalculation, recorded as bucketLastReconciliation. Entries are captured in one pass
+// without locking buckets; claims granted during the pass may be reflected in some entries
+// but not others.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +k8s:openapi-gen=true
+// +kubebuilder:printcolumn:name="Consumer Kind",type="string",JSONPath=".spec.consumerRef.kind"
+// +kubebuilder:printcolumn:name="Consumer Name",type="string",JSONPath=".spec.consumerRef.name"
+// +kubebuilder:printcolumn:name="Captured At",type="date",JSONPath=".status.capturedAt"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:selectablefield:JSONPath=".spec.consumerRef.kind"
+// +kubebuilder:selectablefield:JSONPath=".spec.consumerRef.name"
+// +kubebuilder:metadata:annotations="discovery.miloapis.com/parent-contexts=Organization,Project"
+type QuotaSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:Required
+	Spec   QuotaSnapshotSpec   `json:"spec"`
+	Status QuotaSnapshotStatus `json:"status,omitempty"`
+}
+
+// QuotaSnapshotList contains a list of QuotaSnapshot.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+type QuotaSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QuotaSnapshot `json:"items"`
+}
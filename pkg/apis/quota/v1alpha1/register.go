@@ -40,6 +40,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClaimCreationPolicyList{},
 		&GrantCreationPolicy{},
 		&GrantCreationPolicyList{},
+		&QuotaSnapshot{},
+		&QuotaSnapshotList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 	return nil
@@ -42,6 +42,21 @@ type ResourceRequest struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Required
 	Amount int64 `json:"amount"`
+
+	// AmountExpression computes the amount from the triggering resource using a
+	// CEL expression that must evaluate to a non-negative integer. Only
+	// supported in ClaimCreationPolicy claim templates, where it replaces Amount
+	// in the rendered claim. ResourceClaims must not set this field.
+	// A request whose expression evaluates to zero is left out of the claim,
+	// and no claim is created when no request remains.
+	//
+	// Examples:
+	//
+	//   - "trigger.spec.replicas" (claim one unit per Deployment replica)
+	//   - "trigger.spec.replicas * 2" (claim two units per replica)
+	//
+	// +kubebuilder:validation:Optional
+	AmountExpression string `json:"amountExpression,omitempty"`
 }
 
 // ResourceClaimSpec defines the desired state of ResourceClaim.
@@ -76,6 +91,31 @@ type ResourceClaimSpec struct {
 	// +kubebuilder:validation:MaxItems=20
 	Requests []ResourceRequest `json:"requests"`
 
+	// AllowPartial lets the claim proceed with less quota than requested.
+	// When a request cannot be fully satisfied, the system grants whatever
+	// capacity remains in the bucket (min(requested, available)) and records
+	// the reduced amount in status.allocations[].allocatedAmount. A request is
+	// still denied when no capacity is available.
+	//
+	// Defaults to false: requests are granted in full or denied.
+	//
+	// +kubebuilder:validation:Optional
+	AllowPartial bool `json:"allowPartial,omitempty"`
+
+	// Priority orders pending claims competing for the same AllowanceBucket.
+	// Claims with a higher priority are evaluated first, so when capacity is
+	// tight a critical claim is granted and lower priority claims pending at the
+	// same time are denied. Claims that are already granted are never revoked.
+	// Claims of equal priority are evaluated oldest first.
+	//
+	// Claims created by a ClaimCreationPolicy take the priority set in its
+	// claim template. Defaults to 0.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000
+	Priority int32 `json:"priority,omitempty"`
+
 	// ResourceRef identifies the actual Kubernetes resource that triggered this
 	// claim. ClaimCreationPolicy automatically populates this field during
 	// admission. Uses unversioned reference (apiGroup + kind + name + namespace)
@@ -90,6 +130,74 @@ type ResourceClaimSpec struct {
 	//   - User resource triggering User quota claim
 	//   - Organization resource triggering storage quota claim
 	ResourceRef UnversionedObjectReference `json:"resourceRef,omitempty"`
+
+	// TTLSecondsAfterCreation limits how long the claim may remain ungranted.
+	// Once the claim is older than this many seconds and still has not been
+	// granted, the system deletes it so stale claims stop affecting bucket
+	// recalculation. Granted claims are exempt unless the resource in
+	// resourceRef no longer exists.
+	//
+	// The admission plugin sets a default on claims it creates automatically.
+	// When omitted, the claim never expires.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TTLSecondsAfterCreation *int64 `json:"ttlSecondsAfterCreation,omitempty"`
+
+	// TTLSecondsAfterFinished releases the claim, granted or not, once this many
+	// seconds have passed since the resource in resourceRef finished, that is since
+	// it reported a Complete or Failed condition with status True. Claims whose
+	// resource has not finished do not expire under this field, which makes it
+	// suitable for run-to-completion resources such as Jobs. It is independent of
+	// ttlSecondsAfterCreation, which still bounds how long the claim may remain
+	// ungranted.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// TTLSecondsAfterFinishedExpression computes ttlSecondsAfterFinished from
+	// the triggering resource using a CEL expression, so the claim is released
+	// together with a resource that is cleaned up after it finishes (for example a
+	// Job's ttlSecondsAfterFinished). Only supported in ClaimCreationPolicy claim
+	// templates. The expression has access to the trigger, user, and requestInfo
+	// variables and must return a non-negative integer.
+	//
+	// Examples:
+	//
+	//   - has(trigger.spec.ttlSecondsAfterFinished) ? trigger.spec.ttlSecondsAfterFinished : 86400
+	//
+	// +kubebuilder:validation:Optional
+	TTLSecondsAfterFinishedExpression string `json:"ttlSecondsAfterFinishedExpression,omitempty"`
+
+	// Reservation holds the claim's quota only until it is confirmed, for
+	// provisioning that reserves quota, performs external work, and then
+	// either keeps or releases it. A reserved claim is granted and counts
+	// against its buckets like any other claim, but unless
+	// reservation.confirmed is set within reservation.ttlSeconds of its
+	// creation, the system deletes it and the quota becomes available again.
+	//
+	// Not supported in ClaimCreationPolicy claim templates.
+	//
+	// +kubebuilder:validation:Optional
+	Reservation *ResourceClaimReservation `json:"reservation,omitempty"`
+}
+
+// ResourceClaimReservation configures how long a reserved ResourceClaim holds
+// its quota before it must be confirmed.
+type ResourceClaimReservation struct {
+	// TTLSeconds is how long after the claim's creation the reservation may
+	// remain unconfirmed before the claim is deleted.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	TTLSeconds int64 `json:"ttlSeconds"`
+
+	// Confirmed keeps the reserved quota. Once set, the claim no longer expires
+	// as a reservation and behaves like any other claim.
+	//
+	// +kubebuilder:validation:Optional
+	Confirmed bool `json:"confirmed,omitempty"`
 }
 
 // ResourceClaimAllocationStatus tracks the allocation status for a specific resource
@@ -137,10 +245,10 @@ type ResourceClaimAllocationStatus struct {
 
 	// AllocatedAmount specifies how much quota was actually allocated for this
 	// request. Measured in the BaseUnit defined by the ResourceRegistration.
-	// Currently always equals the requested amount or 0 (partial allocations not
-	// supported).
+	// Equals the requested amount unless the claim sets spec.allowPartial, in
+	// which case it may be less.
 	//
-	// Set to the requested amount when Status=Granted, 0 when Status=Denied or
+	// Set to the granted amount when Status=Granted, 0 when Status=Denied or
 	// Pending.
 	//
 	// +kubebuilder:validation:Optional
@@ -157,6 +265,14 @@ type ResourceClaimAllocationStatus struct {
 	// +kubebuilder:validation:Optional
 	AllocatingBucket string `json:"allocatingBucket,omitempty"`
 
+	// SatisfyingGrant names the ResourceGrant whose capacity this request consumed,
+	// chosen by the quota controllers' grant selection strategy. When the allocation
+	// spans several grants, names the first of them. Set only when Status=Granted
+	// and the capacity came from the bucket's own grants.
+	//
+	// +kubebuilder:validation:Optional
+	SatisfyingGrant string `json:"satisfyingGrant,omitempty"`
+
 	// LastTransitionTime records when this allocation status last changed.
 	// Updates whenever Status, Reason, or Message changes.
 	//
@@ -164,6 +280,47 @@ type ResourceClaimAllocationStatus struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 }
 
+// ResourceClaimDenialDetail records the capacity figures behind the denial of a
+// single resource request, so clients can explain the denial without reading the
+// AllowanceBucket.
+type ResourceClaimDenialDetail struct {
+	// ResourceType identifies the denied request. Matches one of the
+	// resourceType values in spec.requests.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ResourceType string `json:"resourceType"`
+
+	// Requested is the amount the request asked for, in the BaseUnit defined by
+	// the ResourceRegistration.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	Requested int64 `json:"requested"`
+
+	// Allocated is the amount already allocated from the consumer's
+	// AllowanceBucket when the request was evaluated.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	Allocated int64 `json:"allocated"`
+
+	// Limit is the consumer's total limit for the resource type when the
+	// request was evaluated.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	Limit int64 `json:"limit"`
+
+	// Available is the capacity the request was evaluated against. It can be
+	// lower than limit minus allocated when a parent limit applies, or higher
+	// when capacity can be borrowed from other resource types.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Available int64 `json:"available,omitempty"`
+}
+
 // ResourceClaimStatus reports the claim's processing state and allocation
 // results. The system updates this status to communicate whether quota was
 // granted and provide detailed allocation information for each requested
@@ -189,6 +346,15 @@ type ResourceClaimStatus struct {
 	// +listMapKey=resourceType
 	Allocations []ResourceClaimAllocationStatus `json:"allocations,omitempty"`
 
+	// DenialDetails records requested amount, current allocation, and limit for
+	// each denied request. The system adds an entry when it denies a request
+	// for insufficient quota; requests denied for other reasons have none.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=resourceType
+	DenialDetails []ResourceClaimDenialDetail `json:"denialDetails,omitempty"`
+
 	// Conditions represents the overall status of the claim evaluation.
 	// Controllers set these conditions to provide a high-level view of claim
 	// processing.
@@ -203,6 +369,7 @@ type ResourceClaimStatus struct {
 	//   - "QuotaExceeded": Insufficient quota prevented allocation (claim denied)
 	//   - "ValidationFailed": Configuration errors prevented evaluation (claim denied)
 	//   - "PendingEvaluation": Claim is still being processed (initial state)
+	//   - "GrantingPaused": Quota granting is paused for maintenance; the claim stays pending until it resumes
 	//
 	// Claim Lifecycle:
 	//
@@ -210,7 +377,7 @@ type ResourceClaimStatus struct {
 	//   2. Processed: Granted=True/False based on quota availability and validation
 	//   3. Updated: Granted condition changes only when allocation results change
 	//
-	// +kubebuilder:validation:XValidation:rule="self.all(c, c.type == 'Granted' ? c.reason in ['QuotaAvailable', 'QuotaExceeded', 'ValidationFailed', 'PendingEvaluation'] : true)",message="Granted condition reason must be valid"
+	// +kubebuilder:validation:XValidation:rule="self.all(c, c.type == 'Granted' ? c.reason in ['QuotaAvailable', 'QuotaExceeded', 'ValidationFailed', 'PendingEvaluation', 'GrantingPaused'] : true)",message="Granted condition reason must be valid"
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
@@ -231,8 +398,20 @@ const (
 	// Indicates that the ResourceClaim has not finished being evaluated against
 	// the total effective quota limit
 	ResourceClaimPendingReason = "PendingEvaluation"
+	// Indicates that quota granting is paused for maintenance and the claim will
+	// be evaluated once it resumes
+	ResourceClaimGrantingPausedReason = "GrantingPaused"
+	// Request allocation granted for less than the requested amount (spec.allowPartial)
+	ResourceClaimPartiallyGrantedReason = "QuotaPartiallyAvailable"
+	// Request allocation granted without checking capacity because the consumer
+	// carries a quota override for the resource type
+	ResourceClaimOverrideReason = "QuotaOverride"
 )
 
+// ResourceClaimTraceParentAnnotation carries the W3C traceparent of the admission
+// request that created the claim, so that its evaluation continues the same trace.
+const ResourceClaimTraceParentAnnotation = "quota.miloapis.com/traceparent"
+
 // ResourceClaimAllocationStatus status constants
 const (
 	// Request allocation is granted and resources are reserved
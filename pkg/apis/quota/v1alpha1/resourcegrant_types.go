@@ -1,15 +1,27 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Bucket represents a single allocation of quota capacity within an allowance.
 // Each bucket contributes its amount to the total allowance for a resource type.
+//
+// The capacity is given in one of three ways:
+// - Amount: an integer in the BaseUnit of the ResourceRegistration
+// - Quantity: a Kubernetes quantity such as "10Gi" or "500m", converted to the BaseUnit
+// - Percentage: a share of the same resource type's allowance in a parent ResourceGrant
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.quantity) && has(self.percentage))",message="quantity and percentage are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!(has(self.quantity) || has(self.percentage)) || !has(self.amount) || self.amount == 0",message="amount must be omitted or 0 when quantity or percentage is set"
+// +kubebuilder:validation:XValidation:rule="has(self.percentage) == has(self.parentGrantRef)",message="percentage requires parentGrantRef, and parentGrantRef is only used with percentage"
+// +kubebuilder:validation:XValidation:rule="!has(self.amountExpression) || !(has(self.quantity) || has(self.percentage))",message="amountExpression cannot be combined with quantity or percentage"
 type Bucket struct {
 	// Amount specifies the quota capacity provided by this bucket.
 	// Must be measured in the BaseUnit defined by the corresponding ResourceRegistration.
 	// Must be a non-negative integer (0 is valid but provides no quota).
+	// Must be omitted or 0 when quantity or percentage is set.
 	//
 	// Examples:
 	// - 100 (providing 100 projects)
@@ -17,8 +29,58 @@ type Bucket struct {
 	// - 5000 (providing 5000 CPU millicores = 5 cores)
 	//
 	// +kubebuilder:validation:Minimum=0
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	Amount int64 `json:"amount"`
+
+	// AmountExpression computes the amount from the watched resource using a CEL
+	// expression that must evaluate to a non-negative integer. Only supported in
+	// GrantCreationPolicy grant templates, where it replaces Amount in the rendered
+	// grant. ResourceGrants must not set this field.
+	//
+	// Examples:
+	//
+	//   - "trigger.spec.tier == 'gold' ? 500 : 5" (grant by Organization plan tier)
+	//   - "trigger.spec.seats * 10" (grant ten units per seat)
+	//
+	// +kubebuilder:validation:Optional
+	AmountExpression string `json:"amountExpression,omitempty"`
+
+	// Quantity specifies the quota capacity in human units, as a Kubernetes quantity
+	// whose value is measured in the BaseUnit. Fractional values are truncated toward
+	// zero, so "1500m" provides 1 and "10Gi" provides 10737418240.
+	//
+	// +kubebuilder:validation:Optional
+	Quantity *resource.Quantity `json:"quantity,omitempty"`
+
+	// Percentage specifies the quota capacity as a percentage of the parent grant's
+	// allowance for the same resource type. The parent's amount and quantity buckets
+	// are summed, ignoring its own percentage buckets, and the share is truncated
+	// toward zero, so 33% of 10 provides 3. Requires parentGrantRef.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int32 `json:"percentage,omitempty"`
+
+	// ParentGrantRef identifies the ResourceGrant that percentage is resolved against.
+	// The bucket provides no capacity while the parent grant is missing or inactive.
+	//
+	// +kubebuilder:validation:Optional
+	ParentGrantRef *ParentGrantReference `json:"parentGrantRef,omitempty"`
+}
+
+// ParentGrantReference identifies a ResourceGrant in the same control plane.
+type ParentGrantReference struct {
+	// Name of the parent ResourceGrant.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace of the parent ResourceGrant. Defaults to the namespace of the
+	// grant that references it.
+	//
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // Allowance defines quota allocation for a specific resource type within a ResourceGrant.
@@ -52,6 +114,35 @@ type Allowance struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
 	Buckets []Bucket `json:"buckets"`
+
+	// BorrowPolicy lets claims for this resource type borrow unused capacity from the
+	// consumer's allowances for other resource types once this allowance is exhausted.
+	// When several contributing grants set a policy, the lender resource types are
+	// combined and the largest maxBorrowPercentage applies.
+	//
+	// +kubebuilder:validation:Optional
+	BorrowPolicy *BorrowPolicy `json:"borrowPolicy,omitempty"`
+}
+
+// BorrowPolicy defines which of the consumer's other resource types an allowance may
+// borrow unused capacity from. Capacity is borrowed one-for-one in each resource
+// type's BaseUnit, so lender types should share a unit with the borrower.
+type BorrowPolicy struct {
+	// From lists the lender resource types, in the order they are borrowed from.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	From []string `json:"from"`
+
+	// MaxBorrowPercentage caps how much of each lender's limit may be lent out, so the
+	// lender keeps the rest for its own claims. A lender never lends more than it has
+	// available.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MaxBorrowPercentage int32 `json:"maxBorrowPercentage"`
 }
 
 // ResourceGrantSpec defines the desired state of ResourceGrant.
@@ -80,8 +171,49 @@ type ResourceGrantSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
 	Allowances []Allowance `json:"allowances"`
+
+	// AggregationScope controls which ResourceClaims count against this grant's
+	// allowances.
+	//
+	// - "Consumer" (default): only claims made by the consumer in the control plane
+	//   where the grant lives
+	// - "OrganizationTree": claims made in the Organization's control plane and in
+	//   the control planes of all of its Projects, capping their aggregate usage.
+	//   Requires an Organization consumer.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Consumer;OrganizationTree
+	AggregationScope AggregationScope `json:"aggregationScope,omitempty"`
+
+	// ExpiresAt is when the grant stops providing capacity, for temporary quota
+	// increases. Once it passes, the grant's Active condition becomes False with
+	// reason GrantExpired and its allowances drop out of AllowanceBucket limits.
+	// The grant itself is kept. Must be in the future when the grant is created.
+	// When omitted, the grant never expires.
+	//
+	// +kubebuilder:validation:Optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// Priority orders this grant among the consumer's other grants for the same
+	// resource type when the quota controllers consume grants by priority. Grants
+	// with a higher priority are consumed first. Defaults to 0.
+	//
+	// +kubebuilder:validation:Optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
+// AggregationScope identifies which ResourceClaims a grant's allowances are shared by.
+type AggregationScope string
+
+const (
+	// AggregationScopeConsumer shares allowances only among the consumer's claims
+	// in the grant's own control plane.
+	AggregationScopeConsumer AggregationScope = "Consumer"
+	// AggregationScopeOrganizationTree shares allowances among all claims made in
+	// an Organization's control plane and the control planes of its Projects.
+	AggregationScopeOrganizationTree AggregationScope = "OrganizationTree"
+)
+
 // ResourceGrantStatus reports the grant's operational state and processing status.
 // Controllers update status conditions to indicate whether the grant is active
 // and contributing capacity to AllowanceBuckets.
@@ -105,13 +237,15 @@ type ResourceGrantStatus struct {
 	// - "GrantActive": Grant is validated and contributing to quota buckets
 	// - "ValidationFailed": Specification contains errors preventing activation (see message)
 	// - "GrantPending": Grant is being processed by the quota system
+	// - "GrantExpired": spec.expiresAt has passed and the grant no longer provides capacity
 	//
 	// Grant Lifecycle:
 	// 1. Created: Active=Unknown, reason=GrantPending
 	// 2. Validated: Active=True, reason=GrantActive OR Active=False, reason=ValidationFailed
 	// 3. Updated: Active condition changes only when validation results change
+	// 4. Expired: Active=False, reason=GrantExpired once spec.expiresAt passes
 	//
-	// +kubebuilder:validation:XValidation:rule="self.all(c, c.type == 'Active' ? c.reason in ['GrantActive', 'ValidationFailed', 'GrantPending'] : true)",message="Active condition reason must be valid"
+	// +kubebuilder:validation:XValidation:rule="self.all(c, c.type == 'Active' ? c.reason in ['GrantActive', 'ValidationFailed', 'GrantPending', 'GrantExpired'] : true)",message="Active condition reason must be valid"
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
@@ -128,6 +262,8 @@ const (
 	ResourceGrantValidationFailedReason = "ValidationFailed"
 	// Indicates that the grant is pending activation.
 	ResourceGrantPendingReason = "GrantPending"
+	// Indicates that the grant's spec.expiresAt has passed.
+	ResourceGrantExpiredReason = "GrantExpired"
 )
 
 // ResourceGrant allocates quota capacity to a consumer for specific resource types.
@@ -193,12 +329,14 @@ const (
 // - All resource types must reference active ResourceRegistration objects
 // - Maximum 20 allowances per grant
 // - All amounts must be non-negative integers in BaseUnit
+// - Percentage buckets must reference a parent grant
 //
 // ### Field Constraints and Limits
 // - Maximum 20 allowances per grant
 // - Each allowance must have at least 1 bucket
 // - Bucket amounts must be non-negative (0 is allowed but provides no quota)
-// - All amounts measured in BaseUnit from ResourceRegistration
+// - spec.expiresAt, when set, must be in the future at creation
+// - All amounts measured in BaseUnit from ResourceRegistration (quantities and percentages truncate toward zero)
 //
 // ### Status Information
 // - **Active condition**: Indicates whether grant is contributing to quota buckets
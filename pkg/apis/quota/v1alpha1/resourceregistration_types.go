@@ -62,6 +62,21 @@ type ResourceRegistrationSpec struct {
 	// +kubebuilder:validation:Required
 	Type string `json:"type"`
 
+	// MeasurementKind declares whether claim amounts for this resource type are counts of
+	// discrete units or measured quantities.
+	//
+	// Valid values:
+	// - `Count`: Claims must request whole display units, so amounts must be a multiple of
+	//   `unitConversionFactor`. Only valid with `type: Entity`.
+	// - `Quantity`: Claims may request any positive amount of the base unit. Only valid
+	//   with `type: Allocation`.
+	//
+	// When omitted, claim amounts are only required to be positive.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Count;Quantity
+	MeasurementKind string `json:"measurementKind,omitempty"`
+
 	// ResourceType identifies the resource to track with quota.
 	// Platform administrators define resource type identifiers that make sense for their
 	// quota system usage. This field is immutable after creation.
@@ -69,9 +84,14 @@ type ResourceRegistrationSpec struct {
 	// The identifier format is flexible to accommodate various naming conventions
 	// and organizational needs. Service providers can use any meaningful identifier.
 	//
+	// A group wildcard such as "networking.datumapis.com/*" registers every resource type
+	// of that group. A registration of a specific resource type takes precedence over the
+	// wildcard of its group.
+	//
 	// Examples:
 	// - "resourcemanager.miloapis.com/projects"
 	// - "iam.miloapis.com/users"
+	// - "networking.datumapis.com/*"
 	// - "compute_cpu"
 	// - "storage.volumes"
 	// - "custom-service-quota"
@@ -139,6 +159,16 @@ type ResourceRegistrationSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	UnitConversionFactor int64 `json:"unitConversionFactor"`
 
+	// MaxGrantAmount caps the total amount of this resource type a single ResourceGrant
+	// may allocate, summed across the grant's buckets for the type. Measured in BaseUnit.
+	// ResourceGrants over the cap fail validation and provide no capacity, and
+	// GrantCreationPolicies whose grants would exceed it are rejected.
+	// When omitted, grant amounts are not capped.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxGrantAmount *int64 `json:"maxGrantAmount,omitempty"`
+
 	// ClaimingResources specifies which resource types can create ResourceClaims for this registration.
 	// Only resources listed here can trigger quota consumption for this resource type.
 	// At least one claiming resource must be specified.
@@ -148,6 +178,42 @@ type ResourceRegistrationSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=20
 	ClaimingResources []ClaimingResource `json:"claimingResources"`
+
+	// DefaultClaim charges one display unit of this resource type whenever a claiming
+	// resource is created, without a separate ClaimCreationPolicy. A ClaimCreationPolicy
+	// that triggers on the same resource takes precedence. Only valid with `type: Entity`.
+	//
+	// +kubebuilder:validation:Optional
+	DefaultClaim *DefaultClaimTemplate `json:"defaultClaim,omitempty"`
+
+	// DenialMessageTemplate replaces the message returned to clients when a request for
+	// this resource type is denied. It is a Go template with access to `.ResourceType`,
+	// `.Requested`, `.Limit`, `.Used`, and `.Available`. The default message is returned
+	// when it is omitted or fails to render.
+	//
+	// Example: "Your plan allows {{ .Limit }} projects and {{ .Available }} remain."
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=1000
+	DenialMessageTemplate string `json:"denialMessageTemplate,omitempty"`
+}
+
+// DefaultClaimTemplate describes the **ResourceClaim** synthesized for a claiming resource
+// when a registration declares a default claim.
+type DefaultClaimTemplate struct {
+	// ConsumerName is the name of the consumer charged for each claim. The consumer's
+	// API group and kind come from **spec.consumerType**.
+	// Supports CEL expressions wrapped in {{ }} delimiters with access to `trigger`,
+	// `user`, and `requestInfo`.
+	// When omitted, the **Project** the request was made in is charged, which requires
+	// a **Project** consumer type.
+	//
+	// Examples:
+	// - "{{trigger.spec.organizationRef.name}}" (CEL expression)
+	// - "acme" (literal name)
+	//
+	// +kubebuilder:validation:Optional
+	ConsumerName string `json:"consumerName,omitempty"`
 }
 
 // ClaimingResource identifies a resource type that can create **ResourceClaims**
@@ -207,6 +273,14 @@ type ResourceRegistrationStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// Measurement kind constants for ResourceRegistration
+const (
+	// Indicates that claims count discrete units of the resource type.
+	MeasurementKindCount = "Count"
+	// Indicates that claims measure an amount of the resource type.
+	MeasurementKindQuantity = "Quantity"
+)
+
 // Condition type constants for ResourceRegistration
 const (
 	// Indicates that the resource registration is active and ResourceGrants and
@@ -226,6 +300,11 @@ const (
 	ResourceRegistrationPendingReason = "RegistrationPending"
 )
 
+// ResourceRegistrationForceDeleteAnnotation, set to "true" on a ResourceRegistration,
+// allows it to be deleted while ClaimCreationPolicies, ResourceGrants or ResourceClaims
+// still reference its resource type.
+const ResourceRegistrationForceDeleteAnnotation = "quota.miloapis.com/force-delete"
+
 // ResourceRegistration enables quota tracking for a specific resource type.
 // Administrators create registrations to define measurement units, consumer relationships,
 // and claiming permissions.
@@ -49,6 +49,13 @@ type ConsumerRef struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// QuotaOverrideAnnotation may be set on a consumer resource to a comma-separated list
+// of resource types for which the consumer's claims are granted regardless of
+// available capacity. It is intended for emergencies; each overridden grant is
+// recorded with the QuotaOverride reason. Only platform administrators may set or
+// change it; quota admission rejects anyone else.
+const QuotaOverrideAnnotation = "quota.miloapis.com/quota-override"
+
 type ContributingResourceRef struct {
 	// Name of the resource
 	//
@@ -31,7 +31,14 @@ func (in *Allowance) DeepCopyInto(out *Allowance) {
 	if in.Buckets != nil {
 		in, out := &in.Buckets, &out.Buckets
 		*out = make([]Bucket, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BorrowPolicy != nil {
+		in, out := &in.BorrowPolicy, &out.BorrowPolicy
+		*out = new(BorrowPolicy)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -128,6 +135,23 @@ func (in *AllowanceBucketStatus) DeepCopyInto(out *AllowanceBucketStatus) {
 		*out = make([]ContributingGrantRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.Borrowed != nil {
+		in, out := &in.Borrowed, &out.Borrowed
+		*out = make([]BorrowedCapacity, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopClaims != nil {
+		in, out := &in.TopClaims, &out.TopClaims
+		*out = make([]TopClaimRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.LastReconciliation != nil {
 		in, out := &in.LastReconciliation, &out.LastReconciliation
 		*out = (*in).DeepCopy()
@@ -144,9 +168,59 @@ func (in *AllowanceBucketStatus) DeepCopy() *AllowanceBucketStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BorrowPolicy) DeepCopyInto(out *BorrowPolicy) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BorrowPolicy.
+func (in *BorrowPolicy) DeepCopy() *BorrowPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BorrowPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BorrowedCapacity) DeepCopyInto(out *BorrowedCapacity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BorrowedCapacity.
+func (in *BorrowedCapacity) DeepCopy() *BorrowedCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(BorrowedCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bucket) DeepCopyInto(out *Bucket) {
 	*out = *in
+	if in.Quantity != nil {
+		in, out := &in.Quantity, &out.Quantity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Percentage != nil {
+		in, out := &in.Percentage, &out.Percentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ParentGrantRef != nil {
+		in, out := &in.ParentGrantRef, &out.ParentGrantRef
+		*out = new(ParentGrantReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bucket.
@@ -228,6 +302,11 @@ func (in *ClaimCreationPolicySpec) DeepCopyInto(out *ClaimCreationPolicySpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ExemptSubjects != nil {
+		in, out := &in.ExemptSubjects, &out.ExemptSubjects
+		*out = new(ClaimExemptSubjects)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimCreationPolicySpec.
@@ -262,6 +341,31 @@ func (in *ClaimCreationPolicyStatus) DeepCopy() *ClaimCreationPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimExemptSubjects) DeepCopyInto(out *ClaimExemptSubjects) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimExemptSubjects.
+func (in *ClaimExemptSubjects) DeepCopy() *ClaimExemptSubjects {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimExemptSubjects)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClaimTargetSpec) DeepCopyInto(out *ClaimTargetSpec) {
 	*out = *in
@@ -296,7 +400,26 @@ func (in *ClaimTriggerResource) DeepCopy() *ClaimTriggerResource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClaimTriggerSpec) DeepCopyInto(out *ClaimTriggerSpec) {
 	*out = *in
-	out.Resource = in.Resource
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(ClaimTriggerResource)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ClaimTriggerResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Constraints != nil {
 		in, out := &in.Constraints, &out.Constraints
 		*out = make([]ConditionExpression, len(*in))
@@ -404,6 +527,21 @@ func (in *ContributingResourceRef) DeepCopy() *ContributingResourceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultClaimTemplate) DeepCopyInto(out *DefaultClaimTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultClaimTemplate.
+func (in *DefaultClaimTemplate) DeepCopy() *DefaultClaimTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultClaimTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrantCreationPolicy) DeepCopyInto(out *GrantCreationPolicy) {
 	*out = *in
@@ -608,6 +746,148 @@ func (in *ObjectMetaTemplate) DeepCopy() *ObjectMetaTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParentGrantReference) DeepCopyInto(out *ParentGrantReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParentGrantReference.
+func (in *ParentGrantReference) DeepCopy() *ParentGrantReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ParentGrantReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaSnapshot) DeepCopyInto(out *QuotaSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaSnapshot.
+func (in *QuotaSnapshot) DeepCopy() *QuotaSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QuotaSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaSnapshotAllocation) DeepCopyInto(out *QuotaSnapshotAllocation) {
+	*out = *in
+	if in.BucketLastReconciliation != nil {
+		in, out := &in.BucketLastReconciliation, &out.BucketLastReconciliation
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaSnapshotAllocation.
+func (in *QuotaSnapshotAllocation) DeepCopy() *QuotaSnapshotAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaSnapshotAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaSnapshotList) DeepCopyInto(out *QuotaSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]QuotaSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaSnapshotList.
+func (in *QuotaSnapshotList) DeepCopy() *QuotaSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QuotaSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaSnapshotSpec) DeepCopyInto(out *QuotaSnapshotSpec) {
+	*out = *in
+	out.ConsumerRef = in.ConsumerRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaSnapshotSpec.
+func (in *QuotaSnapshotSpec) DeepCopy() *QuotaSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaSnapshotStatus) DeepCopyInto(out *QuotaSnapshotStatus) {
+	*out = *in
+	if in.CapturedAt != nil {
+		in, out := &in.CapturedAt, &out.CapturedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]QuotaSnapshotAllocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaSnapshotStatus.
+func (in *QuotaSnapshotStatus) DeepCopy() *QuotaSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceClaim) DeepCopyInto(out *ResourceClaim) {
 	*out = *in
@@ -651,6 +931,21 @@ func (in *ResourceClaimAllocationStatus) DeepCopy() *ResourceClaimAllocationStat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceClaimDenialDetail) DeepCopyInto(out *ResourceClaimDenialDetail) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceClaimDenialDetail.
+func (in *ResourceClaimDenialDetail) DeepCopy() *ResourceClaimDenialDetail {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceClaimDenialDetail)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceClaimList) DeepCopyInto(out *ResourceClaimList) {
 	*out = *in
@@ -683,6 +978,21 @@ func (in *ResourceClaimList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceClaimReservation) DeepCopyInto(out *ResourceClaimReservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceClaimReservation.
+func (in *ResourceClaimReservation) DeepCopy() *ResourceClaimReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceClaimReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceClaimSpec) DeepCopyInto(out *ResourceClaimSpec) {
 	*out = *in
@@ -693,6 +1003,21 @@ func (in *ResourceClaimSpec) DeepCopyInto(out *ResourceClaimSpec) {
 		copy(*out, *in)
 	}
 	out.ResourceRef = in.ResourceRef
+	if in.TTLSecondsAfterCreation != nil {
+		in, out := &in.TTLSecondsAfterCreation, &out.TTLSecondsAfterCreation
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Reservation != nil {
+		in, out := &in.Reservation, &out.Reservation
+		*out = new(ResourceClaimReservation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceClaimSpec.
@@ -715,6 +1040,11 @@ func (in *ResourceClaimStatus) DeepCopyInto(out *ResourceClaimStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DenialDetails != nil {
+		in, out := &in.DenialDetails, &out.DenialDetails
+		*out = make([]ResourceClaimDenialDetail, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -821,6 +1151,10 @@ func (in *ResourceGrantSpec) DeepCopyInto(out *ResourceGrantSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceGrantSpec.
@@ -935,11 +1269,21 @@ func (in *ResourceRegistrationList) DeepCopyObject() runtime.Object {
 func (in *ResourceRegistrationSpec) DeepCopyInto(out *ResourceRegistrationSpec) {
 	*out = *in
 	out.ConsumerType = in.ConsumerType
+	if in.MaxGrantAmount != nil {
+		in, out := &in.MaxGrantAmount, &out.MaxGrantAmount
+		*out = new(int64)
+		**out = **in
+	}
 	if in.ClaimingResources != nil {
 		in, out := &in.ClaimingResources, &out.ClaimingResources
 		*out = make([]ClaimingResource, len(*in))
 		copy(*out, *in)
 	}
+	if in.DefaultClaim != nil {
+		in, out := &in.DefaultClaim, &out.DefaultClaim
+		*out = new(DefaultClaimTemplate)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRegistrationSpec.
@@ -989,6 +1333,21 @@ func (in *ResourceRequest) DeepCopy() *ResourceRequest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopClaimRef) DeepCopyInto(out *TopClaimRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopClaimRef.
+func (in *TopClaimRef) DeepCopy() *TopClaimRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TopClaimRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnversionedObjectReference) DeepCopyInto(out *UnversionedObjectReference) {
 	*out = *in
@@ -0,0 +1,12 @@
+package quotausage
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"go.miloapis.com/milo/pkg/apis/quotausage/v1alpha1"
+)
+
+// Install registers the quota usage API group versions into the provided scheme.
+func Install(scheme *runtime.Scheme) {
+	v1alpha1.AddToScheme(scheme)
+}
@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AllowanceBucketBreakdown resolves the ResourceGrants and ResourceClaims behind an
+// AllowanceBucket's limit and allocation, in a single read.
+//
+// Breakdowns are computed on request by joining the bucket's contributing grant
+// references and the consumer's granted ResourceClaims to the live objects, and are
+// not stored. A breakdown has the same name and namespace as the AllowanceBucket it
+// describes, and can only be read by name.
+//
+// Use cases:
+//   - Find out which claims, and the resources that own them, fill a bucket at capacity
+//   - See how much each grant contributes to a bucket's limit
+//   - Spot grants the bucket has not caught up with since they changed
+//
+// Important notes:
+//   - This is a read-only resource; quota is changed through ResourceGrants
+//   - Only claims made in the bucket's own control plane are listed. Buckets with an
+//     OrganizationTree aggregation scope also count claims made in Project control
+//     planes; those are reported by the bucket's topClaims.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type AllowanceBucketBreakdown struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status AllowanceBucketBreakdownStatus `json:"status,omitempty"`
+}
+
+// AllowanceBucketBreakdownStatus lists the grants and claims behind a bucket's totals.
+type AllowanceBucketBreakdownStatus struct {
+	// ConsumerRef identifies the quota consumer the bucket tracks.
+	ConsumerRef ConsumerRef `json:"consumerRef"`
+
+	// ResourceType is the resource type the bucket tracks.
+	ResourceType string `json:"resourceType"`
+
+	// Limit is the bucket's limit, as last calculated by the quota system.
+	Limit int64 `json:"limit"`
+
+	// Allocated is the bucket's allocated quota, as last calculated by the quota system.
+	Allocated int64 `json:"allocated"`
+
+	// Grants lists the ResourceGrants contributing to the bucket's limit, in the order
+	// the bucket consumes them.
+	Grants []GrantContribution `json:"grants"`
+
+	// Claims lists the granted ResourceClaims allocated quota from the bucket, largest
+	// allocation first.
+	Claims []ClaimContribution `json:"claims"`
+}
+
+// GrantContribution reports the capacity one ResourceGrant contributes to a bucket.
+type GrantContribution struct {
+	// Name is the name of the ResourceGrant.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ResourceGrant, as recorded by the bucket.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Amount is the capacity the grant contributes, in the BaseUnit of the resource type.
+	Amount int64 `json:"amount"`
+
+	// Stale is true when the grant was deleted or updated after the bucket last
+	// processed it, so the bucket's limit does not reflect it yet.
+	Stale bool `json:"stale,omitempty"`
+}
+
+// ClaimContribution reports the quota one ResourceClaim is allocated from a bucket.
+type ClaimContribution struct {
+	// Name is the name of the ResourceClaim.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the ResourceClaim.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Amount is the quota allocated to the claim, in the BaseUnit of the resource type.
+	Amount int64 `json:"amount"`
+
+	// ResourceRef identifies the resource the claim was made for.
+	ResourceRef ObjectRef `json:"resourceRef"`
+}
+
+// ObjectRef identifies a resource by API group, kind, and name.
+type ObjectRef struct {
+	// APIGroup is the API group of the resource.
+	APIGroup string `json:"apiGroup,omitempty"`
+
+	// Kind is the type of the resource.
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the resource, if it is namespaced.
+	Namespace string `json:"namespace,omitempty"`
+}
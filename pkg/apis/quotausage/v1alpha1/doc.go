@@ -0,0 +1,11 @@
+// Package v1alpha1 contains API Schema definitions for the quotausage.miloapis.com group
+//
+// This package defines virtual types served by the Milo API server. These types are
+// computed on read from the quota system's AllowanceBuckets, and the ResourceGrants and
+// ResourceClaims behind them, and are not persisted in etcd.
+//
+// +kubebuilder:skip
+// +k8s:deepcopy-gen=package
+// +k8s:openapi-gen=true
+// +groupName=quotausage.miloapis.com
+package v1alpha1
@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QuotaUsageSummary reports a quota consumer's usage of every resource type it holds
+// quota for, in a single read.
+//
+// Summaries are computed on request from the consumer's AllowanceBuckets and are not
+// stored. A summary lives in the namespace of the consumer's buckets and is named
+// after the consumer's kind and name, for example "organization-acme-corp" or
+// "project-web-app".
+//
+// Use cases:
+//   - Render a quota dashboard for an Organization or Project without listing buckets
+//   - Show limit, allocated, and available capacity side by side per resource type
+//
+// Important notes:
+//   - This is a read-only resource; quota is changed through ResourceGrants
+//   - Values are as current as the underlying AllowanceBucket status
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type QuotaUsageSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status QuotaUsageSummaryStatus `json:"status,omitempty"`
+}
+
+// QuotaUsageSummaryStatus contains a consumer's quota usage rolled up per resource type.
+type QuotaUsageSummaryStatus struct {
+	// ConsumerRef identifies the quota consumer this summary reports on.
+	ConsumerRef ConsumerRef `json:"consumerRef"`
+
+	// Resources lists the consumer's usage for each resource type it has an
+	// AllowanceBucket for, ordered by resource type.
+	Resources []ResourceUsage `json:"resources"`
+}
+
+// ConsumerRef identifies a quota consumer such as an Organization or Project.
+type ConsumerRef struct {
+	// APIGroup is the API group of the consumer resource.
+	APIGroup string `json:"apiGroup,omitempty"`
+
+	// Kind is the type of the consumer resource.
+	Kind string `json:"kind"`
+
+	// Name is the name of the consumer resource.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the consumer resource, if it is namespaced.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ResourceUsage reports quota usage for one resource type. Amounts are measured in
+// the BaseUnit of the resource type's ResourceRegistration.
+type ResourceUsage struct {
+	// ResourceType is the resource type the usage is reported for.
+	ResourceType string `json:"resourceType"`
+
+	// Limit is the total quota capacity granted to the consumer by active ResourceGrants.
+	Limit int64 `json:"limit"`
+
+	// Allocated is the quota consumed by granted ResourceClaims.
+	Allocated int64 `json:"allocated"`
+
+	// Available is the quota capacity remaining for new ResourceClaims.
+	Available int64 `json:"available"`
+
+	// ClaimCount is the number of granted ResourceClaims consuming this quota.
+	ClaimCount int32 `json:"claimCount"`
+
+	// GrantCount is the number of active ResourceGrants contributing to the limit.
+	GrantCount int32 `json:"grantCount"`
+}
+
+// QuotaUsageSummaryList is a list of QuotaUsageSummary resources.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type QuotaUsageSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QuotaUsageSummary `json:"items"`
+}
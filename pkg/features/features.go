@@ -65,6 +65,15 @@ const (
 	// owner: @datum-cloud/platform
 	// alpha: v0.1.0
 	ServiceAccountKeys featuregate.Feature = "ServiceAccountKeys"
+
+	// QuotaUsageSummaries enables the quotausage.miloapis.com/v1alpha1 virtual APIs:
+	// QuotaUsageSummary, which rolls a consumer's AllowanceBuckets up into a single
+	// per-consumer usage report, and AllowanceBucketBreakdown, which lists the grants
+	// and claims behind a bucket.
+	//
+	// owner: @datum-cloud/platform
+	// alpha: v0.1.0
+	QuotaUsageSummaries featuregate.Feature = "QuotaUsageSummaries"
 )
 
 func init() {
@@ -82,6 +91,10 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 		Default:    false,
 		PreRelease: featuregate.Alpha,
 	},
+	QuotaUsageSummaries: {
+		Default:    false,
+		PreRelease: featuregate.Alpha,
+	},
 	ServiceAccountKeys: {
 		Default:    false,
 		PreRelease: featuregate.Alpha,